@@ -46,6 +46,7 @@ func Audit(auditService protocol.AuditService, profileService protocol.ProfileSe
 		response := convertToResponse(auditResult, profile)
 		response.Pass = summary.IsPass
 		response.Message = summary.Message
+		response.Severity = summary.Severity
 
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -56,7 +57,23 @@ func Audit(auditService protocol.AuditService, profileService protocol.ProfileSe
 }
 
 func convertToResponse(auditResult *protocol.AuditResult, profile *job.Profile) *model.AuditResponse {
-	group := protocol.AuditGroup(auditResult.AuditReports)
+	return &model.AuditResponse{
+		AuditID:      auditResult.Audit.ID,
+		ProfileID:    auditResult.Audit.ProfileID,
+		URN:          auditResult.Audit.URN,
+		GroupName:    profile.GroupName,
+		Filter:       profile.Filter,
+		Mode:         profile.Mode,
+		Status:       string(auditResult.Audit.State),
+		Result:       groupAuditReports(auditResult.AuditReports),
+		TotalRecords: profile.TotalRecords,
+		CreatedAt:    auditResult.Audit.EventTimestamp,
+	}
+}
+
+//groupAuditReports convert audit reports to audit results grouped by the group value
+func groupAuditReports(reports []*protocol.AuditReport) []model.AuditResultGroup {
+	group := protocol.AuditGroup(reports)
 	auditResGrouped := group.ByGroupValue()
 
 	var keys []string
@@ -79,8 +96,12 @@ func convertToResponse(auditResult *protocol.AuditResult, profile *job.Profile)
 				Metadata:       element.Metadata,
 				Pass:           element.PassFlag,
 				ToleranceRules: element.ToleranceRules,
+				Severity:       element.Severity,
+				GroupOverride:  element.GroupOverride,
+				NoBaseline:     element.NoBaseline,
+				ExpectedBand:   element.ExpectedBand,
 			}
-			passFlag = passFlag && converted.Pass
+			passFlag = passFlag && !element.FailsAudit()
 			resultList = append(resultList, converted)
 		}
 		r := model.AuditResultGroup{
@@ -90,17 +111,5 @@ func convertToResponse(auditResult *protocol.AuditResult, profile *job.Profile)
 		}
 		auditGroupedResp = append(auditGroupedResp, r)
 	}
-
-	return &model.AuditResponse{
-		AuditID:      auditResult.Audit.ID,
-		ProfileID:    auditResult.Audit.ProfileID,
-		URN:          auditResult.Audit.URN,
-		GroupName:    profile.GroupName,
-		Filter:       profile.Filter,
-		Mode:         profile.Mode,
-		Status:       string(auditResult.Audit.State),
-		Result:       auditGroupedResp,
-		TotalRecords: profile.TotalRecords,
-		CreatedAt:    auditResult.Audit.EventTimestamp,
-	}
+	return auditGroupedResp
 }
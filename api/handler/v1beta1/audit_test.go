@@ -205,3 +205,23 @@ func TestAudit(t *testing.T) {
 		})
 	})
 }
+
+func TestGroupAuditReports(t *testing.T) {
+	t.Run("should return expected band of anomaly result", func(t *testing.T) {
+		band := &protocol.ExpectedBand{Lower: 90, Upper: 110, BaselineSize: 7}
+		reports := []*protocol.AuditReport{
+			{
+				GroupValue:   "2021-01-01",
+				MetricName:   metric.RowCount,
+				MetricValue:  120,
+				ExpectedBand: band,
+				Severity:     protocol.SeverityWarn,
+			},
+		}
+
+		result := groupAuditReports(reports)
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, band, result[0].AuditResults[0].ExpectedBand)
+	})
+}
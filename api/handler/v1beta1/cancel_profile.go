@@ -0,0 +1,58 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/util"
+)
+
+//CancelProfile cancel profile that is not finished yet
+func CancelProfile(profileService protocol.ProfileService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		ID := vars["profileID"]
+
+		if !util.IsUUIDValid(ID) {
+			printError(w, errors.New("invalid profileID"), http.StatusBadRequest)
+			return
+		}
+
+		profile, err := profileService.Cancel(ID)
+		if err != nil {
+			switch {
+			case errors.Is(err, protocol.ErrProfileNotFound):
+				printError(w, err, http.StatusNotFound)
+			case errors.Is(err, protocol.ErrProfileFinished):
+				printError(w, err, http.StatusConflict)
+			default:
+				printError(w, err, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		response := &model.ProfileResponse{
+			ID:           profile.ID,
+			URN:          profile.URN,
+			Filter:       profile.Filter,
+			Group:        profile.GroupName,
+			Mode:         profile.Mode,
+			AuditTime:    profile.AuditTimestamp,
+			CreatedAt:    profile.EventTimestamp,
+			UpdatedAt:    profile.UpdatedTimestamp,
+			State:        profile.Status,
+			Message:      profile.Message,
+			TotalRecords: profile.TotalRecords,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			printError(w, err, http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelProfile(t *testing.T) {
+	ID := "15d697bc-3aac-11eb-b2c9-0242ac110000"
+	t.Run("should return cancelled profile", func(t *testing.T) {
+		profile := &job.Profile{
+			ID:      ID,
+			URN:     "entity-1-project-1.dataset_a.table_x",
+			Mode:    job.ModeComplete,
+			Status:  job.StateCancelled,
+			Message: "profile cancelled",
+		}
+
+		profileService := mock.NewProfileService()
+		defer profileService.AssertExpectations(t)
+		profileService.On("Cancel", ID).Return(profile, nil)
+
+		handler := CancelProfile(profileService)
+		req := httptest.NewRequest(http.MethodPost, "/profile/"+ID+"/cancel", nil)
+		res := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{
+			"profileID": ID,
+		})
+		handler.ServeHTTP(res, req)
+
+		response := &model.ProfileResponse{
+			ID:      ID,
+			URN:     profile.URN,
+			Mode:    profile.Mode,
+			State:   job.StateCancelled,
+			Message: "profile cancelled",
+		}
+
+		result := &model.ProfileResponse{}
+		err := json.NewDecoder(res.Body).Decode(result)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, response, result)
+	})
+	t.Run("should return conflict when profile is already finished", func(t *testing.T) {
+		var profile *job.Profile
+
+		profileService := mock.NewProfileService()
+		defer profileService.AssertExpectations(t)
+		profileService.On("Cancel", ID).Return(profile, protocol.ErrProfileFinished)
+
+		handler := CancelProfile(profileService)
+		req := httptest.NewRequest(http.MethodPost, "/profile/"+ID+"/cancel", nil)
+		res := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{
+			"profileID": ID,
+		})
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+	})
+	t.Run("should return bad request when profile ID is invalid", func(t *testing.T) {
+		profileService := mock.NewProfileService()
+		defer profileService.AssertExpectations(t)
+
+		handler := CancelProfile(profileService)
+		req := httptest.NewRequest(http.MethodPost, "/profile/abc/cancel", nil)
+		res := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{
+			"profileID": "abc",
+		})
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
@@ -35,11 +35,12 @@ func CreateUpdateEntity(entityStore protocol.EntityStore) http.HandlerFunc {
 		}
 
 		newEntity := &protocol.Entity{
-			ID:            ID,
-			Name:          body.EntityName,
-			Environment:   body.Environment,
-			GitURL:        body.GitURL,
-			GcpProjectIDs: body.GcpProjectIDs,
+			ID:             ID,
+			Name:           body.EntityName,
+			Environment:    body.Environment,
+			GitURL:         body.GitURL,
+			GcpProjectIDs:  body.GcpProjectIDs,
+			MaxBytesBilled: body.MaxBytesBilled,
 		}
 
 		eValidator := &entityValidator{entityStore: entityStore}
@@ -60,6 +61,7 @@ func CreateUpdateEntity(entityStore protocol.EntityStore) http.HandlerFunc {
 			GitURL:           storedEntity.GitURL,
 			Environment:      storedEntity.Environment,
 			GcpProjectIDs:    storedEntity.GcpProjectIDs,
+			MaxBytesBilled:   storedEntity.MaxBytesBilled,
 			CreatedTimestamp: storedEntity.CreatedAt,
 			UpdatedTimestamp: storedEntity.UpdatedAt,
 		}
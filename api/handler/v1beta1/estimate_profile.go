@@ -0,0 +1,63 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/protocol"
+)
+
+//EstimateProfile handle request to estimate bytes processed by a profile, nothing is run or stored
+func EstimateProfile(profileService protocol.ProfileService, sqlExpressionFac protocol.SQLExpressionFactory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body model.ProfileRequest
+		if err := getRequestBody(r, &body); err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		if err := body.Validate(); err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		profile, status, err := newProfile(&body, sqlExpressionFac)
+		if err != nil {
+			printError(w, err, status)
+			return
+		}
+
+		estimate, err := profileService.Estimate(profile)
+		if err != nil {
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		queries := make([]*model.QueryEstimateResponse, 0, len(estimate.Queries))
+		for _, q := range estimate.Queries {
+			queries = append(queries, &model.QueryEstimateResponse{
+				Query:          q.Query.Content,
+				QueryType:      q.Query.Type,
+				EstimatedBytes: q.EstimatedBytes,
+			})
+		}
+
+		response := &model.ProfileEstimateResponse{
+			URN:            profile.URN,
+			Filter:         profile.Filter,
+			Group:          profile.GroupName,
+			Mode:           profile.Mode,
+			TotalBytes:     estimate.TotalBytes,
+			MaxBytesBilled: estimate.MaxBytesBilled,
+			Exceeded:       estimate.IsExceeded(),
+			Queries:        queries,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			printError(w, err, http.StatusInternalServerError)
+		}
+	}
+}
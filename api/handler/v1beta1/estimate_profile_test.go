@@ -0,0 +1,98 @@
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+func TestEstimateProfile(t *testing.T) {
+	request := &model.ProfileRequest{
+		URN:       "sample-project.sample_dataset.sample_table",
+		Filter:    "__PARTITION__ = \"2020-12-01\"",
+		Mode:      job.ModeComplete,
+		AuditTime: "2020-12-01T00:00:00.000Z",
+	}
+	t.Run("should return estimated bytes of profile queries", func(t *testing.T) {
+		estimate := &protocol.CostEstimate{
+			Queries: []*protocol.QueryCost{
+				{
+					Query: &job.Query{
+						URN:     request.URN,
+						Content: "SELECT count(*) FROM `sample-project.sample_dataset.sample_table`",
+						Type:    job.TableLevelQuery,
+					},
+					EstimatedBytes: 2000,
+				},
+			},
+			TotalBytes:     2000,
+			MaxBytesBilled: 1000,
+		}
+
+		body, _ := json.Marshal(request)
+
+		profileService := mock.NewProfileService()
+		defer profileService.AssertExpectations(t)
+		profileService.On("Estimate", testifyMock.AnythingOfType("*job.Profile")).Return(estimate, nil)
+
+		sqlExpressionFactory := mock.NewSQLExpressionFactory()
+		defer sqlExpressionFactory.AssertExpectations(t)
+		sqlExpressionFactory.On("CreatePartitionExpression", request.URN).Return("date(timestamp_field,\"UTC\")", nil)
+
+		handler := EstimateProfile(profileService, sqlExpressionFactory)
+		req := httptest.NewRequest(http.MethodPost, "/profile/estimate", bytes.NewBuffer(body))
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		response := &model.ProfileEstimateResponse{
+			URN:            request.URN,
+			Filter:         "date(timestamp_field,\"UTC\") = \"2020-12-01\"",
+			Mode:           job.ModeComplete,
+			TotalBytes:     2000,
+			MaxBytesBilled: 1000,
+			Exceeded:       true,
+			Queries: []*model.QueryEstimateResponse{
+				{
+					Query:          "SELECT count(*) FROM `sample-project.sample_dataset.sample_table`",
+					QueryType:      job.TableLevelQuery,
+					EstimatedBytes: 2000,
+				},
+			},
+		}
+
+		result := &model.ProfileEstimateResponse{}
+		err := json.NewDecoder(res.Body).Decode(result)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, response, result)
+	})
+	t.Run("should return internal server error when estimation failed", func(t *testing.T) {
+		var estimate *protocol.CostEstimate
+		body, _ := json.Marshal(request)
+
+		profileService := mock.NewProfileService()
+		defer profileService.AssertExpectations(t)
+		profileService.On("Estimate", testifyMock.AnythingOfType("*job.Profile")).Return(estimate, errors.New("API error"))
+
+		sqlExpressionFactory := mock.NewSQLExpressionFactory()
+		sqlExpressionFactory.On("CreatePartitionExpression", request.URN).Return("date(timestamp_field,\"UTC\")", nil)
+
+		handler := EstimateProfile(profileService, sqlExpressionFactory)
+		req := httptest.NewRequest(http.MethodPost, "/profile/estimate", bytes.NewBuffer(body))
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
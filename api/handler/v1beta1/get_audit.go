@@ -0,0 +1,181 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/util"
+)
+
+const (
+	defaultAuditPageLimit = 20
+	maxAuditPageLimit     = 100
+)
+
+//GetAudit provide audit and its result
+func GetAudit(auditService protocol.AuditService, profileService protocol.ProfileService, summaryCreator protocol.AuditSummaryFactory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		ID := vars["auditID"]
+
+		if !util.IsUUIDValid(ID) {
+			printError(w, errors.New("invalid auditID"), http.StatusBadRequest)
+			return
+		}
+
+		auditResult, err := auditService.GetAudit(ID)
+		if err != nil {
+			if err == protocol.ErrAuditNotFound {
+				printError(w, err, http.StatusNotFound)
+				return
+			}
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		profile, err := profileService.Get(auditResult.Audit.ProfileID)
+		if err != nil {
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		response, err := newAuditResponse(auditResult, profile, summaryCreator)
+		if err != nil {
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			printError(w, err, http.StatusInternalServerError)
+		}
+	}
+}
+
+//GetProfileAudits provide audits of a profile
+func GetProfileAudits(auditService protocol.AuditService, summaryCreator protocol.AuditSummaryFactory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		ID := vars["profileID"]
+
+		if !util.IsUUIDValid(ID) {
+			printError(w, errors.New("invalid profileID"), http.StatusBadRequest)
+			return
+		}
+
+		filter, err := parseAuditFilter(r)
+		if err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+		filter.ProfileID = ID
+
+		writeAuditList(w, filter, auditService, summaryCreator)
+	}
+}
+
+//GetTableAudits provide audit history of a table
+func GetTableAudits(auditService protocol.AuditService, summaryCreator protocol.AuditSummaryFactory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		urn := vars["urn"]
+
+		if urn == "" {
+			printError(w, errors.New("invalid urn"), http.StatusBadRequest)
+			return
+		}
+
+		filter, err := parseAuditFilter(r)
+		if err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+		filter.URN = urn
+
+		writeAuditList(w, filter, auditService, summaryCreator)
+	}
+}
+
+//writeAuditList write audits of the filter, profile of each audit is fetched together with the audits
+func writeAuditList(w http.ResponseWriter, filter *protocol.AuditFilter, auditService protocol.AuditService, summaryCreator protocol.AuditSummaryFactory) {
+	auditResults, err := auditService.GetAudits(filter)
+	if err != nil {
+		printError(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	response := &model.AuditListResponse{
+		Audits: []*model.AuditResponse{},
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+
+	for _, auditResult := range auditResults {
+		auditResponse, err := newAuditResponse(auditResult, auditResult.Profile, summaryCreator)
+		if err != nil {
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+		response.Audits = append(response.Audits, auditResponse)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		printError(w, err, http.StatusInternalServerError)
+	}
+}
+
+func newAuditResponse(auditResult *protocol.AuditResult, profile *job.Profile, summaryCreator protocol.AuditSummaryFactory) (*model.AuditResponse, error) {
+	summary, err := summaryCreator.Create(auditResult.AuditReports, auditResult.Audit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := convertToResponse(auditResult, profile)
+	response.Pass = summary.IsPass
+	response.Message = summary.Message
+	response.Severity = summary.Severity
+	return response, nil
+}
+
+//parseAuditFilter parse from, to, pass, field_id, limit and offset query parameters
+func parseAuditFilter(r *http.Request) (*protocol.AuditFilter, error) {
+	query := r.URL.Query()
+	filter := &protocol.AuditFilter{
+		FieldID: query.Get("field_id"),
+		Limit:   defaultAuditPageLimit,
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery(query, "from"); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseTimeQuery(query, "to"); err != nil {
+		return nil, err
+	}
+	if value := query.Get("pass"); value != "" {
+		pass, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pass, %w", err)
+		}
+		filter.Pass = &pass
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil || filter.Limit <= 0 || filter.Limit > maxAuditPageLimit {
+			return nil, fmt.Errorf("invalid limit, should be between 1 and %d", maxAuditPageLimit)
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil || filter.Offset < 0 {
+			return nil, errors.New("invalid offset, should not be negative")
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,252 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAudit(t *testing.T) {
+	auditID := "25d697bc-3aac-11eb-b2c9-0242ac110000"
+	profileID := "15d697bc-3aac-11eb-b2c9-0242ac110000"
+	urn := "project.dataset.table"
+	eventTimestamp := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	newAuditResult := func() *protocol.AuditResult {
+		return &protocol.AuditResult{
+			Audit: &job.Audit{
+				ID:             auditID,
+				ProfileID:      profileID,
+				URN:            urn,
+				State:          job.StateCompleted,
+				TotalRecords:   10,
+				EventTimestamp: eventTimestamp,
+			},
+			AuditReports: []*protocol.AuditReport{
+				{
+					AuditID:     auditID,
+					TableURN:    urn,
+					GroupValue:  "2021-01-01",
+					FieldID:     "field_a",
+					MetricName:  metric.NullnessPct,
+					MetricValue: 10.0,
+					PassFlag:    false,
+				},
+			},
+		}
+	}
+	profile := &job.Profile{
+		ID:        profileID,
+		URN:       urn,
+		GroupName: "__PARTITION__",
+		Mode:      job.ModeIncremental,
+	}
+
+	t.Run("GetAudit", func(t *testing.T) {
+		t.Run("should return audit with its result", func(t *testing.T) {
+			auditResult := newAuditResult()
+			summary := &protocol.AuditSummary{IsPass: false, Message: "field_a nullness_pct failed"}
+
+			auditService := mock.NewAuditService()
+			defer auditService.AssertExpectations(t)
+			auditService.On("GetAudit", auditID).Return(auditResult, nil)
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+			profileService.On("Get", profileID).Return(profile, nil)
+
+			summaryFactory := mock.NewAuditSummaryFactory()
+			defer summaryFactory.AssertExpectations(t)
+			summaryFactory.On("Create", auditResult.AuditReports, auditResult.Audit).Return(summary, nil)
+
+			handler := GetAudit(auditService, profileService, summaryFactory)
+			req := httptest.NewRequest(http.MethodGet, "/audit/"+auditID, nil)
+			req = mux.SetURLVars(req, map[string]string{
+				"auditID": auditID,
+			})
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			result := &model.AuditResponse{}
+			err := json.NewDecoder(res.Body).Decode(result)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Equal(t, auditID, result.AuditID)
+			assert.Equal(t, profileID, result.ProfileID)
+			assert.Equal(t, urn, result.URN)
+			assert.False(t, result.Pass)
+			assert.Equal(t, summary.Message, result.Message)
+			assert.Len(t, result.Result, 1)
+			assert.Equal(t, "2021-01-01", result.Result[0].GroupValue)
+		})
+		t.Run("should return not found when audit is not found", func(t *testing.T) {
+			var auditResult *protocol.AuditResult
+
+			auditService := mock.NewAuditService()
+			defer auditService.AssertExpectations(t)
+			auditService.On("GetAudit", auditID).Return(auditResult, protocol.ErrAuditNotFound)
+
+			handler := GetAudit(auditService, mock.NewProfileService(), mock.NewAuditSummaryFactory())
+			req := httptest.NewRequest(http.MethodGet, "/audit/"+auditID, nil)
+			req = mux.SetURLVars(req, map[string]string{
+				"auditID": auditID,
+			})
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			assert.Equal(t, http.StatusNotFound, res.Code)
+		})
+		t.Run("should return bad request when audit ID is invalid", func(t *testing.T) {
+			auditService := mock.NewAuditService()
+			defer auditService.AssertExpectations(t)
+
+			handler := GetAudit(auditService, mock.NewProfileService(), mock.NewAuditSummaryFactory())
+			req := httptest.NewRequest(http.MethodGet, "/audit/abc", nil)
+			req = mux.SetURLVars(req, map[string]string{
+				"auditID": "abc",
+			})
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			assert.Equal(t, http.StatusBadRequest, res.Code)
+		})
+	})
+	t.Run("GetTableAudits", func(t *testing.T) {
+		t.Run("should return audit history filtered by query parameters", func(t *testing.T) {
+			auditResult := newAuditResult()
+			auditResult.Profile = profile
+			summary := &protocol.AuditSummary{IsPass: false}
+			pass := false
+			filter := &protocol.AuditFilter{
+				URN:     urn,
+				From:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:      time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+				Pass:    &pass,
+				FieldID: "field_a",
+				Limit:   5,
+				Offset:  10,
+			}
+
+			auditService := mock.NewAuditService()
+			defer auditService.AssertExpectations(t)
+			auditService.On("GetAudits", filter).Return([]*protocol.AuditResult{auditResult}, nil)
+
+			summaryFactory := mock.NewAuditSummaryFactory()
+			defer summaryFactory.AssertExpectations(t)
+			summaryFactory.On("Create", auditResult.AuditReports, auditResult.Audit).Return(summary, nil)
+
+			handler := GetTableAudits(auditService, summaryFactory)
+			req := httptest.NewRequest(http.MethodGet, "/table/"+urn+"/audits?from=2021-01-01T00:00:00Z&to=2021-01-03T00:00:00Z&pass=false&field_id=field_a&limit=5&offset=10", nil)
+			req = mux.SetURLVars(req, map[string]string{
+				"urn": urn,
+			})
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			result := &model.AuditListResponse{}
+			err := json.NewDecoder(res.Body).Decode(result)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Equal(t, 5, result.Limit)
+			assert.Equal(t, 10, result.Offset)
+			assert.Len(t, result.Audits, 1)
+			assert.Equal(t, auditID, result.Audits[0].AuditID)
+		})
+		t.Run("should return empty list when no audit found", func(t *testing.T) {
+			filter := &protocol.AuditFilter{
+				URN:   urn,
+				Limit: defaultAuditPageLimit,
+			}
+
+			auditService := mock.NewAuditService()
+			defer auditService.AssertExpectations(t)
+			auditService.On("GetAudits", filter).Return([]*protocol.AuditResult{}, nil)
+
+			handler := GetTableAudits(auditService, mock.NewAuditSummaryFactory())
+			req := httptest.NewRequest(http.MethodGet, "/table/"+urn+"/audits", nil)
+			req = mux.SetURLVars(req, map[string]string{
+				"urn": urn,
+			})
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			result := &model.AuditListResponse{}
+			err := json.NewDecoder(res.Body).Decode(result)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Empty(t, result.Audits)
+			assert.Equal(t, defaultAuditPageLimit, result.Limit)
+		})
+		t.Run("should return bad request when query parameter is invalid", func(t *testing.T) {
+			queries := []string{
+				"limit=0",
+				"limit=101",
+				"offset=-1",
+				"pass=maybe",
+				"from=2021-01-01",
+			}
+			for _, query := range queries {
+				auditService := mock.NewAuditService()
+
+				handler := GetTableAudits(auditService, mock.NewAuditSummaryFactory())
+				req := httptest.NewRequest(http.MethodGet, "/table/"+urn+"/audits?"+query, nil)
+				req = mux.SetURLVars(req, map[string]string{
+					"urn": urn,
+				})
+				res := httptest.NewRecorder()
+				handler.ServeHTTP(res, req)
+
+				assert.Equal(t, http.StatusBadRequest, res.Code, query)
+				auditService.AssertExpectations(t)
+			}
+		})
+	})
+	t.Run("GetProfileAudits", func(t *testing.T) {
+		t.Run("should return audits of profile", func(t *testing.T) {
+			auditResult := newAuditResult()
+			auditResult.Profile = profile
+			summary := &protocol.AuditSummary{IsPass: true}
+			filter := &protocol.AuditFilter{
+				ProfileID: profileID,
+				Limit:     defaultAuditPageLimit,
+			}
+
+			auditService := mock.NewAuditService()
+			defer auditService.AssertExpectations(t)
+			auditService.On("GetAudits", filter).Return([]*protocol.AuditResult{auditResult}, nil)
+
+			summaryFactory := mock.NewAuditSummaryFactory()
+			defer summaryFactory.AssertExpectations(t)
+			summaryFactory.On("Create", auditResult.AuditReports, auditResult.Audit).Return(summary, nil)
+
+			handler := GetProfileAudits(auditService, summaryFactory)
+			req := httptest.NewRequest(http.MethodGet, "/profile/"+profileID+"/audits", nil)
+			req = mux.SetURLVars(req, map[string]string{
+				"profileID": profileID,
+			})
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			result := &model.AuditListResponse{}
+			err := json.NewDecoder(res.Body).Decode(result)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Len(t, result.Audits, 1)
+			assert.True(t, result.Audits[0].Pass)
+		})
+	})
+}
@@ -24,6 +24,7 @@ func GetAllEntities(entityStore protocol.EntityStore) http.HandlerFunc {
 				GitURL:           ent.GitURL,
 				Environment:      ent.Environment,
 				GcpProjectIDs:    ent.GcpProjectIDs,
+				MaxBytesBilled:   ent.MaxBytesBilled,
 				CreatedTimestamp: ent.CreatedAt,
 				UpdatedTimestamp: ent.UpdatedAt,
 			}
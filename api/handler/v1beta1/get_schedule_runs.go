@@ -0,0 +1,48 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/protocol"
+)
+
+//GetScheduleRuns provide run history of the schedule of a table
+func GetScheduleRuns(runStore protocol.ScheduleRunStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		urn := vars["urn"]
+
+		runs, err := runStore.GetByURN(urn)
+		if err != nil {
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		response := &model.ScheduleRunsResponse{
+			URN:  urn,
+			Runs: []*model.ScheduleRunResponse{},
+		}
+		for _, run := range runs {
+			response.Runs = append(response.Runs, &model.ScheduleRunResponse{
+				ID:            run.ID,
+				URN:           run.URN,
+				ScheduledTime: run.ScheduledTime,
+				Status:        string(run.Status),
+				Message:       run.Message,
+				ProfileID:     run.ProfileID,
+				AuditID:       run.AuditID,
+				CreatedAt:     run.CreatedAt,
+				UpdatedAt:     run.UpdatedAt,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			printError(w, err, http.StatusInternalServerError)
+		}
+	}
+}
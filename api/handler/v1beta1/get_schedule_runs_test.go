@@ -0,0 +1,80 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScheduleRuns(t *testing.T) {
+	urn := "project.dataset.table"
+	t.Run("should return runs of the schedule", func(t *testing.T) {
+		scheduledTime := time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC)
+		runs := []*protocol.ScheduleRun{
+			{
+				ID:            1,
+				URN:           urn,
+				ScheduledTime: scheduledTime,
+				Status:        protocol.ScheduleRunCompleted,
+				Message:       "audit completed",
+				ProfileID:     "profile-1",
+				AuditID:       "audit-1",
+			},
+		}
+
+		runStore := mock.NewScheduleRunStore()
+		defer runStore.AssertExpectations(t)
+		runStore.On("GetByURN", urn).Return(runs, nil)
+
+		handler := GetScheduleRuns(runStore)
+		req := httptest.NewRequest(http.MethodGet, "/v1beta1/schedule/"+urn+"/run", nil)
+		req = mux.SetURLVars(req, map[string]string{"urn": urn})
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		expected := &model.ScheduleRunsResponse{
+			URN: urn,
+			Runs: []*model.ScheduleRunResponse{
+				{
+					ID:            1,
+					URN:           urn,
+					ScheduledTime: scheduledTime,
+					Status:        "completed",
+					Message:       "audit completed",
+					ProfileID:     "profile-1",
+					AuditID:       "audit-1",
+				},
+			},
+		}
+
+		result := &model.ScheduleRunsResponse{}
+		err := json.NewDecoder(res.Body).Decode(result)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, expected, result)
+	})
+	t.Run("should return internal server error when get runs failed", func(t *testing.T) {
+		var runs []*protocol.ScheduleRun
+
+		runStore := mock.NewScheduleRunStore()
+		runStore.On("GetByURN", urn).Return(runs, errors.New("connection error"))
+
+		handler := GetScheduleRuns(runStore)
+		req := httptest.NewRequest(http.MethodGet, "/v1beta1/schedule/"+urn+"/run", nil)
+		req = mux.SetURLVars(req, map[string]string{"urn": urn})
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
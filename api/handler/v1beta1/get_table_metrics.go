@@ -0,0 +1,147 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/metric"
+)
+
+//GetTableMetrics provide time series of metrics of a table
+func GetTableMetrics(metricStore protocol.MetricStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		urn := vars["urn"]
+
+		if urn == "" {
+			printError(w, errors.New("invalid urn"), http.StatusBadRequest)
+			return
+		}
+
+		query, err := parseMetricQuery(r)
+		if err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+		query.URN = urn
+
+		metrics, err := metricStore.GetMetrics(query)
+		if err != nil {
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		response := &model.MetricSeriesListResponse{
+			URN:    urn,
+			Series: toMetricSeries(metrics),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			printError(w, err, http.StatusInternalServerError)
+		}
+	}
+}
+
+//toMetricSeries group metrics by field, group value and metric name, metrics are expected ordered by event timestamp
+func toMetricSeries(metrics []*protocol.ProfileMetric) []*model.MetricSeriesResponse {
+	type seriesKey struct {
+		fieldID    string
+		groupValue string
+		metricName string
+	}
+
+	seriesByKey := make(map[seriesKey]*model.MetricSeriesResponse)
+	var keys []seriesKey
+	for _, m := range metrics {
+		key := seriesKey{fieldID: m.FieldID, groupValue: m.Partition, metricName: m.MetricName.String()}
+		series, ok := seriesByKey[key]
+		if !ok {
+			series = &model.MetricSeriesResponse{
+				FieldID:    key.fieldID,
+				GroupValue: key.groupValue,
+				MetricName: key.metricName,
+				Points:     []*model.MetricPointResponse{},
+			}
+			seriesByKey[key] = series
+			keys = append(keys, key)
+		}
+		series.Points = append(series.Points, &model.MetricPointResponse{
+			ProfileID:      m.ProfileID,
+			Value:          m.MetricValue,
+			EventTimestamp: m.EventTimestamp,
+		})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].fieldID != keys[j].fieldID {
+			return keys[i].fieldID < keys[j].fieldID
+		}
+		if keys[i].metricName != keys[j].metricName {
+			return keys[i].metricName < keys[j].metricName
+		}
+		return keys[i].groupValue < keys[j].groupValue
+	})
+
+	result := make([]*model.MetricSeriesResponse, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, seriesByKey[key])
+	}
+	return result
+}
+
+//parseMetricQuery parse metric, field, group, from, to and limit query parameters
+//metric accepts comma separated metric names
+func parseMetricQuery(r *http.Request) (*protocol.MetricQuery, error) {
+	values := r.URL.Query()
+	query := &protocol.MetricQuery{
+		FieldID:   values.Get("field"),
+		Partition: values.Get("group"),
+	}
+
+	for _, name := range strings.Split(values.Get("metric"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			query.MetricTypes = append(query.MetricTypes, metric.Type(name))
+		}
+	}
+
+	var err error
+	if query.From, err = parseTimeQuery(values, "from"); err != nil {
+		return nil, err
+	}
+	if query.To, err = parseTimeQuery(values, "to"); err != nil {
+		return nil, err
+	}
+	if value := values.Get("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("invalid limit, %w", err)
+		}
+		if query.Limit <= 0 || query.Limit > protocol.MaxMetricQueryLimit {
+			return nil, fmt.Errorf("invalid limit, should be between 1 and %d", protocol.MaxMetricQueryLimit)
+		}
+	}
+	return query, nil
+}
+
+//parseTimeQuery parse RFC3339 timestamp query parameter, zero time is returned when the parameter is absent
+func parseTimeQuery(values url.Values, key string) (time.Time, error) {
+	value := values.Get(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s, %w", key, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,114 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTableMetrics(t *testing.T) {
+	urn := "project.dataset.table"
+	day := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should return metric time series of table", func(t *testing.T) {
+		query := &protocol.MetricQuery{
+			URN:         urn,
+			FieldID:     "field_a",
+			Partition:   "2021-01-01",
+			MetricTypes: []metric.Type{metric.NullnessPct, metric.Count},
+			From:        day,
+			To:          day.Add(48 * time.Hour),
+			Limit:       100,
+		}
+		metrics := []*protocol.ProfileMetric{
+			{ProfileID: "profile-1", TableURN: urn, Partition: "2021-01-01", FieldID: "field_a", MetricName: metric.NullnessPct, MetricValue: 1, EventTimestamp: day},
+			{ProfileID: "profile-1", TableURN: urn, Partition: "2021-01-01", FieldID: "field_a", MetricName: metric.Count, MetricValue: 10, EventTimestamp: day},
+			{ProfileID: "profile-2", TableURN: urn, Partition: "2021-01-01", FieldID: "field_a", MetricName: metric.NullnessPct, MetricValue: 2, EventTimestamp: day.Add(24 * time.Hour)},
+		}
+
+		metricStore := mock.NewMetricStore()
+		defer metricStore.AssertExpectations(t)
+		metricStore.On("GetMetrics", query).Return(metrics, nil)
+
+		handler := GetTableMetrics(metricStore)
+		req := httptest.NewRequest(http.MethodGet, "/table/"+urn+"/metrics?metric=nullness_pct,count&field=field_a&group=2021-01-01&from=2021-01-01T00:00:00Z&to=2021-01-03T00:00:00Z&limit=100", nil)
+		req = mux.SetURLVars(req, map[string]string{
+			"urn": urn,
+		})
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		expected := &model.MetricSeriesListResponse{
+			URN: urn,
+			Series: []*model.MetricSeriesResponse{
+				{
+					FieldID:    "field_a",
+					GroupValue: "2021-01-01",
+					MetricName: "count",
+					Points: []*model.MetricPointResponse{
+						{ProfileID: "profile-1", Value: 10, EventTimestamp: day},
+					},
+				},
+				{
+					FieldID:    "field_a",
+					GroupValue: "2021-01-01",
+					MetricName: "nullness_pct",
+					Points: []*model.MetricPointResponse{
+						{ProfileID: "profile-1", Value: 1, EventTimestamp: day},
+						{ProfileID: "profile-2", Value: 2, EventTimestamp: day.Add(24 * time.Hour)},
+					},
+				},
+			},
+		}
+
+		result := &model.MetricSeriesListResponse{}
+		err := json.NewDecoder(res.Body).Decode(result)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, expected, result)
+	})
+	t.Run("should return bad request when query parameter is invalid", func(t *testing.T) {
+		for _, query := range []string{"from=yesterday", "limit=0", "limit=10001", "limit=all"} {
+			metricStore := mock.NewMetricStore()
+
+			handler := GetTableMetrics(metricStore)
+			req := httptest.NewRequest(http.MethodGet, "/table/"+urn+"/metrics?"+query, nil)
+			req = mux.SetURLVars(req, map[string]string{
+				"urn": urn,
+			})
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, req)
+
+			assert.Equal(t, http.StatusBadRequest, res.Code, query)
+			metricStore.AssertExpectations(t)
+		}
+	})
+	t.Run("should return internal server error when store failed", func(t *testing.T) {
+		var metrics []*protocol.ProfileMetric
+
+		metricStore := mock.NewMetricStore()
+		defer metricStore.AssertExpectations(t)
+		metricStore.On("GetMetrics", &protocol.MetricQuery{URN: urn}).Return(metrics, errors.New("db error"))
+
+		handler := GetTableMetrics(metricStore)
+		req := httptest.NewRequest(http.MethodGet, "/table/"+urn+"/metrics", nil)
+		req = mux.SetURLVars(req, map[string]string{
+			"urn": urn,
+		})
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
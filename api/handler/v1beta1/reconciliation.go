@@ -0,0 +1,102 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/util"
+)
+
+//Reconcile handle request to reconcile target table with its source table, response is returned once the reconciliation is enqueued
+func Reconcile(reconciliationService protocol.ReconciliationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body model.ReconciliationRequest
+		if err := getRequestBody(r, &body); err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		if err := body.Validate(); err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		result, err := reconciliationService.Reconcile(body.ToReconciliation())
+		if err != nil {
+			if errors.Is(err, protocol.ErrReconciliationInvalid) {
+				printError(w, err, http.StatusBadRequest)
+				return
+			}
+			if err == protocol.ErrTableMetadataNotFound {
+				printError(w, err, http.StatusNotFound)
+				return
+			}
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		writeReconciliationResponse(w, result)
+	}
+}
+
+//GetReconciliation provide reconciliation and its audit result
+func GetReconciliation(reconciliationService protocol.ReconciliationService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		ID := vars["reconciliationID"]
+
+		if !util.IsUUIDValid(ID) {
+			printError(w, errors.New("invalid reconciliationID"), http.StatusBadRequest)
+			return
+		}
+
+		result, err := reconciliationService.Get(ID)
+		if err != nil {
+			if err == protocol.ErrReconciliationNotFound {
+				printError(w, err, http.StatusNotFound)
+				return
+			}
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		writeReconciliationResponse(w, result)
+	}
+}
+
+func writeReconciliationResponse(w http.ResponseWriter, result *protocol.ReconciliationResult) {
+	reconciliation := result.Reconciliation
+	response := &model.ReconciliationResponse{
+		ID:              reconciliation.ID,
+		SourceURN:       reconciliation.SourceURN,
+		TargetURN:       reconciliation.TargetURN,
+		Filter:          reconciliation.Filter,
+		Group:           reconciliation.GroupName,
+		SumFields:       reconciliation.SumFields,
+		KeyFields:       reconciliation.KeyFields,
+		MaxDiffPct:      reconciliation.MaxDiffPct,
+		SourceProfileID: reconciliation.SourceProfileID,
+		TargetProfileID: reconciliation.TargetProfileID,
+		AuditID:         reconciliation.AuditID,
+		Status:          string(reconciliation.State),
+		Pass:            len(result.AuditReports) > 0,
+		Message:         reconciliation.Message,
+		Result:          groupAuditReports(result.AuditReports),
+		CreatedAt:       reconciliation.EventTimestamp,
+	}
+	for _, report := range result.AuditReports {
+		response.Pass = response.Pass && report.PassFlag
+	}
+	if issues := protocol.FormIssueSummary(result.AuditReports); issues != "" {
+		response.Message = issues
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		printError(w, err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,118 @@
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	sourceURN := "project-a.dataset.orders"
+	targetURN := "project-b.dataset.orders"
+	request := &model.ReconciliationRequest{
+		SourceURN:  sourceURN,
+		TargetURN:  targetURN,
+		Filter:     "__PARTITION__ = '2021-03-01'",
+		Group:      "__PARTITION__",
+		SumFields:  []string{"amount"},
+		MaxDiffPct: 1,
+	}
+
+	t.Run("should return enqueued reconciliation", func(t *testing.T) {
+		reconciliation := request.ToReconciliation()
+		result := &protocol.ReconciliationResult{
+			Reconciliation: &job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, State: job.StateCreated},
+		}
+
+		service := mock.NewReconciliationService()
+		defer service.AssertExpectations(t)
+		service.On("Reconcile", reconciliation).Return(result, nil)
+
+		body, _ := json.Marshal(request)
+		req := httptest.NewRequest(http.MethodPost, "/v1beta1/reconciliation", bytes.NewBuffer(body))
+		res := httptest.NewRecorder()
+		Reconcile(service).ServeHTTP(res, req)
+
+		var response model.ReconciliationResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "reconciliation-1", response.ID)
+		assert.Equal(t, string(job.StateCreated), response.Status)
+		assert.False(t, response.Pass)
+		assert.Empty(t, response.Result)
+	})
+	t.Run("should return bad request when source and target are the same table", func(t *testing.T) {
+		body, _ := json.Marshal(&model.ReconciliationRequest{SourceURN: sourceURN, TargetURN: sourceURN})
+		req := httptest.NewRequest(http.MethodPost, "/v1beta1/reconciliation", bytes.NewBuffer(body))
+		res := httptest.NewRecorder()
+		Reconcile(mock.NewReconciliationService()).ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+	t.Run("should return bad request when compared fields are invalid", func(t *testing.T) {
+		service := mock.NewReconciliationService()
+		service.On("Reconcile", request.ToReconciliation()).Return(&protocol.ReconciliationResult{},
+			fmt.Errorf("%w: sum field amount of table %s is not numeric", protocol.ErrReconciliationInvalid, sourceURN))
+
+		body, _ := json.Marshal(request)
+		req := httptest.NewRequest(http.MethodPost, "/v1beta1/reconciliation", bytes.NewBuffer(body))
+		res := httptest.NewRecorder()
+		Reconcile(service).ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestGetReconciliation(t *testing.T) {
+	t.Run("should return not found when reconciliation is not found", func(t *testing.T) {
+		ID := uuid.Must(uuid.NewRandom()).String()
+		service := mock.NewReconciliationService()
+		defer service.AssertExpectations(t)
+		service.On("Get", ID).Return(&protocol.ReconciliationResult{}, protocol.ErrReconciliationNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1beta1/reconciliation/"+ID, nil)
+		req = mux.SetURLVars(req, map[string]string{"reconciliationID": ID})
+		res := httptest.NewRecorder()
+		GetReconciliation(service).ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+	t.Run("should return failed result with issue message", func(t *testing.T) {
+		ID := uuid.Must(uuid.NewRandom()).String()
+		result := &protocol.ReconciliationResult{
+			Reconciliation: &job.Reconciliation{ID: ID, State: job.StateCompleted, Message: "reconciled"},
+			AuditReports: []*protocol.AuditReport{
+				{MetricName: metric.ReconciliationDiffPct, MetricValue: 100, PassFlag: false},
+			},
+		}
+		service := mock.NewReconciliationService()
+		service.On("Get", ID).Return(result, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/v1beta1/reconciliation/"+ID, nil)
+		req = mux.SetURLVars(req, map[string]string{"reconciliationID": ID})
+		res := httptest.NewRecorder()
+		GetReconciliation(service).ServeHTTP(res, req)
+
+		var response model.ReconciliationResponse
+		err := json.NewDecoder(res.Body).Decode(&response)
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.False(t, response.Pass)
+		assert.Contains(t, response.Message, "RECONCILIATION_DIFF_PCT IS NOT PASSED THE TOLERANCE")
+	})
+}
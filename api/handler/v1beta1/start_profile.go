@@ -25,65 +25,13 @@ func Profile(profileService protocol.ProfileService, sqlExpressionFac protocol.S
 			return
 		}
 
-		currentTime := time.Now().In(time.UTC)
-		profile := &job.Profile{
-			URN:            body.URN,
-			Mode:           body.Mode,
-			Filter:         body.Filter,
-			GroupName:      body.Group,
-			Status:         job.StateCreated,
-			Message:        "profile created",
-			EventTimestamp: currentTime,
-		}
-
-		if len(body.AuditTime) > 0 {
-			auditTime, err := time.Parse(time.RFC3339, body.AuditTime)
-			if err != nil {
-				printError(w, err, http.StatusBadRequest)
-			}
-			profile.AuditTimestamp = auditTime
-		} else {
-			profile.AuditTimestamp = currentTime
-		}
-
-		if macros.IsUsingMacros(profile.GroupName, macros.Partition) {
-			renderedExpression, err := sqlExpressionFac.CreatePartitionExpression(profile.URN)
-			if err != nil {
-				if err == protocol.ErrPartitionExpressionIsNotSupported {
-					printError(w, err, http.StatusBadRequest)
-					return
-				}
-				printError(w, err, http.StatusInternalServerError)
-				return
-			}
-
-			newGroup, err := macros.ReplaceMacros(profile.GroupName, renderedExpression, macros.Partition)
-			if err != nil {
-				printError(w, err, http.StatusInternalServerError)
-				return
-			}
-			profile.GroupName = newGroup
-		}
-
-		if macros.IsUsingMacros(profile.Filter, macros.Partition) {
-			renderedExpression, err := sqlExpressionFac.CreatePartitionExpression(profile.URN)
-			if err != nil {
-				if err == protocol.ErrPartitionExpressionIsNotSupported {
-					printError(w, err, http.StatusBadRequest)
-					return
-				}
-				printError(w, err, http.StatusInternalServerError)
-				return
-			}
-			newFilter, err := macros.ReplaceMacros(profile.Filter, renderedExpression, macros.Partition)
-			if err != nil {
-				printError(w, err, http.StatusInternalServerError)
-				return
-			}
-			profile.Filter = newFilter
+		profile, status, err := newProfile(&body, sqlExpressionFac)
+		if err != nil {
+			printError(w, err, status)
+			return
 		}
 
-		profile, err := profileService.CreateProfile(profile)
+		profile, err = profileService.CreateProfile(profile)
 		if err != nil {
 			printError(w, err, http.StatusInternalServerError)
 			return
@@ -107,3 +55,62 @@ func Profile(profileService protocol.ProfileService, sqlExpressionFac protocol.S
 		}
 	}
 }
+
+//newProfile create profile of the request, partition macros on the group and filter are rendered
+//http status is returned along with the error
+func newProfile(body *model.ProfileRequest, sqlExpressionFac protocol.SQLExpressionFactory) (*job.Profile, int, error) {
+	currentTime := time.Now().In(time.UTC)
+	profile := &job.Profile{
+		URN:            body.URN,
+		Mode:           body.Mode,
+		Filter:         body.Filter,
+		GroupName:      body.Group,
+		Status:         job.StateCreated,
+		Message:        "profile created",
+		EventTimestamp: currentTime,
+	}
+
+	if len(body.AuditTime) > 0 {
+		auditTime, err := time.Parse(time.RFC3339, body.AuditTime)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+		profile.AuditTimestamp = auditTime
+	} else {
+		profile.AuditTimestamp = currentTime
+	}
+
+	if macros.IsUsingMacros(profile.GroupName, macros.Partition) {
+		newGroup, status, err := renderPartitionMacros(profile.GroupName, profile.URN, sqlExpressionFac)
+		if err != nil {
+			return nil, status, err
+		}
+		profile.GroupName = newGroup
+	}
+
+	if macros.IsUsingMacros(profile.Filter, macros.Partition) {
+		newFilter, status, err := renderPartitionMacros(profile.Filter, profile.URN, sqlExpressionFac)
+		if err != nil {
+			return nil, status, err
+		}
+		profile.Filter = newFilter
+	}
+
+	return profile, http.StatusOK, nil
+}
+
+func renderPartitionMacros(expression string, urn string, sqlExpressionFac protocol.SQLExpressionFactory) (string, int, error) {
+	renderedExpression, err := sqlExpressionFac.CreatePartitionExpression(urn)
+	if err != nil {
+		if err == protocol.ErrPartitionExpressionIsNotSupported {
+			return "", http.StatusBadRequest, err
+		}
+		return "", http.StatusInternalServerError, err
+	}
+
+	newExpression, err := macros.ReplaceMacros(expression, renderedExpression, macros.Partition)
+	if err != nil {
+		return "", http.StatusInternalServerError, err
+	}
+	return newExpression, http.StatusOK, nil
+}
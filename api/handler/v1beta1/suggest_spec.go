@@ -0,0 +1,111 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/macros"
+	"github.com/odpf/predator/tolerance"
+	"github.com/odpf/predator/util"
+)
+
+//SuggestSpec handle request to suggest tolerance spec of a table, when the table has no metric history
+//an exploratory profile is enqueued and the suggestion is returned without waiting for the profile
+func SuggestSpec(suggester protocol.SpecSuggester, sqlExpressionFac protocol.SQLExpressionFactory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body model.SpecSuggestionRequest
+		if err := getRequestBody(r, &body); err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		urn := body.URN
+		if urn == "" {
+			printError(w, errors.New("urn is required"), http.StatusBadRequest)
+			return
+		}
+		if _, err := protocol.ParseLabel(urn); err != nil {
+			printError(w, err, http.StatusBadRequest)
+			return
+		}
+
+		filter := body.Filter
+		if macros.IsUsingMacros(filter, macros.Partition) {
+			newFilter, status, err := renderPartitionMacros(filter, urn, sqlExpressionFac)
+			if err != nil {
+				printError(w, err, status)
+				return
+			}
+			filter = newFilter
+		}
+
+		suggestion, err := suggester.Suggest(urn, filter)
+		if err != nil {
+			if err == protocol.ErrTableMetadataNotFound {
+				printError(w, err, http.StatusNotFound)
+				return
+			}
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		writeSpecSuggestionResponse(w, suggestion)
+	}
+}
+
+//GetSpecSuggestion provide spec suggestion of an exploratory profile, the spec is set once the profile is completed
+func GetSpecSuggestion(suggester protocol.SpecSuggester) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		ID := vars["profileID"]
+
+		if !util.IsUUIDValid(ID) {
+			printError(w, errors.New("invalid profileID"), http.StatusBadRequest)
+			return
+		}
+
+		suggestion, err := suggester.GetSuggestion(ID)
+		if err != nil {
+			if err == protocol.ErrProfileNotFound || err == protocol.ErrTableMetadataNotFound {
+				printError(w, err, http.StatusNotFound)
+				return
+			}
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+
+		writeSpecSuggestionResponse(w, suggestion)
+	}
+}
+
+func writeSpecSuggestionResponse(w http.ResponseWriter, suggestion *protocol.SpecSuggestion) {
+	response := &model.SpecSuggestionResponse{
+		URN:    suggestion.URN,
+		Status: job.StateCompleted.String(),
+	}
+	if profile := suggestion.Profile; profile != nil {
+		response.ProfileID = profile.ID
+		response.Status = profile.Status.String()
+		response.Message = profile.Message
+	}
+
+	if suggestion.Spec != nil {
+		parser := &tolerance.CompactSpecParser{}
+		content, err := parser.Serialise(suggestion.Spec)
+		if err != nil {
+			printError(w, err, http.StatusInternalServerError)
+			return
+		}
+		response.Spec = string(content)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		printError(w, err, http.StatusInternalServerError)
+	}
+}
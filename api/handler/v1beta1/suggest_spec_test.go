@@ -0,0 +1,196 @@
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/odpf/predator/api/model"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/odpf/predator/tolerance"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSuggestSpecRequest(t *testing.T, request *model.SpecSuggestionRequest) *http.Request {
+	content, err := json.Marshal(request)
+	assert.Nil(t, err)
+	return httptest.NewRequest(http.MethodPost, "/v1beta1/spec/suggest", bytes.NewBuffer(content))
+}
+
+func TestSuggestSpec(t *testing.T) {
+	urn := "project.dataset.table"
+
+	t.Run("should return suggested spec as compact spec yaml", func(t *testing.T) {
+		spec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					TableURN:       urn,
+					FieldID:        "field_a",
+					MetricName:     metric.NullnessPct,
+					ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+				},
+			},
+		}
+
+		suggester := mock.NewSpecSuggester()
+		defer suggester.AssertExpectations(t)
+		suggester.On("Suggest", urn, "").Return(&protocol.SpecSuggestion{URN: urn, Spec: spec}, nil)
+
+		handler := SuggestSpec(suggester, mock.NewSQLExpressionFactory())
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, newSuggestSpecRequest(t, &model.SpecSuggestionRequest{URN: urn}))
+
+		var response model.SpecSuggestionResponse
+		assert.Nil(t, json.Unmarshal(res.Body.Bytes(), &response))
+
+		parser := &tolerance.CompactSpecParser{}
+		parsed, err := parser.Parse([]byte(response.Spec))
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+		assert.Equal(t, job.StateCompleted.String(), response.Status)
+		assert.Empty(t, response.ProfileID)
+		assert.Equal(t, urn, parsed.URN)
+		assert.Len(t, parsed.Tolerances, 1)
+	})
+	t.Run("should return enqueued exploratory profile when there is no metric history", func(t *testing.T) {
+		profile := &job.Profile{
+			ID:      "a4d7e2b1-3c2f-4e0a-9b8d-6f1e2d3c4b5a",
+			URN:     urn,
+			Kind:    job.KindExploratory,
+			Status:  job.StateCreated,
+			Message: "exploratory profile to suggest tolerance spec",
+		}
+
+		suggester := mock.NewSpecSuggester()
+		defer suggester.AssertExpectations(t)
+		suggester.On("Suggest", urn, "").Return(&protocol.SpecSuggestion{URN: urn, Profile: profile}, nil)
+
+		handler := SuggestSpec(suggester, mock.NewSQLExpressionFactory())
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, newSuggestSpecRequest(t, &model.SpecSuggestionRequest{URN: urn}))
+
+		var response model.SpecSuggestionResponse
+		assert.Nil(t, json.Unmarshal(res.Body.Bytes(), &response))
+
+		expected := model.SpecSuggestionResponse{
+			URN:       urn,
+			ProfileID: profile.ID,
+			Status:    job.StateCreated.String(),
+			Message:   profile.Message,
+		}
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, expected, response)
+	})
+	t.Run("should render partition macros of filter", func(t *testing.T) {
+		spec := &protocol.ToleranceSpec{URN: urn}
+
+		sqlExpressionFactory := mock.NewSQLExpressionFactory()
+		defer sqlExpressionFactory.AssertExpectations(t)
+		sqlExpressionFactory.On("CreatePartitionExpression", urn).Return("DATE(created_at)", nil)
+
+		suggester := mock.NewSpecSuggester()
+		defer suggester.AssertExpectations(t)
+		suggester.On("Suggest", urn, "DATE(created_at) = '2021-01-01'").Return(&protocol.SpecSuggestion{URN: urn, Spec: spec}, nil)
+
+		handler := SuggestSpec(suggester, sqlExpressionFactory)
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, newSuggestSpecRequest(t, &model.SpecSuggestionRequest{URN: urn, Filter: "__PARTITION__ = '2021-01-01'"}))
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+	t.Run("should return bad request when urn is invalid", func(t *testing.T) {
+		suggester := mock.NewSpecSuggester()
+		defer suggester.AssertExpectations(t)
+
+		handler := SuggestSpec(suggester, mock.NewSQLExpressionFactory())
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, newSuggestSpecRequest(t, &model.SpecSuggestionRequest{URN: "table"}))
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+	t.Run("should return not found when table is not found", func(t *testing.T) {
+		var suggestion *protocol.SpecSuggestion
+
+		suggester := mock.NewSpecSuggester()
+		defer suggester.AssertExpectations(t)
+		suggester.On("Suggest", urn, "").Return(suggestion, protocol.ErrTableMetadataNotFound)
+
+		handler := SuggestSpec(suggester, mock.NewSQLExpressionFactory())
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, newSuggestSpecRequest(t, &model.SpecSuggestionRequest{URN: urn}))
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+	t.Run("should return internal server error when suggestion failed", func(t *testing.T) {
+		var suggestion *protocol.SpecSuggestion
+
+		suggester := mock.NewSpecSuggester()
+		defer suggester.AssertExpectations(t)
+		suggester.On("Suggest", urn, "").Return(suggestion, errors.New("database error"))
+
+		handler := SuggestSpec(suggester, mock.NewSQLExpressionFactory())
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, newSuggestSpecRequest(t, &model.SpecSuggestionRequest{URN: urn}))
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
+
+func TestGetSpecSuggestion(t *testing.T) {
+	urn := "project.dataset.table"
+	profileID := "a4d7e2b1-3c2f-4e0a-9b8d-6f1e2d3c4b5a"
+
+	serve := func(suggester protocol.SpecSuggester, ID string) *httptest.ResponseRecorder {
+		router := mux.NewRouter()
+		router.Methods(http.MethodGet).Path("/v1beta1/spec/suggest/{profileID}").Handler(GetSpecSuggestion(suggester))
+		req := httptest.NewRequest(http.MethodGet, "/v1beta1/spec/suggest/"+ID, nil)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		return res
+	}
+
+	t.Run("should return suggested spec of completed exploratory profile", func(t *testing.T) {
+		profile := &job.Profile{ID: profileID, URN: urn, Kind: job.KindExploratory, Status: job.StateCompleted, Message: "profile completed"}
+		spec := &protocol.ToleranceSpec{URN: urn}
+
+		suggester := mock.NewSpecSuggester()
+		defer suggester.AssertExpectations(t)
+		suggester.On("GetSuggestion", profileID).Return(&protocol.SpecSuggestion{URN: urn, Spec: spec, Profile: profile}, nil)
+
+		res := serve(suggester, profileID)
+
+		var response model.SpecSuggestionResponse
+		assert.Nil(t, json.Unmarshal(res.Body.Bytes(), &response))
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, profileID, response.ProfileID)
+		assert.Equal(t, job.StateCompleted.String(), response.Status)
+		assert.NotEmpty(t, response.Spec)
+	})
+	t.Run("should return bad request when profile ID is invalid", func(t *testing.T) {
+		res := serve(mock.NewSpecSuggester(), "profile-1")
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+	t.Run("should return not found when exploratory profile is not found", func(t *testing.T) {
+		var suggestion *protocol.SpecSuggestion
+
+		suggester := mock.NewSpecSuggester()
+		defer suggester.AssertExpectations(t)
+		suggester.On("GetSuggestion", profileID).Return(suggestion, protocol.ErrProfileNotFound)
+
+		res := serve(suggester, profileID)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+}
@@ -29,7 +29,7 @@ func Upload(uploadFactory protocol.UploadFactory) http.HandlerFunc {
 			PathPrefix: body.PathPrefix,
 		}
 
-		uploadTask, err := uploadFactory.Create(gitRepo)
+		uploadTask, err := uploadFactory.Create(gitRepo, body.DryRun)
 		if err != nil {
 			printError(w, err, http.StatusInternalServerError)
 			return
@@ -37,7 +37,7 @@ func Upload(uploadFactory protocol.UploadFactory) http.HandlerFunc {
 
 		result, err := uploadTask.Run()
 		if err != nil {
-			if protocol.IsUploadSpecValidationError(err) {
+			if protocol.IsUploadSpecValidationError(err) || protocol.IsUploadRemovalLimitError(err) {
 				printError(w, err, http.StatusBadRequest)
 				return
 			}
@@ -45,18 +45,21 @@ func Upload(uploadFactory protocol.UploadFactory) http.HandlerFunc {
 			return
 		}
 
-		diff, ok := result.(*job.Diff)
-		if !ok {
+		var report *model.UploadReport
+		switch diff := result.(type) {
+		case *job.Diff:
+			report = &model.UploadReport{
+				RemovedCount:  diff.RemovedCount(),
+				UploadedCount: diff.AddedCount() + diff.UpdatedCount(),
+			}
+		case *protocol.UploadDiff:
+			report = model.NewUploadDiffReport(diff)
+		default:
 			err := errors.New("something wrong with upload job")
 			printError(w, err, http.StatusInternalServerError)
 			return
 		}
 
-		report := &model.UploadReport{
-			RemovedCount:  diff.RemovedCount(),
-			UploadedCount: diff.AddedCount() + diff.UpdatedCount(),
-		}
-
 		if err := json.NewEncoder(w).Encode(report); err != nil {
 			printError(w, err, http.StatusInternalServerError)
 		}
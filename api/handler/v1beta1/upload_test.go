@@ -8,6 +8,7 @@ import (
 	"github.com/odpf/predator/mock"
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
@@ -40,7 +41,7 @@ func TestUpload(t *testing.T) {
 			uploadFactory := mock.NewMockUploadFactory()
 			defer uploadFactory.AssertExpectations(t)
 
-			uploadFactory.On("Create", gitRepo).Return(uploadTask, nil)
+			uploadFactory.On("Create", gitRepo, false).Return(uploadTask, nil)
 			report := &job.Diff{
 				Add: []string{"a.b.c"},
 			}
@@ -94,7 +95,7 @@ func TestUpload(t *testing.T) {
 			uploadFactory := mock.NewMockUploadFactory()
 			defer uploadFactory.AssertExpectations(t)
 
-			uploadFactory.On("Create", gitRepo).Return(uploadTask, nil)
+			uploadFactory.On("Create", gitRepo, false).Return(uploadTask, nil)
 			report := &job.Diff{
 				Add: []string{"a.b.c"},
 			}
@@ -130,13 +131,97 @@ func TestUpload(t *testing.T) {
 			uploadFactory := mock.NewMockUploadFactory()
 			defer uploadFactory.AssertExpectations(t)
 
-			uploadFactory.On("Create", gitRepo).Return(uploadTask, nil)
+			uploadFactory.On("Create", gitRepo, false).Return(uploadTask, nil)
 			var report *job.Diff
 			uploadTask.On("Run").Return(report, gitError)
 
 			handler := Upload(uploadFactory)
 			handler.ServeHTTP(res, req)
 
+			assert.Equal(t, http.StatusBadRequest, res.Code)
+		})
+		t.Run("should return changes of the specs on dry run", func(t *testing.T) {
+			uploadRequest := &model.UploadRequest{
+				GitURL:   "git@sample-url:entity-1.git",
+				CommitID: "123abcd",
+				DryRun:   true,
+			}
+
+			gitRepo := &protocol.GitInfo{
+				URL:      "git@sample-url:entity-1.git",
+				CommitID: "123abcd",
+			}
+
+			requestBody, _ := json.Marshal(uploadRequest)
+
+			req := httptest.NewRequest("POST", "/upload", bytes.NewBuffer(requestBody))
+			res := httptest.NewRecorder()
+
+			uploadTask := mock.NewMockUpload()
+			defer uploadTask.AssertExpectations(t)
+
+			uploadFactory := mock.NewMockUploadFactory()
+			defer uploadFactory.AssertExpectations(t)
+
+			uploadFactory.On("Create", gitRepo, true).Return(uploadTask, nil)
+			diff := &protocol.UploadDiff{
+				Added: []string{"a.b.c"},
+				Changed: []*protocol.SpecDiff{
+					{
+						URN: "a.b.d",
+						Changes: []*protocol.SpecChange{
+							{Type: protocol.ChangeModified, FieldID: "id", MetricName: metric.NullnessPct, Attribute: "severity", Before: protocol.SeverityError, After: protocol.SeverityWarn},
+							{
+								Type:       protocol.ChangeModified,
+								MetricName: metric.RowCount,
+								Attribute:  "group_overrides",
+								Before:     []*protocol.GroupOverride(nil),
+								After:      []*protocol.GroupOverride{{Group: "ID", ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 10}}}},
+							},
+						},
+					},
+				},
+				UnchangedCount: 2,
+			}
+			uploadTask.On("Run").Return(diff, nil)
+
+			handler := Upload(uploadFactory)
+			handler.ServeHTTP(res, req)
+
+			expected := `{"uploaded":4,"removed":0,"dry_run":true,"diff":{"added":["a.b.c"],"removed":[],"changed":[{"urn":"a.b.d","changes":[{"type":"modified","field_id":"id","metric_name":"nullness_pct","attribute":"severity","before":"error","after":"warn"},{"type":"modified","metric_name":"row_count","attribute":"group_overrides","after":[{"group":"ID","tolerance_rules":[{"comparator":"more_than","value":10}]}]}]}],"unchanged":2,"removal_limit_exceeded":false}}
+`
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.Equal(t, expected, res.Body.String())
+		})
+		t.Run("should return bad request when removed specs are more than the limit", func(t *testing.T) {
+			uploadRequest := &model.UploadRequest{
+				GitURL:   "git@sample-url:entity-1.git",
+				CommitID: "123abcd",
+			}
+
+			gitRepo := &protocol.GitInfo{
+				URL:      "git@sample-url:entity-1.git",
+				CommitID: "123abcd",
+			}
+
+			requestBody, _ := json.Marshal(uploadRequest)
+
+			req := httptest.NewRequest("POST", "/upload", bytes.NewBuffer(requestBody))
+			res := httptest.NewRecorder()
+
+			uploadTask := mock.NewMockUpload()
+			defer uploadTask.AssertExpectations(t)
+
+			uploadFactory := mock.NewMockUploadFactory()
+			defer uploadFactory.AssertExpectations(t)
+
+			uploadFactory.On("Create", gitRepo, false).Return(uploadTask, nil)
+			var report *job.Diff
+			uploadTask.On("Run").Return(report, &protocol.ErrUploadRemovalLimit{RemovedCount: 8, TotalCount: 10, MaxRemovalPct: 50})
+
+			handler := Upload(uploadFactory)
+			handler.ServeHTTP(res, req)
+
 			assert.Equal(t, http.StatusBadRequest, res.Code)
 		})
 	})
@@ -16,6 +16,10 @@ type AuditResult struct {
 	Metadata       map[string]interface{}   `json:"metadata"`
 	ToleranceRules []protocol.ToleranceRule `json:"tolerance_rule"`
 	Pass           bool                     `json:"pass"`
+	Severity       protocol.Severity        `json:"severity"`
+	GroupOverride  string                   `json:"group_override,omitempty"`
+	NoBaseline     bool                     `json:"no_baseline,omitempty"`
+	ExpectedBand   *protocol.ExpectedBand   `json:"expected_band,omitempty"`
 }
 
 //AuditResultGroup is result of audit per group
@@ -36,7 +40,15 @@ type AuditResponse struct {
 	Status       string             `json:"status"`
 	Pass         bool               `json:"pass"`
 	Message      string             `json:"message"`
+	Severity     protocol.Severity  `json:"severity,omitempty"`
 	TotalRecords int64              `json:"total_records"`
 	Result       []AuditResultGroup `json:"result"`
 	CreatedAt    time.Time          `json:"created_at"`
 }
+
+//AuditListResponse is page of audit history
+type AuditListResponse struct {
+	Audits []*AuditResponse `json:"audits"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
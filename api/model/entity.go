@@ -8,10 +8,11 @@ import (
 
 //CreateUpdateEntityRequest request to create and update entity
 type CreateUpdateEntityRequest struct {
-	EntityName    string   `json:"entity_name"`
-	GitURL        string   `json:"git_url"`
-	Environment   string   `json:"environment"`
-	GcpProjectIDs []string `json:"gcloud_project_ids"`
+	EntityName     string   `json:"entity_name"`
+	GitURL         string   `json:"git_url"`
+	Environment    string   `json:"environment"`
+	GcpProjectIDs  []string `json:"gcloud_project_ids"`
+	MaxBytesBilled int64    `json:"max_bytes_billed,omitempty"`
 }
 
 func (c *CreateUpdateEntityRequest) Validate() error {
@@ -40,6 +41,10 @@ func (c *CreateUpdateEntityRequest) Validate() error {
 		}
 	}
 
+	if c.MaxBytesBilled < 0 {
+		return errors.New("max_bytes_billed cannot be negative")
+	}
+
 	return nil
 }
 
@@ -50,6 +55,7 @@ type CreateUpdateEntityResponse struct {
 	GitURL           string    `json:"git_url"`
 	Environment      string    `json:"environment"`
 	GcpProjectIDs    []string  `json:"gcloud_project_ids"`
+	MaxBytesBilled   int64     `json:"max_bytes_billed,omitempty"`
 	CreatedTimestamp time.Time `json:"created_timestamp"`
 	UpdatedTimestamp time.Time `json:"updated_timestamp"`
 }
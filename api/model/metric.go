@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/odpf/predator/protocol/metric"
 )
 
@@ -20,3 +22,24 @@ type MetricGroup struct {
 	Group   string    `json:"group"`
 	Metrics []*Metric `json:"metrics"`
 }
+
+//MetricPointResponse is value of a metric produced by a profile
+type MetricPointResponse struct {
+	ProfileID      string    `json:"profile_id"`
+	Value          float64   `json:"value"`
+	EventTimestamp time.Time `json:"event_timestamp"`
+}
+
+//MetricSeriesResponse is values of a metric of a field and group over time
+type MetricSeriesResponse struct {
+	FieldID    string                 `json:"field_id"`
+	GroupValue string                 `json:"group_value"`
+	MetricName string                 `json:"metric_name"`
+	Points     []*MetricPointResponse `json:"points"`
+}
+
+//MetricSeriesListResponse is metric time series of a table
+type MetricSeriesListResponse struct {
+	URN    string                  `json:"urn"`
+	Series []*MetricSeriesResponse `json:"series"`
+}
@@ -71,3 +71,22 @@ type ProfileLogResponse struct {
 	State        job.State `json:"state,omitempty"`
 	Logs         []Log     `json:"logs"`
 }
+
+//QueryEstimateResponse estimated bytes processed by a query
+type QueryEstimateResponse struct {
+	Query          string        `json:"query"`
+	QueryType      job.QueryType `json:"query_type"`
+	EstimatedBytes int64         `json:"estimated_bytes"`
+}
+
+//ProfileEstimateResponse queries of the profile and the estimated bytes processed, the profile is not run
+type ProfileEstimateResponse struct {
+	URN            string                   `json:"urn"`
+	Filter         string                   `json:"filter"`
+	Group          string                   `json:"group"`
+	Mode           job.Mode                 `json:"mode"`
+	TotalBytes     int64                    `json:"total_bytes"`
+	MaxBytesBilled int64                    `json:"max_bytes_billed"`
+	Exceeded       bool                     `json:"exceeded"`
+	Queries        []*QueryEstimateResponse `json:"queries"`
+}
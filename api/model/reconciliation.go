@@ -0,0 +1,75 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/odpf/predator/protocol/job"
+)
+
+//ReconciliationRequest request to reconcile target table with its source table
+type ReconciliationRequest struct {
+	SourceURN  string   `json:"source_urn"`
+	TargetURN  string   `json:"target_urn"`
+	Filter     string   `json:"filter"`
+	Group      string   `json:"group"`
+	SumFields  []string `json:"sum_fields"`
+	KeyFields  []string `json:"key_fields"`
+	MaxDiffPct float64  `json:"max_diff_pct"`
+}
+
+//Validate to check data payload
+func (r *ReconciliationRequest) Validate() error {
+	for _, urn := range []string{r.SourceURN, r.TargetURN} {
+		tableURN := strings.TrimSpace(urn)
+		if tableURN == "" {
+			return errors.New("source_urn and target_urn are required")
+		} else if len(strings.Split(tableURN, ".")) != 3 {
+			return errors.New("wrong URN format")
+		}
+	}
+
+	if r.SourceURN == r.TargetURN {
+		return errors.New("source_urn and target_urn should be different tables")
+	}
+
+	if r.MaxDiffPct < 0 {
+		return errors.New("max_diff_pct should not be negative")
+	}
+
+	return nil
+}
+
+//ToReconciliation create reconciliation job of the request
+func (r *ReconciliationRequest) ToReconciliation() *job.Reconciliation {
+	return &job.Reconciliation{
+		SourceURN:  strings.TrimSpace(r.SourceURN),
+		TargetURN:  strings.TrimSpace(r.TargetURN),
+		GroupName:  r.Group,
+		Filter:     r.Filter,
+		SumFields:  r.SumFields,
+		KeyFields:  r.KeyFields,
+		MaxDiffPct: r.MaxDiffPct,
+	}
+}
+
+//ReconciliationResponse is reconciliation state and audit result of the difference of each metric
+type ReconciliationResponse struct {
+	ID              string             `json:"reconciliation_id"`
+	SourceURN       string             `json:"source_urn"`
+	TargetURN       string             `json:"target_urn"`
+	Filter          string             `json:"filter"`
+	Group           string             `json:"group"`
+	SumFields       []string           `json:"sum_fields"`
+	KeyFields       []string           `json:"key_fields"`
+	MaxDiffPct      float64            `json:"max_diff_pct"`
+	SourceProfileID string             `json:"source_profile_id"`
+	TargetProfileID string             `json:"target_profile_id"`
+	AuditID         string             `json:"audit_id"`
+	Status          string             `json:"status"`
+	Pass            bool               `json:"pass"`
+	Message         string             `json:"message"`
+	Result          []AuditResultGroup `json:"result"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+)
+
+//ScheduleRunResponse represents run of a schedule
+type ScheduleRunResponse struct {
+	ID            int       `json:"id"`
+	URN           string    `json:"urn"`
+	ScheduledTime time.Time `json:"scheduled_time"`
+	Status        string    `json:"status"`
+	Message       string    `json:"message"`
+	ProfileID     string    `json:"profile_id,omitempty"`
+	AuditID       string    `json:"audit_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+//ScheduleRunsResponse represents run history of a schedule
+type ScheduleRunsResponse struct {
+	URN  string                 `json:"urn"`
+	Runs []*ScheduleRunResponse `json:"runs"`
+}
@@ -0,0 +1,16 @@
+package model
+
+//SpecSuggestionRequest request to suggest tolerance spec of a table
+type SpecSuggestionRequest struct {
+	URN    string `json:"urn"`
+	Filter string `json:"filter"`
+}
+
+//SpecSuggestionResponse is state of spec suggestion, the spec is compact spec yaml set once the suggestion is completed
+type SpecSuggestionResponse struct {
+	URN       string `json:"urn"`
+	ProfileID string `json:"profile_id,omitempty"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	Spec      string `json:"spec,omitempty"`
+}
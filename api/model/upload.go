@@ -3,12 +3,15 @@ package model
 import (
 	"errors"
 	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/metric"
 )
 
 type UploadRequest struct {
 	GitURL     string `json:"git_url"`
 	CommitID   string `json:"commit_id"`
 	PathPrefix string `json:"path_prefix"`
+	//DryRun report the changes of the upload without writing the specs
+	DryRun bool `json:"dry_run,omitempty"`
 }
 
 func (u *UploadRequest) Validate() error {
@@ -24,6 +27,97 @@ func (u *UploadRequest) Validate() error {
 }
 
 type UploadReport struct {
-	UploadedCount int `json:"uploaded"`
-	RemovedCount  int `json:"removed"`
+	UploadedCount int  `json:"uploaded"`
+	RemovedCount  int  `json:"removed"`
+	DryRun        bool `json:"dry_run"`
+	//Diff is per table changes of the specs, only returned on dry run
+	Diff *UploadDiff `json:"diff,omitempty"`
+}
+
+type UploadDiff struct {
+	Added                []string    `json:"added"`
+	Removed              []string    `json:"removed"`
+	Changed              []*SpecDiff `json:"changed"`
+	UnchangedCount       int         `json:"unchanged"`
+	RemovalLimitExceeded bool        `json:"removal_limit_exceeded"`
+}
+
+type SpecDiff struct {
+	URN     string        `json:"urn"`
+	Changes []*SpecChange `json:"changes"`
+}
+
+type SpecChange struct {
+	Type       protocol.ChangeType `json:"type"`
+	FieldID    string              `json:"field_id,omitempty"`
+	MetricName metric.Type         `json:"metric_name,omitempty"`
+	Condition  string              `json:"condition,omitempty"`
+	Identity   string              `json:"identity,omitempty"`
+	Attribute  string              `json:"attribute,omitempty"`
+	Before     interface{}         `json:"before,omitempty"`
+	After      interface{}         `json:"after,omitempty"`
+}
+
+//NewUploadDiffReport create UploadReport of dry run upload
+func NewUploadDiffReport(diff *protocol.UploadDiff) *UploadReport {
+	uploadDiff := &UploadDiff{
+		Added:                nonNilStrings(diff.Added),
+		Removed:              nonNilStrings(diff.Removed),
+		Changed:              []*SpecDiff{},
+		UnchangedCount:       diff.UnchangedCount,
+		RemovalLimitExceeded: diff.RemovalLimitExceeded,
+	}
+	for _, specDiff := range diff.Changed {
+		var changes []*SpecChange
+		for _, c := range specDiff.Changes {
+			changes = append(changes, &SpecChange{
+				Type:       c.Type,
+				FieldID:    c.FieldID,
+				MetricName: c.MetricName,
+				Condition:  c.Condition,
+				Identity:   c.Identity,
+				Attribute:  c.Attribute,
+				Before:     newChangeValue(c.Before),
+				After:      newChangeValue(c.After),
+			})
+		}
+		uploadDiff.Changed = append(uploadDiff.Changed, &SpecDiff{URN: specDiff.URN, Changes: changes})
+	}
+
+	return &UploadReport{
+		UploadedCount: len(diff.Added) + len(diff.Changed) + diff.UnchangedCount,
+		RemovedCount:  len(diff.Removed),
+		DryRun:        true,
+		Diff:          uploadDiff,
+	}
+}
+
+//GroupOverride is group override presented in the spec changes
+type GroupOverride struct {
+	Group          string                   `json:"group"`
+	ToleranceRules []protocol.ToleranceRule `json:"tolerance_rules"`
+	Anomaly        *protocol.AnomalyRule    `json:"anomaly,omitempty"`
+}
+
+//newChangeValue present value of the changed attribute, group overrides are presented with their json names
+func newChangeValue(value interface{}) interface{} {
+	overrides, ok := value.([]*protocol.GroupOverride)
+	if !ok {
+		return value
+	}
+	if len(overrides) == 0 {
+		return nil
+	}
+	var presented []*GroupOverride
+	for _, o := range overrides {
+		presented = append(presented, &GroupOverride{Group: o.Group, ToleranceRules: o.ToleranceRules, Anomaly: o.Anomaly})
+	}
+	return presented
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
 }
@@ -11,14 +11,17 @@ import (
 
 //V1Beta1RouteGroup as a struct for v1beta1 route group
 type V1Beta1RouteGroup struct {
-	profileService       protocol.ProfileService
-	auditService         protocol.AuditService
-	toleranceStore       protocol.ToleranceStore
-	entityStore          protocol.EntityStore
-	uploadFactory        protocol.UploadFactory
-	auditSummaryFactory  protocol.AuditSummaryFactory
-	sqlExpressionFactory protocol.SQLExpressionFactory
-	metricStore          protocol.MetricStore
+	profileService        protocol.ProfileService
+	auditService          protocol.AuditService
+	toleranceStore        protocol.ToleranceStore
+	entityStore           protocol.EntityStore
+	uploadFactory         protocol.UploadFactory
+	auditSummaryFactory   protocol.AuditSummaryFactory
+	sqlExpressionFactory  protocol.SQLExpressionFactory
+	metricStore           protocol.MetricStore
+	scheduleRunStore      protocol.ScheduleRunStore
+	specSuggester         protocol.SpecSuggester
+	reconciliationService protocol.ReconciliationService
 }
 
 //NewV1Beta1RouteGroup to construct v1beta1 route group
@@ -29,16 +32,22 @@ func NewV1Beta1RouteGroup(profileService protocol.ProfileService,
 	uploadFactory protocol.UploadFactory,
 	auditSummaryFactory protocol.AuditSummaryFactory,
 	sqlExpressionFactory protocol.SQLExpressionFactory,
-	metricStore protocol.MetricStore) *V1Beta1RouteGroup {
+	metricStore protocol.MetricStore,
+	scheduleRunStore protocol.ScheduleRunStore,
+	specSuggester protocol.SpecSuggester,
+	reconciliationService protocol.ReconciliationService) *V1Beta1RouteGroup {
 	return &V1Beta1RouteGroup{
-		profileService:       profileService,
-		auditService:         auditService,
-		toleranceStore:       toleranceStore,
-		entityStore:          entityStore,
-		uploadFactory:        uploadFactory,
-		auditSummaryFactory:  auditSummaryFactory,
-		sqlExpressionFactory: sqlExpressionFactory,
-		metricStore:          metricStore,
+		profileService:        profileService,
+		auditService:          auditService,
+		toleranceStore:        toleranceStore,
+		entityStore:           entityStore,
+		uploadFactory:         uploadFactory,
+		auditSummaryFactory:   auditSummaryFactory,
+		sqlExpressionFactory:  sqlExpressionFactory,
+		metricStore:           metricStore,
+		scheduleRunStore:      scheduleRunStore,
+		specSuggester:         specSuggester,
+		reconciliationService: reconciliationService,
 	}
 }
 
@@ -49,10 +58,30 @@ func (v *V1Beta1RouteGroup) RegisterHandler(router *mux.Router) {
 		Name("v1beta1_create_audit_task").
 		Handler(v1beta1.Audit(v.auditService, v.profileService, v.auditSummaryFactory))
 
+	router.Methods("GET").Path("/v1beta1/audit/{auditID}").
+		Name("v1beta1_get_audit").
+		Handler(v1beta1.GetAudit(v.auditService, v.profileService, v.auditSummaryFactory))
+
+	router.Methods("GET").Path("/v1beta1/profile/{profileID}/audits").
+		Name("v1beta1_get_profile_audits").
+		Handler(v1beta1.GetProfileAudits(v.auditService, v.auditSummaryFactory))
+
+	router.Methods("GET").Path("/v1beta1/table/{urn}/audits").
+		Name("v1beta1_get_table_audits").
+		Handler(v1beta1.GetTableAudits(v.auditService, v.auditSummaryFactory))
+
+	router.Methods("GET").Path("/v1beta1/table/{urn}/metrics").
+		Name("v1beta1_get_table_metrics").
+		Handler(v1beta1.GetTableMetrics(v.metricStore))
+
 	router.Methods("POST").Path("/v1beta1/profile").
 		Name("v1beta1_profile").
 		Handler(v1beta1.Profile(v.profileService, v.sqlExpressionFactory))
 
+	router.Methods("POST").Path("/v1beta1/profile/estimate").
+		Name("v1beta1_estimate_profile").
+		Handler(v1beta1.EstimateProfile(v.profileService, v.sqlExpressionFactory))
+
 	router.Methods("GET").Path("/v1beta1/profile/{profileID}").
 		Name("v1beta1_get_profile").
 		Handler(v1beta1.GetProfile(v.profileService, v.metricStore))
@@ -61,6 +90,10 @@ func (v *V1Beta1RouteGroup) RegisterHandler(router *mux.Router) {
 		Name("v1beta1_get_profile_log").
 		Handler(v1beta1.GetProfileLog(v.profileService))
 
+	router.Methods("POST").Path("/v1beta1/profile/{profileID}/cancel").
+		Name("v1beta1_cancel_profile").
+		Handler(v1beta1.CancelProfile(v.profileService))
+
 	router.
 		Methods("POST").Path("/v1beta1/entity/{entityID}").
 		Name("v1beta1_upsert_entity").
@@ -76,6 +109,31 @@ func (v *V1Beta1RouteGroup) RegisterHandler(router *mux.Router) {
 		Name("v1beta1_upload_spec").
 		Handler(v1beta1.Upload(v.uploadFactory))
 
+	router.
+		Methods("POST").Path("/v1beta1/spec/suggest").
+		Name("v1beta1_suggest_spec").
+		Handler(v1beta1.SuggestSpec(v.specSuggester, v.sqlExpressionFactory))
+
+	router.
+		Methods("GET").Path("/v1beta1/spec/suggest/{profileID}").
+		Name("v1beta1_get_spec_suggestion").
+		Handler(v1beta1.GetSpecSuggestion(v.specSuggester))
+
+	router.
+		Methods("GET").Path("/v1beta1/schedule/{urn}/run").
+		Name("v1beta1_get_schedule_runs").
+		Handler(v1beta1.GetScheduleRuns(v.scheduleRunStore))
+
+	router.
+		Methods("POST").Path("/v1beta1/reconciliation").
+		Name("v1beta1_reconcile").
+		Handler(v1beta1.Reconcile(v.reconciliationService))
+
+	router.
+		Methods("GET").Path("/v1beta1/reconciliation/{reconciliationID}").
+		Name("v1beta1_get_reconciliation").
+		Handler(v1beta1.GetReconciliation(v.reconciliationService))
+
 	router.
 		Methods("GET").Path("/wait").
 		Name("wait").
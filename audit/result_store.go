@@ -7,6 +7,7 @@ import (
 
 	"github.com/jinzhu/gorm"
 	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/metric"
 )
 
 //Report as a struct to store audit result to DB
@@ -20,6 +21,12 @@ type Report struct {
 	Metadata       datatypes.JSON
 	ToleranceRules string
 	PassFlag       bool
+	Severity       string
+	ExpectedLower  *float64
+	ExpectedUpper  *float64
+	BaselineSize   *int
+	NoBaseline     bool
+	GroupOverride  string
 	CreatedAt      time.Time
 }
 
@@ -78,9 +85,87 @@ func convertToStored(auditReports []*protocol.AuditReport) ([]Report, error) {
 			Metadata:       metadataInBytes,
 			ToleranceRules: string(content),
 			PassFlag:       r.PassFlag,
+			Severity:       string(r.Severity.OrDefault()),
+			NoBaseline:     r.NoBaseline,
+			GroupOverride:  r.GroupOverride,
 			CreatedAt:      r.EventTimestamp,
 		}
+		if r.ExpectedBand != nil {
+			lower, upper, baselineSize := r.ExpectedBand.Lower, r.ExpectedBand.Upper, r.ExpectedBand.BaselineSize
+			a.ExpectedLower, a.ExpectedUpper, a.BaselineSize = &lower, &upper, &baselineSize
+		}
 		auditResults = append(auditResults, a)
 	}
 	return auditResults, nil
 }
+
+//GetResultsByAuditID get reports of the audit, reports of other fields are excluded when fieldID is not empty
+func (rs *ResultStore) GetResultsByAuditID(auditID string, fieldID string) ([]*protocol.AuditReport, error) {
+	return rs.GetResultsByAuditIDs([]string{auditID}, fieldID)
+}
+
+//GetResultsByAuditIDs get reports of the audits ordered by audit, reports of other fields are excluded when fieldID is not empty
+func (rs *ResultStore) GetResultsByAuditIDs(auditIDs []string, fieldID string) ([]*protocol.AuditReport, error) {
+	if len(auditIDs) == 0 {
+		return nil, nil
+	}
+
+	query := rs.db.Where("audit_id IN (?)", auditIDs)
+	if fieldID != "" {
+		query = query.Where("field_id = ?", fieldID)
+	}
+
+	var records []*Report
+	if err := query.Order("audit_id, group_value, field_id, metric_name").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	var reports []*protocol.AuditReport
+	for _, record := range records {
+		report, err := record.toAuditReport()
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (r *Report) toAuditReport() (*protocol.AuditReport, error) {
+	var toleranceRules []protocol.ToleranceRule
+	if err := json.Unmarshal([]byte(r.ToleranceRules), &toleranceRules); err != nil {
+		return nil, err
+	}
+
+	var expectedBand *protocol.ExpectedBand
+	if r.BaselineSize != nil {
+		expectedBand = &protocol.ExpectedBand{BaselineSize: *r.BaselineSize}
+		if r.ExpectedLower != nil && r.ExpectedUpper != nil {
+			expectedBand.Lower, expectedBand.Upper = *r.ExpectedLower, *r.ExpectedUpper
+		}
+	}
+
+	var metadata map[string]interface{}
+	if len(r.Metadata) > 0 {
+		if err := json.Unmarshal(r.Metadata, &metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	return &protocol.AuditReport{
+		AuditID:        r.AuditID,
+		GroupValue:     r.GroupValue,
+		FieldID:        r.FieldID,
+		MetricName:     metric.Type(r.MetricName),
+		MetricValue:    r.MetricValue,
+		Condition:      r.Condition,
+		Metadata:       metadata,
+		ToleranceRules: toleranceRules,
+		ExpectedBand:   expectedBand,
+		GroupOverride:  r.GroupOverride,
+		NoBaseline:     r.NoBaseline,
+		PassFlag:       r.PassFlag,
+		Severity:       protocol.Severity(r.Severity),
+		EventTimestamp: r.CreatedAt,
+	}, nil
+}
@@ -83,6 +83,7 @@ func TestResultStore(t *testing.T) {
 					MetricName:     "duplication_pct",
 					MetricValue:    0.1,
 					PassFlag:       true,
+					Severity:       "error",
 					CreatedAt:      currentTime,
 					ToleranceRules: "[{\"comparator\":\"more_than_eq\",\"value\":1}]",
 				},
@@ -92,6 +93,7 @@ func TestResultStore(t *testing.T) {
 					MetricName:     "row_count",
 					MetricValue:    100.0,
 					PassFlag:       true,
+					Severity:       "error",
 					CreatedAt:      currentTime,
 					ToleranceRules: "[{\"comparator\":\"less_than_eq\",\"value\":1}]",
 				},
@@ -176,6 +178,7 @@ func TestResultStore(t *testing.T) {
 					MetricName:     "duplication_pct",
 					MetricValue:    0.1,
 					PassFlag:       true,
+					Severity:       "error",
 					CreatedAt:      currentTime,
 					ToleranceRules: "[{\"comparator\":\"more_than_eq\",\"value\":1}]",
 					Metadata:       metadataInBytes,
@@ -186,6 +189,7 @@ func TestResultStore(t *testing.T) {
 					MetricName:     "row_count",
 					MetricValue:    100.0,
 					PassFlag:       true,
+					Severity:       "error",
 					CreatedAt:      currentTime,
 					ToleranceRules: "[{\"comparator\":\"less_than_eq\",\"value\":1}]",
 				},
@@ -194,5 +198,141 @@ func TestResultStore(t *testing.T) {
 			assert.Equal(t, expected, reports)
 			assert.Nil(t, err)
 		})
+		t.Run("should store audit results with expected band of anomaly rule", func(t *testing.T) {
+			db, clear := getMockDB()
+			defer clear()
+
+			currentTime := time.Now().In(time.UTC)
+
+			auditReports := []*protocol.AuditReport{
+				{
+					AuditID:     "abc",
+					TableURN:    "project.dataset.table",
+					GroupValue:  "2020-01-01",
+					MetricName:  "row_count",
+					MetricValue: 100.0,
+					ExpectedBand: &protocol.ExpectedBand{
+						Lower:        80.0,
+						Upper:        120.5,
+						BaselineSize: 28,
+					},
+					PassFlag:       true,
+					EventTimestamp: currentTime,
+				},
+			}
+
+			store := NewResultStore(db, "reports")
+			err := store.StoreResults(auditReports)
+			assert.Nil(t, err)
+
+			var reports []*Report
+			db.Find(&reports)
+
+			lower, upper, baselineSize := 80.0, 120.5, 28
+			expected := []*Report{
+				{
+					AuditID:        "abc",
+					GroupValue:     "2020-01-01",
+					MetricName:     "row_count",
+					MetricValue:    100.0,
+					PassFlag:       true,
+					Severity:       "error",
+					ExpectedLower:  &lower,
+					ExpectedUpper:  &upper,
+					BaselineSize:   &baselineSize,
+					CreatedAt:      currentTime,
+					ToleranceRules: "null",
+				},
+			}
+
+			assert.Equal(t, expected, reports)
+		})
+	})
+	t.Run("GetResultsByAuditID", func(t *testing.T) {
+		currentTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		expectedBand := &protocol.ExpectedBand{Lower: 90, Upper: 110, BaselineSize: 7}
+		auditReports := []*protocol.AuditReport{
+			{
+				AuditID:     "abc",
+				GroupValue:  "2020-01-01",
+				FieldID:     "field_1",
+				MetricName:  metric.NullnessPct,
+				MetricValue: 12.5,
+				ToleranceRules: []protocol.ToleranceRule{
+					{
+						Comparator: protocol.ComparatorLessThanEq,
+						Value:      10.0,
+					},
+				},
+				GroupOverride:  "2020-*",
+				NoBaseline:     true,
+				PassFlag:       false,
+				Severity:       protocol.SeverityWarn,
+				EventTimestamp: currentTime,
+			},
+			{
+				AuditID:        "abc",
+				GroupValue:     "2020-01-01",
+				MetricName:     metric.RowCount,
+				MetricValue:    100.0,
+				ExpectedBand:   expectedBand,
+				PassFlag:       true,
+				Severity:       protocol.SeverityError,
+				EventTimestamp: currentTime,
+			},
+			{
+				AuditID:        "def",
+				GroupValue:     "2020-01-01",
+				MetricName:     metric.RowCount,
+				MetricValue:    100.0,
+				PassFlag:       true,
+				Severity:       protocol.SeverityError,
+				EventTimestamp: currentTime,
+			},
+		}
+		t.Run("should return reports of the audit", func(t *testing.T) {
+			db, clear := getMockDB()
+			defer clear()
+
+			store := NewResultStore(db, "reports")
+			assert.Nil(t, store.StoreResults(auditReports))
+
+			reports, err := store.GetResultsByAuditID("abc", "")
+
+			assert.Nil(t, err)
+			assert.Equal(t, []*protocol.AuditReport{auditReports[1], auditReports[0]}, reports)
+		})
+		t.Run("should return reports of the field when field ID is given", func(t *testing.T) {
+			db, clear := getMockDB()
+			defer clear()
+
+			store := NewResultStore(db, "reports")
+			assert.Nil(t, store.StoreResults(auditReports))
+
+			reports, err := store.GetResultsByAuditID("abc", "field_1")
+
+			assert.Nil(t, err)
+			assert.Equal(t, []*protocol.AuditReport{auditReports[0]}, reports)
+		})
+	})
+	t.Run("GetResultsByAuditIDs", func(t *testing.T) {
+		t.Run("should return reports of every audit ordered by audit", func(t *testing.T) {
+			currentTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			auditReports := []*protocol.AuditReport{
+				{AuditID: "def", MetricName: metric.RowCount, MetricValue: 100.0, PassFlag: true, Severity: protocol.SeverityError, EventTimestamp: currentTime},
+				{AuditID: "abc", MetricName: metric.RowCount, MetricValue: 90.0, PassFlag: true, Severity: protocol.SeverityError, EventTimestamp: currentTime},
+				{AuditID: "ghi", MetricName: metric.RowCount, MetricValue: 80.0, PassFlag: true, Severity: protocol.SeverityError, EventTimestamp: currentTime},
+			}
+			db, clear := getMockDB()
+			defer clear()
+
+			store := NewResultStore(db, "reports")
+			assert.Nil(t, store.StoreResults(auditReports))
+
+			reports, err := store.GetResultsByAuditIDs([]string{"abc", "def"}, "")
+
+			assert.Nil(t, err)
+			assert.Equal(t, []*protocol.AuditReport{auditReports[1], auditReports[0]}, reports)
+		})
 	})
 }
@@ -130,3 +130,56 @@ func (s *Service) run(statsClient stats.Client, audit *job.Audit) ([]*protocol.A
 
 	return auditResults, nil
 }
+
+//GetAudit get audit and its reports
+func (s *Service) GetAudit(ID string) (*protocol.AuditResult, error) {
+	audit, err := s.auditStore.GetAudit(ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withReports(audit, "")
+}
+
+//GetAudits get audits matching the filter with their reports and profile, reports are limited to the field of the filter
+//reports of every audit are fetched at once
+func (s *Service) GetAudits(filter *protocol.AuditFilter) ([]*protocol.AuditResult, error) {
+	results, err := s.auditStore.GetAudits(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var auditIDs []string
+	for _, result := range results {
+		auditIDs = append(auditIDs, result.Audit.ID)
+	}
+	reports, err := s.resultStore.GetResultsByAuditIDs(auditIDs, filter.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	reportsByAuditID := make(map[string][]*protocol.AuditReport)
+	for _, report := range reports {
+		reportsByAuditID[report.AuditID] = append(reportsByAuditID[report.AuditID], report)
+	}
+	for _, result := range results {
+		result.AuditReports = reportsByAuditID[result.Audit.ID]
+		for _, report := range result.AuditReports {
+			report.TableURN = result.Audit.URN
+		}
+	}
+	return results, nil
+}
+
+func (s *Service) withReports(audit *job.Audit, fieldID string) (*protocol.AuditResult, error) {
+	reports, err := s.resultStore.GetResultsByAuditID(audit.ID, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		report.TableURN = audit.URN
+	}
+	return &protocol.AuditResult{
+		Audit:        audit,
+		AuditReports: reports,
+	}, nil
+}
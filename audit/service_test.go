@@ -8,6 +8,7 @@ import (
 	"github.com/odpf/predator/mock"
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -140,4 +141,76 @@ func TestAuditService(t *testing.T) {
 			assert.Nil(t, actualErr)
 		})
 	})
+	t.Run("GetAudit", func(t *testing.T) {
+		t.Run("should return audit with its reports", func(t *testing.T) {
+			auditJob := &job.Audit{ID: "audit-1", ProfileID: "profile-1", URN: "project.dataset.table", State: job.StateCompleted}
+			reports := []*protocol.AuditReport{{AuditID: "audit-1", MetricName: metric.RowCount, PassFlag: true}}
+
+			auditStore := mock.NewAuditStore()
+			defer auditStore.AssertExpectations(t)
+			auditStore.On("GetAudit", "audit-1").Return(auditJob, nil)
+
+			resultStore := mock.NewAuditResultStore()
+			defer resultStore.AssertExpectations(t)
+			resultStore.On("GetResultsByAuditID", "audit-1", "").Return(reports, nil)
+
+			auditService := &Service{auditStore: auditStore, resultStore: resultStore}
+			result, err := auditService.GetAudit("audit-1")
+
+			expected := &protocol.AuditResult{
+				Audit:        auditJob,
+				AuditReports: []*protocol.AuditReport{{AuditID: "audit-1", TableURN: "project.dataset.table", MetricName: metric.RowCount, PassFlag: true}},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should return error when audit not found", func(t *testing.T) {
+			var auditJob *job.Audit
+
+			auditStore := mock.NewAuditStore()
+			auditStore.On("GetAudit", "audit-1").Return(auditJob, protocol.ErrAuditNotFound)
+
+			auditService := &Service{auditStore: auditStore}
+			result, err := auditService.GetAudit("audit-1")
+
+			assert.Nil(t, result)
+			assert.Equal(t, protocol.ErrAuditNotFound, err)
+		})
+	})
+	t.Run("GetAudits", func(t *testing.T) {
+		t.Run("should return audits with reports of the filtered field", func(t *testing.T) {
+			filter := &protocol.AuditFilter{URN: "project.dataset.table", FieldID: "field_1", Limit: 20}
+			audits := []*job.Audit{
+				{ID: "audit-2", URN: "project.dataset.table"},
+				{ID: "audit-1", URN: "project.dataset.table"},
+			}
+			profile := &job.Profile{ID: "profile-1", URN: "project.dataset.table"}
+			reports := []*protocol.AuditReport{{AuditID: "audit-1", FieldID: "field_1", MetricName: metric.NullnessPct}}
+
+			auditStore := mock.NewAuditStore()
+			defer auditStore.AssertExpectations(t)
+			auditStore.On("GetAudits", filter).Return([]*protocol.AuditResult{
+				{Audit: audits[0], Profile: profile},
+				{Audit: audits[1], Profile: profile},
+			}, nil)
+
+			resultStore := mock.NewAuditResultStore()
+			defer resultStore.AssertExpectations(t)
+			resultStore.On("GetResultsByAuditIDs", []string{"audit-2", "audit-1"}, "field_1").Return(reports, nil).Once()
+
+			auditService := &Service{auditStore: auditStore, resultStore: resultStore}
+			results, err := auditService.GetAudits(filter)
+
+			expected := []*protocol.AuditResult{
+				{Audit: audits[0], Profile: profile},
+				{
+					Audit:        audits[1],
+					AuditReports: []*protocol.AuditReport{{AuditID: "audit-1", TableURN: "project.dataset.table", FieldID: "field_1", MetricName: metric.NullnessPct}},
+					Profile:      profile,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, results)
+		})
+	})
 }
@@ -1,6 +1,7 @@
 package audit
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -11,6 +12,7 @@ import (
 type audit struct {
 	ID             string
 	ProfileID      string
+	URN            string
 	TotalRecords   int64
 	EventTimestamp time.Time
 }
@@ -19,22 +21,81 @@ func newAuditFromJob(auditJob *job.Audit) *audit {
 	return &audit{
 		ID:             auditJob.ID,
 		ProfileID:      auditJob.ProfileID,
+		URN:            auditJob.URN,
 		TotalRecords:   auditJob.TotalRecords,
 		EventTimestamp: auditJob.EventTimestamp,
 	}
 }
 
+func (a *audit) toAudit(status *protocol.Status) *job.Audit {
+	return &job.Audit{
+		ID:             a.ID,
+		ProfileID:      a.ProfileID,
+		URN:            a.URN,
+		State:          job.State(status.Status),
+		Message:        status.Message,
+		TotalRecords:   a.TotalRecords,
+		EventTimestamp: a.EventTimestamp,
+	}
+}
+
+//auditListRecord is audit joined with its latest status and the audited profile
+type auditListRecord struct {
+	ID                  string
+	ProfileID           string
+	URN                 string
+	TotalRecords        int64
+	EventTimestamp      time.Time
+	Status              string
+	Message             string
+	GroupName           string
+	Filter              string
+	Mode                string
+	ProfileTotalRecords int64
+}
+
+func (r *auditListRecord) toAuditResult() *protocol.AuditResult {
+	return &protocol.AuditResult{
+		Audit: &job.Audit{
+			ID:             r.ID,
+			ProfileID:      r.ProfileID,
+			URN:            r.URN,
+			State:          job.State(r.Status),
+			Message:        r.Message,
+			TotalRecords:   r.TotalRecords,
+			EventTimestamp: r.EventTimestamp,
+		},
+		Profile: &job.Profile{
+			ID:           r.ProfileID,
+			URN:          r.URN,
+			GroupName:    r.GroupName,
+			Filter:       r.Filter,
+			Mode:         job.Mode(r.Mode),
+			TotalRecords: r.ProfileTotalRecords,
+		},
+	}
+}
+
+const (
+	profileTableName = "profile"
+	statusTableName  = "status"
+)
+
 //Store as a model for resultstore struct
 type Store struct {
-	db          *gorm.DB
-	statusStore protocol.StatusStore
+	db              *gorm.DB
+	tableName       string
+	resultTableName string
+	statusStore     protocol.StatusStore
 }
 
-//NewStore to construct result store
-func NewStore(db *gorm.DB, tableName string, statusStore protocol.StatusStore) *Store {
+//NewStore to construct result store, audit result table is used to filter audits by their results
+func NewStore(db *gorm.DB, tableName string, resultTableName string, statusStore protocol.StatusStore) *Store {
 	return &Store{
-		db:          db.Table(tableName),
-		statusStore: statusStore,
+		db:              db.Table(tableName),
+		tableName:       tableName,
+		resultTableName: resultTableName,
+		statusStore:     statusStore,
 	}
 }
 
@@ -86,3 +147,89 @@ func (a *Store) UpdateAudit(auditJob *job.Audit) error {
 	}
 	return nil
 }
+
+//GetAudit get audit with its latest state
+func (a *Store) GetAudit(ID string) (*job.Audit, error) {
+	var records []*audit
+	if err := a.db.Where("id = ?", ID).Limit(1).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, protocol.ErrAuditNotFound
+	}
+	return a.withLatestStatus(records[0])
+}
+
+//GetAudits get audits matching the filter with their profile ordered by the latest event timestamp
+//the latest status and the profile of every audit are joined in a single query
+func (a *Store) GetAudits(filter *protocol.AuditFilter) ([]*protocol.AuditResult, error) {
+	latestStatus := fmt.Sprintf("LEFT JOIN %s s ON s.id = (SELECT ls.id FROM %s ls WHERE ls.job_id = CAST(a.id AS TEXT) AND ls.job_type = ? ORDER BY ls.created_at DESC LIMIT 1)", statusTableName, statusTableName)
+	query := a.db.New().
+		Table(a.tableName+" a").
+		Select("a.id, a.profile_id, a.urn, a.total_records, a.event_timestamp, "+
+			"COALESCE(s.status, '') AS status, COALESCE(s.message, '') AS message, "+
+			"COALESCE(p.group_name, '') AS group_name, COALESCE(p.filter, '') AS filter, COALESCE(p.mode, '') AS mode, "+
+			"COALESCE(p.total_records, 0) AS profile_total_records").
+		Joins(fmt.Sprintf("LEFT JOIN %s p ON p.id = a.profile_id", profileTableName)).
+		Joins(latestStatus, job.TypeAudit)
+
+	if filter.URN != "" {
+		query = query.Where("a.urn = ?", filter.URN)
+	}
+	if filter.ProfileID != "" {
+		query = query.Where("a.profile_id = ?", filter.ProfileID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("a.event_timestamp >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("a.event_timestamp < ?", filter.To)
+	}
+	if filter.FieldID != "" {
+		resultQuery := fmt.Sprintf("a.id IN (SELECT audit_id FROM %s WHERE field_id = ?)", a.resultTableName)
+		query = query.Where(resultQuery, filter.FieldID)
+	}
+	if filter.Pass != nil {
+		failedQuery := fmt.Sprintf("SELECT audit_id FROM %s WHERE pass_flag = ? AND severity = ?", a.resultTableName)
+		failedArgs := []interface{}{false, protocol.SeverityError}
+		if filter.FieldID != "" {
+			failedQuery += " AND field_id = ?"
+			failedArgs = append(failedArgs, filter.FieldID)
+		}
+
+		operator := "IN"
+		if *filter.Pass {
+			operator = "NOT IN"
+			query = query.Where("s.status = ?", job.StateCompleted)
+		}
+		query = query.Where(fmt.Sprintf("a.id %s (%s)", operator, failedQuery), failedArgs...)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var records []*auditListRecord
+	if err := query.Order("a.event_timestamp DESC").Scan(&records).Error; err != nil {
+		return nil, err
+	}
+
+	var results []*protocol.AuditResult
+	for _, record := range records {
+		results = append(results, record.toAuditResult())
+	}
+	return results, nil
+}
+
+func (a *Store) withLatestStatus(record *audit) (*job.Audit, error) {
+	status, err := a.statusStore.GetLatestStatusByIDandType(record.ID, job.TypeAudit)
+	if err != nil {
+		if err != protocol.ErrStatusNotFound {
+			return nil, err
+		}
+		status = &protocol.Status{}
+	}
+	return record.toAudit(status), nil
+}
@@ -38,6 +38,20 @@ func newAuditTable() (*gorm.DB, func()) {
 	return db, clearDB
 }
 
+func newAuditHistoryTables() (*gorm.DB, func()) {
+	db, _ := gorm.Open("sqlite3", ":memory:")
+
+	clearDB := func() {
+		db.Close()
+	}
+	db.CreateTable(new(audit))
+	db.CreateTable(new(Report))
+	db.Exec(fmt.Sprintf("CREATE TABLE %s (id TEXT PRIMARY KEY, urn TEXT, group_name TEXT, filter TEXT, mode TEXT, total_records BIGINT)", profileTableName))
+	db.Exec(fmt.Sprintf("CREATE TABLE %s (id INTEGER PRIMARY KEY, job_id TEXT, job_type TEXT, status TEXT, message TEXT, created_at DATETIME)", statusTableName))
+
+	return db, clearDB
+}
+
 func TestAuditStore(t *testing.T) {
 	t.Run("StoreAudit", func(t *testing.T) {
 		t.Run("should store audit results", func(t *testing.T) {
@@ -64,7 +78,7 @@ func TestAuditStore(t *testing.T) {
 			statusStore := mock.NewStatusStore()
 			statusStore.On("Store", status).Return(nil)
 
-			store := NewStore(db, "audits", statusStore)
+			store := NewStore(db, "audits", "reports", statusStore)
 			_, err := store.CreateAudit(auditObj)
 
 			var result []job.Audit
@@ -95,7 +109,7 @@ func TestAuditStore(t *testing.T) {
 			}
 
 			statusStore := mock.NewStatusStore()
-			store := NewStore(db, "audits", statusStore)
+			store := NewStore(db, "audits", "reports", statusStore)
 			_, err := store.CreateAudit(auditObj)
 
 			assert.NotNil(t, err)
@@ -127,10 +141,188 @@ func TestAuditStore(t *testing.T) {
 			statusStore.On("Store", status).Return(nil)
 			defer statusStore.AssertExpectations(t)
 
-			store := NewStore(db, "audits", statusStore)
+			store := NewStore(db, "audits", "reports", statusStore)
 			err := store.UpdateAudit(audit)
 
 			assert.Nil(t, err)
 		})
 	})
+	t.Run("GetAudit", func(t *testing.T) {
+		t.Run("should return audit with its latest state", func(t *testing.T) {
+			db, clear := newAuditHistoryTables()
+			defer clear()
+
+			eventTimestamp := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			db.Table("audits").Create(&audit{ID: "audit-1", ProfileID: "profile-1", URN: "project.dataset.table", TotalRecords: 10, EventTimestamp: eventTimestamp})
+
+			statusStore := mock.NewStatusStore()
+			statusStore.On("GetLatestStatusByIDandType", "audit-1", job.TypeAudit).Return(&protocol.Status{Status: "completed", Message: "audit completed"}, nil)
+
+			store := NewStore(db, "audits", "reports", statusStore)
+			result, err := store.GetAudit("audit-1")
+
+			expected := &job.Audit{
+				ID:             "audit-1",
+				ProfileID:      "profile-1",
+				URN:            "project.dataset.table",
+				State:          job.StateCompleted,
+				Message:        "audit completed",
+				TotalRecords:   10,
+				EventTimestamp: eventTimestamp,
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should return ErrAuditNotFound when audit not found", func(t *testing.T) {
+			db, clear := newAuditHistoryTables()
+			defer clear()
+
+			store := NewStore(db, "audits", "reports", mock.NewStatusStore())
+			result, err := store.GetAudit("audit-1")
+
+			assert.Nil(t, result)
+			assert.Equal(t, protocol.ErrAuditNotFound, err)
+		})
+	})
+	t.Run("GetAudits", func(t *testing.T) {
+		urn := "project.dataset.table"
+		day := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		passed := true
+		failed := false
+
+		setup := func(db *gorm.DB) {
+			audits := []*audit{
+				{ID: "audit-1", ProfileID: "profile-1", URN: urn, EventTimestamp: day},
+				{ID: "audit-2", ProfileID: "profile-2", URN: urn, EventTimestamp: day.Add(24 * time.Hour)},
+				{ID: "audit-3", ProfileID: "profile-3", URN: urn, EventTimestamp: day.Add(48 * time.Hour)},
+				{ID: "audit-4", ProfileID: "profile-4", URN: "project.dataset.other", EventTimestamp: day},
+			}
+			for _, a := range audits {
+				db.Table("audits").Create(a)
+			}
+
+			reports := []*Report{
+				{AuditID: "audit-1", FieldID: "field_1", MetricName: "nullness_pct", ToleranceRules: "[]", PassFlag: false, Severity: "error"},
+				{AuditID: "audit-1", MetricName: "row_count", ToleranceRules: "[]", PassFlag: true, Severity: "error"},
+				{AuditID: "audit-2", MetricName: "row_count", ToleranceRules: "[]", PassFlag: false, Severity: "error"},
+				{AuditID: "audit-3", FieldID: "field_1", MetricName: "nullness_pct", ToleranceRules: "[]", PassFlag: true, Severity: "error"},
+				{AuditID: "audit-3", MetricName: "row_count", ToleranceRules: "[]", PassFlag: false, Severity: "warn"},
+			}
+			for _, r := range reports {
+				db.Table("reports").Create(r)
+			}
+
+			for i := 1; i <= 4; i++ {
+				db.Exec(fmt.Sprintf("INSERT INTO %s (id, urn, group_name, filter, mode, total_records) VALUES (?, ?, ?, ?, ?, ?)", profileTableName),
+					fmt.Sprintf("profile-%d", i), urn, "__PARTITION__", "", "complete", 10*i)
+			}
+			db.Exec(fmt.Sprintf("INSERT INTO %s (job_id, job_type, status, message, created_at) VALUES (?, ?, ?, ?, ?), (?, ?, ?, ?, ?), (?, ?, ?, ?, ?)", statusTableName),
+				"audit-2", job.TypeAudit, "created", "audit created", day,
+				"audit-2", job.TypeAudit, "completed", "audit completed", day.Add(time.Minute),
+				"audit-3", job.TypeAudit, "completed", "audit completed", day.Add(48*time.Hour))
+		}
+
+		suites := []struct {
+			Description string
+			Filter      *protocol.AuditFilter
+			Expected    []string
+		}{
+			{
+				Description: "should return audits of the urn ordered by the latest event timestamp",
+				Filter:      &protocol.AuditFilter{URN: urn},
+				Expected:    []string{"audit-3", "audit-2", "audit-1"},
+			},
+			{
+				Description: "should return audits of the profile",
+				Filter:      &protocol.AuditFilter{ProfileID: "profile-2"},
+				Expected:    []string{"audit-2"},
+			},
+			{
+				Description: "should return audits within time range",
+				Filter:      &protocol.AuditFilter{URN: urn, From: day, To: day.Add(48 * time.Hour)},
+				Expected:    []string{"audit-2", "audit-1"},
+			},
+			{
+				Description: "should return audits with failed result",
+				Filter:      &protocol.AuditFilter{URN: urn, Pass: &failed},
+				Expected:    []string{"audit-2", "audit-1"},
+			},
+			{
+				Description: "should return audits without failed result of error severity",
+				Filter:      &protocol.AuditFilter{URN: urn, Pass: &passed},
+				Expected:    []string{"audit-3"},
+			},
+			{
+				Description: "should return audits with result of the field",
+				Filter:      &protocol.AuditFilter{URN: urn, FieldID: "field_1"},
+				Expected:    []string{"audit-3", "audit-1"},
+			},
+			{
+				Description: "should return audits that pass on the field",
+				Filter:      &protocol.AuditFilter{URN: urn, FieldID: "field_1", Pass: &passed},
+				Expected:    []string{"audit-3"},
+			},
+			{
+				Description: "should return page of audits",
+				Filter:      &protocol.AuditFilter{URN: urn, Limit: 1, Offset: 1},
+				Expected:    []string{"audit-2"},
+			},
+		}
+		for _, test := range suites {
+			t.Run(test.Description, func(t *testing.T) {
+				db, clear := newAuditHistoryTables()
+				defer clear()
+				setup(db)
+
+				statusStore := mock.NewStatusStore()
+				defer statusStore.AssertExpectations(t)
+
+				store := NewStore(db, "audits", "reports", statusStore)
+				results, err := store.GetAudits(test.Filter)
+
+				var IDs []string
+				for _, result := range results {
+					IDs = append(IDs, result.Audit.ID)
+				}
+				assert.Nil(t, err)
+				assert.Equal(t, test.Expected, IDs)
+			})
+		}
+		t.Run("should not return audits that are not completed as passed", func(t *testing.T) {
+			db, clear := newAuditHistoryTables()
+			defer clear()
+			setup(db)
+
+			db.Table("audits").Create(&audit{ID: "audit-5", ProfileID: "profile-1", URN: urn, EventTimestamp: day.Add(72 * time.Hour)})
+			db.Table("audits").Create(&audit{ID: "audit-6", ProfileID: "profile-1", URN: urn, EventTimestamp: day.Add(96 * time.Hour)})
+			db.Exec(fmt.Sprintf("INSERT INTO %s (job_id, job_type, status, message, created_at) VALUES (?, ?, ?, ?, ?)", statusTableName),
+				"audit-5", job.TypeAudit, "failed", "unable to audit", day.Add(72*time.Hour))
+
+			store := NewStore(db, "audits", "reports", mock.NewStatusStore())
+			results, err := store.GetAudits(&protocol.AuditFilter{URN: urn, Pass: &passed})
+
+			var IDs []string
+			for _, result := range results {
+				IDs = append(IDs, result.Audit.ID)
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"audit-3"}, IDs)
+		})
+		t.Run("should return audits with their latest status and profile", func(t *testing.T) {
+			db, clear := newAuditHistoryTables()
+			defer clear()
+			setup(db)
+
+			store := NewStore(db, "audits", "reports", mock.NewStatusStore())
+			results, err := store.GetAudits(&protocol.AuditFilter{URN: urn, From: day, To: day.Add(48 * time.Hour)})
+
+			assert.Nil(t, err)
+			assert.Len(t, results, 2)
+			assert.Equal(t, job.StateCompleted, results[0].Audit.State)
+			assert.Equal(t, "audit completed", results[0].Audit.Message)
+			assert.Equal(t, &job.Profile{ID: "profile-2", URN: urn, GroupName: "__PARTITION__", Mode: job.ModeComplete, TotalRecords: 20}, results[0].Profile)
+			assert.Equal(t, job.State(""), results[1].Audit.State)
+			assert.Equal(t, "profile-1", results[1].Profile.ID)
+		})
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/odpf/predator/protocol/metric"
 	"github.com/odpf/predator/util"
 	"strings"
+	"time"
 )
 
 //DefaultAuditSummaryFactory to create summary log message of audit
@@ -42,20 +43,35 @@ func formSummary(auditResults []*protocol.AuditReport) *protocol.AuditSummary {
 	passFlag := isAllResultPass(auditResults)
 	message := formMessage(auditResults)
 	summary := &protocol.AuditSummary{
-		IsPass:  passFlag,
-		Message: message,
+		IsPass:   passFlag,
+		Message:  message,
+		Severity: highestFailedSeverity(auditResults),
 	}
 	return summary
 }
 
+//isAllResultPass whether no result is failed with error severity, failure of lower severity does not fail the audit
 func isAllResultPass(auditResults []*protocol.AuditReport) bool {
 	var passFlag = true
 	for _, auditResult := range auditResults {
-		passFlag = passFlag && auditResult.PassFlag
+		passFlag = passFlag && !auditResult.FailsAudit()
 	}
 	return passFlag
 }
 
+func highestFailedSeverity(auditResults []*protocol.AuditReport) protocol.Severity {
+	var highest protocol.Severity
+	for _, auditResult := range auditResults {
+		if auditResult.PassFlag {
+			continue
+		}
+		if highest == "" || auditResult.Severity.IsHigherThan(highest) {
+			highest = auditResult.Severity.OrDefault()
+		}
+	}
+	return highest
+}
+
 func (a *DefaultAuditSummaryFactory) formNoRecordsSummary(auditJob *job.Audit) (*protocol.AuditSummary, error) {
 	hasAvailabilityRules, err := a.hasAvailabilityRule(auditJob.URN)
 	if err != nil {
@@ -120,26 +136,49 @@ func formGroupInfo(group string) string {
 func formToleranceInfo(toleranceRules []protocol.ToleranceRule) string {
 	var toleranceRulesInfo []string
 	for _, toleranceRule := range toleranceRules {
-		toleranceRuleInfo := fmt.Sprintf("%s %.2f", strings.ToUpper(string(toleranceRule.Comparator)), toleranceRule.Value)
-		toleranceRulesInfo = append(toleranceRulesInfo, toleranceRuleInfo)
+		toleranceRulesInfo = append(toleranceRulesInfo, toleranceRule.String())
 	}
 	return strings.Join(toleranceRulesInfo, ", ")
 }
 
 func formIssueMessage(element *protocol.AuditReport) string {
+	if element.MetricName == metric.FreshnessLagSeconds {
+		return formFreshnessIssueMessage(element)
+	}
+
 	fieldInfo := formFieldInfo(element.FieldID)
 	groupInfo := formGroupInfo(element.GroupValue)
 	toleranceInfo := formToleranceInfo(element.ToleranceRules)
 	metricValue := util.RoundMetricValue(element.MetricValue)
 	conditionInfo := formConditionInfo(element.MetricName, element.Condition)
+	ruleInfo := protocol.FormRuleInfo(element.MetricName, element.Metadata)
+
+	return fmt.Sprintf("%s%s %sIS NOT PASSED THE TOLERANCE %s%s%s\nTolerance: %s\nACTUAL VALUE: %s", protocol.FormSeverityInfo(element.Severity), strings.ToUpper(element.MetricName.String()), fieldInfo, groupInfo, conditionInfo, ruleInfo, toleranceInfo, metricValue)
+}
+
+//formFreshnessIssueMessage describe how late the data is instead of the metric name
+func formFreshnessIssueMessage(element *protocol.AuditReport) string {
+	latestInfo := "TABLE LAST MODIFIED TIME"
+	if timestampField := metric.GetTimestampField(element.Metadata); timestampField != "" {
+		latestInfo = fmt.Sprintf("LATEST %s", strings.ToUpper(timestampField))
+	}
+	var groupInfo string
+	if element.GroupValue != "" {
+		groupInfo = fmt.Sprintf(" IN GROUP %s", element.GroupValue)
+	}
+	lag := time.Duration(element.MetricValue) * time.Second
+	toleranceInfo := formToleranceInfo(element.ToleranceRules)
+	metricValue := util.RoundMetricValue(element.MetricValue)
 
-	return fmt.Sprintf("%s %sIS NOT PASSED THE TOLERANCE %s%s\nTolerance: %s\nACTUAL VALUE: %s", strings.ToUpper(element.MetricName.String()), fieldInfo, groupInfo, conditionInfo, toleranceInfo, metricValue)
+	return fmt.Sprintf("%sDATA IS LATE%s, %s IS %s BEFORE AUDIT TIME\nTolerance: %s\nACTUAL VALUE: %s", protocol.FormSeverityInfo(element.Severity), groupInfo, latestInfo, lag, toleranceInfo, metricValue)
 }
 
 func formConditionInfo(metricName metric.Type, condition string) string {
 	var conditionInfo string
 	if metricName == metric.InvalidPct {
 		conditionInfo = fmt.Sprintf("\nCONDITION: %s", strings.ToUpper(condition))
+	} else if metricName == metric.SchemaDrift && condition != "" {
+		conditionInfo = fmt.Sprintf("\nCHANGE: %s", strings.ToUpper(condition))
 	}
 	return conditionInfo
 }
@@ -138,6 +138,96 @@ func TestIssueSummary(t *testing.T) {
 			issueSum := FormIssueSummary(auditRes)
 			expected := "INVALID_PCT OF FIELD3 IS NOT PASSED THE TOLERANCE IN GROUP 2019-01-02\nCONDITION: FIELD3 <= 0\nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 0.050"
 
+			assert.Equal(t, expected, issueSum)
+		})
+		t.Run("should return conformity metric issue summary with rule parameters", func(t *testing.T) {
+			tolRule := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorLessThanEq,
+					Value:      0.0,
+				},
+			}
+
+			auditRes := []*protocol.AuditReport{
+				{
+					AuditID:        "audit-abcd",
+					TableURN:       "project.dataset.table",
+					FieldID:        "status",
+					GroupValue:     "2019-01-02",
+					MetricName:     metric.AcceptedValuesPct,
+					MetricValue:    2.5,
+					Metadata:       map[string]interface{}{metric.AcceptedValues: []interface{}{"active", "inactive"}},
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+				{
+					AuditID:        "audit-abcd",
+					TableURN:       "project.dataset.table",
+					FieldID:        "code",
+					GroupValue:     "2019-01-02",
+					MetricName:     metric.PatternMismatchPct,
+					MetricValue:    1.0,
+					Metadata:       map[string]interface{}{metric.Pattern: "^[a-z]+$"},
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+				{
+					AuditID:        "audit-abcd",
+					TableURN:       "project.dataset.table",
+					FieldID:        "code",
+					GroupValue:     "2019-01-02",
+					MetricName:     metric.LengthOutOfRangePct,
+					MetricValue:    1.0,
+					Metadata:       map[string]interface{}{metric.MaxLength: float64(8)},
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "ACCEPTED_VALUES_PCT OF STATUS IS NOT PASSED THE TOLERANCE IN GROUP 2019-01-02\nACCEPTED VALUES: active, inactive\nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 2.500" +
+				"\n\nPATTERN_MISMATCH_PCT OF CODE IS NOT PASSED THE TOLERANCE IN GROUP 2019-01-02\nPATTERN: ^[a-z]+$\nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 1.000" +
+				"\n\nLENGTH_OUT_OF_RANGE_PCT OF CODE IS NOT PASSED THE TOLERANCE IN GROUP 2019-01-02\nLENGTH RANGE: AT MOST 8\nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 1.000"
+
+			assert.Equal(t, expected, issueSum)
+		})
+		t.Run("should return freshness issue summary with how late the data is", func(t *testing.T) {
+			tolRule := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorLessThan,
+					Value:      3600,
+				},
+			}
+			auditRes := []*protocol.AuditReport{
+				{
+					AuditID:        "audit-abcd",
+					TableURN:       "project.dataset.table",
+					GroupValue:     "2019-01-02",
+					MetricName:     metric.FreshnessLagSeconds,
+					MetricValue:    5400,
+					Metadata:       map[string]interface{}{metric.TimestampField: "event_timestamp"},
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+				{
+					AuditID:        "audit-abcd",
+					TableURN:       "project.dataset.table",
+					MetricName:     metric.FreshnessLagSeconds,
+					MetricValue:    7230,
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "DATA IS LATE IN GROUP 2019-01-02, LATEST EVENT_TIMESTAMP IS 1h30m0s BEFORE AUDIT TIME\nTolerance: LESS_THAN 3600.00\nACTUAL VALUE: 5400.000" +
+				"\n\nDATA IS LATE, TABLE LAST MODIFIED TIME IS 2h0m30s BEFORE AUDIT TIME\nTolerance: LESS_THAN 3600.00\nACTUAL VALUE: 7230.000"
+
 			assert.Equal(t, expected, issueSum)
 		})
 	})
@@ -163,6 +253,92 @@ func TestIssueSummary(t *testing.T) {
 			assert.Nil(t, err)
 			assert.Equal(t, expected, summary)
 		})
+		t.Run("should return pass with warning when only failed results are below error severity", func(t *testing.T) {
+			auditJob := &job.Audit{
+				URN:          "project.dataset.table",
+				TotalRecords: 20,
+			}
+			tolRule := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorLessThanEq,
+					Value:      0.0,
+				},
+			}
+			auditRes := []*protocol.AuditReport{
+				{
+					MetricName:     metric.DuplicationPct,
+					MetricValue:    0.0,
+					ToleranceRules: tolRule,
+					PassFlag:       true,
+					Severity:       protocol.SeverityError,
+				},
+				{
+					FieldID:        "field1",
+					MetricName:     metric.NullnessPct,
+					MetricValue:    0.5,
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					Severity:       protocol.SeverityInfo,
+				},
+				{
+					FieldID:        "field2",
+					MetricName:     metric.NullnessPct,
+					MetricValue:    0.1,
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					Severity:       protocol.SeverityWarn,
+				},
+			}
+
+			summaryFactory := NewAuditSummaryFactory(mock.NewToleranceStore())
+			expected := &protocol.AuditSummary{
+				IsPass: true,
+				Message: "[INFO] NULLNESS_PCT OF FIELD1 IS NOT PASSED THE TOLERANCE \nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 0.500\n\n" +
+					"[WARN] NULLNESS_PCT OF FIELD2 IS NOT PASSED THE TOLERANCE \nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 0.100",
+				Severity: protocol.SeverityWarn,
+			}
+
+			summary, err := summaryFactory.Create(auditRes, auditJob)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, summary)
+		})
+		t.Run("should return not pass when a result failed with error severity", func(t *testing.T) {
+			auditJob := &job.Audit{
+				URN:          "project.dataset.table",
+				TotalRecords: 20,
+			}
+			tolRule := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorMoreThan,
+					Value:      0.0,
+				},
+			}
+			auditRes := []*protocol.AuditReport{
+				{
+					MetricName:     metric.RowCount,
+					MetricValue:    0.0,
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					Severity:       protocol.SeverityError,
+				},
+				{
+					FieldID:        "field1",
+					MetricName:     metric.NullnessPct,
+					MetricValue:    0.5,
+					ToleranceRules: tolRule,
+					PassFlag:       false,
+					Severity:       protocol.SeverityWarn,
+				},
+			}
+
+			summaryFactory := NewAuditSummaryFactory(mock.NewToleranceStore())
+			summary, err := summaryFactory.Create(auditRes, auditJob)
+
+			assert.Nil(t, err)
+			assert.False(t, summary.IsPass)
+			assert.Equal(t, protocol.SeverityError, summary.Severity)
+		})
 	})
 	t.Run("formNoRecordsSummary", func(t *testing.T) {
 		tableID := "project.dataset.table"
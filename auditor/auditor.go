@@ -8,34 +8,47 @@ import (
 
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
 	"github.com/odpf/predator/protocol/metric"
 )
 
 var logger = log.New(os.Stdout, "INFO: ", log.Lshortfile|log.LstdFlags)
 
+//History is metrics of previous profiles, keyed by lookback of anomaly rules
+type History map[int][]*metric.Metric
+
+//previousLookback is lookback of history used by pct_change_vs_previous rule
+const previousLookback = 1
+
 //RuleValidator to validate metric value with rule
 type RuleValidator interface {
-	Validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance) ([]*protocol.ValidatedMetric, error)
+	Validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance, history History) ([]*protocol.ValidatedMetric, error)
 }
 
 //Auditor as a structure of auditor
 type Auditor struct {
 	ruleValidator  RuleValidator
 	toleranceStore protocol.ToleranceStore
+	profileStore   protocol.ProfileStore
 	metadataStore  protocol.MetadataStore
 	metricStore    protocol.MetricStore
+	schemaStore    protocol.SchemaStore
 }
 
 //New create Auditor
 func New(toleranceStore protocol.ToleranceStore,
 	validator RuleValidator,
+	profileStore protocol.ProfileStore,
 	metadataStore protocol.MetadataStore,
-	metricStore protocol.MetricStore) *Auditor {
+	metricStore protocol.MetricStore,
+	schemaStore protocol.SchemaStore) *Auditor {
 	return &Auditor{
 		ruleValidator:  validator,
 		toleranceStore: toleranceStore,
+		profileStore:   profileStore,
 		metadataStore:  metadataStore,
 		metricStore:    metricStore,
+		schemaStore:    schemaStore,
 	}
 }
 
@@ -52,7 +65,21 @@ func (a *Auditor) Audit(audit *job.Audit) ([]*protocol.AuditReport, error) {
 		return nil, e
 	}
 
-	auditResults, err := a.auditing(audit, tolerance.Tolerances)
+	tolerances, err := a.expandFieldSelectors(audit, tolerance.Tolerances)
+	if err != nil {
+		e := fmt.Errorf("failed to expand field selectors for table %s ,%w", audit.URN, err)
+		logger.Println(e)
+		return nil, e
+	}
+
+	profile, err := a.profileStore.Get(audit.ProfileID)
+	if err != nil {
+		e := fmt.Errorf("failed to get profile %s of table %s ,%w", audit.ProfileID, audit.URN, err)
+		logger.Println(e)
+		return nil, e
+	}
+
+	auditResults, err := a.auditing(audit, profile, tolerances)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +87,78 @@ func (a *Auditor) Audit(audit *job.Audit) ([]*protocol.AuditReport, error) {
 	return auditResults, nil
 }
 
-func (a *Auditor) auditing(audit *job.Audit, tolerance []*protocol.Tolerance) ([]*protocol.AuditReport, error) {
+//expandFieldSelectors expand tolerances with field selector to the selected fields, so they match metrics of the profile
+//schema of the table is only fetched when a field selector is configured
+func (a *Auditor) expandFieldSelectors(audit *job.Audit, tolerances []*protocol.Tolerance) ([]*protocol.Tolerance, error) {
+	for _, t := range tolerances {
+		if t.FieldSelector == nil {
+			continue
+		}
+		tableSpec, err := a.getProfiledTableSpec(audit)
+		if err != nil {
+			return nil, err
+		}
+		return protocol.ExpandFieldSelectors(tableSpec, tolerances)
+	}
+	return tolerances, nil
+}
+
+//getProfiledTableSpec get table spec with schema snapshot taken by the profile, so columns changed after the profile are not selected
+//current metadata of the table is used for profile without snapshot
+func (a *Auditor) getProfiledTableSpec(audit *job.Audit) (*meta.TableSpec, error) {
+	snapshot, err := a.schemaStore.GetByProfileID(audit.ProfileID)
+	if err == protocol.ErrSchemaSnapshotNotFound {
+		return a.metadataStore.GetMetadata(audit.URN)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := meta.FieldsOf(snapshot.Columns)
+	if err != nil {
+		return nil, err
+	}
+	return &meta.TableSpec{Fields: fields}, nil
+}
+
+func (a *Auditor) auditing(audit *job.Audit, profile *job.Profile, tolerances []*protocol.Tolerance) ([]*protocol.AuditReport, error) {
+	var metricTolerances []*protocol.Tolerance
+	var schemaTolerances []*protocol.Tolerance
+	for _, t := range tolerances {
+		if t.MetricName == metric.SchemaDrift {
+			schemaTolerances = append(schemaTolerances, t)
+		} else {
+			metricTolerances = append(metricTolerances, t)
+		}
+	}
+
+	var auditReports []*protocol.AuditReport
+	if len(metricTolerances) > 0 || len(schemaTolerances) == 0 {
+		metricReports, err := a.auditMetrics(audit, profile, metricTolerances)
+		if err != nil {
+			return nil, err
+		}
+		auditReports = append(auditReports, metricReports...)
+	}
+
+	if len(schemaTolerances) > 0 {
+		schemaReports, err := a.auditSchemaDrift(audit, schemaTolerances)
+		if err != nil {
+			e := fmt.Errorf("failed to audit schema drift for table %s,%w", audit.URN, err)
+			logger.Println(e)
+			return nil, e
+		}
+		auditReports = append(auditReports, schemaReports...)
+	}
+
+	if audit.TotalRecords > 0 && len(auditReports) == 0 {
+		return nil, errors.New("failed to audit result")
+	}
+
+	return auditReports, nil
+}
+
+func (a *Auditor) auditMetrics(audit *job.Audit, profile *job.Profile, tolerances []*protocol.Tolerance) ([]*protocol.AuditReport, error) {
 	metrics, err := a.metricStore.GetMetricsByProfileID(audit.ProfileID)
 	if err != nil {
 		e := fmt.Errorf("failed to get metrics for table %s,%w", audit.URN, err)
@@ -68,19 +166,126 @@ func (a *Auditor) auditing(audit *job.Audit, tolerance []*protocol.Tolerance) ([
 		return nil, e
 	}
 
-	validatedMetrics, err := a.ruleValidator.Validate(metrics, tolerance)
+	history, err := a.getHistory(profile, tolerances)
+	if err != nil {
+		e := fmt.Errorf("failed to get metric history for table %s,%w", audit.URN, err)
+		logger.Println(e)
+		return nil, e
+	}
+
+	validatedMetrics, err := a.ruleValidator.Validate(metrics, tolerances, history)
 	if err != nil {
 		e := fmt.Errorf("failed to check score against tolerance rules for table %s,%w", audit.URN, err)
 		logger.Println(e)
 		return nil, e
 	}
 
-	auditReports := generateAuditReports(audit, validatedMetrics)
-	if audit.TotalRecords > 0 && len(auditReports) == 0 {
-		return nil, errors.New("failed to audit result")
+	return generateAuditReports(audit, validatedMetrics), nil
+}
+
+//auditSchemaDrift compare schema snapshot of the profile against pinned schema of the tolerance or the previous snapshot of the table
+//each change is reported with value 1 on the changed column, a single report with value 0 is returned when nothing changed
+//first snapshot of a table without pinned schema has no baseline and is reported as no change
+func (a *Auditor) auditSchemaDrift(audit *job.Audit, tolerances []*protocol.Tolerance) ([]*protocol.AuditReport, error) {
+	current, err := a.schemaStore.GetByProfileID(audit.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *protocol.SchemaSnapshot
+	var auditReports []*protocol.AuditReport
+	for _, t := range tolerances {
+		baseline, pinned := metric.GetPinnedSchema(t.Metadata)
+		if !pinned {
+			if previous == nil {
+				previous, err = a.schemaStore.GetPrevious(current)
+				if err == protocol.ErrSchemaSnapshotNotFound {
+					previous, err = current, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+			}
+			baseline = previous.Columns
+		}
+
+		changes := meta.DiffSchema(baseline, current.Columns)
+		auditReports = append(auditReports, generateSchemaDriftReports(audit, t, changes)...)
 	}
+	return auditReports, nil
+}
 
-	return auditReports, err
+func generateSchemaDriftReports(audit *job.Audit, tolerance *protocol.Tolerance, changes []*meta.SchemaChange) []*protocol.AuditReport {
+	newReport := func(value float64) *protocol.AuditReport {
+		return &protocol.AuditReport{
+			AuditID:        audit.ID,
+			TableURN:       audit.URN,
+			MetricName:     metric.SchemaDrift,
+			MetricValue:    value,
+			ToleranceRules: tolerance.ToleranceRules,
+			PassFlag:       check(&metric.Metric{Value: value}, nil, tolerance.ToleranceRules),
+			Severity:       tolerance.Severity.OrDefault(),
+			EventTimestamp: audit.EventTimestamp,
+		}
+	}
+
+	if len(changes) == 0 {
+		return []*protocol.AuditReport{newReport(0)}
+	}
+
+	var auditReports []*protocol.AuditReport
+	for _, change := range changes {
+		report := newReport(1)
+		report.FieldID = change.Column
+		report.Condition = change.Description()
+		report.Metadata = map[string]interface{}{metric.SchemaChange: string(change.Kind)}
+		if change.Previous != nil {
+			report.Metadata[metric.PreviousType] = string(change.Previous.Type)
+			report.Metadata[metric.PreviousMode] = string(change.Previous.Mode)
+		}
+		if change.Current != nil {
+			report.Metadata[metric.CurrentType] = string(change.Current.Type)
+			report.Metadata[metric.CurrentMode] = string(change.Current.Mode)
+		}
+		auditReports = append(auditReports, report)
+	}
+	return auditReports
+}
+
+//getHistory get metrics of profiles before the audited profile for each lookback of the anomaly rules, and of the previous profile for pct_change_vs_previous rules
+//rules of group overrides are included
+func (a *Auditor) getHistory(profile *job.Profile, tolerances []*protocol.Tolerance) (History, error) {
+	history := make(History)
+	for _, t := range tolerances {
+		lookbacks := getLookbacks(t.ToleranceRules, t.Anomaly)
+		for _, override := range t.GroupOverrides {
+			lookbacks = append(lookbacks, getLookbacks(override.ToleranceRules, override.Anomaly)...)
+		}
+
+		for _, lookback := range lookbacks {
+			if _, ok := history[lookback]; ok {
+				continue
+			}
+			metrics, err := a.metricStore.GetPreviousMetrics(profile, lookback)
+			if err != nil {
+				return nil, err
+			}
+			history[lookback] = metrics
+		}
+	}
+	return history, nil
+}
+
+//getLookbacks get lookback of history needed by the rules
+func getLookbacks(toleranceRules []protocol.ToleranceRule, anomaly *protocol.AnomalyRule) []int {
+	var lookbacks []int
+	if anomaly != nil {
+		lookbacks = append(lookbacks, anomaly.Lookback)
+	}
+	if protocol.HasComparator(toleranceRules, protocol.ComparatorPctChangeVsPrevious) {
+		lookbacks = append(lookbacks, previousLookback)
+	}
+	return lookbacks
 }
 
 func generateAuditReports(audit *job.Audit, validatedMetrics []*protocol.ValidatedMetric) []*protocol.AuditReport {
@@ -96,7 +301,11 @@ func generateAuditReports(audit *job.Audit, validatedMetrics []*protocol.Validat
 			Condition:      validatedMetric.Metric.Condition,
 			Metadata:       validatedMetric.Metric.Metadata,
 			ToleranceRules: validatedMetric.ToleranceRules,
+			ExpectedBand:   validatedMetric.ExpectedBand,
+			GroupOverride:  validatedMetric.GroupOverride,
+			NoBaseline:     validatedMetric.NoBaseline,
 			PassFlag:       validatedMetric.PassFlag,
+			Severity:       validatedMetric.Severity.OrDefault(),
 			EventTimestamp: audit.EventTimestamp,
 		}
 		auditReports = append(auditReports, auditReport)
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/odpf/predator/mock"
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
 	"github.com/odpf/predator/protocol/metric"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,6 +19,13 @@ func TestAuditor(t *testing.T) {
 		tableID := "project-1.dataset_a.table_x"
 		auditID := "abcd"
 		profileID := "1234"
+		profile := &job.Profile{
+			ID:             profileID,
+			URN:            tableID,
+			EventTimestamp: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		profileStore := mock.NewProfileStore()
+		profileStore.On("Get", profileID).Return(profile, nil)
 		toleranceDuplicationPct := &protocol.Tolerance{
 			TableURN:   tableID,
 			MetricName: "duplication_pct",
@@ -56,7 +65,7 @@ func TestAuditor(t *testing.T) {
 			defer metricStore.AssertExpectations(t)
 
 			defaultRuleValidator := NewMockRuleValidator()
-			defaultRuleValidator.On("Validate", metrics, toleranceSpec.Tolerances).Return(validatedMetrics, nil)
+			defaultRuleValidator.On("Validate", metrics, toleranceSpec.Tolerances, History{}).Return(validatedMetrics, nil)
 			defer defaultRuleValidator.AssertExpectations(t)
 
 			expected := []*protocol.AuditReport{
@@ -67,14 +76,341 @@ func TestAuditor(t *testing.T) {
 					MetricValue:    metricDuplicationPct.Value,
 					ToleranceRules: toleranceDuplicationPct.ToleranceRules,
 					PassFlag:       false,
+					Severity:       protocol.SeverityError,
+				},
+			}
+
+			auditor := &Auditor{
+				profileStore:   profileStore,
+				metricStore:    metricStore,
+				ruleValidator:  defaultRuleValidator,
+				toleranceStore: toleranceStore,
+			}
+			audit := &job.Audit{
+				ID:           auditID,
+				ProfileID:    profileID,
+				URN:          tableID,
+				TotalRecords: 20,
+			}
+			result, err := auditor.Audit(audit)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should audit tolerance with field selector on the selected fields of the profiled schema", func(t *testing.T) {
+			metrics := []*metric.Metric{{}}
+			toleranceNullnessPct := &protocol.Tolerance{
+				TableURN:       tableID,
+				MetricName:     metric.NullnessPct,
+				ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+				FieldSelector:  &protocol.FieldSelector{FieldID: "*_id"},
+			}
+			toleranceSpec := &protocol.ToleranceSpec{
+				URN:        tableID,
+				Tolerances: []*protocol.Tolerance{toleranceNullnessPct},
+			}
+			snapshot := &protocol.SchemaSnapshot{
+				ProfileID: profileID,
+				URN:       tableID,
+				Columns: []*meta.ColumnSchema{
+					{Name: "user_id", Type: meta.FieldTypeString},
+					{Name: "name", Type: meta.FieldTypeString},
+				},
+			}
+			expandedTolerances := []*protocol.Tolerance{
+				{
+					TableURN:       tableID,
+					FieldID:        "user_id",
+					MetricName:     metric.NullnessPct,
+					ToleranceRules: toleranceNullnessPct.ToleranceRules,
+				},
+			}
+			metricNullnessPct := &metric.Metric{
+				ID:      "2",
+				FieldID: "user_id",
+				Type:    metric.NullnessPct,
+				Value:   0,
+			}
+			validatedMetrics := []*protocol.ValidatedMetric{
+				{
+					Metric:         metricNullnessPct,
+					ToleranceRules: toleranceNullnessPct.ToleranceRules,
+					PassFlag:       true,
+				},
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetByTableID", tableID).Return(toleranceSpec, nil)
+			defer toleranceStore.AssertExpectations(t)
+
+			schemaStore := mock.NewSchemaStore()
+			schemaStore.On("GetByProfileID", profileID).Return(snapshot, nil)
+			defer schemaStore.AssertExpectations(t)
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			metricStore := mock.NewMetricStore()
+			metricStore.On("GetMetricsByProfileID", profileID).Return(metrics, nil)
+			defer metricStore.AssertExpectations(t)
+
+			defaultRuleValidator := NewMockRuleValidator()
+			defaultRuleValidator.On("Validate", metrics, expandedTolerances, History{}).Return(validatedMetrics, nil)
+			defer defaultRuleValidator.AssertExpectations(t)
+
+			expected := []*protocol.AuditReport{
+				{
+					AuditID:        auditID,
+					TableURN:       tableID,
+					FieldID:        "user_id",
+					MetricName:     metric.NullnessPct,
+					MetricValue:    0,
+					ToleranceRules: toleranceNullnessPct.ToleranceRules,
+					PassFlag:       true,
+					Severity:       protocol.SeverityError,
+				},
+			}
+
+			auditor := &Auditor{
+				profileStore:   profileStore,
+				metricStore:    metricStore,
+				metadataStore:  metadataStore,
+				schemaStore:    schemaStore,
+				ruleValidator:  defaultRuleValidator,
+				toleranceStore: toleranceStore,
+			}
+			audit := &job.Audit{
+				ID:           auditID,
+				ProfileID:    profileID,
+				URN:          tableID,
+				TotalRecords: 20,
+			}
+			result, err := auditor.Audit(audit)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should audit anomaly rules with metrics of previous profiles", func(t *testing.T) {
+			toleranceAnomaly := &protocol.Tolerance{
+				TableURN:   tableID,
+				MetricName: metric.DuplicationPct,
+				Anomaly:    &protocol.AnomalyRule{Lookback: 7, ZScore: 3},
+			}
+			metrics := []*metric.Metric{metricDuplicationPct}
+			previousMetrics := []*metric.Metric{{Type: metric.DuplicationPct, Value: 1.0}}
+			toleranceSpec := &protocol.ToleranceSpec{
+				URN:        tableID,
+				Tolerances: []*protocol.Tolerance{toleranceAnomaly},
+			}
+			expectedBand := &protocol.ExpectedBand{Lower: 0.5, Upper: 1.5, BaselineSize: 7}
+			validatedMetrics := []*protocol.ValidatedMetric{
+				{
+					Metric:       metricDuplicationPct,
+					ExpectedBand: expectedBand,
+					PassFlag:     false,
+				},
+			}
+			audit := &job.Audit{
+				ID:           auditID,
+				ProfileID:    profileID,
+				URN:          tableID,
+				TotalRecords: 20,
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetByTableID", tableID).Return(toleranceSpec, nil)
+			defer toleranceStore.AssertExpectations(t)
+
+			metricStore := mock.NewMetricStore()
+			metricStore.On("GetMetricsByProfileID", profileID).Return(metrics, nil)
+			metricStore.On("GetPreviousMetrics", profile, 7).Return(previousMetrics, nil)
+			defer metricStore.AssertExpectations(t)
+
+			defaultRuleValidator := NewMockRuleValidator()
+			defaultRuleValidator.On("Validate", metrics, toleranceSpec.Tolerances, History{7: previousMetrics}).Return(validatedMetrics, nil)
+			defer defaultRuleValidator.AssertExpectations(t)
+
+			expected := []*protocol.AuditReport{
+				{
+					AuditID:      auditID,
+					TableURN:     tableID,
+					MetricName:   metric.DuplicationPct,
+					MetricValue:  metricDuplicationPct.Value,
+					ExpectedBand: expectedBand,
+					PassFlag:     false,
+					Severity:     protocol.SeverityError,
+				},
+			}
+
+			auditor := &Auditor{
+				profileStore:   profileStore,
+				metricStore:    metricStore,
+				ruleValidator:  defaultRuleValidator,
+				toleranceStore: toleranceStore,
+			}
+			result, err := auditor.Audit(audit)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should audit pct change rules nested in any of rule with metrics of the previous profile", func(t *testing.T) {
+			toleranceRules := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorAnyOf,
+					Groups: [][]protocol.ToleranceRule{
+						{{Comparator: protocol.ComparatorLessThan, Value: 1.0}},
+						{{Comparator: protocol.ComparatorPctChangeVsPrevious, Value: 10.0}},
+					},
+				},
+			}
+			tolerancePctChange := &protocol.Tolerance{
+				TableURN:       tableID,
+				MetricName:     metric.DuplicationPct,
+				ToleranceRules: toleranceRules,
+			}
+			metrics := []*metric.Metric{metricDuplicationPct}
+			previousMetrics := []*metric.Metric{{Type: metric.DuplicationPct, Value: 1.0}}
+			toleranceSpec := &protocol.ToleranceSpec{
+				URN:        tableID,
+				Tolerances: []*protocol.Tolerance{tolerancePctChange},
+			}
+			validatedMetrics := []*protocol.ValidatedMetric{
+				{
+					Metric:         metricDuplicationPct,
+					ToleranceRules: toleranceRules,
+					PassFlag:       true,
+				},
+			}
+			audit := &job.Audit{
+				ID:           auditID,
+				ProfileID:    profileID,
+				URN:          tableID,
+				TotalRecords: 20,
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetByTableID", tableID).Return(toleranceSpec, nil)
+			defer toleranceStore.AssertExpectations(t)
+
+			metricStore := mock.NewMetricStore()
+			metricStore.On("GetMetricsByProfileID", profileID).Return(metrics, nil)
+			metricStore.On("GetPreviousMetrics", profile, 1).Return(previousMetrics, nil)
+			defer metricStore.AssertExpectations(t)
+
+			defaultRuleValidator := NewMockRuleValidator()
+			defaultRuleValidator.On("Validate", metrics, toleranceSpec.Tolerances, History{1: previousMetrics}).Return(validatedMetrics, nil)
+			defer defaultRuleValidator.AssertExpectations(t)
+
+			expected := []*protocol.AuditReport{
+				{
+					AuditID:        auditID,
+					TableURN:       tableID,
+					MetricName:     metric.DuplicationPct,
+					MetricValue:    metricDuplicationPct.Value,
+					ToleranceRules: toleranceRules,
+					PassFlag:       true,
+					Severity:       protocol.SeverityError,
 				},
 			}
 
 			auditor := &Auditor{
+				profileStore:   profileStore,
 				metricStore:    metricStore,
 				ruleValidator:  defaultRuleValidator,
 				toleranceStore: toleranceStore,
 			}
+			result, err := auditor.Audit(audit)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should audit schema drift against previous snapshot along with other metrics", func(t *testing.T) {
+			noChange := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}}
+			toleranceSchemaDrift := &protocol.Tolerance{
+				TableURN:       tableID,
+				MetricName:     metric.SchemaDrift,
+				ToleranceRules: noChange,
+				Severity:       protocol.SeverityWarn,
+			}
+			metrics := []*metric.Metric{metricDuplicationPct}
+			toleranceSpec := &protocol.ToleranceSpec{
+				URN:        tableID,
+				Tolerances: []*protocol.Tolerance{toleranceDuplicationPct, toleranceSchemaDrift},
+			}
+			validatedMetrics := []*protocol.ValidatedMetric{
+				{
+					Metric:         metricDuplicationPct,
+					ToleranceRules: toleranceDuplicationPct.ToleranceRules,
+					PassFlag:       false,
+				},
+			}
+			current := &protocol.SchemaSnapshot{
+				ID:        2,
+				ProfileID: profileID,
+				URN:       tableID,
+				Columns: []*meta.ColumnSchema{
+					{Name: "id", Type: meta.FieldTypeInteger, Mode: meta.ModeRequired},
+					{Name: "tags", Type: meta.FieldTypeString, Mode: meta.ModeRepeated},
+				},
+			}
+			previous := &protocol.SchemaSnapshot{
+				ID:        1,
+				ProfileID: "profile-0",
+				URN:       tableID,
+				Columns: []*meta.ColumnSchema{
+					{Name: "id", Type: meta.FieldTypeInteger, Mode: meta.ModeRequired},
+					{Name: "tags", Type: meta.FieldTypeString, Mode: meta.ModeNullable},
+				},
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetByTableID", tableID).Return(toleranceSpec, nil)
+			defer toleranceStore.AssertExpectations(t)
+
+			metricStore := mock.NewMetricStore()
+			metricStore.On("GetMetricsByProfileID", profileID).Return(metrics, nil)
+			defer metricStore.AssertExpectations(t)
+
+			schemaStore := mock.NewSchemaStore()
+			schemaStore.On("GetByProfileID", profileID).Return(current, nil)
+			schemaStore.On("GetPrevious", current).Return(previous, nil)
+			defer schemaStore.AssertExpectations(t)
+
+			defaultRuleValidator := NewMockRuleValidator()
+			defaultRuleValidator.On("Validate", metrics, []*protocol.Tolerance{toleranceDuplicationPct}, History{}).Return(validatedMetrics, nil)
+			defer defaultRuleValidator.AssertExpectations(t)
+
+			expected := []*protocol.AuditReport{
+				{
+					AuditID:        auditID,
+					TableURN:       tableID,
+					MetricName:     metric.DuplicationPct,
+					MetricValue:    metricDuplicationPct.Value,
+					ToleranceRules: toleranceDuplicationPct.ToleranceRules,
+					PassFlag:       false,
+					Severity:       protocol.SeverityError,
+				},
+				{
+					AuditID:     auditID,
+					TableURN:    tableID,
+					FieldID:     "tags",
+					MetricName:  metric.SchemaDrift,
+					MetricValue: 1,
+					Condition:   "mode changed from NULLABLE to REPEATED",
+					Metadata: map[string]interface{}{
+						metric.SchemaChange: "mode_changed",
+						metric.PreviousType: "STRING",
+						metric.PreviousMode: "NULLABLE",
+						metric.CurrentType:  "STRING",
+						metric.CurrentMode:  "REPEATED",
+					},
+					ToleranceRules: noChange,
+					PassFlag:       false,
+					Severity:       protocol.SeverityWarn,
+				},
+			}
+
+			auditor := New(toleranceStore, defaultRuleValidator, profileStore, mock.NewMetadataStore(), metricStore, schemaStore)
 			audit := &job.Audit{
 				ID:           auditID,
 				ProfileID:    profileID,
@@ -86,6 +422,124 @@ func TestAuditor(t *testing.T) {
 			assert.Equal(t, expected, result)
 			assert.Nil(t, err)
 		})
+		t.Run("should audit schema drift against pinned schema without getting metrics", func(t *testing.T) {
+			noChange := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}}
+			toleranceSchemaDrift := &protocol.Tolerance{
+				TableURN:   tableID,
+				MetricName: metric.SchemaDrift,
+				Metadata: map[string]interface{}{metric.PinnedSchema: []interface{}{
+					map[string]interface{}{"name": "id", "type": "INTEGER", "mode": "REQUIRED"},
+				}},
+				ToleranceRules: noChange,
+			}
+			toleranceSpec := &protocol.ToleranceSpec{
+				URN:        tableID,
+				Tolerances: []*protocol.Tolerance{toleranceSchemaDrift},
+			}
+			current := &protocol.SchemaSnapshot{
+				ID:        2,
+				ProfileID: profileID,
+				URN:       tableID,
+				Columns:   []*meta.ColumnSchema{{Name: "id", Type: meta.FieldTypeInteger, Mode: meta.ModeRequired}},
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetByTableID", tableID).Return(toleranceSpec, nil)
+			defer toleranceStore.AssertExpectations(t)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+
+			schemaStore := mock.NewSchemaStore()
+			schemaStore.On("GetByProfileID", profileID).Return(current, nil)
+			defer schemaStore.AssertExpectations(t)
+
+			expected := []*protocol.AuditReport{
+				{
+					AuditID:        auditID,
+					TableURN:       tableID,
+					MetricName:     metric.SchemaDrift,
+					MetricValue:    0,
+					ToleranceRules: noChange,
+					PassFlag:       true,
+					Severity:       protocol.SeverityError,
+				},
+			}
+
+			auditor := New(toleranceStore, NewMockRuleValidator(), profileStore, mock.NewMetadataStore(), metricStore, schemaStore)
+			audit := &job.Audit{
+				ID:           auditID,
+				ProfileID:    profileID,
+				URN:          tableID,
+				TotalRecords: 20,
+			}
+			result, err := auditor.Audit(audit)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should report no schema drift on the first snapshot of the table", func(t *testing.T) {
+			toleranceSchemaDrift := &protocol.Tolerance{
+				TableURN:   tableID,
+				MetricName: metric.SchemaDrift,
+			}
+			toleranceSpec := &protocol.ToleranceSpec{
+				URN:        tableID,
+				Tolerances: []*protocol.Tolerance{toleranceSchemaDrift},
+			}
+			current := &protocol.SchemaSnapshot{
+				ID:        1,
+				ProfileID: profileID,
+				URN:       tableID,
+				Columns:   []*meta.ColumnSchema{{Name: "id", Type: meta.FieldTypeInteger, Mode: meta.ModeRequired}},
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetByTableID", tableID).Return(toleranceSpec, nil)
+
+			schemaStore := mock.NewSchemaStore()
+			schemaStore.On("GetByProfileID", profileID).Return(current, nil)
+			schemaStore.On("GetPrevious", current).Return(&protocol.SchemaSnapshot{}, protocol.ErrSchemaSnapshotNotFound)
+			defer schemaStore.AssertExpectations(t)
+
+			auditor := New(toleranceStore, NewMockRuleValidator(), profileStore, mock.NewMetadataStore(), mock.NewMetricStore(), schemaStore)
+			audit := &job.Audit{
+				ID:           auditID,
+				ProfileID:    profileID,
+				URN:          tableID,
+				TotalRecords: 20,
+			}
+			result, err := auditor.Audit(audit)
+
+			assert.Nil(t, err)
+			assert.Len(t, result, 1)
+			assert.Equal(t, float64(0), result[0].MetricValue)
+			assert.True(t, result[0].PassFlag)
+		})
+		t.Run("should return error when schema snapshot of the profile is not found", func(t *testing.T) {
+			toleranceSpec := &protocol.ToleranceSpec{
+				URN:        tableID,
+				Tolerances: []*protocol.Tolerance{{TableURN: tableID, MetricName: metric.SchemaDrift}},
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetByTableID", tableID).Return(toleranceSpec, nil)
+
+			schemaStore := mock.NewSchemaStore()
+			schemaStore.On("GetByProfileID", profileID).Return(&protocol.SchemaSnapshot{}, protocol.ErrSchemaSnapshotNotFound)
+
+			auditor := New(toleranceStore, NewMockRuleValidator(), profileStore, mock.NewMetadataStore(), mock.NewMetricStore(), schemaStore)
+			audit := &job.Audit{
+				ID:           auditID,
+				ProfileID:    profileID,
+				URN:          tableID,
+				TotalRecords: 20,
+			}
+			result, err := auditor.Audit(audit)
+
+			assert.True(t, errors.Is(err, protocol.ErrSchemaSnapshotNotFound))
+			assert.Nil(t, result)
+		})
 		t.Run("should failed when no audit result but total records more than 0", func(t *testing.T) {
 			metrics := []*metric.Metric{{}}
 			toleranceSpec := &protocol.ToleranceSpec{
@@ -103,10 +557,11 @@ func TestAuditor(t *testing.T) {
 			defer metricStore.AssertExpectations(t)
 
 			defaultRuleValidator := NewMockRuleValidator()
-			defaultRuleValidator.On("Validate", metrics, toleranceSpec.Tolerances).Return(validatedMetrics, nil)
+			defaultRuleValidator.On("Validate", metrics, toleranceSpec.Tolerances, History{}).Return(validatedMetrics, nil)
 			defer defaultRuleValidator.AssertExpectations(t)
 
 			auditor := &Auditor{
+				profileStore:   profileStore,
 				metricStore:    metricStore,
 				ruleValidator:  defaultRuleValidator,
 				toleranceStore: toleranceStore,
@@ -132,6 +587,7 @@ func TestAuditor(t *testing.T) {
 				URN:       tableID,
 			}
 			auditor := &Auditor{
+				profileStore:   profileStore,
 				toleranceStore: toleranceStore,
 			}
 
@@ -157,6 +613,7 @@ func TestAuditor(t *testing.T) {
 				TotalRecords: 20,
 			}
 			auditor := &Auditor{
+				profileStore:   profileStore,
 				toleranceStore: toleranceStore,
 			}
 
@@ -167,6 +624,39 @@ func TestAuditor(t *testing.T) {
 			assert.Nil(t, actualResult)
 			assert.Equal(t, expectedErr, actualErr)
 		})
+		t.Run("should return error when get profile failed", func(t *testing.T) {
+			toleranceSpec := &protocol.ToleranceSpec{
+				URN:        tableID,
+				Tolerances: []*protocol.Tolerance{toleranceDuplicationPct},
+			}
+			dbErr := errors.New("database error")
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetByTableID", tableID).Return(toleranceSpec, nil)
+			defer toleranceStore.AssertExpectations(t)
+
+			missingProfileStore := mock.NewProfileStore()
+			missingProfileStore.On("Get", profileID).Return(&job.Profile{}, dbErr)
+			defer missingProfileStore.AssertExpectations(t)
+
+			audit := &job.Audit{
+				ID:           auditID,
+				ProfileID:    profileID,
+				URN:          tableID,
+				TotalRecords: 20,
+			}
+			auditor := &Auditor{
+				toleranceStore: toleranceStore,
+				profileStore:   missingProfileStore,
+			}
+
+			expectedErr := fmt.Errorf("failed to get profile %s of table %s ,%w", profileID, tableID, dbErr)
+
+			actualResult, actualErr := auditor.Audit(audit)
+
+			assert.Nil(t, actualResult)
+			assert.Equal(t, expectedErr, actualErr)
+		})
 		t.Run("should return error when get metrics failed", func(t *testing.T) {
 			var metrics []*metric.Metric
 			toleranceSpec := &protocol.ToleranceSpec{
@@ -186,6 +676,7 @@ func TestAuditor(t *testing.T) {
 			expectedErr := fmt.Errorf("failed to get metrics for table %s,%w", tableID, apiErr)
 
 			auditor := &Auditor{
+				profileStore:   profileStore,
 				metricStore:    metricStore,
 				toleranceStore: toleranceStore,
 			}
@@ -217,12 +708,13 @@ func TestAuditor(t *testing.T) {
 			defaultRuleValidator := NewMockRuleValidator()
 			validationErr := errors.New("validation error")
 			var validatedMetrics []*protocol.ValidatedMetric
-			defaultRuleValidator.On("Validate", metrics, toleranceSpec.Tolerances).Return(validatedMetrics, validationErr)
+			defaultRuleValidator.On("Validate", metrics, toleranceSpec.Tolerances, History{}).Return(validatedMetrics, validationErr)
 			defer defaultRuleValidator.AssertExpectations(t)
 
 			expectedErr := fmt.Errorf("failed to check score against tolerance rules for table %s,%w", tableID, validationErr)
 
 			auditor := &Auditor{
+				profileStore:   profileStore,
 				metricStore:    metricStore,
 				ruleValidator:  defaultRuleValidator,
 				toleranceStore: toleranceStore,
@@ -15,7 +15,7 @@ func NewMockRuleValidator() *mockRuleValidator {
 	return &mockRuleValidator{}
 }
 
-func (m *mockRuleValidator) Validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance) ([]*protocol.ValidatedMetric, error) {
-	args := m.Called(metrics, tolerances)
+func (m *mockRuleValidator) Validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance, history History) ([]*protocol.ValidatedMetric, error) {
+	args := m.Called(metrics, tolerances, history)
 	return args.Get(0).([]*protocol.ValidatedMetric), args.Error(1)
 }
@@ -2,12 +2,21 @@ package auditor
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/odpf/predator/protocol/metric"
 
 	"github.com/odpf/predator/protocol"
 )
 
+const (
+	//minRelativeStdDev is minimum standard deviation of the expected band relative to the baseline mean
+	//so a constant history does not produce a band where any change fails
+	minRelativeStdDev = 0.01
+	//minStdDev is minimum standard deviation of the expected band when the baseline mean is zero
+	minStdDev = 0.01
+)
+
 //DefaultRuleValidator as a default rule validator
 type DefaultRuleValidator struct {
 }
@@ -18,30 +27,58 @@ func NewDefaultRuleValidator() *DefaultRuleValidator {
 }
 
 //Validate to validate metrics based on tolerances
-func (d DefaultRuleValidator) Validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance) ([]*protocol.ValidatedMetric, error) {
-	return validate(metrics, tolerances)
+func (d DefaultRuleValidator) Validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance, history History) ([]*protocol.ValidatedMetric, error) {
+	return validate(metrics, tolerances, history)
 }
 
-func validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance) ([]*protocol.ValidatedMetric, error) {
+func validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance, history History) ([]*protocol.ValidatedMetric, error) {
 	var result []*protocol.ValidatedMetric
 	for _, t := range tolerances {
-		scores := metric.NewFinder(metrics).
-			WithType(t.MetricName).
-			WithFieldID(t.FieldID).
-			WithCondition(t.Condition).
-			WithCategory(metric.Quality).
-			Find()
+		scores := findScores(metrics, t)
 
 		if scores == nil {
 			return nil, fmt.Errorf("failed to find quality score %s ,for field %s, with name %s", t.TableURN, t.FieldID, t.MetricName)
 		}
 
+		historicalScores := make(map[int]map[string][]*metric.Metric)
+		getHistoricalScores := func(lookback int, groupValue string) []*metric.Metric {
+			if _, ok := historicalScores[lookback]; !ok {
+				historicalScores[lookback] = groupByGroupValue(findScores(history[lookback], t))
+			}
+			return historicalScores[lookback][groupValue]
+		}
+
 		for _, score := range scores {
-			pass := check(score, t.ToleranceRules)
+			toleranceRules, anomaly := t.ToleranceRules, t.Anomaly
+			var groupOverride string
+			if override := getGroupOverride(t, score); override != nil {
+				toleranceRules, anomaly = override.ToleranceRules, override.Anomaly
+				groupOverride = override.Group
+			}
+
+			var previous *metric.Metric
+			var noBaseline bool
+			if protocol.HasComparator(toleranceRules, protocol.ComparatorPctChangeVsPrevious) {
+				if previousScore := getHistoricalScores(previousLookback, score.GroupValue); len(previousScore) > 0 {
+					previous = previousScore[0]
+				} else {
+					noBaseline = true
+				}
+			}
+
+			pass := check(score, previous, toleranceRules)
 			report := &protocol.ValidatedMetric{
 				Metric:         score,
-				ToleranceRules: t.ToleranceRules,
+				ToleranceRules: toleranceRules,
 				PassFlag:       pass,
+				Severity:       t.Severity,
+				GroupOverride:  groupOverride,
+				NoBaseline:     noBaseline,
+			}
+			if anomaly != nil {
+				band := calculateExpectedBand(getHistoricalScores(anomaly.Lookback, score.GroupValue), anomaly.ZScore)
+				report.ExpectedBand = band
+				report.PassFlag = pass && checkBand(score, band)
 			}
 			result = append(result, report)
 		}
@@ -50,46 +87,137 @@ func validate(metrics []*metric.Metric, tolerances []*protocol.Tolerance) ([]*pr
 	return result, nil
 }
 
-func check(score *metric.Metric, toleranceRules []protocol.ToleranceRule) bool {
-	var countPass int
-	for _, rule := range toleranceRules {
-		scoreValue := score.Value
-		ruleValue := rule.Value
+//getGroupOverride get override of the tolerance for group of the metric, metric of ungrouped profile has no override
+func getGroupOverride(t *protocol.Tolerance, score *metric.Metric) *protocol.GroupOverride {
+	if score.GroupValue == "" {
+		return nil
+	}
+	return t.GetGroupOverride(score.GroupValue)
+}
 
-		res := compare(rule.Comparator, scoreValue, ruleValue)
-		if res {
-			countPass++
+func findScores(metrics []*metric.Metric, t *protocol.Tolerance) []*metric.Metric {
+	finder := metric.NewFinder(metrics).
+		WithType(t.MetricName).
+		WithFieldID(t.FieldID).
+		WithCondition(t.Condition).
+		WithCategory(metric.Quality)
+	if t.MetricName == metric.Quantile {
+		quantile, _ := metric.GetQuantile(t.Metadata)
+		finder = finder.WithQuantile(quantile)
+	}
+	if t.MetricName == metric.FreshnessLagSeconds {
+		finder = finder.WithTimestampField(metric.GetTimestampField(t.Metadata))
+	}
+	if t.MetricName == metric.CustomSQL {
+		if custom, ok := metric.GetCustom(t.Metadata); ok {
+			finder = finder.WithCustomName(custom.Name)
+		}
+	}
+	if t.MetricName == metric.OrphanPct {
+		if reference, ok := metric.GetReference(t.Metadata); ok {
+			finder = finder.WithReference(reference)
 		}
 	}
+	return finder.Find()
+}
+
+func groupByGroupValue(metrics []*metric.Metric) map[string][]*metric.Metric {
+	grouped := make(map[string][]*metric.Metric)
+	for _, m := range metrics {
+		grouped[m.GroupValue] = append(grouped[m.GroupValue], m)
+	}
+	return grouped
+}
+
+//calculateExpectedBand calculate mean ± zScore * sample standard deviation of the historical values
+//standard deviation is floored to a fraction of the mean, see minRelativeStdDev and minStdDev
+func calculateExpectedBand(history []*metric.Metric, zScore float64) *protocol.ExpectedBand {
+	band := &protocol.ExpectedBand{BaselineSize: len(history)}
+	if !band.IsAvailable() {
+		return band
+	}
 
-	var pass bool
+	var total float64
+	for _, h := range history {
+		total += h.Value
+	}
+	mean := total / float64(len(history))
 
-	if countPass == len(toleranceRules) {
-		pass = true
+	var squaredDiff float64
+	for _, h := range history {
+		squaredDiff += (h.Value - mean) * (h.Value - mean)
 	}
-	return pass
+	stdDev := math.Sqrt(squaredDiff / float64(len(history)-1))
+	stdDev = math.Max(stdDev, math.Max(math.Abs(mean)*minRelativeStdDev, minStdDev))
+
+	band.Lower = mean - zScore*stdDev
+	band.Upper = mean + zScore*stdDev
+	return band
 }
 
-func compare(logic protocol.Comparator, scoreValue float64, ruleValue float64) bool {
-	var res bool
+//checkBand metric pass when it is within the expected band, or when the band is not available because of short history
+func checkBand(score *metric.Metric, band *protocol.ExpectedBand) bool {
+	if !band.IsAvailable() {
+		return true
+	}
+	return score.Value >= band.Lower && score.Value <= band.Upper
+}
 
-	switch logic {
-	case protocol.ComparatorLessThan:
-		if scoreValue < ruleValue {
-			res = true
+//check metric pass when all of the rules pass, previous is metric of the previous profile, nil when there is no previous profile
+//pct_change_vs_previous rule without previous profile is skipped, the result is marked as having no baseline by the caller
+func check(score *metric.Metric, previous *metric.Metric, toleranceRules []protocol.ToleranceRule) bool {
+	for _, rule := range toleranceRules {
+		if !compare(rule, score, previous) {
+			return false
 		}
+	}
+	return true
+}
+
+func compare(rule protocol.ToleranceRule, score *metric.Metric, previous *metric.Metric) bool {
+	scoreValue := score.Value
+	ruleValue := rule.Value
+
+	switch rule.Comparator {
+	case protocol.ComparatorLessThan:
+		return scoreValue < ruleValue
 	case protocol.ComparatorLessThanEq:
-		if scoreValue <= ruleValue {
-			res = true
-		}
+		return scoreValue <= ruleValue
 	case protocol.ComparatorMoreThan:
-		if scoreValue > ruleValue {
-			res = true
-		}
+		return scoreValue > ruleValue
 	case protocol.ComparatorMoreThanEq:
-		if scoreValue >= ruleValue {
-			res = true
+		return scoreValue >= ruleValue
+	case protocol.ComparatorEqual:
+		return scoreValue == ruleValue
+	case protocol.ComparatorNotEqual:
+		return scoreValue != ruleValue
+	case protocol.ComparatorBetween:
+		return scoreValue >= ruleValue && scoreValue <= rule.Upper
+	case protocol.ComparatorOutside:
+		return scoreValue < ruleValue || scoreValue > rule.Upper
+	case protocol.ComparatorPctChangeVsPrevious:
+		if previous == nil {
+			return true
+		}
+		return pctChange(previous.Value, scoreValue) <= ruleValue
+	case protocol.ComparatorAnyOf:
+		for _, group := range rule.Groups {
+			if check(score, previous, group) {
+				return true
+			}
 		}
+		return false
+	}
+	return false
+}
+
+//pctChange absolute percentage change from previous to current, change from zero is infinite unless current is also zero
+func pctChange(previous float64, current float64) float64 {
+	if previous == current {
+		return 0
+	}
+	if previous == 0 {
+		return math.Inf(1)
 	}
-	return res
+	return math.Abs(current-previous) / math.Abs(previous) * 100
 }
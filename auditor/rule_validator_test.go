@@ -135,12 +135,411 @@ func TestMetricComparator(t *testing.T) {
 
 		for _, test := range tests {
 			t.Run(test.description, func(t *testing.T) {
-				result, err := validate(test.qualityScore, test.tolerance)
+				result, err := validate(test.qualityScore, test.tolerance, History{})
 				assert.Equal(t, test.expected, result)
 				assert.Nil(t, err)
 			})
 		}
 
+		t.Run("should validate quantile metric with the same quantile", func(t *testing.T) {
+			tableID := "sample-project.sample_dataset.sample_table"
+			p50 := &metric.Metric{
+				Type:     metric.Quantile,
+				Category: metric.Quality,
+				Owner:    metric.Field,
+				FieldID:  "amount",
+				Value:    100.0,
+				Metadata: map[string]interface{}{metric.QuantileFraction: 0.5},
+			}
+			p99 := &metric.Metric{
+				Type:     metric.Quantile,
+				Category: metric.Quality,
+				Owner:    metric.Field,
+				FieldID:  "amount",
+				Value:    900.0,
+				Metadata: map[string]interface{}{metric.QuantileFraction: 0.99},
+			}
+			toleranceRules := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorLessThanEq,
+					Value:      500.0,
+				},
+			}
+
+			tolerances := []*protocol.Tolerance{
+				{
+					TableURN:       tableID,
+					FieldID:        "amount",
+					MetricName:     metric.Quantile,
+					Metadata:       map[string]interface{}{metric.QuantileFraction: 0.99},
+					ToleranceRules: toleranceRules,
+				},
+			}
+
+			result, err := validate([]*metric.Metric{p50, p99}, tolerances, History{})
+
+			expected := []*protocol.ValidatedMetric{
+				{
+					Metric:         p99,
+					ToleranceRules: toleranceRules,
+					PassFlag:       false,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should validate orphan metric with the same reference", func(t *testing.T) {
+			tableID := "sample-project.sample_dataset.sample_table"
+			customerOrphan := &metric.Metric{
+				Type:     metric.OrphanPct,
+				Category: metric.Quality,
+				Owner:    metric.Table,
+				Value:    0.0,
+				Metadata: map[string]interface{}{
+					metric.ReferenceURN:    "sample-project.sample_dataset.customers",
+					metric.JoinFields:      []interface{}{"customer_id"},
+					metric.ReferenceFields: []interface{}{"id"},
+				},
+			}
+			productOrphan := &metric.Metric{
+				Type:     metric.OrphanPct,
+				Category: metric.Quality,
+				Owner:    metric.Table,
+				Value:    5.0,
+				Metadata: map[string]interface{}{
+					metric.ReferenceURN:    "sample-project.sample_dataset.products",
+					metric.JoinFields:      []interface{}{"product_id"},
+					metric.ReferenceFields: []interface{}{"id"},
+				},
+			}
+			toleranceRules := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorLessThanEq,
+					Value:      0.0,
+				},
+			}
+
+			tolerances := []*protocol.Tolerance{
+				{
+					TableURN:   tableID,
+					MetricName: metric.OrphanPct,
+					Metadata: map[string]interface{}{
+						metric.ReferenceURN:    "sample-project.sample_dataset.products",
+						metric.JoinFields:      []string{"product_id"},
+						metric.ReferenceFields: []string{"id"},
+					},
+					ToleranceRules: toleranceRules,
+				},
+			}
+
+			result, err := validate([]*metric.Metric{customerOrphan, productOrphan}, tolerances, History{})
+
+			expected := []*protocol.ValidatedMetric{
+				{
+					Metric:         productOrphan,
+					ToleranceRules: toleranceRules,
+					PassFlag:       false,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should validate freshness metric with the same timestamp field", func(t *testing.T) {
+			tableID := "sample-project.sample_dataset.sample_table"
+			lastModifiedFreshness := &metric.Metric{
+				Type:     metric.FreshnessLagSeconds,
+				Category: metric.Quality,
+				Owner:    metric.Table,
+				Value:    600.0,
+			}
+			eventFreshness := &metric.Metric{
+				Type:     metric.FreshnessLagSeconds,
+				Category: metric.Quality,
+				Owner:    metric.Table,
+				Value:    5400.0,
+				Metadata: map[string]interface{}{metric.TimestampField: "event_timestamp"},
+			}
+			toleranceRules := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorLessThan,
+					Value:      3600.0,
+				},
+			}
+
+			tolerances := []*protocol.Tolerance{
+				{
+					TableURN:       tableID,
+					MetricName:     metric.FreshnessLagSeconds,
+					Metadata:       map[string]interface{}{metric.TimestampField: "event_timestamp"},
+					ToleranceRules: toleranceRules,
+				},
+			}
+
+			result, err := validate([]*metric.Metric{lastModifiedFreshness, eventFreshness}, tolerances, History{})
+
+			expected := []*protocol.ValidatedMetric{
+				{
+					Metric:         eventFreshness,
+					ToleranceRules: toleranceRules,
+					PassFlag:       false,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should validate custom sql metric with the same name", func(t *testing.T) {
+			tableID := "sample-project.sample_dataset.sample_table"
+			lateOrders := &metric.Metric{
+				Type:     metric.CustomSQL,
+				Category: metric.Quality,
+				Owner:    metric.Table,
+				Value:    12.0,
+				Metadata: map[string]interface{}{metric.CustomName: "late_orders"},
+			}
+			refundRatio := &metric.Metric{
+				Type:     metric.CustomSQL,
+				Category: metric.Quality,
+				Owner:    metric.Table,
+				Value:    0.05,
+				Metadata: map[string]interface{}{metric.CustomName: "refund_ratio"},
+			}
+			toleranceRules := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorLessThan,
+					Value:      0.1,
+				},
+			}
+
+			tolerances := []*protocol.Tolerance{
+				{
+					TableURN:       tableID,
+					MetricName:     metric.CustomSQL,
+					Metadata:       map[string]interface{}{metric.CustomName: "refund_ratio"},
+					ToleranceRules: toleranceRules,
+				},
+			}
+
+			result, err := validate([]*metric.Metric{lateOrders, refundRatio}, tolerances, History{})
+
+			expected := []*protocol.ValidatedMetric{
+				{
+					Metric:         refundRatio,
+					ToleranceRules: toleranceRules,
+					PassFlag:       true,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should validate anomaly rule against history of the same group", func(t *testing.T) {
+			tableID := "sample-project.sample_dataset.sample_table"
+			anomaly := &protocol.AnomalyRule{Lookback: 3, ZScore: 2}
+			toleranceRules := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorMoreThanEq,
+					Value:      0.0,
+				},
+			}
+			tolerances := []*protocol.Tolerance{
+				{
+					TableURN:       tableID,
+					FieldID:        "amount",
+					MetricName:     metric.NullnessPct,
+					ToleranceRules: toleranceRules,
+					Anomaly:        anomaly,
+				},
+			}
+			newMetric := func(groupValue string, value float64) *metric.Metric {
+				return &metric.Metric{
+					Type:       metric.NullnessPct,
+					Category:   metric.Quality,
+					Owner:      metric.Field,
+					FieldID:    "amount",
+					GroupValue: groupValue,
+					Value:      value,
+				}
+			}
+			normal := newMetric("2021-01-01", 11.0)
+			anomalous := newMetric("2021-01-02", 50.0)
+			noHistory := newMetric("2021-01-03", 90.0)
+			history := History{
+				3: {
+					newMetric("2021-01-01", 8.0),
+					newMetric("2021-01-01", 10.0),
+					newMetric("2021-01-01", 12.0),
+					newMetric("2021-01-02", 10.0),
+					newMetric("2021-01-02", 10.0),
+					newMetric("2021-01-03", 10.0),
+				},
+			}
+
+			result, err := validate([]*metric.Metric{normal, anomalous, noHistory}, tolerances, history)
+
+			expected := []*protocol.ValidatedMetric{
+				{
+					Metric:         normal,
+					ToleranceRules: toleranceRules,
+					ExpectedBand:   &protocol.ExpectedBand{Lower: 6.0, Upper: 14.0, BaselineSize: 3},
+					PassFlag:       true,
+				},
+				{
+					Metric:         anomalous,
+					ToleranceRules: toleranceRules,
+					ExpectedBand:   &protocol.ExpectedBand{Lower: 9.8, Upper: 10.2, BaselineSize: 2},
+					PassFlag:       false,
+				},
+				{
+					Metric:         noHistory,
+					ToleranceRules: toleranceRules,
+					ExpectedBand:   &protocol.ExpectedBand{BaselineSize: 1},
+					PassFlag:       true,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should floor standard deviation of constant history", func(t *testing.T) {
+			tolerances := []*protocol.Tolerance{
+				{
+					TableURN:   "sample-project.sample_dataset.sample_table",
+					MetricName: metric.RowCount,
+					Anomaly:    &protocol.AnomalyRule{Lookback: 3, ZScore: 1},
+				},
+			}
+			newMetric := func(value float64) *metric.Metric {
+				return &metric.Metric{Type: metric.RowCount, Category: metric.Quality, Owner: metric.Table, Value: value}
+			}
+			current := newMetric(0.005)
+			history := History{3: {newMetric(0), newMetric(0), newMetric(0)}}
+
+			result, err := validate([]*metric.Metric{current}, tolerances, history)
+
+			expected := []*protocol.ValidatedMetric{
+				{
+					Metric:       current,
+					ExpectedBand: &protocol.ExpectedBand{Lower: -minStdDev, Upper: minStdDev, BaselineSize: 3},
+					PassFlag:     true,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should validate pct change rule against previous metric of the same group and mark group without previous metric", func(t *testing.T) {
+			tableID := "sample-project.sample_dataset.sample_table"
+			toleranceRules := []protocol.ToleranceRule{
+				{
+					Comparator: protocol.ComparatorPctChangeVsPrevious,
+					Value:      10.0,
+				},
+			}
+			tolerances := []*protocol.Tolerance{
+				{
+					TableURN:       tableID,
+					MetricName:     metric.RowCount,
+					ToleranceRules: toleranceRules,
+				},
+			}
+			newMetric := func(groupValue string, value float64) *metric.Metric {
+				return &metric.Metric{
+					Type:       metric.RowCount,
+					Category:   metric.Quality,
+					Owner:      metric.Table,
+					GroupValue: groupValue,
+					Value:      value,
+				}
+			}
+			stable := newMetric("ID", 105.0)
+			dropped := newMetric("SG", 50.0)
+			newGroup := newMetric("MY", 10.0)
+			history := History{
+				1: {
+					newMetric("ID", 100.0),
+					newMetric("SG", 100.0),
+				},
+			}
+
+			result, err := validate([]*metric.Metric{stable, dropped, newGroup}, tolerances, history)
+
+			expected := []*protocol.ValidatedMetric{
+				{
+					Metric:         stable,
+					ToleranceRules: toleranceRules,
+					PassFlag:       true,
+				},
+				{
+					Metric:         dropped,
+					ToleranceRules: toleranceRules,
+					PassFlag:       false,
+				},
+				{
+					Metric:         newGroup,
+					ToleranceRules: toleranceRules,
+					NoBaseline:     true,
+					PassFlag:       true,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should validate metric of each group with the most specific group override", func(t *testing.T) {
+			tableID := "sample-project.sample_dataset.sample_table"
+			toleranceRules := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10.0}}
+			globRules := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 30.0}}
+			exactRules := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 20.0}}
+			anomaly := &protocol.AnomalyRule{Lookback: 3, ZScore: 2}
+			tolerances := []*protocol.Tolerance{
+				{
+					TableURN:       tableID,
+					FieldID:        "phone",
+					MetricName:     metric.NullnessPct,
+					ToleranceRules: toleranceRules,
+					GroupOverrides: []*protocol.GroupOverride{
+						{Group: "S*", ToleranceRules: globRules},
+						{Group: "SG", ToleranceRules: exactRules},
+						{Group: "/^M/", Anomaly: anomaly},
+					},
+					Severity: protocol.SeverityWarn,
+				},
+			}
+			newMetric := func(groupValue string, value float64) *metric.Metric {
+				return &metric.Metric{
+					Type:       metric.NullnessPct,
+					Category:   metric.Quality,
+					Owner:      metric.Field,
+					FieldID:    "phone",
+					GroupValue: groupValue,
+					Value:      value,
+				}
+			}
+			big := newMetric("ID", 15.0)
+			exact := newMetric("SG", 25.0)
+			glob := newMetric("SA", 25.0)
+			regex := newMetric("MY", 50.0)
+			history := History{
+				3: {
+					newMetric("MY", 48.0),
+					newMetric("MY", 50.0),
+					newMetric("MY", 52.0),
+				},
+			}
+
+			result, err := validate([]*metric.Metric{big, exact, glob, regex}, tolerances, history)
+
+			expected := []*protocol.ValidatedMetric{
+				{Metric: big, ToleranceRules: toleranceRules, PassFlag: false, Severity: protocol.SeverityWarn},
+				{Metric: exact, ToleranceRules: exactRules, GroupOverride: "SG", PassFlag: false, Severity: protocol.SeverityWarn},
+				{Metric: glob, ToleranceRules: globRules, GroupOverride: "S*", PassFlag: true, Severity: protocol.SeverityWarn},
+				{
+					Metric:        regex,
+					ExpectedBand:  &protocol.ExpectedBand{Lower: 46.0, Upper: 54.0, BaselineSize: 3},
+					GroupOverride: "/^M/",
+					PassFlag:      true,
+					Severity:      protocol.SeverityWarn,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
 		t.Run("should return error  when a quality score is not found", func(t *testing.T) {
 			tableID := "sample-project.sample_dataset.sample_table"
 			qualityScore := []*metric.Metric{
@@ -170,7 +569,7 @@ func TestMetricComparator(t *testing.T) {
 				},
 			}
 
-			result, err := validate(qualityScore, tolerance)
+			result, err := validate(qualityScore, tolerance, History{})
 			var expected []*protocol.ValidatedMetric
 			assert.Equal(t, expected, result)
 			assert.NotNil(t, err)
@@ -181,7 +580,9 @@ func TestMetricComparator(t *testing.T) {
 			description string
 			rule        protocol.Comparator
 			ruleValue   float64
+			upper       float64
 			scoreValue  float64
+			previous    *metric.Metric
 			expected    bool
 		}{
 			{
@@ -240,11 +641,89 @@ func TestMetricComparator(t *testing.T) {
 				scoreValue:  21.0,
 				expected:    false,
 			},
+			{
+				description: "should pass if equal",
+				rule:        protocol.ComparatorEqual,
+				ruleValue:   20.0,
+				scoreValue:  20.0,
+				expected:    true,
+			},
+			{
+				description: "should not pass if not equal",
+				rule:        protocol.ComparatorNotEqual,
+				ruleValue:   20.0,
+				scoreValue:  20.0,
+				expected:    false,
+			},
+			{
+				description: "should pass if between inclusive bounds",
+				rule:        protocol.ComparatorBetween,
+				ruleValue:   10.0,
+				upper:       20.0,
+				scoreValue:  20.0,
+				expected:    true,
+			},
+			{
+				description: "should not pass if not between",
+				rule:        protocol.ComparatorBetween,
+				ruleValue:   10.0,
+				upper:       20.0,
+				scoreValue:  21.0,
+				expected:    false,
+			},
+			{
+				description: "should pass if outside",
+				rule:        protocol.ComparatorOutside,
+				ruleValue:   10.0,
+				upper:       20.0,
+				scoreValue:  9.0,
+				expected:    true,
+			},
+			{
+				description: "should not pass if on bound of outside",
+				rule:        protocol.ComparatorOutside,
+				ruleValue:   10.0,
+				upper:       20.0,
+				scoreValue:  10.0,
+				expected:    false,
+			},
+			{
+				description: "should pass if change from previous is within percentage",
+				rule:        protocol.ComparatorPctChangeVsPrevious,
+				ruleValue:   10.0,
+				scoreValue:  91.0,
+				previous:    &metric.Metric{Value: 100.0},
+				expected:    true,
+			},
+			{
+				description: "should not pass if change from previous is more than percentage",
+				rule:        protocol.ComparatorPctChangeVsPrevious,
+				ruleValue:   10.0,
+				scoreValue:  111.0,
+				previous:    &metric.Metric{Value: 100.0},
+				expected:    false,
+			},
+			{
+				description: "should not pass if previous is zero and metric is not",
+				rule:        protocol.ComparatorPctChangeVsPrevious,
+				ruleValue:   10.0,
+				scoreValue:  1.0,
+				previous:    &metric.Metric{Value: 0.0},
+				expected:    false,
+			},
+			{
+				description: "should pass if there is no previous metric",
+				rule:        protocol.ComparatorPctChangeVsPrevious,
+				ruleValue:   10.0,
+				scoreValue:  1000.0,
+				expected:    true,
+			},
 		}
 
 		for _, test := range tests {
 			t.Run(test.description, func(t *testing.T) {
-				result := compare(test.rule, test.scoreValue, test.ruleValue)
+				rule := protocol.ToleranceRule{Comparator: test.rule, Value: test.ruleValue, Upper: test.upper}
+				result := compare(rule, &metric.Metric{Value: test.scoreValue}, test.previous)
 				assert.Equal(t, test.expected, result)
 			})
 		}
@@ -315,11 +794,39 @@ func TestMetricComparator(t *testing.T) {
 				},
 				expected: false,
 			},
+			{
+				description: "should pass if any group of any of rule pass",
+				score:       &metric.Metric{Value: 150.0},
+				toleranceRules: []protocol.ToleranceRule{
+					{
+						Comparator: protocol.ComparatorAnyOf,
+						Groups: [][]protocol.ToleranceRule{
+							{{Comparator: protocol.ComparatorLessThan, Value: 5.0}},
+							{{Comparator: protocol.ComparatorMoreThan, Value: 100.0}, {Comparator: protocol.ComparatorLessThan, Value: 200.0}},
+						},
+					},
+				},
+				expected: true,
+			},
+			{
+				description: "should not pass if no group of any of rule pass",
+				score:       &metric.Metric{Value: 250.0},
+				toleranceRules: []protocol.ToleranceRule{
+					{
+						Comparator: protocol.ComparatorAnyOf,
+						Groups: [][]protocol.ToleranceRule{
+							{{Comparator: protocol.ComparatorLessThan, Value: 5.0}},
+							{{Comparator: protocol.ComparatorMoreThan, Value: 100.0}, {Comparator: protocol.ComparatorLessThan, Value: 200.0}},
+						},
+					},
+				},
+				expected: false,
+			},
 		}
 
 		for _, test := range tests {
 			t.Run(test.description, func(t *testing.T) {
-				result := check(test.score, test.toleranceRules)
+				result := check(test.score, nil, test.toleranceRules)
 				assert.Equal(t, test.expected, result)
 			})
 		}
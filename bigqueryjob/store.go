@@ -23,3 +23,13 @@ func (p *Store) Store(bigqueryJob *protocol.BigqueryJob) error {
 	}
 	return nil
 }
+
+//GetByProfileID get bigquery jobs started by the profile
+func (p *Store) GetByProfileID(profileID string) ([]*protocol.BigqueryJob, error) {
+	var bigqueryJobs []*protocol.BigqueryJob
+	handler := p.db.Where("profile_id = ?", profileID).Order("created_at").Find(&bigqueryJobs)
+	if err := handler.Error; err != nil {
+		return nil, err
+	}
+	return bigqueryJobs, nil
+}
@@ -50,4 +50,25 @@ func TestProfileBqStore(t *testing.T) {
 			assert.Error(t, err)
 		})
 	})
+	t.Run("GetByProfileID", func(t *testing.T) {
+		t.Run("should return bigquery jobs of the profile", func(t *testing.T) {
+			currentTime := time.Now().In(time.UTC)
+			first := &protocol.BigqueryJob{ID: "1", ProfileID: "profile-id", BqID: "bq-id-1", CreatedAt: currentTime}
+			second := &protocol.BigqueryJob{ID: "2", ProfileID: "profile-id", BqID: "bq-id-2", CreatedAt: currentTime.Add(time.Minute)}
+			other := &protocol.BigqueryJob{ID: "3", ProfileID: "other-profile-id", BqID: "bq-id-3", CreatedAt: currentTime}
+
+			db, clearDB := mock.NewDatabase(new(protocol.BigqueryJob))
+			defer clearDB()
+
+			profileBqStore := NewStore(db, "bigquery_jobs")
+			assert.Nil(t, profileBqStore.Store(first))
+			assert.Nil(t, profileBqStore.Store(second))
+			assert.Nil(t, profileBqStore.Store(other))
+
+			result, err := profileBqStore.GetByProfileID("profile-id")
+
+			assert.Nil(t, err)
+			assert.Equal(t, []*protocol.BigqueryJob{first, second}, result)
+		})
+	})
 }
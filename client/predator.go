@@ -35,13 +35,14 @@ func New(hostURL string, client xhttp.Client) *Predator {
 	}
 }
 
-//Upload to upload spec
-func (p *Predator) Upload(gitInfo *protocol.GitInfo) (*model.UploadReport, error) {
+//Upload to upload spec, on dry run the specs are not written and the report contains the changes
+func (p *Predator) Upload(gitInfo *protocol.GitInfo, dryRun bool) (*model.UploadReport, error) {
 	var err error
 	request := &model.UploadRequest{
 		GitURL:     gitInfo.URL,
 		CommitID:   gitInfo.CommitID,
 		PathPrefix: gitInfo.PathPrefix,
+		DryRun:     dryRun,
 	}
 
 	reqContent, err := json.Marshal(request)
@@ -152,13 +153,41 @@ func (p *Predator) GetProfile(profileID string) (*model.ProfileResponse, error)
 			if err != nil {
 				return nil, err
 			}
-			if profileResponse.State == job.StateCompleted || profileResponse.State == job.StateFailed {
+			if profileResponse.State.IsFinished() {
 				return profileResponse, nil
 			}
 		}
 	}
 }
 
+//CancelProfile to call cancel profile API
+func (p *Predator) CancelProfile(profileID string) (*model.ProfileResponse, error) {
+	resourcePath := fmt.Sprintf("%s/v1beta1/profile/%s/cancel", p.hostURL, profileID)
+	resp, err := p.client.Post(resourcePath, contentType, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = resp.Body.Close()
+	}()
+
+	respContent, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error %d %s", resp.StatusCode, string(respContent))
+	}
+
+	var profileResponse model.ProfileResponse
+	if err = json.Unmarshal(respContent, &profileResponse); err != nil {
+		return nil, err
+	}
+
+	return &profileResponse, nil
+}
+
 //Audit to call Audit API
 func (p *Predator) Audit(profileID string) (*model.AuditResponse, error) {
 	resourcePath := fmt.Sprintf("%s/v1beta1/profile/%s/audit", p.hostURL, profileID)
@@ -186,3 +215,83 @@ func (p *Predator) Audit(profileID string) (*model.AuditResponse, error) {
 
 	return &auditResponse, nil
 }
+
+//SuggestSpec to call suggest spec API and wait until the suggestion is completed, the suggested spec is returned as compact spec yaml
+func (p *Predator) SuggestSpec(urn string, filter string) ([]byte, error) {
+	request := &model.SpecSuggestionRequest{
+		URN:    urn,
+		Filter: filter,
+	}
+	reqContent, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	resourcePath := fmt.Sprintf("%s/v1beta1/spec/suggest", p.hostURL)
+	suggestion, err := p.callSpecSuggestion(func() (*http.Response, error) {
+		return p.client.Post(resourcePath, contentType, bytes.NewBuffer(reqContent))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if suggestion.ProfileID != "" {
+		suggestion, err = p.waitSpecSuggestion(suggestion.ProfileID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if suggestion.Status != job.StateCompleted.String() {
+		return nil, fmt.Errorf("exploratory profile %s is %s: %s", suggestion.ProfileID, suggestion.Status, suggestion.Message)
+	}
+	return []byte(suggestion.Spec), nil
+}
+
+func (p *Predator) waitSpecSuggestion(profileID string) (*model.SpecSuggestionResponse, error) {
+	resourcePath := fmt.Sprintf("%s/v1beta1/spec/suggest/%s", p.hostURL, profileID)
+	timeout := time.After(time.Duration(getProfileTimeoutInSecond) * time.Second)
+	ticker := time.NewTicker(time.Duration(getProfileRetryIntervalInSecond) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-timeout:
+			return nil, errors.New("Get spec suggestion time out")
+		case <-ticker.C:
+			suggestion, err := p.callSpecSuggestion(func() (*http.Response, error) {
+				return p.client.Get(resourcePath)
+			})
+			if err != nil {
+				return nil, err
+			}
+			if job.State(suggestion.Status).IsFinished() {
+				return suggestion, nil
+			}
+		}
+	}
+}
+
+func (p *Predator) callSpecSuggestion(call func() (*http.Response, error)) (*model.SpecSuggestionResponse, error) {
+	resp, err := call()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = resp.Body.Close()
+	}()
+
+	respContent, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error %d %s", resp.StatusCode, string(respContent))
+	}
+
+	var suggestion model.SpecSuggestionResponse
+	if err = json.Unmarshal(respContent, &suggestion); err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
@@ -47,7 +47,7 @@ func TestPredator(t *testing.T) {
 			mockClient.On("Post", resourceURL, contentType, bytes.NewBuffer(reqContent)).Return(resp, nil)
 
 			client := New(baseURL, mockClient)
-			uploadReport, err := client.Upload(gitInfo)
+			uploadReport, err := client.Upload(gitInfo, false)
 
 			assert.Nil(t, err)
 			assert.Equal(t, http.StatusOK, resp.StatusCode)
@@ -73,7 +73,7 @@ func TestPredator(t *testing.T) {
 			mockClient.On("Post", resourceURL, contentType, bytes.NewBuffer(reqContent)).Return(resp, networkErr)
 
 			client := New(baseURL, mockClient)
-			uploadReport, err := client.Upload(gitInfo)
+			uploadReport, err := client.Upload(gitInfo, false)
 
 			assert.Nil(t, uploadReport)
 			assert.Error(t, err)
@@ -100,7 +100,7 @@ func TestPredator(t *testing.T) {
 			mockClient.On("Post", resourceURL, contentType, bytes.NewBuffer(reqContent)).Return(resp, nil)
 
 			client := New(baseURL, mockClient)
-			uploadReport, err := client.Upload(gitInfo)
+			uploadReport, err := client.Upload(gitInfo, false)
 
 			assert.Nil(t, uploadReport)
 			assert.Error(t, err)
@@ -127,7 +127,7 @@ func TestPredator(t *testing.T) {
 			mockClient.On("Post", resourceURL, contentType, bytes.NewBuffer(reqContent)).Return(resp, nil)
 
 			client := New(baseURL, mockClient)
-			uploadReport, err := client.Upload(gitInfo)
+			uploadReport, err := client.Upload(gitInfo, false)
 
 			assert.Nil(t, uploadReport)
 			assert.Error(t, err)
@@ -442,4 +442,79 @@ func TestPredator(t *testing.T) {
 			assert.Error(t, err)
 		})
 	})
+	t.Run("SuggestSpec", func(t *testing.T) {
+		baseURL := "http://localhost:8080"
+		resourceURL := "http://localhost:8080/v1beta1/spec/suggest"
+		urn := "entity-1-project-1.dataset_a.table_x"
+		profileID := "a4d7e2b1-3c2f-4e0a-9b8d-6f1e2d3c4b5a"
+		spec := "tableid: entity-1-project-1.dataset_a.table_x\n"
+
+		newResponse := func(suggestion *model.SpecSuggestionResponse) *http.Response {
+			content, _ := json.Marshal(suggestion)
+			return &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBuffer(content)),
+			}
+		}
+		requestBody := func(filter string) *bytes.Buffer {
+			content, _ := json.Marshal(&model.SpecSuggestionRequest{URN: urn, Filter: filter})
+			return bytes.NewBuffer(content)
+		}
+
+		t.Run("should return spec suggested from metric history", func(t *testing.T) {
+			resp := newResponse(&model.SpecSuggestionResponse{URN: urn, Status: job.StateCompleted.String(), Spec: spec})
+
+			mockClient := mock.NewHttpClient()
+			mockClient.On("Post", resourceURL, contentType, requestBody("date(created_at) = '2021-01-01'")).Return(resp, nil)
+
+			client := New(baseURL, mockClient)
+			result, err := client.SuggestSpec(urn, "date(created_at) = '2021-01-01'")
+
+			assert.Nil(t, err)
+			assert.Equal(t, []byte(spec), result)
+		})
+		t.Run("should wait until exploratory profile is completed", func(t *testing.T) {
+			created := newResponse(&model.SpecSuggestionResponse{URN: urn, ProfileID: profileID, Status: job.StateCreated.String()})
+			completed := newResponse(&model.SpecSuggestionResponse{URN: urn, ProfileID: profileID, Status: job.StateCompleted.String(), Spec: spec})
+
+			mockClient := mock.NewHttpClient()
+			mockClient.On("Post", resourceURL, contentType, requestBody("")).Return(created, nil)
+			mockClient.On("Get", resourceURL+"/"+profileID).Return(completed, nil)
+
+			client := New(baseURL, mockClient)
+			result, err := client.SuggestSpec(urn, "")
+
+			assert.Nil(t, err)
+			assert.Equal(t, []byte(spec), result)
+		})
+		t.Run("should return error when exploratory profile failed", func(t *testing.T) {
+			created := newResponse(&model.SpecSuggestionResponse{URN: urn, ProfileID: profileID, Status: job.StateCreated.String()})
+			failed := newResponse(&model.SpecSuggestionResponse{URN: urn, ProfileID: profileID, Status: job.StateFailed.String(), Message: "profile failed because query failed"})
+
+			mockClient := mock.NewHttpClient()
+			mockClient.On("Post", resourceURL, contentType, requestBody("")).Return(created, nil)
+			mockClient.On("Get", resourceURL+"/"+profileID).Return(failed, nil)
+
+			client := New(baseURL, mockClient)
+			result, err := client.SuggestSpec(urn, "")
+
+			assert.Nil(t, result)
+			assert.EqualError(t, err, "exploratory profile a4d7e2b1-3c2f-4e0a-9b8d-6f1e2d3c4b5a is failed: profile failed because query failed")
+		})
+		t.Run("should return error when http status code is not 200", func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: 404,
+				Body:       ioutil.NopCloser(bytes.NewBufferString("table metadata not found")),
+			}
+
+			mockClient := mock.NewHttpClient()
+			mockClient.On("Post", resourceURL, contentType, requestBody("")).Return(resp, nil)
+
+			client := New(baseURL, mockClient)
+			result, err := client.SuggestSpec(urn, "")
+
+			assert.Nil(t, result)
+			assert.Error(t, err)
+		})
+	})
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/odpf/predator/conf"
 	"github.com/odpf/predator/db"
 	"github.com/odpf/predator/server"
+	"github.com/odpf/predator/tolerance"
 	"gopkg.in/alecthomas/kingpin.v2"
 	"log"
 	"os"
@@ -20,6 +21,11 @@ var (
 
 	profileCmd      = newCommandProfileAudit(predator.Command("profile", "profile only"))
 	profileAuditCmd = newCommandProfileAudit(predator.Command("profile_audit", "profile and audit"))
+	cancelCmd       = newCommandCancel(predator.Command("cancel", "cancel running profile"))
+
+	specCmd    = predator.Command("spec", "data quality spec")
+	suggestCmd = newCommandSuggest(specCmd.Command("suggest", "suggest data quality spec of a table"))
+	lintCmd    = newCommandLint(specCmd.Command("lint", "lint data quality specs of a directory without predator server"))
 
 	versionCmd = predator.Command("version", "version of predator")
 )
@@ -58,12 +64,63 @@ func newCommandProfileAudit(cmdClause *kingpin.CmdClause) *commandProfileAudit {
 	}
 }
 
+type commandCancel struct {
+	cmd       *kingpin.CmdClause
+	server    *string
+	profileID *string
+}
+
+func newCommandCancel(cmdClause *kingpin.CmdClause) *commandCancel {
+	return &commandCancel{
+		cmd:       cmdClause,
+		server:    cmdClause.Flag("server", "predator server url").Short('s').Envar("URL").String(),
+		profileID: cmdClause.Flag("profile-id", "ID of profile to be cancelled").Required().Short('p').Envar("PROFILE_ID").String(),
+	}
+}
+
+type commandSuggest struct {
+	cmd    *kingpin.CmdClause
+	server *string
+	urn    *string
+	filter *string
+	output *string
+}
+
+func newCommandSuggest(cmdClause *kingpin.CmdClause) *commandSuggest {
+	return &commandSuggest{
+		cmd:    cmdClause,
+		server: cmdClause.Flag("server", "predator server url").Short('s').Envar("URL").String(),
+		urn:    cmdClause.Flag("urn", "table URN").Required().Short('u').Envar("URN").String(),
+		filter: cmdClause.Flag("filter", "data filter of exploratory profile when the table has no profile history").Default("").Short('f').Envar("FILTER").String(),
+		output: cmdClause.Flag("output", "path of file to write the suggested spec, default will print the spec").Default("").Short('o').String(),
+	}
+}
+
+type commandLint struct {
+	cmd        *kingpin.CmdClause
+	dir        *string
+	format     *string
+	schemaFile *string
+	output     *string
+}
+
+func newCommandLint(cmdClause *kingpin.CmdClause) *commandLint {
+	return &commandLint{
+		cmd:        cmdClause,
+		dir:        cmdClause.Arg("dir", "path to root of predator specs directory").Required().String(),
+		format:     cmdClause.Flag("format", "output format, one of text, json or sarif").Default(string(tolerance.LintFormatText)).Short('f').Enum(string(tolerance.LintFormatText), string(tolerance.LintFormatJSON), string(tolerance.LintFormatSARIF)),
+		schemaFile: cmdClause.Flag("schema-file", "path of cached schema file, default only the structure of specs is validated").Default("").Short('s').String(),
+		output:     cmdClause.Flag("output", "path of file to write the result, default will print the result").Default("").Short('o').String(),
+	}
+}
+
 type commandUpload struct {
 	cmd        *kingpin.CmdClause
 	host       *string
 	pathPrefix *string
 	gitURL     *string
 	commitID   *string
+	dryRun     *bool
 }
 
 func newCommandUpload(cmdClause *kingpin.CmdClause) *commandUpload {
@@ -73,6 +130,7 @@ func newCommandUpload(cmdClause *kingpin.CmdClause) *commandUpload {
 		pathPrefix: cmdClause.Flag("path-prefix", "path to root of predator specs directory, default will be empty").Default("").Short('p').String(),
 		gitURL:     cmdClause.Flag("git-url", "url of git, the source of data quality spec").Required().Short('g').String(),
 		commitID:   cmdClause.Flag("commit-id", "specific git commit hash, default value will be empty and always upload latest commit").Default("").Short('c').String(),
+		dryRun:     cmdClause.Flag("dry-run", "report the added, removed and changed specs without uploading").Bool(),
 	}
 }
 
@@ -103,6 +161,7 @@ func Execute() {
 			PathPrefix: *uploadCmd.pathPrefix,
 			GitURL:     *uploadCmd.gitURL,
 			CommitID:   *uploadCmd.commitID,
+			DryRun:     *uploadCmd.dryRun,
 		}
 		Upload(config)
 	case profileCmd.cmd.FullCommand():
@@ -125,6 +184,28 @@ func Execute() {
 			AuditTime: *profileAuditCmd.auditTime,
 		}
 		ProfileAudit(config)
+	case cancelCmd.cmd.FullCommand():
+		config := &CancelConfig{
+			Host:      *cancelCmd.server,
+			ProfileID: *cancelCmd.profileID,
+		}
+		Cancel(config)
+	case suggestCmd.cmd.FullCommand():
+		config := &SuggestConfig{
+			Host:   *suggestCmd.server,
+			URN:    *suggestCmd.urn,
+			Filter: *suggestCmd.filter,
+			Output: *suggestCmd.output,
+		}
+		Suggest(config)
+	case lintCmd.cmd.FullCommand():
+		config := &LintConfig{
+			Dir:        *lintCmd.dir,
+			Format:     *lintCmd.format,
+			SchemaFile: *lintCmd.schemaFile,
+			Output:     *lintCmd.output,
+		}
+		Lint(config)
 	default:
 		log.Println("command not found")
 	}
@@ -26,6 +26,9 @@ func checkProfileFailed(state job.State, message string) {
 	if state == job.StateFailed {
 		log.Fatalf("Profiling failed because: %s", message)
 	}
+	if state == job.StateCancelled {
+		log.Fatalf("Profiling cancelled: %s", message)
+	}
 }
 
 func profile(config *ProfileConfig, cli *client.Predator) string {
@@ -64,6 +67,23 @@ func profile(config *ProfileConfig, cli *client.Predator) string {
 	return profileResult.ID
 }
 
+//CancelConfig config
+type CancelConfig struct {
+	Host      string
+	ProfileID string
+}
+
+//Cancel to cancel running profile
+func Cancel(config *CancelConfig) {
+	cli := client.New(config.Host, xhttp.NewClientWithTimeout(time.Minute))
+
+	profileResponse, err := cli.CancelProfile(config.ProfileID)
+	if err != nil {
+		log.Fatal(fmt.Errorf("Cancelling profile failed because:\n%w", err))
+	}
+	log.Printf("Profile with ID %s is %s", profileResponse.ID, profileResponse.State)
+}
+
 //Profile to start profile
 func Profile(config *ProfileConfig) {
 	cli := client.New(config.Host, xhttp.NewClientWithTimeout(10*time.Minute))
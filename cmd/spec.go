@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/odpf/predator/client"
+	xhttp "github.com/odpf/predator/external/http"
+	"github.com/odpf/predator/metadata"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/tolerance"
+)
+
+//SuggestConfig config of spec suggestion
+type SuggestConfig struct {
+	Host   string
+	URN    string
+	Filter string
+	Output string
+}
+
+//Suggest to suggest data quality spec of a table, the spec is written to output file or printed when output is empty
+func Suggest(config *SuggestConfig) {
+	cli := client.New(config.Host, xhttp.NewClientWithTimeout(30*time.Minute))
+
+	log.Printf("suggesting spec of %s", config.URN)
+	content, err := cli.SuggestSpec(config.URN, config.Filter)
+	if err != nil {
+		log.Fatal(fmt.Errorf("suggest spec failed because :\n%w", err))
+	}
+
+	if config.Output == "" {
+		if _, err := os.Stdout.Write(content); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := ioutil.WriteFile(config.Output, content, 0644); err != nil {
+		log.Fatal(fmt.Errorf("unable to write spec to %s :\n%w", config.Output, err))
+	}
+	log.Printf("spec written to %s", config.Output)
+}
+
+//LintConfig config of spec lint
+type LintConfig struct {
+	Dir        string
+	Format     string
+	SchemaFile string
+	Output     string
+}
+
+//Lint to lint data quality specs of a directory without access to predator server
+//the schema checks are only done when schema file is configured, the process exit with code 1 when any error found
+func Lint(config *LintConfig) {
+	var metadataStore protocol.MetadataStore
+	if config.SchemaFile != "" {
+		content, err := ioutil.ReadFile(config.SchemaFile)
+		if err != nil {
+			log.Fatal(fmt.Errorf("unable to read schema file %s :\n%w", config.SchemaFile, err))
+		}
+		schemaStore, err := metadata.NewSchemaFileStore(content)
+		if err != nil {
+			log.Fatal(fmt.Errorf("invalid schema file %s :\n%w", config.SchemaFile, err))
+		}
+		metadataStore = schemaStore
+	}
+
+	linter := tolerance.NewLinter(tolerance.NewLocalRepository(config.Dir), &tolerance.GitPathResolver{}, metadataStore)
+	result, err := linter.Lint()
+	if err != nil {
+		log.Fatal(fmt.Errorf("lint spec failed because :\n%w", err))
+	}
+
+	dir, err := filepath.Abs(config.Dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	root, err := repositoryRoot(dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, d := range result.Diagnostics {
+		relativePath, err := filepath.Rel(root, filepath.Join(dir, d.Path))
+		if err != nil {
+			log.Fatal(err)
+		}
+		d.Path = filepath.ToSlash(relativePath)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := result.Write(buf, tolerance.LintFormat(config.Format)); err != nil {
+		log.Fatal(err)
+	}
+
+	if config.Output == "" {
+		if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+			log.Fatal(err)
+		}
+	} else if err := ioutil.WriteFile(config.Output, buf.Bytes(), 0644); err != nil {
+		log.Fatal(fmt.Errorf("unable to write lint result to %s :\n%w", config.Output, err))
+	}
+
+	if result.HasError() {
+		os.Exit(1)
+	}
+}
+
+//repositoryRoot find root of the git repository that contains the dir, so the linted paths can be placed by code scanning
+//working directory is used when the dir is not inside a git repository
+func repositoryRoot(dir string) (string, error) {
+	for current := dir; ; current = filepath.Dir(current) {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current, nil
+		}
+		if filepath.Dir(current) == current {
+			break
+		}
+	}
+	return os.Getwd()
+}
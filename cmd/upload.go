@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"github.com/odpf/predator/api/model"
 	"github.com/odpf/predator/client"
 	xhttp "github.com/odpf/predator/external/http"
 	"github.com/odpf/predator/protocol"
@@ -15,6 +17,7 @@ type UploadConfig struct {
 	PathPrefix string
 	GitURL     string
 	CommitID   string
+	DryRun     bool
 }
 
 func Upload(config *UploadConfig) {
@@ -29,10 +32,14 @@ func Upload(config *UploadConfig) {
 	log.Printf("git url : %s", gitInfo.URL)
 	log.Printf("commit SHA :%s", gitInfo.CommitID)
 	log.Printf("path prefix :%s", gitInfo.PathPrefix)
-	log.Println("uploading spec")
+	if config.DryRun {
+		log.Println("uploading spec on dry run, no spec will be written")
+	} else {
+		log.Println("uploading spec")
+	}
 
 	start := time.Now().In(time.UTC)
-	report, err := cli.Upload(gitInfo)
+	report, err := cli.Upload(gitInfo, config.DryRun)
 	end := time.Now().In(time.UTC)
 	duration := end.Sub(start)
 
@@ -42,5 +49,40 @@ func Upload(config *UploadConfig) {
 		log.Fatal(fmt.Errorf("upload spec failed because :\n%w", err))
 	}
 
+	if report.Diff != nil {
+		printUploadDiff(report.Diff)
+		log.Printf("spec to be uploaded: %d, removed: %d, changed: %d\n", report.UploadedCount, report.RemovedCount, len(report.Diff.Changed))
+		if report.Diff.RemovalLimitExceeded {
+			log.Fatal("upload would be refused, removed specs are more than the limit")
+		}
+		return
+	}
+
 	log.Printf("spec uploaded: %d, removed: %d\n", report.UploadedCount, report.RemovedCount)
 }
+
+//printUploadDiff print added, removed and changed specs, a line for each change of the changed spec
+func printUploadDiff(diff *model.UploadDiff) {
+	for _, urn := range diff.Added {
+		fmt.Printf("+ %s\n", urn)
+	}
+	for _, urn := range diff.Removed {
+		fmt.Printf("- %s\n", urn)
+	}
+	for _, specDiff := range diff.Changed {
+		fmt.Printf("~ %s\n", specDiff.URN)
+		for _, c := range specDiff.Changes {
+			target := string(c.MetricName)
+			if c.FieldID != "" {
+				target = fmt.Sprintf("%s %s", c.FieldID, c.MetricName)
+			}
+			if c.Attribute == "" {
+				fmt.Printf("    %s %s\n", c.Type, target)
+				continue
+			}
+			before, _ := json.Marshal(c.Before)
+			after, _ := json.Marshal(c.After)
+			fmt.Printf("    %s %s %s: %s -> %s\n", c.Type, target, c.Attribute, before, after)
+		}
+	}
+}
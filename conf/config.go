@@ -30,6 +30,13 @@ type Publisher struct {
 	Audit   *Kafka
 }
 
+//ProfileWorker is configuration of workers that run profile jobs
+type ProfileWorker struct {
+	Count        int
+	LeaseSeconds int
+	MaxAttempts  int
+}
+
 //Config is service config
 type Config struct {
 	Port          int
@@ -38,8 +45,28 @@ type Config struct {
 
 	Database *Database
 
+	//PostgresWarehouseURL connection URL of postgresql warehouse, tables with postgres URN scheme are profiled when set
+	PostgresWarehouseURL string
+
 	Publisher *Publisher
 
+	ProfileWorker *ProfileWorker
+
+	//SchedulerIntervalSeconds is interval of scheduler checking the schedules of tolerance specs
+	SchedulerIntervalSeconds int
+	//SchedulerSpecRefreshSeconds is how long the scheduler use the tolerance specs it read before reading them again
+	SchedulerSpecRefreshSeconds int
+	//SchedulerRunTimeoutSeconds is how long a schedule run can stay created or auditing before it is failed
+	SchedulerRunTimeoutSeconds int
+
+	//IncrementalMaxCarryForwardDays is the longest a metric of an unmodified partition is carried forward by incremental profile,
+	//the table is fully profiled again when a carried metric was profiled longer ago
+	IncrementalMaxCarryForwardDays int
+
+	//MaxBytesBilled is default limit of bytes processed by a profile, zero means unlimited
+	//the limit can be overridden by entity and tolerance spec
+	MaxBytesBilled int64
+
 	ToleranceURL        string
 	UniqueConstraintURL string
 
@@ -49,6 +76,19 @@ type Config struct {
 	//if MULTI_TENANCY_ENABLED env variable is NOT present the value will be false
 	MultiTenancyEnabled bool
 
+	//DefaultsEnabled inherit project and dataset level default tolerance specs
+	//if TOLERANCE_DEFAULTS_ENABLED env variable is NOT present the value will be false
+	DefaultsEnabled bool
+
+	//DefaultsExpansionEnabled expand dataset level default tolerance spec to every table of the bigquery dataset without spec file
+	//only applied when DefaultsEnabled is true
+	//if TOLERANCE_DEFAULTS_EXPANSION_ENABLED env variable is NOT present the value will be false
+	DefaultsExpansionEnabled bool
+
+	//SpecUploadMaxRemovalPct refuse spec upload that remove more than the percentage of stored specs
+	//if SPEC_UPLOAD_MAX_REMOVAL_PCT env variable is NOT present the value will be 0 and there is no limit
+	SpecUploadMaxRemovalPct int
+
 	//PodName name of replication pod
 	PodName string
 	//Deployment name of deployment
@@ -83,6 +123,26 @@ func printEnv() {
 	}
 }
 
+const (
+	defaultProfileWorkerCount     = 4
+	defaultProfileJobLeaseSeconds = 60
+	defaultProfileJobMaxAttempts  = 3
+
+	defaultSchedulerIntervalSeconds    = 60
+	defaultSchedulerSpecRefreshSeconds = 300
+	defaultSchedulerRunTimeoutSeconds  = 3600
+
+	defaultIncrementalMaxCarryForwardDays = 7
+)
+
+func intFromEnv(key string, defaultValue int) (int, error) {
+	envValue, set := os.LookupEnv(key)
+	if !set || envValue == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(envValue)
+}
+
 func loadFromEnv() (*Config, error) {
 	printEnv()
 	port, err := strconv.Atoi(os.Getenv("PORT"))
@@ -101,6 +161,70 @@ func loadFromEnv() (*Config, error) {
 		multiTenancyEnabled = value
 	}
 
+	var defaultsEnabled bool
+	if envValue, set := os.LookupEnv("TOLERANCE_DEFAULTS_ENABLED"); set {
+		value, err := strconv.ParseBool(envValue)
+		if err != nil {
+			return nil, err
+		}
+		defaultsEnabled = value
+	}
+
+	var defaultsExpansionEnabled bool
+	if envValue, set := os.LookupEnv("TOLERANCE_DEFAULTS_EXPANSION_ENABLED"); set {
+		value, err := strconv.ParseBool(envValue)
+		if err != nil {
+			return nil, err
+		}
+		defaultsExpansionEnabled = value
+	}
+
+	specUploadMaxRemovalPct, err := intFromEnv("SPEC_UPLOAD_MAX_REMOVAL_PCT", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	workerCount, err := intFromEnv("PROFILE_WORKER_COUNT", defaultProfileWorkerCount)
+	if err != nil {
+		return nil, err
+	}
+	leaseSeconds, err := intFromEnv("PROFILE_JOB_LEASE_SECONDS", defaultProfileJobLeaseSeconds)
+	if err != nil {
+		return nil, err
+	}
+	maxAttempts, err := intFromEnv("PROFILE_JOB_MAX_ATTEMPTS", defaultProfileJobMaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	schedulerIntervalSeconds, err := intFromEnv("SCHEDULER_INTERVAL_SECONDS", defaultSchedulerIntervalSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	schedulerSpecRefreshSeconds, err := intFromEnv("SCHEDULER_SPEC_REFRESH_SECONDS", defaultSchedulerSpecRefreshSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	schedulerRunTimeoutSeconds, err := intFromEnv("SCHEDULER_RUN_TIMEOUT_SECONDS", defaultSchedulerRunTimeoutSeconds)
+	if err != nil {
+		return nil, err
+	}
+
+	incrementalMaxCarryForwardDays, err := intFromEnv("INCREMENTAL_MAX_CARRY_FORWARD_DAYS", defaultIncrementalMaxCarryForwardDays)
+	if err != nil {
+		return nil, err
+	}
+
+	var maxBytesBilled int64
+	if envValue := os.Getenv("MAX_BYTES_BILLED"); envValue != "" {
+		maxBytesBilled, err = strconv.ParseInt(envValue, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	dbHost := os.Getenv("DB_HOST")
 	dbPort, err := strconv.Atoi(os.Getenv("DB_PORT"))
 	if err != nil {
@@ -128,6 +252,7 @@ func loadFromEnv() (*Config, error) {
 			User: dbUser,
 			Pass: dbPass,
 		},
+		PostgresWarehouseURL: os.Getenv("POSTGRES_WAREHOUSE_URL"),
 
 		Publisher: &Publisher{
 			Profile: &Kafka{
@@ -139,12 +264,25 @@ func loadFromEnv() (*Config, error) {
 				Broker: kafkaBroker,
 			},
 		},
-		ToleranceURL:          os.Getenv("TOLERANCE_STORE_URL"),
-		UniqueConstraintURL:   os.Getenv("UNIQUE_CONSTRAINT_STORE_URL"),
-		MultiTenancyEnabled:   multiTenancyEnabled,
-		GitAuthPrivateKeyPath: os.Getenv("GIT_AUTH_PRIVATE_KEY_PATH"),
-		PodName:               podName,
-		Deployment:            os.Getenv("DEPLOYMENT"),
-		Environment:           environmentValue,
+		ProfileWorker: &ProfileWorker{
+			Count:        workerCount,
+			LeaseSeconds: leaseSeconds,
+			MaxAttempts:  maxAttempts,
+		},
+		SchedulerIntervalSeconds:       schedulerIntervalSeconds,
+		SchedulerSpecRefreshSeconds:    schedulerSpecRefreshSeconds,
+		SchedulerRunTimeoutSeconds:     schedulerRunTimeoutSeconds,
+		IncrementalMaxCarryForwardDays: incrementalMaxCarryForwardDays,
+		MaxBytesBilled:                 maxBytesBilled,
+		ToleranceURL:                   os.Getenv("TOLERANCE_STORE_URL"),
+		UniqueConstraintURL:            os.Getenv("UNIQUE_CONSTRAINT_STORE_URL"),
+		MultiTenancyEnabled:            multiTenancyEnabled,
+		DefaultsEnabled:                defaultsEnabled,
+		DefaultsExpansionEnabled:       defaultsExpansionEnabled,
+		SpecUploadMaxRemovalPct:        specUploadMaxRemovalPct,
+		GitAuthPrivateKeyPath:          os.Getenv("GIT_AUTH_PRIVATE_KEY_PATH"),
+		PodName:                        podName,
+		Deployment:                     os.Getenv("DEPLOYMENT"),
+		Environment:                    environmentValue,
 	}, err
 }
@@ -0,0 +1,57 @@
+package cost
+
+import (
+	"fmt"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+)
+
+//Estimator estimate bytes processed by a profile by dry running every planned query of the profile
+type Estimator struct {
+	planner        protocol.MetricGenerator
+	queryEstimator protocol.QueryEstimator
+	limitResolver  protocol.BytesLimitResolver
+}
+
+//NewEstimator create Estimator
+func NewEstimator(planner protocol.MetricGenerator, queryEstimator protocol.QueryEstimator, limitResolver protocol.BytesLimitResolver) *Estimator {
+	return &Estimator{
+		planner:        planner,
+		queryEstimator: queryEstimator,
+		limitResolver:  limitResolver,
+	}
+}
+
+//Estimate plan queries of the profile and estimate bytes processed by each query
+func (e *Estimator) Estimate(entry protocol.Entry, profile *job.Profile) (*protocol.CostEstimate, error) {
+	plannedQueries, err := e.planner.Plan(profile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to plan queries of %s ,%w", profile.URN, err)
+	}
+
+	return e.EstimateQueries(entry, profile, plannedQueries)
+}
+
+//EstimateQueries estimate bytes processed by each planned query of the profile
+func (e *Estimator) EstimateQueries(entry protocol.Entry, profile *job.Profile, plannedQueries []*job.Query) (*protocol.CostEstimate, error) {
+	maxBytesBilled, err := e.limitResolver.Resolve(profile.URN)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve max bytes billed of %s ,%w", profile.URN, err)
+	}
+
+	estimate := &protocol.CostEstimate{MaxBytesBilled: maxBytesBilled}
+	for _, plannedQuery := range plannedQueries {
+		estimatedBytes, err := e.queryEstimator.Estimate(entry, profile, plannedQuery.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		estimate.Queries = append(estimate.Queries, &protocol.QueryCost{
+			Query:          plannedQuery,
+			EstimatedBytes: estimatedBytes,
+		})
+		estimate.TotalBytes += estimatedBytes
+	}
+	return estimate, nil
+}
@@ -0,0 +1,134 @@
+package cost
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator(t *testing.T) {
+	profile := &job.Profile{
+		ID:  "profile-1",
+		URN: "project.dataset.table",
+	}
+	t.Run("Estimate", func(t *testing.T) {
+		t.Run("should return estimated bytes of every planned query", func(t *testing.T) {
+			statisticalQuery := &job.Query{URN: profile.URN, Content: "SELECT count(*)", Type: job.StatisticalQuery}
+			tableQuery := &job.Query{URN: profile.URN, Content: "SELECT count(1)", Type: job.TableLevelQuery}
+
+			planner := mock.NewMetricGenerator()
+			defer planner.AssertExpectations(t)
+
+			queryEstimator := mock.NewQueryEstimator()
+			defer queryEstimator.AssertExpectations(t)
+
+			limitResolver := mock.NewBytesLimitResolver()
+			defer limitResolver.AssertExpectations(t)
+
+			planner.On("Plan", profile).Return([]*job.Query{statisticalQuery, tableQuery}, nil)
+			limitResolver.On("Resolve", profile.URN).Return(int64(1000), nil)
+			queryEstimator.On("Estimate", profile, statisticalQuery.Content).Return(int64(100), nil)
+			queryEstimator.On("Estimate", profile, tableQuery.Content).Return(int64(300), nil)
+
+			estimator := NewEstimator(planner, queryEstimator, limitResolver)
+
+			estimate, err := estimator.Estimate(protocol.NewEntry(), profile)
+
+			expected := &protocol.CostEstimate{
+				Queries: []*protocol.QueryCost{
+					{Query: statisticalQuery, EstimatedBytes: 100},
+					{Query: tableQuery, EstimatedBytes: 300},
+				},
+				TotalBytes:     400,
+				MaxBytesBilled: 1000,
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, estimate)
+			assert.False(t, estimate.IsExceeded())
+		})
+		t.Run("should return error when dry run failed", func(t *testing.T) {
+			statisticalQuery := &job.Query{URN: profile.URN, Content: "SELECT count(*)", Type: job.StatisticalQuery}
+			dryRunErr := errors.New("dry run failed")
+
+			planner := mock.NewMetricGenerator()
+			defer planner.AssertExpectations(t)
+
+			queryEstimator := mock.NewQueryEstimator()
+			defer queryEstimator.AssertExpectations(t)
+
+			limitResolver := mock.NewBytesLimitResolver()
+			defer limitResolver.AssertExpectations(t)
+
+			planner.On("Plan", profile).Return([]*job.Query{statisticalQuery}, nil)
+			limitResolver.On("Resolve", profile.URN).Return(int64(0), nil)
+			queryEstimator.On("Estimate", profile, statisticalQuery.Content).Return(int64(0), dryRunErr)
+
+			estimator := NewEstimator(planner, queryEstimator, limitResolver)
+
+			estimate, err := estimator.Estimate(protocol.NewEntry(), profile)
+
+			assert.Nil(t, estimate)
+			assert.Equal(t, dryRunErr, err)
+		})
+	})
+	t.Run("EstimateQueries", func(t *testing.T) {
+		t.Run("should return estimated bytes of the given queries without planning", func(t *testing.T) {
+			tableQuery := &job.Query{URN: profile.URN, Content: "SELECT count(1)", Type: job.TableLevelQuery}
+
+			planner := mock.NewMetricGenerator()
+			defer planner.AssertExpectations(t)
+
+			queryEstimator := mock.NewQueryEstimator()
+			defer queryEstimator.AssertExpectations(t)
+
+			limitResolver := mock.NewBytesLimitResolver()
+			defer limitResolver.AssertExpectations(t)
+
+			limitResolver.On("Resolve", profile.URN).Return(int64(100), nil)
+			queryEstimator.On("Estimate", profile, tableQuery.Content).Return(int64(300), nil)
+
+			estimator := NewEstimator(planner, queryEstimator, limitResolver)
+
+			estimate, err := estimator.EstimateQueries(protocol.NewEntry(), profile, []*job.Query{tableQuery})
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(300), estimate.TotalBytes)
+			assert.True(t, estimate.IsExceeded())
+		})
+	})
+}
+
+func TestCostEstimate(t *testing.T) {
+	t.Run("IsExceeded", func(t *testing.T) {
+		t.Run("should return true when total bytes more than max bytes billed", func(t *testing.T) {
+			estimate := &protocol.CostEstimate{TotalBytes: 1001, MaxBytesBilled: 1000}
+			assert.True(t, estimate.IsExceeded())
+		})
+		t.Run("should return false when max bytes billed is not configured", func(t *testing.T) {
+			estimate := &protocol.CostEstimate{TotalBytes: 1001}
+			assert.False(t, estimate.IsExceeded())
+		})
+	})
+	t.Run("Limit", func(t *testing.T) {
+		t.Run("should apply max bytes billed to the entry", func(t *testing.T) {
+			estimate := &protocol.CostEstimate{TotalBytes: 100, MaxBytesBilled: 1000}
+
+			entry, err := estimate.Limit(protocol.NewEntry())
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1000), entry.MaxBytesBilled())
+		})
+		t.Run("should return ErrBytesLimitExceeded when total bytes more than max bytes billed", func(t *testing.T) {
+			estimate := &protocol.CostEstimate{TotalBytes: 1001, MaxBytesBilled: 1000}
+
+			_, err := estimate.Limit(protocol.NewEntry())
+
+			assert.True(t, errors.Is(err, protocol.ErrBytesLimitExceeded))
+		})
+	})
+}
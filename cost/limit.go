@@ -0,0 +1,51 @@
+package cost
+
+import (
+	"errors"
+
+	"github.com/odpf/predator/protocol"
+)
+
+//LimitResolver resolve max bytes billed of a table
+//limit configured on the tolerance spec of the table is used first, then the limit of the entity that own the table
+//and the default limit when neither is configured
+type LimitResolver struct {
+	defaultLimit   int64
+	entityStore    protocol.EntityStore
+	toleranceStore protocol.ToleranceStore
+}
+
+//NewLimitResolver create LimitResolver
+func NewLimitResolver(defaultLimit int64, entityStore protocol.EntityStore, toleranceStore protocol.ToleranceStore) *LimitResolver {
+	return &LimitResolver{
+		defaultLimit:   defaultLimit,
+		entityStore:    entityStore,
+		toleranceStore: toleranceStore,
+	}
+}
+
+//Resolve get max bytes billed of the table, zero means unlimited
+func (l *LimitResolver) Resolve(urn string) (int64, error) {
+	spec, err := l.toleranceStore.GetByTableID(urn)
+	if err != nil && !errors.Is(err, protocol.ErrToleranceNotFound) {
+		return 0, err
+	}
+	if spec != nil && spec.MaxBytesBilled > 0 {
+		return spec.MaxBytesBilled, nil
+	}
+
+	label, err := protocol.ParseLabel(urn)
+	if err != nil {
+		return 0, err
+	}
+
+	entity, err := l.entityStore.GetEntityByProjectID(label.Project)
+	if err != nil && err != protocol.ErrEntityNotFound {
+		return 0, err
+	}
+	if entity != nil && entity.MaxBytesBilled > 0 {
+		return entity.MaxBytesBilled, nil
+	}
+
+	return l.defaultLimit, nil
+}
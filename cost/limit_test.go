@@ -0,0 +1,62 @@
+package cost
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitResolver(t *testing.T) {
+	urn := "project.dataset.table"
+	defaultLimit := int64(1000)
+	t.Run("Resolve", func(t *testing.T) {
+		t.Run("should use limit of tolerance spec", func(t *testing.T) {
+			entityStore := mock.NewEntityStore()
+			toleranceStore := mock.NewToleranceStore()
+			defer toleranceStore.AssertExpectations(t)
+
+			toleranceStore.On("GetByTableID", urn).Return(&protocol.ToleranceSpec{URN: urn, MaxBytesBilled: 10}, nil)
+
+			resolver := NewLimitResolver(defaultLimit, entityStore, toleranceStore)
+			limit, err := resolver.Resolve(urn)
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(10), limit)
+			entityStore.AssertNotCalled(t, "GetEntityByProjectID", "project")
+		})
+		t.Run("should use limit of entity when tolerance spec has no limit", func(t *testing.T) {
+			entityStore := mock.NewEntityStore()
+			defer entityStore.AssertExpectations(t)
+			toleranceStore := mock.NewToleranceStore()
+			defer toleranceStore.AssertExpectations(t)
+
+			toleranceStore.On("GetByTableID", urn).Return(&protocol.ToleranceSpec{URN: urn}, nil)
+			entityStore.On("GetEntityByProjectID", "project").Return(&protocol.Entity{ID: "entity-1", MaxBytesBilled: 100}, nil)
+
+			resolver := NewLimitResolver(defaultLimit, entityStore, toleranceStore)
+			limit, err := resolver.Resolve(urn)
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(100), limit)
+		})
+		t.Run("should use default limit when spec and entity not found", func(t *testing.T) {
+			entityStore := mock.NewEntityStore()
+			defer entityStore.AssertExpectations(t)
+			toleranceStore := mock.NewToleranceStore()
+			defer toleranceStore.AssertExpectations(t)
+
+			notFoundErr := fmt.Errorf("failed to get file :\n%w", protocol.ErrToleranceNotFound)
+			toleranceStore.On("GetByTableID", urn).Return((*protocol.ToleranceSpec)(nil), notFoundErr)
+			entityStore.On("GetEntityByProjectID", "project").Return((*protocol.Entity)(nil), protocol.ErrEntityNotFound)
+
+			resolver := NewLimitResolver(defaultLimit, entityStore, toleranceStore)
+			limit, err := resolver.Resolve(urn)
+
+			assert.Nil(t, err)
+			assert.Equal(t, defaultLimit, limit)
+		})
+	})
+}
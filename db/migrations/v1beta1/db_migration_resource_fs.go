@@ -19,7 +19,7 @@ var DBMigrationFileSystem = func() http.FileSystem {
 	fs := vfsgen۰FS{
 		"/": &vfsgen۰DirInfo{
 			name:    "/",
-			modTime: time.Date(2022, 8, 23, 10, 51, 53, 124223012, time.UTC),
+			modTime: time.Date(2026, 10, 18, 15, 1, 32, 920548951, time.UTC),
 		},
 		"/000001_create_predator_tables.down.sql": &vfsgen۰CompressedFileInfo{
 			name:             "000001_create_predator_tables.down.sql",
@@ -35,10 +35,170 @@ var DBMigrationFileSystem = func() http.FileSystem {
 
 			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xbc\x55\x4b\x6f\xda\x4e\x10\xbf\xf3\x29\x46\x39\x81\x94\x48\xf9\xeb\xaf\xf6\x92\x93\x09\x4e\xeb\x96\x98\x08\x4c\x45\x4e\xab\xc5\x1e\xe8\x22\x7b\xd7\xd9\x07\x2d\xdf\xbe\xb2\xd7\xc6\x2f\x5e\x49\xd3\x70\x9c\xf9\x8d\x3d\xfe\x3d\x86\x9b\x1b\x08\x25\x52\x8d\x90\x4a\xb1\x62\x31\xf6\xee\xa7\xae\x13\xb8\xe0\x2e\x02\xd7\x9f\x79\x13\x1f\xbc\x07\xf0\x27\x01\xb8\x0b\x6f\x16\xcc\xe0\xca\x18\x16\xdd\x08\xa5\xd2\xab\xbb\x12\x1b\x38\xc3\xb1\xdb\xc2\x15\x8f\xeb\xf7\x00\x00\x58\x04\xf3\xb9\x37\x82\xa7\xa9\xf7\xe8\x4c\x9f\xe1\xbb\xfb\x9c\x63\xfd\xf9\x78\x0c\x23\xf7\xc1\x99\x8f\x03\xc8\x1e\x4c\xd6\xc8\x51\x52\x8d\x64\xfb\x5f\x7f\x70\x9d\x0f\x1b\xc9\x21\x70\x17\xc1\x7e\xc2\x96\xd7\x52\x98\x94\x70\x9a\x20\xfc\x70\xa6\xf7\x5f\x9d\xa9\xad\xaf\x58\xac\x51\xe6\x13\xb6\x90\x88\xa8\x05\xd1\x42\xd3\x98\x48\x0c\x85\x8c\x14\x0c\xbd\x2f\x9e\x1f\x74\xf7\xb9\xb5\x60\x6a\x22\xa6\x89\x66\x09\x42\xe0\x3d\xba\xb3\xc0\x79\x7c\xb2\x1d\xdc\x22\xb7\x1d\xa5\x69\x92\x56\xed\xbc\x3b\xb8\xeb\xf5\x2a\x76\x35\x5d\xc6\x08\x4b\xb6\x7e\x31\x28\x77\xb0\x11\xcb\x53\xe4\x95\x38\xb2\x11\x4b\xcb\xe0\x85\x3f\x16\xc1\xcc\x9d\x7a\xce\xb8\x4e\xf5\xf5\x6b\x9e\x50\xe8\x46\x4a\xc9\xf6\xb4\x48\x5c\xa1\x44\x1e\xa2\xda\x6b\xcb\xa2\x42\xa2\xe5\x4b\x86\x3f\x20\x92\xfd\xfa\x88\x50\x5d\xb1\xb3\x87\xec\x69\x2a\x98\xf0\xfc\x91\xbb\x80\xe5\x86\x54\x3b\x10\x16\xfd\x86\x89\xdf\x20\x04\xfa\x55\x7f\x70\xd7\x19\xce\x77\x39\x3c\x97\xb7\x9a\xba\x24\xa8\x25\x0b\xad\x3c\xa7\x14\xb1\xb8\x0b\xb5\xf8\x1b\x15\x6a\xfc\x67\x81\xb8\x8c\x7f\x9b\x85\x2d\x8d\x0d\xd6\x8c\xbf\x62\x18\x47\xa5\x30\xb6\x24\x7e\x71\x94\x44\xef\xd2\x7d\x22\xa0\xff\xff\xed\xa0\xa5\x9a\xfd\xd8\x46\xb6\xa0\xff\xe9\x18\xcc\xbe\x76\x34\x99\x67\xac\x3d\x4d\xdd\x7b\x2f\x3f\x1b\x2d\x23\x08\x1e\x31\xcd\x04\xaf\x2d\x13\x52\x8d\x6b\x21\x77\xcd\x70\x26\xa8\x69\x44\x35\x85\x6f\xb3\x89\x3f\x7c\xb3\x8d\x92\x03\x2e\x2a\xc4\x3e\xe1\x9f\x84\x94\xa4\xb5\x67\xca\x7a\x77\xa2\x75\x06\xda\x83\xd5\xee\x4d\xe7\xe5\x77\xe5\xbc\xf1\x72\xd8\x3b\x5c\xd1\x37\xe5\xfa\x55\x87\xf2\xe8\x39\x3c\xa3\x14\x35\x07\xa4\xb2\xec\x34\x95\xea\x90\x27\x51\x99\xb8\xe4\xf0\x2c\x89\xc4\xc2\x3f\xf8\x9e\xda\x57\x9f\x62\xdd\x2a\xfc\xfa\x2c\xff\x83\x94\x6a\x11\xa3\xa4\x3c\x44\x22\x4d\x8c\x2a\x8f\xe0\x05\x41\x4e\xa9\x52\x64\x15\xd3\x35\x0c\x27\x93\xb1\xeb\xf8\xdd\x15\xde\x23\xd2\x54\x92\x92\xcd\x86\x4d\x0a\x61\xa1\x5f\x76\xdb\x19\xa5\xb2\x13\xeb\xe6\x60\x2d\xdc\x35\x93\x29\x4d\xb5\x51\x17\xd8\xcb\x02\x3f\xe0\xcf\x61\x23\x96\x99\x07\xaa\xd3\xfd\xb9\xad\x76\x86\x38\x77\xde\x8b\xef\x3a\xe9\x19\xa5\xe8\xba\xee\xbf\x37\xc8\xa5\x88\x5d\xb7\xa4\xbc\x78\x6d\xdf\x56\xdb\x1a\x59\x74\xb6\xfa\x21\x7c\x56\xef\x4e\x1c\xb9\xbc\xe5\xe0\xb1\xcb\x8b\x5c\x33\xbd\xbb\x40\x57\x0b\xcc\x75\x65\x11\x6c\xa9\x0c\x7f\x52\xd9\x91\x2d\x0f\x60\xd9\xe4\x42\x03\x37\x71\x9c\x77\x90\x6f\x99\x14\x3c\x41\xae\x0f\x03\xd6\x4c\x13\x23\xe3\x23\xcd\x30\xcd\x0e\xe3\x06\xc3\xcc\xd3\xea\x30\xa8\xa6\x4b\x75\x79\x1b\x08\x93\x46\x1d\x44\xcf\xea\xf5\x27\x00\x00\xff\xff\xfb\xaa\x66\x98\xfd\x0b\x00\x00"),
 		},
+		"/000002_create_job_queue.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000002_create_job_queue.down.sql",
+			modTime:          time.Date(2026, 10, 18, 14, 49, 36, 906260063, time.UTC),
+			uncompressedSize: 32,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\xca\x4f\x8a\x2f\x2c\x4d\x2d\x4d\xb5\xe6\x02\x00\x00\x00\xff\xff\x03\x00\xce\xeb\xc5\x9b\x20\x00\x00\x00"),
+		},
+		"/000002_create_job_queue.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000002_create_job_queue.up.sql",
+			modTime:          time.Date(2026, 10, 18, 14, 49, 36, 903017190, time.UTC),
+			uncompressedSize: 526,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x90\xcd\x6e\xea\x30\x14\x06\xf7\x79\x8a\x6f\x49\x24\x90\x90\xee\x92\x95\x2f\x18\xd5\x6a\x08\x34\xb1\x2b\x58\x45\x86\x1c\x24\xa3\x34\x0e\xb1\xa3\xd2\xb7\xaf\x88\x0b\xb4\xf4\x67\x3d\x73\x3e\xd9\x33\x1a\x61\xd7\x92\xf6\x84\x83\xdd\xe2\xd8\x51\x47\xf0\x7a\x5b\x11\xec\x1e\x4d\x6b\xf7\xa6\x22\xe8\xba\x44\x4b\x3b\x5b\xef\x4c\x65\xb4\x37\xb6\x3e\xdb\x2e\x8a\xa6\x19\x67\x92\x43\xb2\xff\x09\x87\x98\x23\x5d\x4a\xf0\xb5\xc8\x65\x7e\x16\x8a\x7e\x6e\x10\x01\x80\x29\x91\xf3\x4c\xb0\x04\xab\x4c\x2c\x58\xb6\xc1\x23\xdf\x0c\x7b\x74\x36\x4d\x09\xa5\xc4\xac\x1f\x48\x55\x92\xdc\x88\x7f\x6b\x08\xcf\x2c\x9b\x3e\xb0\x0c\x83\x7f\xe3\xf8\xce\x71\x5e\xfb\xce\xfd\x65\x68\xef\xe9\xa5\xf1\x0e\x22\x95\x57\x84\x19\x9f\x33\x95\x48\x8c\x83\x54\x91\x76\x54\xd8\xd7\x9a\xda\xcb\xd6\x67\x40\xa7\xc6\xb4\xe4\x0a\xed\x21\xc5\x82\xe7\x92\x2d\x56\x81\x87\x7a\xe5\x17\x72\xf7\x80\xae\x29\x7f\x57\x7a\x23\x9e\x5c\x5b\xaa\x54\x3c\x29\x0e\x91\xce\xf8\x1a\x87\x63\x11\xea\x9c\xb0\x4c\x6f\x49\x31\xb8\x94\x19\x7e\xd4\x8b\x27\x97\xfb\xeb\x61\x08\xf3\xc3\x6d\x00\xc3\x6f\x3f\x8b\x27\xd1\x3b\x00\x00\x00\xff\xff\x03\x00\x18\x7e\xa1\xbd\x0e\x02\x00\x00"),
+		},
+		"/000003_add_entity_max_bytes_billed.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000003_add_entity_max_bytes_billed.down.sql",
+			modTime:          time.Date(2026, 10, 18, 10, 40, 31, 2472731, time.UTC),
+			uncompressedSize: 59,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xcd\x2b\xc9\x2c\xa9\x54\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x4d\xac\x88\x4f\xaa\x2c\x49\x2d\x8e\x4f\xca\xcc\xc9\x49\x4d\xb1\xe6\x02\x00\x00\x00\xff\xff\x03\x00\x5a\x1d\x18\x6f\x3b\x00\x00\x00"),
+		},
+		"/000003_add_entity_max_bytes_billed.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000003_add_entity_max_bytes_billed.up.sql",
+			modTime:          time.Date(2026, 10, 18, 10, 40, 30, 998128762, time.UTC),
+			uncompressedSize: 88,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xcd\x2b\xc9\x2c\xa9\x54\x70\x74\x71\x51\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\xf0\xf3\x0f\x51\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x4d\xac\x88\x4f\xaa\x2c\x49\x2d\x8e\x4f\xca\xcc\xc9\x49\x4d\x51\x70\xf2\x74\xf7\xf4\x0b\x01\x2b\xf1\x0b\xf5\xf1\x51\x70\x71\x75\x73\x0c\xf5\x09\x51\x30\xb0\xe6\x02\x00\x00\x00\xff\xff\x03\x00\x6a\x95\x43\x5e\x58\x00\x00\x00"),
+		},
+		"/000004_create_schedule_run.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000004_create_schedule_run.down.sql",
+			modTime:          time.Date(2026, 10, 18, 10, 52, 12, 747190036, time.UTC),
+			uncompressedSize: 35,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\x08\x71\x74\xf2\x71\x55\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4e\xce\x48\x4d\x29\xcd\x49\x8d\x2f\x2a\xcd\xb3\xe6\x02\x00\x00\x00\xff\xff\x03\x00\x89\x7c\x3e\xa4\x23\x00\x00\x00"),
+		},
+		"/000004_create_schedule_run.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000004_create_schedule_run.up.sql",
+			modTime:          time.Date(2026, 10, 18, 10, 52, 12, 742170475, time.UTC),
+			uncompressedSize: 521,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x90\xc1\x6a\x32\x31\x14\x46\xf7\xf3\x14\xdf\xd2\x01\x85\x1f\xfe\xa5\xab\xa8\x57\x1a\x1a\x33\x36\x93\x29\xba\x0a\xa9\x49\xdb\x80\x4e\x25\x93\x40\x1f\xbf\x60\x70\x40\xd1\xed\xfd\x0e\x87\xcb\x99\xcd\x70\x88\xde\x26\x8f\xe1\xf0\xed\x5d\x3e\x7a\xc4\xdc\x23\xd9\x8f\xa3\xaf\xaa\xa5\x22\xa6\x09\x9a\x2d\x04\x81\xaf\x21\x1b\x0d\xda\xf1\x56\xb7\x23\x6e\x62\xee\x27\x15\x00\x04\x87\x96\x14\x67\x02\x5b\xc5\x37\x4c\xed\xf1\x4a\xfb\xe9\x65\xca\xb1\xc7\x3b\x53\xcb\x17\xa6\x2e\x0e\xd9\x09\x51\x96\xab\xc6\x99\x14\x4e\x1e\x9a\x6f\xa8\xd5\x6c\xb3\xbd\xc7\x92\x4d\x79\x18\x1d\x93\xff\xff\xea\x3b\xe2\xe4\x87\xc1\x7e\xf9\x2b\x52\x8e\xe7\xf8\xf3\x19\x8e\xde\x04\x77\x7b\xb7\xd9\x85\xf4\xf8\x8a\x45\xd3\x08\x62\x72\xd4\x63\x45\x6b\xd6\x09\x8d\x35\x13\x2d\x15\xb2\x24\x73\xc6\xa6\xa7\x1f\xe7\xb3\x7b\x8e\x5c\x88\x7a\x3e\x06\xee\x24\x7f\xeb\x08\x5c\xae\x68\x87\x21\x9a\x1c\x7b\x73\x5b\xc6\x04\xf7\x8b\x46\xde\x64\xc7\x24\xc7\x7e\x7a\x97\xb0\x9e\x5f\xa5\xa3\xad\xc4\x7b\x6c\x28\x5b\x3d\xaf\xfe\x00\x00\x00\xff\xff\x03\x00\xfb\x65\x54\x36\x09\x02\x00\x00"),
+		},
+		"/000005_add_audit_urn.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000005_add_audit_urn.down.sql",
+			modTime:          time.Date(2026, 10, 18, 10, 57, 6, 81767979, time.UTC),
+			uncompressedSize: 142,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x2c\x8a\x4f\x2c\x4d\xc9\x2c\x89\xcf\x4c\x89\x2f\x48\x2c\x2e\x8e\x4f\xcb\x49\x4c\x8f\xcf\x4c\xa9\xb0\xe6\xc2\xae\xbe\x34\xbe\xb4\x28\x2f\x3e\xb5\x2c\x35\xaf\x24\xbe\x24\x33\x37\xb5\xb8\x24\x31\xb7\x00\xa2\xc1\xd1\x27\xc4\x35\x48\x21\xc4\xd1\xc9\xc7\x55\x01\x6c\xa8\x02\xd8\x08\x67\x7f\x9f\x50\x5f\x3f\x24\x33\x4a\x8b\xf2\xac\xb9\x00\x00\x00\x00\xff\xff\x03\x00\xe1\x0d\x03\xc4\x8e\x00\x00\x00"),
+		},
+		"/000005_add_audit_urn.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000005_add_audit_urn.up.sql",
+			modTime:          time.Date(2026, 10, 18, 10, 57, 6, 80400011, time.UTC),
+			uncompressedSize: 406,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8e\x41\x4e\xc3\x30\x10\x45\xf7\x3e\xc5\x2c\x5b\xa9\xed\x05\x22\x16\x26\x71\xd5\x48\xa9\x83\x1c\x07\xba\x1b\x19\xd9\x01\x8b\x34\x8e\x1c\x1b\xc1\xed\x51\x82\x83\xa0\x0b\x96\xf3\xe7\xbd\x99\xbf\xdf\x83\xd2\x1a\xa2\x1f\x20\x38\x50\x51\xdb\x00\x41\x3d\xf7\x66\x1e\x7b\xe7\xde\xe2\x98\xd2\x57\x3b\x05\xe7\x3f\xc1\x75\xa0\xbe\x11\x42\x68\x25\x99\x00\x49\xef\x2b\x96\x28\x5a\x14\x90\xd7\x55\x7b\xe6\x50\x1e\x81\xd7\x12\xd8\xa5\x6c\x64\xb3\x7c\x78\xa4\x22\x3f\x51\x91\x11\xd2\x3e\x14\x54\xae\x4e\xc3\xe4\xb2\xbe\x83\xd1\xbb\xce\xf6\xe6\x30\x4f\x47\x51\x9f\xd7\x00\x9e\x4e\x4c\x24\xfc\x90\x32\xb4\xfa\x97\x61\x35\x50\x5e\x24\x62\xd6\xcb\x06\x78\x5b\x55\x19\x21\xb9\x60\xf3\xaf\x92\x17\xec\x72\x53\x4a\x45\x8c\x7e\x40\xf3\x6e\x86\x80\xc1\x5e\xcd\x14\xd4\x75\x44\xab\x3f\xa0\xe6\xa9\xdd\x26\xfa\x61\x07\x37\xc8\x36\xfb\xf7\xac\xc7\xc5\x45\xab\x71\x54\xd3\x84\x5d\xaf\x5e\xfe\x5c\x45\x6f\xa6\xd8\x07\xd8\xac\xdc\x0e\x7e\xc0\x6d\x46\xbe\x00\x00\x00\xff\xff\x03\x00\x3b\x1b\x69\x2b\x96\x01\x00\x00"),
+		},
+		"/000006_add_metric_series_index.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000006_add_metric_series_index.down.sql",
+			modTime:          time.Date(2026, 10, 18, 11, 3, 5, 323216359, time.UTC),
+			uncompressedSize: 99,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\x8d\x2f\x28\xca\x4f\xcb\xcc\x49\x8d\xcf\x4c\x89\xcf\x4d\x2d\x29\xca\x4c\x8e\xcf\x4b\xcc\x05\x71\x2b\xac\xb9\xb0\x6a\x29\x88\x2f\x2d\xca\x8b\x4f\x2d\x4b\xcd\x2b\x89\x2f\xc9\xcc\x4d\x2d\x2e\x49\xcc\x2d\x80\xa8\x07\x00\x00\x00\xff\xff\x03\x00\x00\x68\x11\xd7\x63\x00\x00\x00"),
+		},
+		"/000006_add_metric_series_index.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000006_add_metric_series_index.up.sql",
+			modTime:          time.Date(2026, 10, 18, 11, 3, 5, 321898238, time.UTC),
+			uncompressedSize: 230,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x7c\x8e\xc1\x0a\x82\x40\x14\x45\xf7\x7e\xc5\x5d\x2a\xe8\x17\xb8\x8a\x9a\xc0\x8d\x42\xba\x70\xf7\x98\xf2\x09\x0f\x9c\xd1\xc6\x67\xd8\xdf\x47\x58\x14\x2d\xda\x5e\xce\x39\xdc\x2c\x83\xf8\x8e\x57\xe8\x88\xeb\xc2\xe1\x0e\xc7\x1a\xe4\x02\x15\xc7\x98\x39\x08\xcf\x18\x7b\x58\xa8\x3d\x0f\x1c\x45\xfb\x93\xd9\x35\x06\x45\x79\x30\x2d\x8a\x23\xca\xaa\x81\x69\x8b\xba\xa9\x31\xd1\x12\x3c\xf1\x8d\xbd\xd2\x53\x9f\xd5\xba\x89\xa4\x5b\x51\x95\x98\xc2\xd8\xcb\xc0\x88\x97\xe0\x53\xfc\x40\x49\xfe\xaf\xeb\xe8\x25\x93\x74\xb4\xdd\x23\x6f\x1d\xbf\xd3\xdb\x84\xf8\x43\xa5\xf8\xc2\x92\x3c\x7a\x00\x00\x00\xff\xff\x03\x00\xd0\xe9\x8d\x98\xe6\x00\x00\x00"),
+		},
+		"/000007_create_reconciliation.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000007_create_reconciliation.down.sql",
+			modTime:          time.Date(2026, 10, 18, 11, 36, 44, 982624568, time.UTC),
+			uncompressedSize: 93,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x4a\x8e\x2f\x49\x2c\x4a\x4f\x2d\x89\x2f\x2d\xca\x8b\x4f\x2d\x4b\xcd\x2b\x89\x2f\xc9\xcc\x4d\x2d\x2e\x49\xcc\x2d\x88\xcf\x4c\xa9\xb0\xe6\x02\xeb\x0b\x71\x74\xf2\x71\x45\xd6\x97\x9a\x9c\x9f\x97\x9c\x99\x93\x99\x58\x92\x99\x9f\x67\xcd\x05\x00\x00\x00\xff\xff\x03\x00\xf7\x0a\xb9\x69\x5d\x00\x00\x00"),
+		},
+		"/000007_create_reconciliation.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000007_create_reconciliation.up.sql",
+			modTime:          time.Date(2026, 10, 18, 11, 36, 44, 978329321, time.UTC),
+			uncompressedSize: 629,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x92\xc1\x8f\xaa\x30\x10\xc6\xef\xfc\x15\x73\xd4\x44\x93\xf7\xce\x9e\x78\x52\xf3\x9a\x45\x30\x50\x36\x7a\x6a\xba\x74\x70\x9b\x05\x4a\xca\xd4\xb8\xff\xfd\x46\x34\xba\x42\xbc\x7e\xdf\xfc\xa6\xf3\xcd\x74\xb9\x84\xd2\xa1\x22\x04\x87\xa5\x6d\x4b\x53\x1b\x45\xc6\xb6\x40\xea\xa3\xc6\x05\xf4\x74\xf1\x6c\x05\xf4\x39\x29\x31\x3d\xf4\x64\x1d\x6a\xb0\xed\x50\xe8\xfb\x2b\x16\x04\xeb\x8c\x85\x82\x81\x08\xff\xc5\x0c\xf8\x06\x92\x54\x00\xdb\xf3\x5c\xe4\xa3\x26\xb3\x00\x00\xc0\x68\x28\x0a\x1e\xc1\x2e\xe3\xdb\x30\x3b\xc0\x1b\x3b\x0c\x48\x52\xc4\x31\x44\x6c\x13\x16\xb1\x00\xef\x8d\x96\x47\x6c\xd1\x29\x42\x79\xfa\x3b\x9b\x2f\x06\xb8\xb7\xde\x95\x28\xbd\x6b\x41\xb0\xbd\xb8\x83\x57\x97\x94\x3b\x22\xbd\x72\x8f\xce\xfa\x4e\xb6\xaa\x41\x78\x0f\xb3\xf5\xff\x30\xbb\xea\x95\xa9\x09\xdd\x40\xdc\x1e\xf1\x8d\xac\x0c\xd6\xba\xff\x25\x7e\xe1\xf7\x54\x6c\xd4\x59\x6a\x53\x55\xb2\x2b\x09\xa2\xb4\xb8\x6c\x60\x97\xb1\x35\xcf\x79\x9a\x4c\x53\xfd\x79\x0a\xd1\x39\x5b\x99\x1a\xa5\xd1\xcf\xf3\xdc\x52\xbc\xb2\x95\xd7\x86\x26\x2a\x9e\xb0\x25\x49\xa6\xc1\x9e\x54\xd3\x81\xe0\x5b\x96\x8b\x70\xbb\xbb\x4f\x31\x94\xcd\x57\xf7\x7b\xf1\x24\x62\xfb\xf1\xbd\x4a\xf9\xd8\xa1\x1c\xf5\x94\x46\x9f\x21\x4d\xc6\x3f\x63\xf6\x20\x16\xe3\x31\xe6\xab\xe0\x07\x00\x00\xff\xff\x03\x00\x46\x0b\xc8\x75\x75\x02\x00\x00"),
+		},
+		"/000008_create_schema_snapshot.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000008_create_schema_snapshot.down.sql",
+			modTime:          time.Date(2026, 10, 18, 11, 44, 14, 950960247, time.UTC),
+			uncompressedSize: 114,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x2e\x8e\x2f\x28\xca\x4f\xcb\xcc\x49\x8d\xcf\x4c\x89\xcf\x4c\xa9\xb0\xe6\xc2\xa5\xae\xb4\x28\x0f\x55\x4d\x88\xa3\x93\x8f\x2b\xb2\x9a\xe4\x8c\xd4\xdc\xc4\xf8\xe2\xbc\xc4\x82\xe2\x8c\xfc\x12\x6b\x2e\x00\x00\x00\x00\xff\xff\x03\x00\x4c\x3d\x9c\x64\x72\x00\x00\x00"),
+		},
+		"/000008_create_schema_snapshot.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000008_create_schema_snapshot.up.sql",
+			modTime:          time.Date(2026, 10, 18, 11, 44, 14, 946356068, time.UTC),
+			uncompressedSize: 428,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x84\x90\xdf\x6a\xc3\x20\x1c\x46\xef\xf3\x14\xdf\x65\x03\xe9\x13\xe4\xca\x76\x8e\xb9\xe5\x4f\x31\x6e\xa4\x57\xe2\xa2\xa5\x42\xaa\x45\xcd\xd8\xde\x7e\x90\x76\x2d\x64\x8c\xdd\x7e\xbf\xc3\x91\xe3\x7a\x8d\x21\x18\x95\x0c\xe2\x70\x34\x27\x25\xa3\x53\xe7\x78\xf4\x09\x49\xbd\x8f\xa6\xb8\xce\xf0\x87\xcb\x00\x1b\xf1\x83\x24\xa3\xe1\x1d\xcc\x87\x09\x5f\x38\x07\x7f\xb0\xa3\xc9\xb2\x2d\xa7\x44\x50\x08\xb2\xa9\x28\xd8\x23\x9a\x56\x80\xf6\xac\x13\xdd\xf2\x89\x55\x06\x00\x56\xa3\xa3\x9c\x91\x0a\x3b\xce\x6a\xc2\xf7\x78\xa1\xfb\x62\x3e\x5d\x9d\xd2\x6a\xbc\x11\xbe\x7d\x22\x7c\xb6\x35\xaf\x55\x75\x01\xa6\xe0\x20\x68\x2f\x16\xf3\xe0\xc7\xe9\xe4\x22\x9e\xbb\xb6\xd9\x2c\x6f\x73\xad\x96\x2a\x41\xb0\x9a\x76\x82\xd4\xbb\x1b\x32\x13\x79\x79\x8b\x60\xcd\x03\xed\x97\x11\x51\x4e\xc1\x49\xab\xa5\xd5\x9f\x68\x9b\x5f\x1f\xb7\x9a\x82\x2b\x60\x75\x5e\xfe\xa3\xb9\xe7\xfd\xa9\xba\x23\x79\x99\x7d\x03\x00\x00\xff\xff\x03\x00\xc1\xf0\x8e\x5a\xac\x01\x00\x00"),
+		},
+		"/000009_add_audit_result_columns.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000009_add_audit_result_columns.down.sql",
+			modTime:          time.Date(2026, 10, 18, 15, 1, 32, 916947942, time.UTC),
+			uncompressedSize: 368,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x9c\xcb\x4b\x0a\xc2\x30\x10\x06\xe0\x7d\x4f\x31\xf7\xe8\xaa\x6a\x84\x42\xb4\xd2\x46\x70\x17\xaa\xf9\x91\x81\xd0\x84\x49\x52\x1f\xa7\x77\xe5\x01\xd2\x03\x7c\x9d\x36\x6a\x24\xd3\xed\xb4\xa2\xb9\x38\xce\x56\x90\x8a\xcf\x74\x18\x87\x0b\xed\x07\x7d\x3d\x9d\xa9\x3f\x92\xba\xf5\x93\x99\xe8\x29\xa1\x44\x1b\x56\x88\xb0\x43\xdb\x54\xf2\x25\xd8\xfb\x9c\xe0\x79\xa9\xb7\x7f\x68\x13\x7f\xeb\x35\xde\x11\x8f\x0c\x67\x4b\x8c\x90\xed\xdc\x87\xd7\x06\x9e\xb0\x42\x38\x7f\xda\xe6\x07\x00\x00\xff\xff\x03\x00\xbe\xa1\x4a\xc3\x70\x01\x00\x00"),
+		},
+		"/000009_add_audit_result_columns.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000009_add_audit_result_columns.up.sql",
+			modTime:          time.Date(2026, 10, 18, 15, 1, 32, 911059752, time.UTC),
+			uncompressedSize: 902,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xac\x52\xc1\x8e\xd3\x30\x10\xbd\xe7\x2b\xde\xad\x20\xb5\x12\x9c\x7b\xca\xb6\x59\x88\x14\x12\x94\xa6\x68\x6f\x91\x37\x9e\x6e\xac\x75\x6c\x6b\x6c\xb7\x94\xaf\x47\x49\xda\xae\x56\x70\x2a\x5c\x67\xde\x9b\x79\xf3\xe6\xad\x56\x10\x52\xc2\xd3\x91\x58\x85\x33\x82\x85\x88\x52\x05\x30\xf9\xa8\xc3\x12\xd6\xe8\x33\x0e\x42\x69\x92\x97\x1a\xec\x01\xc4\x6c\xf9\x8d\x35\xf6\x11\x7a\x9a\xb9\x49\x92\x16\x4d\x56\xa3\x49\x1f\x8a\x6c\x2e\xb5\x17\x6a\xba\xdd\x62\x53\x15\xfb\x6f\x25\xf2\x47\x94\x55\x83\xec\x29\xdf\x35\xbb\xb7\x51\x3f\xd2\x7a\xf3\x35\xad\xf1\xe1\xf3\xa7\x8f\x13\xa0\xdc\x17\x05\xb6\xd9\x63\xba\x2f\x1a\x2c\xa6\xc5\x8b\x75\x92\xac\x56\xa0\x9f\x8e\xba\x40\x12\xcf\xc2\xc8\x51\x95\x30\x76\x10\xfa\x0c\x8e\x9a\x96\xe8\xac\x8e\x83\xf1\x10\x4c\x30\x51\x6b\x9c\x7a\x32\x30\xf6\x1d\x0c\xca\xcf\x0a\x49\xde\x23\xfb\x2a\xa1\xd5\xf6\x44\x8c\x6d\xb5\x1f\xb9\xdf\xeb\x6c\x93\xef\xf2\xaa\x5c\xff\xcb\xc8\xe8\xdc\x7f\x1a\xf9\x2c\x3c\x69\x65\xa8\xf5\xea\x17\x21\x2f\x9b\xec\x4b\x56\xcf\x1e\x0e\x82\x5f\xdf\x7d\x1c\xa7\xde\x7a\x82\xeb\x42\xdb\xf5\xc2\xbc\x50\x7b\xf4\xad\x63\x3a\x2a\x1b\xfd\xcd\x32\xff\xaa\x9c\x1b\x9d\xa7\x4e\x44\x4f\xe3\xef\x79\x6a\x18\x8b\x1b\xd8\xb1\x3d\x28\x4d\xf7\xf8\x6a\x6c\x7b\x15\x8d\x87\xaa\x2a\xb2\xb4\xfc\x33\x0c\x07\xa1\x3d\xcd\x67\xbc\xb0\x8d\x0e\x4e\x84\x40\x6c\xc6\x28\x8c\x61\x1c\x44\xe8\x7a\x92\x97\xa6\x3d\x12\xb3\x92\xb4\x04\x0d\x2e\x9c\xe7\x34\x8c\xb0\x60\x35\xb1\x30\x1d\x4d\xd7\xf9\x1b\x9b\x02\xab\x6e\x4a\x4f\xf4\xf7\xa5\x63\x5a\xdc\x5e\x17\xa3\xc9\x9e\x9a\xbf\x44\x7a\xb1\x4e\x7e\x03\x00\x00\xff\xff\x03\x00\x14\xe6\x6d\x18\x86\x03\x00\x00"),
+		},
+		"/000010_add_profile_kind.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000010_add_profile_kind.down.sql",
+			modTime:          time.Date(2026, 10, 18, 13, 1, 30, 702222586, time.UTC),
+			uncompressedSize: 102,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\x09\xf2\x0f\x50\xf0\xf4\x73\x71\x8d\x50\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x28\x28\x8a\x2f\x2d\xca\x8b\xcf\xce\xcc\x4b\x89\x4f\x2d\x4b\xcd\x2b\x89\x2f\xc9\xcc\x4d\x2d\x2e\x49\xcc\x2d\x88\xcf\x4c\xa9\xb0\xe6\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x28\xca\x4f\xcb\xcc\x49\x55\x00\x9b\xe4\xec\xef\x13\xea\xeb\x87\x64\x14\xc8\x0c\x6b\x2e\x00\x00\x00\x00\xff\xff\x03\x00\xeb\xdc\x1b\x16\x66\x00\x00\x00"),
+		},
+		"/000010_add_profile_kind.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000010_add_profile_kind.up.sql",
+			modTime:          time.Date(2026, 10, 18, 13, 1, 30, 700645274, time.UTC),
+			uncompressedSize: 291,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x5c\x8e\xc1\x6b\xc2\x30\x1c\x85\xef\xf9\x2b\xde\x4d\x05\x85\xc1\x8e\x9e\xb2\x36\xb2\x42\x96\x42\x4d\x87\xb7\x90\x2d\x11\xc3\xda\xa4\x24\xbf\x8e\xf9\xdf\x0f\xdd\xf4\xe0\xf9\x7d\x7c\xef\xdb\x6c\x60\x9d\xc3\x57\x88\x0e\x94\x30\xe5\x74\x0c\x83\x07\xd9\x8f\xc1\xaf\x91\xe2\x70\xc6\xe8\x29\x87\xcf\x82\x74\x44\x21\x1b\x9d\xcd\xee\xc6\x15\xd8\xec\x31\x17\xef\x60\x0b\x4e\xa1\x50\xca\xe7\x0b\x48\xa7\x7f\x07\x63\x5c\x6a\xd1\x41\xf3\x17\x29\xee\x7a\x5e\xd7\xa8\x5a\xd9\xbf\x29\x34\x3b\xa8\x56\x43\x1c\x9a\xbd\xde\xff\x75\xbc\xf3\xae\x7a\xe5\x1d\x96\xcf\x4f\xab\xeb\xa8\x7a\x29\x51\x8b\x1d\xef\xa5\xc6\xe2\x16\xb1\xd8\x32\x56\x75\x82\x6b\x81\x46\xd5\xe2\xf0\xa0\x9a\xb2\x99\x73\x34\x17\xa3\xf1\xdf\x3e\x92\xa1\x30\xfa\x42\x76\x9c\x4c\x70\x3f\x68\xd5\x3d\x67\x39\xe7\xb8\xbe\x7e\xaf\xf1\x80\xae\xb6\xec\x17\x00\x00\xff\xff\x03\x00\xa1\x2e\x68\xfe\x23\x01\x00\x00"),
+		},
+		"/000011_add_bigquery_job_location.down.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000011_add_bigquery_job_location.down.sql",
+			modTime:          time.Date(2026, 10, 18, 13, 25, 30, 474646193, time.UTC),
+			uncompressedSize: 57,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xca\x4c\x2f\x2c\x4d\x2d\xaa\x8c\xcf\xca\x4f\x52\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\xc8\xc9\x4f\x4e\x2c\xc9\xcc\xcf\xb3\xe6\x02\x00\x00\x00\xff\xff\x03\x00\xe1\x13\x4f\xbc\x39\x00\x00\x00"),
+		},
+		"/000011_add_bigquery_job_location.up.sql": &vfsgen۰CompressedFileInfo{
+			name:             "000011_add_bigquery_job_location.up.sql",
+			modTime:          time.Date(2026, 10, 18, 13, 25, 30, 474286553, time.UTC),
+			uncompressedSize: 186,
+
+			compressedContent: []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x44\xcd\xb1\xaa\x83\x30\x18\xc5\xf1\xdd\xa7\x38\x9b\xcb\xf5\x09\xee\x94\xd6\x08\x42\xaa\x50\x3f\xc1\xad\x24\x26\xb6\x29\x92\x8f\xd6\x64\xf0\xed\x8b\x1d\xd2\xf1\x70\xfe\xf0\xab\x2a\x18\x7f\x7f\x25\xf7\xde\xf1\x64\x83\x95\x67\x1d\x3d\x87\xbf\x63\x6d\xe0\x14\x37\x6f\x1d\x78\x41\x7c\x38\x58\xb7\xe8\xb4\xc6\x5c\x61\xd6\x01\x1c\xd6\x1d\xc6\x61\xe1\x14\x2c\xcc\x0e\x6f\xa1\x83\xcd\x51\x51\x08\x45\xf2\x0a\x12\x27\x25\xb3\x76\x3b\x34\x51\xd7\x38\xf7\x6a\xbc\x74\x68\x1b\x74\x3d\x41\x4e\xed\x40\xc3\x0f\x20\x39\xd1\xf7\xe8\x46\xa5\x50\xcb\x46\x8c\x8a\x50\x96\xff\xc5\x07\x00\x00\xff\xff\x03\x00\x3f\x2a\x14\x01\xba\x00\x00\x00"),
+		},
 	}
 	fs["/"].(*vfsgen۰DirInfo).entries = []os.FileInfo{
 		fs["/000001_create_predator_tables.down.sql"].(os.FileInfo),
 		fs["/000001_create_predator_tables.up.sql"].(os.FileInfo),
+		fs["/000002_create_job_queue.down.sql"].(os.FileInfo),
+		fs["/000002_create_job_queue.up.sql"].(os.FileInfo),
+		fs["/000003_add_entity_max_bytes_billed.down.sql"].(os.FileInfo),
+		fs["/000003_add_entity_max_bytes_billed.up.sql"].(os.FileInfo),
+		fs["/000004_create_schedule_run.down.sql"].(os.FileInfo),
+		fs["/000004_create_schedule_run.up.sql"].(os.FileInfo),
+		fs["/000005_add_audit_urn.down.sql"].(os.FileInfo),
+		fs["/000005_add_audit_urn.up.sql"].(os.FileInfo),
+		fs["/000006_add_metric_series_index.down.sql"].(os.FileInfo),
+		fs["/000006_add_metric_series_index.up.sql"].(os.FileInfo),
+		fs["/000007_create_reconciliation.down.sql"].(os.FileInfo),
+		fs["/000007_create_reconciliation.up.sql"].(os.FileInfo),
+		fs["/000008_create_schema_snapshot.down.sql"].(os.FileInfo),
+		fs["/000008_create_schema_snapshot.up.sql"].(os.FileInfo),
+		fs["/000009_add_audit_result_columns.down.sql"].(os.FileInfo),
+		fs["/000009_add_audit_result_columns.up.sql"].(os.FileInfo),
+		fs["/000010_add_profile_kind.down.sql"].(os.FileInfo),
+		fs["/000010_add_profile_kind.up.sql"].(os.FileInfo),
+		fs["/000011_add_bigquery_job_location.down.sql"].(os.FileInfo),
+		fs["/000011_add_bigquery_job_location.up.sql"].(os.FileInfo),
 	}
 
 	return fs
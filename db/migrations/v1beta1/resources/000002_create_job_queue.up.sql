@@ -0,0 +1,16 @@
+-- create job queue table of profile and reconciliation jobs
+
+CREATE TABLE IF NOT EXISTS job_queue(
+    id SERIAL PRIMARY KEY,
+    job_id UUID NOT NULL,
+    job_type VARCHAR (30) NOT NULL,
+    status VARCHAR (30) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    lease_owner VARCHAR,
+    lease_expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+    );
+
+CREATE UNIQUE INDEX jq_job_idx ON job_queue (job_type, job_id);
+CREATE INDEX jq_status_idx ON job_queue (status, lease_expires_at);
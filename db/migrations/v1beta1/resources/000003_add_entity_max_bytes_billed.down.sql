@@ -0,0 +1 @@
+ALTER TABLE entity DROP COLUMN IF EXISTS max_bytes_billed;
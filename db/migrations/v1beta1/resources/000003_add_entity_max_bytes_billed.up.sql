@@ -0,0 +1 @@
+ALTER TABLE entity ADD COLUMN IF NOT EXISTS max_bytes_billed BIGINT NOT NULL DEFAULT 0;
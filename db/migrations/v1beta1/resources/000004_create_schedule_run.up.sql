@@ -0,0 +1,17 @@
+-- create schedule run table
+
+CREATE TABLE IF NOT EXISTS schedule_run(
+    id SERIAL PRIMARY KEY,
+    urn VARCHAR NOT NULL,
+    scheduled_time TIMESTAMP NOT NULL,
+    status VARCHAR (30) NOT NULL,
+    message VARCHAR,
+    profile_id VARCHAR,
+    audit_id VARCHAR,
+    audit BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+    );
+
+CREATE UNIQUE INDEX sr_urn_scheduled_time_idx ON schedule_run (urn, scheduled_time);
+CREATE INDEX sr_status_idx ON schedule_run (status);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ar_audit_id_pass_flag_idx;
+DROP INDEX IF EXISTS au_urn_event_timestamp_idx;
+ALTER TABLE audit DROP COLUMN IF EXISTS urn;
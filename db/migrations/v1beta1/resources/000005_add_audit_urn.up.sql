@@ -0,0 +1,8 @@
+-- add urn to audit table to lookup audit history of a table
+
+ALTER TABLE audit ADD COLUMN IF NOT EXISTS urn VARCHAR;
+
+UPDATE audit SET urn = profile.urn FROM profile WHERE audit.profile_id = profile.id AND audit.urn IS NULL;
+
+CREATE INDEX IF NOT EXISTS au_urn_event_timestamp_idx ON audit (urn, event_timestamp);
+CREATE INDEX IF NOT EXISTS ar_audit_id_pass_flag_idx ON audit_result (audit_id, pass_flag);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS m_profile_id_metric_name_idx;
+DROP INDEX IF EXISTS p_urn_event_timestamp_idx;
@@ -0,0 +1,4 @@
+-- index to query metric time series of a table
+
+CREATE INDEX IF NOT EXISTS p_urn_event_timestamp_idx ON profile (urn, event_timestamp);
+CREATE INDEX IF NOT EXISTS m_profile_id_metric_name_idx ON metric (profile_id, metric_name);
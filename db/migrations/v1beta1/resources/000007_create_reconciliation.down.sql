@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS rc_target_urn_event_timestamp_idx;
+DROP TABLE IF EXISTS reconciliation;
@@ -0,0 +1,18 @@
+-- create reconciliation table, state of the reconciliation is stored on status table
+
+CREATE TABLE IF NOT EXISTS reconciliation(
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    source_urn TEXT NOT NULL,
+    target_urn TEXT NOT NULL,
+    group_name VARCHAR,
+    filter TEXT,
+    sum_fields TEXT,
+    key_fields TEXT,
+    max_diff_pct DOUBLE PRECISION NOT NULL DEFAULT 0,
+    source_profile_id VARCHAR,
+    target_profile_id VARCHAR,
+    audit_id VARCHAR,
+    event_timestamp TIMESTAMP NOT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS rc_target_urn_event_timestamp_idx ON reconciliation (target_urn, event_timestamp);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ss_profile_id_idx;
+DROP INDEX IF EXISTS ss_urn_id_idx;
+DROP TABLE IF EXISTS schema_snapshot;
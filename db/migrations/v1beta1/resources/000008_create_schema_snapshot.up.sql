@@ -0,0 +1,12 @@
+-- create schema_snapshot table, schema of table is snapshotted on every profile
+
+CREATE TABLE IF NOT EXISTS schema_snapshot(
+    id SERIAL PRIMARY KEY,
+    profile_id VARCHAR NOT NULL,
+    urn TEXT NOT NULL,
+    columns JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS ss_urn_id_idx ON schema_snapshot (urn, id);
+CREATE INDEX IF NOT EXISTS ss_profile_id_idx ON schema_snapshot (profile_id);
@@ -0,0 +1,6 @@
+ALTER TABLE audit_result DROP COLUMN IF EXISTS group_override;
+ALTER TABLE audit_result DROP COLUMN IF EXISTS no_baseline;
+ALTER TABLE audit_result DROP COLUMN IF EXISTS baseline_size;
+ALTER TABLE audit_result DROP COLUMN IF EXISTS expected_upper;
+ALTER TABLE audit_result DROP COLUMN IF EXISTS expected_lower;
+ALTER TABLE audit_result DROP COLUMN IF EXISTS severity;
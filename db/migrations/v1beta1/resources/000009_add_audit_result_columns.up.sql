@@ -0,0 +1,17 @@
+-- add severity to audit result, only failed result of error severity fail the audit
+
+ALTER TABLE audit_result ADD COLUMN IF NOT EXISTS severity VARCHAR (10) NOT NULL DEFAULT 'error';
+
+-- expected band of anomaly rule, columns are null when no anomaly rule is audited
+
+ALTER TABLE audit_result ADD COLUMN IF NOT EXISTS expected_lower DOUBLE PRECISION;
+ALTER TABLE audit_result ADD COLUMN IF NOT EXISTS expected_upper DOUBLE PRECISION;
+ALTER TABLE audit_result ADD COLUMN IF NOT EXISTS baseline_size INTEGER;
+
+-- mark audit result whose pct_change_vs_previous rule is skipped because there is no previous profile
+
+ALTER TABLE audit_result ADD COLUMN IF NOT EXISTS no_baseline BOOLEAN NOT NULL DEFAULT false;
+
+-- group pattern of the matched group override, empty when the tolerance rules of the metric are used
+
+ALTER TABLE audit_result ADD COLUMN IF NOT EXISTS group_override TEXT NOT NULL DEFAULT '';
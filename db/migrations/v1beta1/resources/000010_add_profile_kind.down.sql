@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS pr_urn_kind_event_timestamp_idx;
+ALTER TABLE profile DROP COLUMN IF EXISTS kind;
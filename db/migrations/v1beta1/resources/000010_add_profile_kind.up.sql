@@ -0,0 +1,5 @@
+-- add kind to profile table, only metrics of standard profiles are used as history of the table
+
+ALTER TABLE profile ADD COLUMN IF NOT EXISTS kind VARCHAR (30) NOT NULL DEFAULT 'standard';
+
+CREATE INDEX IF NOT EXISTS pr_urn_kind_event_timestamp_idx ON profile (urn, kind, event_timestamp);
@@ -0,0 +1 @@
+ALTER TABLE bigquery_job DROP COLUMN IF EXISTS location;
@@ -0,0 +1,3 @@
+-- bigquery job location, jobs outside of the default location can only be found by id and location
+
+ALTER TABLE bigquery_job ADD COLUMN IF NOT EXISTS location TEXT NOT NULL DEFAULT '';
@@ -8,13 +8,14 @@ import (
 )
 
 type entityRecord struct {
-	ID            string
-	Name          string
-	Environment   string
-	GitURL        string
-	GcpProjectIDs string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID             string
+	Name           string
+	Environment    string
+	GitURL         string
+	GcpProjectIDs  string
+	MaxBytesBilled int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 const projectIdsSeparator = ","
@@ -22,13 +23,14 @@ const projectIdsSeparator = ","
 func newRecord(entity *protocol.Entity) *entityRecord {
 	projectIds := strings.Join(entity.GcpProjectIDs, projectIdsSeparator)
 	return &entityRecord{
-		ID:            entity.ID,
-		Name:          entity.Name,
-		Environment:   entity.Environment,
-		GitURL:        entity.GitURL,
-		GcpProjectIDs: projectIds,
-		CreatedAt:     entity.CreatedAt,
-		UpdatedAt:     entity.UpdatedAt,
+		ID:             entity.ID,
+		Name:           entity.Name,
+		Environment:    entity.Environment,
+		GitURL:         entity.GitURL,
+		GcpProjectIDs:  projectIds,
+		MaxBytesBilled: entity.MaxBytesBilled,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
 	}
 }
 
@@ -40,13 +42,14 @@ func (e *entityRecord) toEntity() *protocol.Entity {
 	}
 
 	return &protocol.Entity{
-		ID:            e.ID,
-		Name:          e.Name,
-		Environment:   e.Environment,
-		GitURL:        e.GitURL,
-		GcpProjectIDs: projectIDs,
-		CreatedAt:     e.CreatedAt,
-		UpdatedAt:     e.UpdatedAt,
+		ID:             e.ID,
+		Name:           e.Name,
+		Environment:    e.Environment,
+		GitURL:         e.GitURL,
+		GcpProjectIDs:  projectIDs,
+		MaxBytesBilled: e.MaxBytesBilled,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
 	}
 }
 
@@ -43,6 +43,7 @@ func newFieldCache(fs *meta.FieldSpec) *FieldCache {
 }
 
 type TableCache struct {
+	Warehouse              meta.Warehouse
 	ProjectName            string
 	DatasetName            string
 	TableName              string
@@ -53,6 +54,8 @@ type TableCache struct {
 
 	Labels map[string]string
 	Fields []*FieldCache
+
+	LastModifiedTime time.Time
 }
 
 func newTableCache(tableSpec *meta.TableSpec) *TableCache {
@@ -65,6 +68,7 @@ func newTableCache(tableSpec *meta.TableSpec) *TableCache {
 	}
 
 	return &TableCache{
+		Warehouse:              tableSpec.Warehouse,
 		ProjectName:            tableSpec.ProjectName,
 		DatasetName:            tableSpec.DatasetName,
 		TableName:              tableSpec.TableName,
@@ -73,6 +77,7 @@ func newTableCache(tableSpec *meta.TableSpec) *TableCache {
 		TimePartitioningType:   tableSpec.TimePartitioningType,
 		Labels:                 tableSpec.Labels,
 		Fields:                 fs,
+		LastModifiedTime:       tableSpec.LastModifiedTime,
 	}
 }
 
@@ -136,6 +141,7 @@ func (s *TableCache) toTableSpec() *meta.TableSpec {
 	}
 
 	return &meta.TableSpec{
+		Warehouse:              s.Warehouse,
 		ProjectName:            s.ProjectName,
 		DatasetName:            s.DatasetName,
 		TableName:              s.TableName,
@@ -144,6 +150,7 @@ func (s *TableCache) toTableSpec() *meta.TableSpec {
 		TimePartitioningType:   s.TimePartitioningType,
 		Labels:                 s.Labels,
 		Fields:                 nestedFS,
+		LastModifiedTime:       s.LastModifiedTime.UTC(),
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/odpf/predator/protocol/meta"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestCache(t *testing.T) {
@@ -57,6 +58,8 @@ func TestCache(t *testing.T) {
 					DatasetName: "sample_dataset",
 					TableName:   "sample_table",
 					Fields:      []*meta.FieldSpec{field1, field2},
+
+					LastModifiedTime: time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC),
 				}
 
 				source := predatormock.NewMetadataStore()
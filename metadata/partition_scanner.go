@@ -0,0 +1,63 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/util"
+	"google.golang.org/api/iterator"
+)
+
+const partitionIDColumn = "partition_id"
+
+//PartitionScanner get partitions of a bigquery table using INFORMATION_SCHEMA.PARTITIONS view
+type PartitionScanner struct {
+	bqClient bqiface.Client
+}
+
+//NewPartitionScanner create PartitionScanner
+func NewPartitionScanner(bqClient bqiface.Client) *PartitionScanner {
+	return &PartitionScanner{bqClient: bqClient}
+}
+
+//GetAffectedPartition get IDs of partitions that modified after the last modified timestamp
+func (p *PartitionScanner) GetAffectedPartition(tableURN string, lastModifiedTimestamp time.Time) ([]string, error) {
+	label, err := protocol.ParseLabel(tableURN)
+	if err != nil {
+		return nil, err
+	}
+
+	informationSchema := fmt.Sprintf("`%s.%s.INFORMATION_SCHEMA.PARTITIONS`", label.Project, label.Dataset)
+	sql := fmt.Sprintf("SELECT %s FROM %s WHERE table_name = %s AND last_modified_time > TIMESTAMP(%s) ORDER BY %s",
+		partitionIDColumn, informationSchema, util.DoubleQuote(label.Table),
+		util.DoubleQuote(lastModifiedTimestamp.UTC().Format(time.RFC3339Nano)), partitionIDColumn)
+
+	it, err := p.bqClient.Query(sql).Read(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	var partitionIDs []string
+	for {
+		var row map[string]bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		partitionID, ok := row[partitionIDColumn].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %s value of table %s", partitionIDColumn, tableURN)
+		}
+		partitionIDs = append(partitionIDs, partitionID)
+	}
+
+	return partitionIDs, nil
+}
@@ -0,0 +1,64 @@
+package metadata_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/odpf/predator/metadata"
+	"github.com/odpf/predator/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionScanner(t *testing.T) {
+	t.Run("GetAffectedPartition", func(t *testing.T) {
+		lastModified := time.Date(2021, 1, 2, 10, 0, 0, 0, time.UTC)
+		sql := "SELECT partition_id FROM `project.dataset.INFORMATION_SCHEMA.PARTITIONS` WHERE table_name = \"table\" " +
+			"AND last_modified_time > TIMESTAMP(\"2021-01-02T10:00:00Z\") ORDER BY partition_id"
+
+		t.Run("should return partitions modified after the timestamp", func(t *testing.T) {
+			rows := []*map[string]bigquery.Value{
+				{"partition_id": "20210102"},
+				{"partition_id": "20210103"},
+			}
+
+			query := &mock.QueryMock{}
+			query.On("Read", context.Background()).Return(mock.NewIteratorStub(rows), nil)
+			defer query.AssertExpectations(t)
+
+			client := &mock.BQClientMock{}
+			client.On("Query", sql).Return(query)
+			defer client.AssertExpectations(t)
+
+			scanner := metadata.NewPartitionScanner(client)
+			partitions, err := scanner.GetAffectedPartition("project.dataset.table", lastModified)
+
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"20210102", "20210103"}, partitions)
+		})
+		t.Run("should return error when query failed", func(t *testing.T) {
+			query := &mock.QueryMock{}
+			query.On("Read", context.Background()).Return(mock.NewIteratorStub(nil), errors.New("query error"))
+			defer query.AssertExpectations(t)
+
+			client := &mock.BQClientMock{}
+			client.On("Query", sql).Return(query)
+			defer client.AssertExpectations(t)
+
+			scanner := metadata.NewPartitionScanner(client)
+			partitions, err := scanner.GetAffectedPartition("project.dataset.table", lastModified)
+
+			assert.Error(t, err)
+			assert.Nil(t, partitions)
+		})
+		t.Run("should return error when urn is invalid", func(t *testing.T) {
+			scanner := metadata.NewPartitionScanner(&mock.BQClientMock{})
+			partitions, err := scanner.GetAffectedPartition("table", lastModified)
+
+			assert.Error(t, err)
+			assert.Nil(t, partitions)
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package metadata
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+)
+
+const postgresColumnsQuery = "SELECT column_name, data_type, udt_name, is_nullable FROM information_schema.columns " +
+	"WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position"
+
+//PostgresStore store to get metadata of postgresql table
+type PostgresStore struct {
+	db              *sql.DB
+	database        string
+	constraintStore protocol.ConstraintStore
+}
+
+//NewPostgresStore is constructor of PostgresStore, database is name of the database db is connected to
+func NewPostgresStore(db *sql.DB, database string, constraintStore protocol.ConstraintStore) *PostgresStore {
+	return &PostgresStore{db: db, database: database, constraintStore: constraintStore}
+}
+
+//GetMetadata get metadata of table from information_schema, postgres table is not partitioned
+//table of other database than the connected database is rejected
+func (p *PostgresStore) GetMetadata(tableID string) (*meta.TableSpec, error) {
+	databaseName, schemaName, tableName, err := meta.ParsePostgresTableID(tableID)
+	if err != nil {
+		return nil, err
+	}
+	if err := protocol.CheckPostgresDatabase(tableID, p.database); err != nil {
+		return nil, err
+	}
+
+	fields, err := p.getFields(schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return nil, protocol.ErrTableMetadataNotFound
+	}
+
+	return &meta.TableSpec{
+		Warehouse:   meta.WarehousePostgres,
+		ProjectName: databaseName,
+		DatasetName: schemaName,
+		TableName:   tableName,
+		Fields:      fields,
+	}, nil
+}
+
+//GetUniqueConstraints to fetch unique constraints
+func (p *PostgresStore) GetUniqueConstraints(tableID string) ([]string, error) {
+	return p.constraintStore.FetchConstraints(tableID)
+}
+
+func (p *PostgresStore) getFields(schemaName, tableName string) ([]*meta.FieldSpec, error) {
+	rows, err := p.db.Query(postgresColumnsQuery, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fieldSpecs []*meta.FieldSpec
+	for rows.Next() {
+		var columnName, dataType, udtName, isNullable string
+		if err := rows.Scan(&columnName, &dataType, &udtName, &isNullable); err != nil {
+			return nil, err
+		}
+
+		fieldSpec := &meta.FieldSpec{
+			Name:      columnName,
+			FieldType: postgresFieldType(strings.TrimPrefix(udtName, "_")),
+			Mode:      postgresFieldMode(dataType, isNullable),
+			Level:     meta.RootLevel,
+		}
+		fieldSpecs = append(fieldSpecs, fieldSpec)
+	}
+
+	return fieldSpecs, rows.Err()
+}
+
+func postgresFieldMode(dataType string, isNullable string) meta.Mode {
+	if dataType == "ARRAY" {
+		return meta.ModeRepeated
+	}
+	if isNullable == "NO" {
+		return meta.ModeRequired
+	}
+	return meta.ModeNullable
+}
+
+//postgresFieldType map postgres udt_name to the field type, array element type is used for array column
+func postgresFieldType(udtName string) meta.FieldType {
+	switch udtName {
+	case "int2", "int4", "int8":
+		return meta.FieldTypeInteger
+	case "float4", "float8":
+		return meta.FieldTypeFloat
+	case "numeric":
+		return meta.FieldTypeNumeric
+	case "bool":
+		return meta.FieldTypeBoolean
+	case "bytea":
+		return meta.FieldTypeBytes
+	case "date":
+		return meta.FieldTypeDate
+	case "time", "timetz":
+		return meta.FieldTypeTime
+	case "timestamp":
+		return meta.FieldTypeDateTime
+	case "timestamptz":
+		return meta.FieldTypeTimestamp
+	default:
+		return meta.FieldTypeString
+	}
+}
@@ -0,0 +1,135 @@
+package metadata_test
+
+import (
+	"database/sql"
+	"testing"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/odpf/predator/metadata"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+//newInformationSchemaDB create sqlite database that mimic columns view of postgres information_schema
+func newInformationSchemaDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	assert.Nil(t, err)
+	db.SetMaxOpenConns(1)
+
+	statements := []string{
+		"ATTACH DATABASE ':memory:' AS information_schema",
+		"CREATE TABLE information_schema.columns (table_schema TEXT, table_name TEXT, column_name TEXT, " +
+			"data_type TEXT, udt_name TEXT, is_nullable TEXT, ordinal_position INTEGER)",
+		"INSERT INTO information_schema.columns VALUES " +
+			"('public', 'orders', 'id', 'bigint', 'int8', 'NO', 1), " +
+			"('public', 'orders', 'amount', 'numeric', 'numeric', 'YES', 3), " +
+			"('public', 'orders', 'created_at', 'timestamp with time zone', 'timestamptz', 'YES', 2), " +
+			"('public', 'orders', 'tags', 'ARRAY', '_text', 'YES', 4), " +
+			"('sales', 'orders', 'id', 'integer', 'int4', 'NO', 1)",
+	}
+	for _, statement := range statements {
+		_, err := db.Exec(statement)
+		assert.Nil(t, err)
+	}
+	return db
+}
+
+func TestPostgresStore(t *testing.T) {
+	t.Run("GetMetadata", func(t *testing.T) {
+		t.Run("should return metadata from information schema", func(t *testing.T) {
+			db := newInformationSchemaDB(t)
+			defer db.Close()
+
+			store := metadata.NewPostgresStore(db, "mart", nil)
+			tableSpec, err := store.GetMetadata("postgres:mart.public.orders")
+
+			expected := &meta.TableSpec{
+				Warehouse:   meta.WarehousePostgres,
+				ProjectName: "mart",
+				DatasetName: "public",
+				TableName:   "orders",
+				Fields: []*meta.FieldSpec{
+					{
+						Name:      "id",
+						FieldType: meta.FieldTypeInteger,
+						Mode:      meta.ModeRequired,
+						Level:     meta.RootLevel,
+					},
+					{
+						Name:      "created_at",
+						FieldType: meta.FieldTypeTimestamp,
+						Mode:      meta.ModeNullable,
+						Level:     meta.RootLevel,
+					},
+					{
+						Name:      "amount",
+						FieldType: meta.FieldTypeNumeric,
+						Mode:      meta.ModeNullable,
+						Level:     meta.RootLevel,
+					},
+					{
+						Name:      "tags",
+						FieldType: meta.FieldTypeString,
+						Mode:      meta.ModeRepeated,
+						Level:     meta.RootLevel,
+					},
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, tableSpec)
+			assert.Equal(t, "postgres:mart.public.orders", tableSpec.TableID())
+		})
+		t.Run("should return ErrTableMetadataNotFound when table has no column", func(t *testing.T) {
+			db := newInformationSchemaDB(t)
+			defer db.Close()
+
+			store := metadata.NewPostgresStore(db, "mart", nil)
+			tableSpec, err := store.GetMetadata("postgres:mart.public.customers")
+
+			assert.Nil(t, tableSpec)
+			assert.Equal(t, protocol.ErrTableMetadataNotFound, err)
+		})
+		t.Run("should return ErrWarehouseNotConfigured when table is on other database", func(t *testing.T) {
+			db := newInformationSchemaDB(t)
+			defer db.Close()
+
+			store := metadata.NewPostgresStore(db, "mart", nil)
+			tableSpec, err := store.GetMetadata("postgres:staging.public.orders")
+
+			assert.Nil(t, tableSpec)
+			assert.ErrorIs(t, err, protocol.ErrWarehouseNotConfigured)
+		})
+		t.Run("should return error when urn is not postgres table", func(t *testing.T) {
+			store := metadata.NewPostgresStore(nil, "mart", nil)
+			tableSpec, err := store.GetMetadata("project.dataset.table")
+
+			assert.Nil(t, tableSpec)
+			assert.Error(t, err)
+		})
+	})
+}
+
+func TestWarehouseStore(t *testing.T) {
+	t.Run("GetMetadata", func(t *testing.T) {
+		t.Run("should get metadata from store of the urn warehouse", func(t *testing.T) {
+			db := newInformationSchemaDB(t)
+			defer db.Close()
+
+			store := metadata.NewWarehouseStore(map[meta.Warehouse]protocol.MetadataStore{
+				meta.WarehousePostgres: metadata.NewPostgresStore(db, "mart", nil),
+			})
+			tableSpec, err := store.GetMetadata("postgres:mart.sales.orders")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "postgres:mart.sales.orders", tableSpec.TableID())
+		})
+		t.Run("should return ErrWarehouseNotConfigured when warehouse has no store", func(t *testing.T) {
+			store := metadata.NewWarehouseStore(map[meta.Warehouse]protocol.MetadataStore{})
+			tableSpec, err := store.GetMetadata("project.dataset.table")
+
+			assert.Nil(t, tableSpec)
+			assert.ErrorIs(t, err, protocol.ErrWarehouseNotConfigured)
+		})
+	})
+}
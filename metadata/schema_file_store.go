@@ -0,0 +1,56 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+	"gopkg.in/yaml.v2"
+)
+
+//SchemaFileStore store to get table metadata from cached schema, such as schema used to lint spec without warehouse access
+//the schema is yaml or json keyed by table urn, each table is a list of flattened columns with name, type and mode
+type SchemaFileStore struct {
+	schemas map[string][]*meta.ColumnSchema
+}
+
+//NewSchemaFileStore create SchemaFileStore of the schema file content
+func NewSchemaFileStore(content []byte) (*SchemaFileStore, error) {
+	var schemas map[string][]*meta.ColumnSchema
+	if err := yaml.UnmarshalStrict(content, &schemas); err != nil {
+		return nil, fmt.Errorf("failed to read schema file ,%w", err)
+	}
+	return &SchemaFileStore{schemas: schemas}, nil
+}
+
+//GetMetadata get table metadata of the cached schema, protocol.ErrTableMetadataNotFound is returned when the table is not cached
+func (s *SchemaFileStore) GetMetadata(tableID string) (*meta.TableSpec, error) {
+	columns, ok := s.schemas[tableID]
+	if !ok {
+		return nil, protocol.ErrTableMetadataNotFound
+	}
+
+	urnsSegments := strings.Split(meta.TrimWarehouseScheme(tableID), ".")
+	if len(urnsSegments) != 3 {
+		return nil, fmt.Errorf("wrong format of urn %s. expected ${project-id}.${dataset}.${table_name}", tableID)
+	}
+
+	fields, err := meta.FieldsOf(columns)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cached schema of %s ,%w", tableID, err)
+	}
+
+	return &meta.TableSpec{
+		Warehouse:   meta.ParseWarehouse(tableID),
+		ProjectName: urnsSegments[0],
+		DatasetName: urnsSegments[1],
+		TableName:   urnsSegments[2],
+		Fields:      fields,
+	}, nil
+}
+
+//GetUniqueConstraints unique constraints are not cached, the constraints configured on the spec are used
+func (s *SchemaFileStore) GetUniqueConstraints(tableID string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,69 @@
+package metadata_test
+
+import (
+	"testing"
+
+	"github.com/odpf/predator/metadata"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaFileStore(t *testing.T) {
+	content := `project.dataset.orders:
+- name: id
+  type: INTEGER
+  mode: REQUIRED
+- name: items
+  type: RECORD
+  mode: REPEATED
+- name: items.quantity
+  type: INTEGER
+postgres:mart.public.orders:
+- name: id
+  type: INTEGER
+  mode: REQUIRED`
+
+	t.Run("GetMetadata", func(t *testing.T) {
+		t.Run("should return metadata of cached schema", func(t *testing.T) {
+			store, err := metadata.NewSchemaFileStore([]byte(content))
+			assert.Nil(t, err)
+
+			tableSpec, err := store.GetMetadata("project.dataset.orders")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "project.dataset.orders", tableSpec.TableID())
+			assert.Equal(t, []*meta.ColumnSchema{
+				{Name: "id", Type: meta.FieldTypeInteger, Mode: meta.ModeRequired},
+				{Name: "items", Type: meta.FieldTypeRecord, Mode: meta.ModeRepeated},
+				{Name: "items.quantity", Type: meta.FieldTypeInteger, Mode: meta.ModeNullable},
+			}, meta.SchemaOf(tableSpec))
+		})
+		t.Run("should return metadata of postgres table", func(t *testing.T) {
+			store, err := metadata.NewSchemaFileStore([]byte(content))
+			assert.Nil(t, err)
+
+			tableSpec, err := store.GetMetadata("postgres:mart.public.orders")
+
+			assert.Nil(t, err)
+			assert.Equal(t, meta.WarehousePostgres, tableSpec.Warehouse)
+			assert.Equal(t, "postgres:mart.public.orders", tableSpec.TableID())
+		})
+		t.Run("should return ErrTableMetadataNotFound when table is not cached", func(t *testing.T) {
+			store, err := metadata.NewSchemaFileStore([]byte(content))
+			assert.Nil(t, err)
+
+			tableSpec, err := store.GetMetadata("project.dataset.payments")
+
+			assert.Nil(t, tableSpec)
+			assert.Equal(t, protocol.ErrTableMetadataNotFound, err)
+		})
+	})
+	t.Run("NewSchemaFileStore", func(t *testing.T) {
+		t.Run("should return error when schema file is invalid", func(t *testing.T) {
+			_, err := metadata.NewSchemaFileStore([]byte(`project.dataset.orders: [{name: id, kind: INTEGER}]`))
+
+			assert.NotNil(t, err)
+		})
+	})
+}
@@ -0,0 +1,94 @@
+package metadata
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+	"gorm.io/datatypes"
+)
+
+type schemaSnapshotRecord struct {
+	ID        int
+	ProfileID string
+	URN       string
+	Columns   datatypes.JSON
+	CreatedAt time.Time
+}
+
+func newSchemaSnapshotRecord(snapshot *protocol.SchemaSnapshot) (*schemaSnapshotRecord, error) {
+	columns, err := json.Marshal(snapshot.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemaSnapshotRecord{
+		ProfileID: snapshot.ProfileID,
+		URN:       snapshot.URN,
+		Columns:   columns,
+		CreatedAt: snapshot.CreatedAt,
+	}, nil
+}
+
+func (s *schemaSnapshotRecord) toSchemaSnapshot() (*protocol.SchemaSnapshot, error) {
+	var columns []*meta.ColumnSchema
+	if err := json.Unmarshal(s.Columns, &columns); err != nil {
+		return nil, err
+	}
+
+	return &protocol.SchemaSnapshot{
+		ID:        s.ID,
+		ProfileID: s.ProfileID,
+		URN:       s.URN,
+		Columns:   columns,
+		CreatedAt: s.CreatedAt.In(time.UTC),
+	}, nil
+}
+
+//SchemaStore is postgres backed store of table schema snapshot
+type SchemaStore struct {
+	db *gorm.DB
+}
+
+//NewSchemaStore create SchemaStore
+func NewSchemaStore(db *gorm.DB, tableName string) *SchemaStore {
+	return &SchemaStore{db: db.Table(tableName)}
+}
+
+//Store store schema snapshot
+func (s *SchemaStore) Store(snapshot *protocol.SchemaSnapshot) error {
+	record, err := newSchemaSnapshotRecord(snapshot)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.Create(record).Error; err != nil {
+		return err
+	}
+
+	snapshot.ID = record.ID
+	return nil
+}
+
+//GetByProfileID get the latest schema snapshot taken by the profile
+func (s *SchemaStore) GetByProfileID(profileID string) (*protocol.SchemaSnapshot, error) {
+	return s.getFirst(s.db.Where("profile_id = ?", profileID))
+}
+
+//GetPrevious get the latest snapshot of the same urn taken by other profile before the snapshot
+func (s *SchemaStore) GetPrevious(snapshot *protocol.SchemaSnapshot) (*protocol.SchemaSnapshot, error) {
+	return s.getFirst(s.db.Where("urn = ? AND profile_id <> ? AND id < ?", snapshot.URN, snapshot.ProfileID, snapshot.ID))
+}
+
+func (s *SchemaStore) getFirst(query *gorm.DB) (*protocol.SchemaSnapshot, error) {
+	var records []*schemaSnapshotRecord
+	if err := query.Order("id DESC").Limit(1).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, protocol.ErrSchemaSnapshotNotFound
+	}
+	return records[0].toSchemaSnapshot()
+}
@@ -0,0 +1,83 @@
+package metadata
+
+import (
+	"testing"
+	"time"
+
+	pmock "github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaStore(t *testing.T) {
+	tableName := "schema_snapshot_records"
+	urn := "project.dataset.table"
+	createdAt := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	newSnapshot := func(profileID string, columns ...*meta.ColumnSchema) *protocol.SchemaSnapshot {
+		return &protocol.SchemaSnapshot{ProfileID: profileID, URN: urn, Columns: columns, CreatedAt: createdAt}
+	}
+	idColumn := &meta.ColumnSchema{Name: "id", Type: meta.FieldTypeInteger, Mode: meta.ModeRequired}
+	tagsColumn := &meta.ColumnSchema{Name: "tags", Type: meta.FieldTypeString, Mode: meta.ModeRepeated}
+
+	t.Run("GetByProfileID", func(t *testing.T) {
+		t.Run("should return stored snapshot of the profile", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(schemaSnapshotRecord))
+			defer clearDb()
+
+			store := NewSchemaStore(db, tableName)
+			snapshot := newSnapshot("profile-1", idColumn, tagsColumn)
+			err := store.Store(snapshot)
+			assert.Nil(t, err)
+
+			result, err := store.GetByProfileID("profile-1")
+
+			assert.Nil(t, err)
+			assert.NotZero(t, snapshot.ID)
+			assert.Equal(t, snapshot, result)
+		})
+		t.Run("should return ErrSchemaSnapshotNotFound when profile has no snapshot", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(schemaSnapshotRecord))
+			defer clearDb()
+
+			store := NewSchemaStore(db, tableName)
+			result, err := store.GetByProfileID("profile-1")
+
+			assert.Nil(t, result)
+			assert.Equal(t, protocol.ErrSchemaSnapshotNotFound, err)
+		})
+	})
+	t.Run("GetPrevious", func(t *testing.T) {
+		t.Run("should return the latest snapshot of the same urn taken before", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(schemaSnapshotRecord))
+			defer clearDb()
+
+			store := NewSchemaStore(db, tableName)
+			first := newSnapshot("profile-1", idColumn)
+			second := newSnapshot("profile-2", idColumn, tagsColumn)
+			other := &protocol.SchemaSnapshot{ProfileID: "profile-3", URN: "project.dataset.other", Columns: []*meta.ColumnSchema{idColumn}, CreatedAt: createdAt}
+			current := newSnapshot("profile-4", tagsColumn)
+			for _, snapshot := range []*protocol.SchemaSnapshot{first, second, other, current} {
+				assert.Nil(t, store.Store(snapshot))
+			}
+
+			result, err := store.GetPrevious(current)
+
+			assert.Nil(t, err)
+			assert.Equal(t, second, result)
+		})
+		t.Run("should return ErrSchemaSnapshotNotFound when there is no previous snapshot", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(schemaSnapshotRecord))
+			defer clearDb()
+
+			store := NewSchemaStore(db, tableName)
+			current := newSnapshot("profile-1", idColumn)
+			assert.Nil(t, store.Store(current))
+
+			result, err := store.GetPrevious(current)
+
+			assert.Nil(t, result)
+			assert.Equal(t, protocol.ErrSchemaSnapshotNotFound, err)
+		})
+	})
+}
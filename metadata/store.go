@@ -50,6 +50,7 @@ func (store *Store) GetMetadata(tableID string) (*meta.TableSpec, error) {
 	tableSpec.Fields = transformFields(tableMetadata.Schema)
 
 	tableSpec.RequirePartitionFilter = tableMetadata.RequirePartitionFilter
+	tableSpec.LastModifiedTime = tableMetadata.LastModifiedTime
 
 	if tableMetadata.TimePartitioning != nil {
 		timePartitioningType, err := convertTimePartitioningType(tableMetadata.TimePartitioning.Type)
@@ -3,6 +3,7 @@ package metadata_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/meta"
@@ -44,6 +45,7 @@ func TestGetMetadata(t *testing.T) {
 					"key": "value",
 				},
 				RequirePartitionFilter: true,
+				LastModifiedTime:       time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC),
 			},
 			UniqueConstraints: []string{"id"},
 			TableSpec: &meta.TableSpec{
@@ -64,6 +66,7 @@ func TestGetMetadata(t *testing.T) {
 					},
 				},
 				TimePartitioningType: meta.DayPartitioning,
+				LastModifiedTime:     time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC),
 			},
 		},
 		{
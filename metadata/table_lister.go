@@ -0,0 +1,55 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/odpf/predator/protocol"
+	"google.golang.org/api/iterator"
+)
+
+const tableNameColumn = "table_name"
+
+//TableLister get tables of a bigquery dataset using INFORMATION_SCHEMA.TABLES view, views are not listed
+//tables of other warehouses are not listed, expansion of dataset defaults only apply to bigquery datasets
+type TableLister struct {
+	bqClient bqiface.Client
+}
+
+//NewTableLister create TableLister
+func NewTableLister(bqClient bqiface.Client) *TableLister {
+	return &TableLister{bqClient: bqClient}
+}
+
+//ListTables get URN of every base table in the dataset ordered by table name
+func (t *TableLister) ListTables(project string, dataset string) ([]string, error) {
+	informationSchema := fmt.Sprintf("`%s.%s.INFORMATION_SCHEMA.TABLES`", project, dataset)
+	sql := fmt.Sprintf("SELECT %s FROM %s WHERE table_type = \"BASE TABLE\" ORDER BY %s", tableNameColumn, informationSchema, tableNameColumn)
+
+	it, err := t.bqClient.Query(sql).Read(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	var urns []string
+	for {
+		var row map[string]bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tableName, ok := row[tableNameColumn].(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected %s value of dataset %s.%s", tableNameColumn, project, dataset)
+		}
+		label := &protocol.Label{Project: project, Dataset: dataset, Table: tableName}
+		urns = append(urns, label.String())
+	}
+	return urns, nil
+}
@@ -0,0 +1,54 @@
+package metadata_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/odpf/predator/metadata"
+	"github.com/odpf/predator/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableLister(t *testing.T) {
+	t.Run("ListTables", func(t *testing.T) {
+		sql := "SELECT table_name FROM `project.dataset.INFORMATION_SCHEMA.TABLES` WHERE table_type = \"BASE TABLE\" ORDER BY table_name"
+
+		t.Run("should return urn of tables in the dataset", func(t *testing.T) {
+			rows := []*map[string]bigquery.Value{
+				{"table_name": "orders"},
+				{"table_name": "payments"},
+			}
+
+			query := &mock.QueryMock{}
+			query.On("Read", context.Background()).Return(mock.NewIteratorStub(rows), nil)
+			defer query.AssertExpectations(t)
+
+			client := &mock.BQClientMock{}
+			client.On("Query", sql).Return(query)
+			defer client.AssertExpectations(t)
+
+			lister := metadata.NewTableLister(client)
+			urns, err := lister.ListTables("project", "dataset")
+
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"project.dataset.orders", "project.dataset.payments"}, urns)
+		})
+		t.Run("should return error when query failed", func(t *testing.T) {
+			query := &mock.QueryMock{}
+			query.On("Read", context.Background()).Return(mock.NewIteratorStub(nil), errors.New("query error"))
+			defer query.AssertExpectations(t)
+
+			client := &mock.BQClientMock{}
+			client.On("Query", sql).Return(query)
+			defer client.AssertExpectations(t)
+
+			lister := metadata.NewTableLister(client)
+			urns, err := lister.ListTables("project", "dataset")
+
+			assert.Error(t, err)
+			assert.Nil(t, urns)
+		})
+	})
+}
@@ -0,0 +1,45 @@
+package metadata
+
+import (
+	"fmt"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+)
+
+//WarehouseStore get metadata from store of the warehouse where the table stored
+type WarehouseStore struct {
+	stores map[meta.Warehouse]protocol.MetadataStore
+}
+
+//NewWarehouseStore create WarehouseStore
+func NewWarehouseStore(stores map[meta.Warehouse]protocol.MetadataStore) *WarehouseStore {
+	return &WarehouseStore{stores: stores}
+}
+
+//GetMetadata get metadata of the table from the warehouse of the URN
+func (w *WarehouseStore) GetMetadata(tableID string) (*meta.TableSpec, error) {
+	store, err := w.getStore(tableID)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetMetadata(tableID)
+}
+
+//GetUniqueConstraints get unique constraints of the table from the warehouse of the URN
+func (w *WarehouseStore) GetUniqueConstraints(tableID string) ([]string, error) {
+	store, err := w.getStore(tableID)
+	if err != nil {
+		return nil, err
+	}
+	return store.GetUniqueConstraints(tableID)
+}
+
+func (w *WarehouseStore) getStore(tableID string) (protocol.MetadataStore, error) {
+	warehouse := meta.ParseWarehouse(tableID)
+	store, ok := w.stores[warehouse]
+	if !ok {
+		return nil, fmt.Errorf("unable to get metadata of %s on %s ,%w", tableID, warehouse, protocol.ErrWarehouseNotConfigured)
+	}
+	return store, nil
+}
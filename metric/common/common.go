@@ -84,6 +84,9 @@ func (q *QueryResultParser) Parse(row protocol.Row, metricPairs []*SpecExpressio
 		if err != nil {
 			return nil, err
 		}
+		if fieldMetric == nil {
+			continue
+		}
 		metrics = append(metrics, fieldMetric)
 	}
 
@@ -0,0 +1,172 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/odpf/predator/metric/common"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/odpf/predator/protocol/query"
+)
+
+const (
+	//valueAlias is column of the value of custom sql metric
+	valueAlias = "value"
+	//groupValueAlias is optional column of the group of the value
+	groupValueAlias = "group_value"
+)
+
+//Profiler profile custom sql metrics, each metric is calculated by its own query
+type Profiler struct {
+	queryExecutor protocol.QueryExecutor
+	metadataStore protocol.MetadataStore
+}
+
+//New create custom sql metric profiler
+func New(queryExecutor protocol.QueryExecutor, metadataStore protocol.MetadataStore) *Profiler {
+	return &Profiler{queryExecutor: queryExecutor, metadataStore: metadataStore}
+}
+
+//Profile run query of every custom sql metric, a group with null value has no metric
+func (p *Profiler) Profile(entry protocol.Entry, profile *job.Profile, metricSpecs []*metric.Spec) ([]*metric.Metric, error) {
+	if len(metricSpecs) == 0 || profile.TotalRecords == 0 {
+		return nil, nil
+	}
+
+	queries, err := p.buildQueries(profile, metricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	var metrics []*metric.Metric
+	for i, q := range queries {
+		rows, err := p.queryExecutor.Run(entry, profile, q, job.TableLevelQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			m, err := parseRow(row, metricSpecs[i])
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse result of %s metric of table %s ,%w", metricSpecs[i].Name, profile.URN, err)
+			}
+			if m == nil {
+				continue
+			}
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics, nil
+}
+
+//Plan plan query of every custom sql metric without running them
+func (p *Profiler) Plan(profile *job.Profile, metricSpecs []*metric.Spec) ([]*job.Query, error) {
+	if len(metricSpecs) == 0 {
+		return nil, nil
+	}
+
+	queries, err := p.buildQueries(profile, metricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	var planned []*job.Query
+	for _, q := range queries {
+		planned = append(planned, &job.Query{URN: profile.URN, Content: q, Type: job.TableLevelQuery})
+	}
+	return planned, nil
+}
+
+//buildQueries build a query for each spec in the same order, configured query is used as is
+//expression is selected from the profiled table with the filter and group of the profile
+func (p *Profiler) buildQueries(profile *job.Profile, metricSpecs []*metric.Spec) ([]string, error) {
+	tableSpec, err := p.metadataStore.GetMetadata(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []string
+	for _, spec := range metricSpecs {
+		custom, ok := metric.GetCustom(spec.Metadata)
+		if !ok {
+			return nil, fmt.Errorf("name of %s metric of table %s is not configured", spec.Name, profile.URN)
+		}
+
+		switch {
+		case custom.Query != "":
+			queries = append(queries, custom.Query)
+		case custom.Expression != "":
+			queries = append(queries, buildExpressionQuery(profile, tableSpec, custom.Expression))
+		default:
+			return nil, fmt.Errorf("%s metric %s of table %s has neither expression nor query", spec.Name, custom.Name, profile.URN)
+		}
+	}
+	return queries, nil
+}
+
+func buildExpressionQuery(profile *job.Profile, tableSpec *meta.TableSpec, expression string) string {
+	var selectExpressions []*query.SelectExpression
+	if profile.GroupName != "" {
+		selectExpressions = append(selectExpressions, &query.SelectExpression{Expression: profile.GroupName, Alias: groupValueAlias})
+	}
+	selectExpressions = append(selectExpressions, &query.SelectExpression{Expression: expression, Alias: valueAlias})
+
+	q := &query.Query{
+		Expressions: selectExpressions,
+		From: &query.FromClause{
+			TableID: profile.URN,
+		},
+		Where:   common.GenerateFilterExpression(profile.Filter, tableSpec),
+		GroupBy: common.GenerateGroupExpression(profile.GroupName),
+		Dialect: query.DialectOf(profile.URN),
+	}
+	return q.String()
+}
+
+func parseRow(row protocol.Row, spec *metric.Spec) (*metric.Metric, error) {
+	rawValue, ok := row[valueAlias]
+	if !ok {
+		return nil, errors.New("value column is not found")
+	}
+	if rawValue == nil {
+		return nil, nil
+	}
+
+	value, err := toFloat(rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &metric.Metric{
+		Type:     spec.Name,
+		Category: metric.Quality,
+		Owner:    spec.Owner,
+		Value:    value,
+		Metadata: spec.Metadata,
+	}
+	if groupValue, ok := row[groupValueAlias]; ok && groupValue != nil {
+		m.GroupValue, err = common.ConvertValueToString(groupValue)
+		if err != nil {
+			return nil, fmt.Errorf("group value is invalid %w", err)
+		}
+	}
+	return m, nil
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case *big.Rat:
+		f, _ := v.Float64()
+		return f, nil
+	default:
+		return 0, fmt.Errorf("value %v is not numeric", value)
+	}
+}
@@ -0,0 +1,148 @@
+package custom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfiler(t *testing.T) {
+	entry := protocol.NewEntry()
+	urn := "sample-project.sample_dataset.sample_table"
+	tableSpec := &meta.TableSpec{
+		ProjectName: "sample-project",
+		DatasetName: "sample_dataset",
+		TableName:   "sample_table",
+		Fields: []*meta.FieldSpec{
+			{Name: "active", FieldType: meta.FieldTypeBoolean, Level: meta.RootLevel},
+		},
+	}
+	expressionMetadata := map[string]interface{}{
+		metric.CustomName:       "late_orders",
+		metric.CustomExpression: "COUNTIF(delivered_at > promised_at)",
+	}
+	queryMetadata := map[string]interface{}{
+		metric.CustomName:  "refund_ratio",
+		metric.CustomQuery: "SELECT SUM(refund) / SUM(amount) AS value FROM `sample-project.sample_dataset.sample_table`",
+	}
+	metricSpecs := []*metric.Spec{
+		{Name: metric.CustomSQL, TableID: urn, Owner: metric.Table, Metadata: expressionMetadata},
+		{Name: metric.CustomSQL, TableID: urn, Owner: metric.Table, Metadata: queryMetadata},
+	}
+
+	t.Run("Profile", func(t *testing.T) {
+		t.Run("should return metric of each group of expression and value of query", func(t *testing.T) {
+			profile := &job.Profile{URN: urn, GroupName: "country", TotalRecords: 10}
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			queryExecutor := mock.NewQueryExecutor()
+			defer queryExecutor.AssertExpectations(t)
+
+			expressionQuery := "SELECT country AS group_value , COUNTIF(delivered_at > promised_at) AS value FROM `sample-project.sample_dataset.sample_table` WHERE TRUE GROUP BY country"
+
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+			queryExecutor.On("Run", profile, expressionQuery, job.TableLevelQuery).Return([]protocol.Row{
+				{groupValueAlias: "ID", valueAlias: int64(3)},
+				{groupValueAlias: "SG", valueAlias: nil},
+			}, nil)
+			queryExecutor.On("Run", profile, queryMetadata[metric.CustomQuery], job.TableLevelQuery).Return([]protocol.Row{
+				{valueAlias: 0.25},
+			}, nil)
+
+			expected := []*metric.Metric{
+				{Type: metric.CustomSQL, Category: metric.Quality, Owner: metric.Table, Value: 3, GroupValue: "ID", Metadata: expressionMetadata},
+				{Type: metric.CustomSQL, Category: metric.Quality, Owner: metric.Table, Value: 0.25, Metadata: queryMetadata},
+			}
+
+			profiler := New(queryExecutor, metadataStore)
+			metrics, err := profiler.Profile(entry, profile, metricSpecs)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, metrics)
+		})
+		t.Run("should return nil when table is empty", func(t *testing.T) {
+			profile := &job.Profile{URN: urn}
+
+			profiler := New(mock.NewQueryExecutor(), mock.NewMetadataStore())
+			metrics, err := profiler.Profile(entry, profile, metricSpecs)
+
+			assert.Nil(t, err)
+			assert.Nil(t, metrics)
+		})
+		t.Run("should return error when value is not numeric", func(t *testing.T) {
+			profile := &job.Profile{URN: urn, TotalRecords: 10}
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			queryExecutor := mock.NewQueryExecutor()
+			defer queryExecutor.AssertExpectations(t)
+
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+			queryExecutor.On("Run", profile, "SELECT COUNTIF(delivered_at > promised_at) AS value FROM `sample-project.sample_dataset.sample_table` WHERE TRUE", job.TableLevelQuery).
+				Return([]protocol.Row{{valueAlias: "late"}}, nil)
+
+			profiler := New(queryExecutor, metadataStore)
+			metrics, err := profiler.Profile(entry, profile, metricSpecs[:1])
+
+			assert.Nil(t, metrics)
+			assert.EqualError(t, err, "unable to parse result of custom_sql metric of table sample-project.sample_dataset.sample_table ,value late is not numeric")
+		})
+		t.Run("should return error when query failed", func(t *testing.T) {
+			profile := &job.Profile{URN: urn, TotalRecords: 10}
+			queryErr := errors.New("query failed")
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			queryExecutor := mock.NewQueryExecutor()
+			defer queryExecutor.AssertExpectations(t)
+
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+			queryExecutor.On("Run", profile, queryMetadata[metric.CustomQuery], job.TableLevelQuery).Return([]protocol.Row{}, queryErr)
+
+			profiler := New(queryExecutor, metadataStore)
+			metrics, err := profiler.Profile(entry, profile, metricSpecs[1:])
+
+			assert.Nil(t, metrics)
+			assert.Equal(t, queryErr, err)
+		})
+	})
+	t.Run("Plan", func(t *testing.T) {
+		t.Run("should return query of each metric with filter of the profile", func(t *testing.T) {
+			profile := &job.Profile{URN: urn, Filter: "active = true"}
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+			expected := []*job.Query{
+				{
+					URN:     urn,
+					Content: "SELECT COUNTIF(delivered_at > promised_at) AS value FROM `sample-project.sample_dataset.sample_table` WHERE active = true",
+					Type:    job.TableLevelQuery,
+				},
+				{
+					URN:     urn,
+					Content: queryMetadata[metric.CustomQuery].(string),
+					Type:    job.TableLevelQuery,
+				},
+			}
+
+			profiler := New(mock.NewQueryExecutor(), metadataStore)
+			queries, err := profiler.Plan(profile, metricSpecs)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, queries)
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package custom
+
+import (
+	"fmt"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
+)
+
+//QueryValidator dry run query of custom sql metrics of the spec, queries of warehouse that does not support dry run are not checked
+type QueryValidator struct {
+	metadataStore  protocol.MetadataStore
+	specGenerator  protocol.MetricSpecGenerator
+	profiler       protocol.MetricProfiler
+	queryEstimator protocol.QueryEstimator
+}
+
+//NewQueryValidator create QueryValidator
+func NewQueryValidator(metadataStore protocol.MetadataStore, specGenerator protocol.MetricSpecGenerator, profiler protocol.MetricProfiler, queryEstimator protocol.QueryEstimator) *QueryValidator {
+	return &QueryValidator{metadataStore: metadataStore, specGenerator: specGenerator, profiler: profiler, queryEstimator: queryEstimator}
+}
+
+//Validate return ErrSpecInvalid with an error for each query that fail the dry run
+//the queries are planned for the whole table, without filter and group
+func (v *QueryValidator) Validate(spec *protocol.ToleranceSpec) error {
+	tableSpec, err := v.metadataStore.GetMetadata(spec.URN)
+	if err != nil {
+		return err
+	}
+
+	metricSpecs, err := v.specGenerator.Generate(tableSpec, spec.Tolerances)
+	if err != nil {
+		return err
+	}
+
+	profile := &job.Profile{URN: spec.URN}
+	queries, err := v.profiler.Plan(profile, metricSpecs)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i, q := range queries {
+		if _, err := v.queryEstimator.Estimate(protocol.NewEntry(), profile, q.Content); err != nil {
+			name := ""
+			if custom, ok := metric.GetCustom(metricSpecs[i].Metadata); ok {
+				name = custom.Name
+			}
+			errs = append(errs, fmt.Errorf("query of %s metric %s is invalid ,%w", metric.CustomSQL, name, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return &protocol.ErrSpecInvalid{Errors: errs, URN: spec.URN}
+	}
+	return nil
+}
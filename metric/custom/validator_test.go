@@ -0,0 +1,90 @@
+package custom
+
+import (
+	"errors"
+	"testing"
+
+	metricmock "github.com/odpf/predator/metric/mock"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryValidator(t *testing.T) {
+	urn := "sample-project.sample_dataset.sample_table"
+	tableSpec := &meta.TableSpec{
+		ProjectName: "sample-project",
+		DatasetName: "sample_dataset",
+		TableName:   "sample_table",
+	}
+	tolerances := []*protocol.Tolerance{
+		{
+			TableURN:   urn,
+			MetricName: metric.CustomSQL,
+			Metadata:   map[string]interface{}{metric.CustomName: "late_orders", metric.CustomExpression: "COUNTIF(delivered_at > promised_at)"},
+		},
+	}
+	spec := &protocol.ToleranceSpec{URN: urn, Tolerances: tolerances}
+	metricSpecs := []*metric.Spec{
+		{Name: metric.CustomSQL, TableID: urn, Owner: metric.Table, Metadata: tolerances[0].Metadata},
+	}
+	profile := &job.Profile{URN: urn}
+	planned := []*job.Query{{URN: urn, Content: "SELECT COUNTIF(delivered_at > promised_at) AS value FROM `sample-project.sample_dataset.sample_table`", Type: job.TableLevelQuery}}
+
+	t.Run("Validate", func(t *testing.T) {
+		t.Run("should return nil when dry run of every query succeed", func(t *testing.T) {
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			specGenerator := metricmock.NewMetricSpecGenerator()
+			defer specGenerator.AssertExpectations(t)
+
+			profiler := mock.NewProfiler()
+			defer profiler.AssertExpectations(t)
+
+			queryEstimator := mock.NewQueryEstimator()
+			defer queryEstimator.AssertExpectations(t)
+
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+			specGenerator.On("Generate", tableSpec, tolerances).Return(metricSpecs, nil)
+			profiler.On("Plan", profile, metricSpecs).Return(planned, nil)
+			queryEstimator.On("Estimate", profile, planned[0].Content).Return(int64(100), nil)
+
+			validator := NewQueryValidator(metadataStore, specGenerator, profiler, queryEstimator)
+			err := validator.Validate(spec)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should return ErrSpecInvalid when dry run failed", func(t *testing.T) {
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			specGenerator := metricmock.NewMetricSpecGenerator()
+			defer specGenerator.AssertExpectations(t)
+
+			profiler := mock.NewProfiler()
+			defer profiler.AssertExpectations(t)
+
+			queryEstimator := mock.NewQueryEstimator()
+			defer queryEstimator.AssertExpectations(t)
+
+			dryRunErr := errors.New("Unrecognized name: delivered_at")
+
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+			specGenerator.On("Generate", tableSpec, tolerances).Return(metricSpecs, nil)
+			profiler.On("Plan", profile, metricSpecs).Return(planned, nil)
+			queryEstimator.On("Estimate", profile, planned[0].Content).Return(int64(0), dryRunErr)
+
+			validator := NewQueryValidator(metadataStore, specGenerator, profiler, queryEstimator)
+			err := validator.Validate(spec)
+
+			assert.True(t, protocol.IsSpecInvalidError(err))
+			specInvalidErr := err.(*protocol.ErrSpecInvalid)
+			assert.Len(t, specInvalidErr.Errors, 1)
+			assert.Equal(t, "query of custom_sql metric late_orders is invalid ,Unrecognized name: delivered_at", specInvalidErr.Errors[0].Error())
+		})
+	})
+}
@@ -11,6 +11,15 @@ var metricParserMap = map[metric.Type]common.RowParserType{
 	metric.Count:        getCountMetric,
 	metric.NullCount:    getNullCountMetric,
 	metric.Sum:          getSumMetric,
+	metric.Min:          getStatisticalMetricParser(metric.Min),
+	metric.Max:          getStatisticalMetricParser(metric.Max),
+	metric.Avg:          getStatisticalMetricParser(metric.Avg),
+	metric.StdDev:       getStatisticalMetricParser(metric.StdDev),
+	metric.Quantile:     getStatisticalMetricParser(metric.Quantile),
+
+	metric.UnacceptedCount:       getConformityCountMetricParser(metric.UnacceptedCount),
+	metric.PatternMismatchCount:  getConformityCountMetricParser(metric.PatternMismatchCount),
+	metric.LengthOutOfRangeCount: getConformityCountMetricParser(metric.LengthOutOfRangeCount),
 }
 
 func getCountMetric(result map[string]interface{}, alias string, metricSpec *metric.Spec) (*metric.Metric, error) {
@@ -89,3 +98,53 @@ func getSumMetric(result map[string]interface{}, alias string, metricSpec *metri
 	}
 	return sumMetric, nil
 }
+
+//getStatisticalMetricParser create parser of statistical metric
+//no metric is produced when the value is NULL, such as when all values of the field are NULL
+func getStatisticalMetricParser(metricType metric.Type) common.RowParserType {
+	return func(result map[string]interface{}, alias string, metricSpec *metric.Spec) (*metric.Metric, error) {
+		value, ok := result[alias]
+		if !ok {
+			return nil, fmt.Errorf("%s value with alias %s, not found", metricType, alias)
+		}
+		if value == nil {
+			return nil, nil
+		}
+		statistic, ok := value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("parse %s value to float64 with alias %s, failed", metricType, alias)
+		}
+		statisticalMetric := &metric.Metric{
+			FieldID:  metricSpec.FieldID,
+			Type:     metricType,
+			Category: metric.Quality,
+			Owner:    metric.Field,
+			Value:    statistic,
+			Metadata: metricSpec.Metadata,
+		}
+		return statisticalMetric, nil
+	}
+}
+
+//getConformityCountMetricParser create parser of count of values that do not conform the rule in the metric spec metadata
+func getConformityCountMetricParser(metricType metric.Type) common.RowParserType {
+	return func(result map[string]interface{}, alias string, metricSpec *metric.Spec) (*metric.Metric, error) {
+		value, ok := result[alias]
+		if !ok {
+			return nil, fmt.Errorf("%s value with alias %s, not found", metricType, alias)
+		}
+		count, ok := value.(int64)
+		if !ok {
+			return nil, fmt.Errorf("parse %s value to int64 with alias %s, failed", metricType, alias)
+		}
+		conformityCountMetric := &metric.Metric{
+			FieldID:  metricSpec.FieldID,
+			Type:     metricType,
+			Category: metric.Basic,
+			Owner:    metric.Field,
+			Value:    float64(count),
+			Metadata: metricSpec.Metadata,
+		}
+		return conformityCountMetric, nil
+	}
+}
@@ -179,5 +179,139 @@ func TestParse(t *testing.T) {
 			assert.ElementsMatch(t, expectedResponse, actual)
 			assert.Nil(t, err)
 		})
+		t.Run("should return statistical metrics", func(t *testing.T) {
+			quantileMetadata := map[string]interface{}{metric.QuantileFraction: 0.99}
+			pairs := []*common.SpecExpressionPair{
+				{
+					MetricSpec: &metric.Spec{
+						Name:    metric.Max,
+						FieldID: "amount",
+						TableID: "entity-1-project-1.dataset_a.table_x",
+					},
+					MetricExpression: &query.MetricExpression{
+						Alias: "max_amount_0",
+					},
+				},
+				{
+					MetricSpec: &metric.Spec{
+						Name:     metric.Quantile,
+						FieldID:  "amount",
+						TableID:  "entity-1-project-1.dataset_a.table_x",
+						Metadata: quantileMetadata,
+					},
+					MetricExpression: &query.MetricExpression{
+						Alias: "quantile_amount_1",
+					},
+				},
+			}
+
+			queryResult := make(map[string]interface{})
+			queryResult["max_amount_0"] = float64(1000)
+			queryResult["quantile_amount_1"] = float64(950)
+
+			expected := []*metric.Metric{
+				{
+					Type:     metric.Max,
+					Category: metric.Quality,
+					Owner:    metric.Field,
+					FieldID:  "amount",
+					Value:    float64(1000),
+				},
+				{
+					Type:     metric.Quantile,
+					Category: metric.Quality,
+					Owner:    metric.Field,
+					FieldID:  "amount",
+					Value:    float64(950),
+					Metadata: quantileMetadata,
+				},
+			}
+
+			parser := &common.QueryResultParser{ParserMap: metricParserMap}
+
+			actual, err := parser.Parse(queryResult, pairs)
+			assert.ElementsMatch(t, expected, actual)
+			assert.Nil(t, err)
+		})
+		t.Run("should return conformity count metrics with the rule metadata", func(t *testing.T) {
+			acceptedValuesMetadata := map[string]interface{}{metric.AcceptedValues: []string{"A", "B"}}
+			patternMetadata := map[string]interface{}{metric.Pattern: "^[A-Z]$"}
+			pairs := []*common.SpecExpressionPair{
+				{
+					MetricSpec: &metric.Spec{
+						Name:     metric.UnacceptedCount,
+						FieldID:  "status",
+						TableID:  "entity-1-project-1.dataset_a.table_x",
+						Metadata: acceptedValuesMetadata,
+					},
+					MetricExpression: &query.MetricExpression{
+						Alias: "unacceptedcount_status_0",
+					},
+				},
+				{
+					MetricSpec: &metric.Spec{
+						Name:     metric.PatternMismatchCount,
+						FieldID:  "status",
+						TableID:  "entity-1-project-1.dataset_a.table_x",
+						Metadata: patternMetadata,
+					},
+					MetricExpression: &query.MetricExpression{
+						Alias: "patternmismatchcount_status_1",
+					},
+				},
+			}
+
+			queryResult := make(map[string]interface{})
+			queryResult["unacceptedcount_status_0"] = int64(3)
+			queryResult["patternmismatchcount_status_1"] = int64(5)
+
+			expected := []*metric.Metric{
+				{
+					Type:     metric.UnacceptedCount,
+					Category: metric.Basic,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Value:    float64(3),
+					Metadata: acceptedValuesMetadata,
+				},
+				{
+					Type:     metric.PatternMismatchCount,
+					Category: metric.Basic,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Value:    float64(5),
+					Metadata: patternMetadata,
+				},
+			}
+
+			parser := &common.QueryResultParser{ParserMap: metricParserMap}
+
+			actual, err := parser.Parse(queryResult, pairs)
+			assert.ElementsMatch(t, expected, actual)
+			assert.Nil(t, err)
+		})
+		t.Run("should skip statistical metric when the value is null", func(t *testing.T) {
+			pairs := []*common.SpecExpressionPair{
+				{
+					MetricSpec: &metric.Spec{
+						Name:    metric.StdDev,
+						FieldID: "amount",
+						TableID: "entity-1-project-1.dataset_a.table_x",
+					},
+					MetricExpression: &query.MetricExpression{
+						Alias: "stddev_amount_0",
+					},
+				},
+			}
+
+			queryResult := make(map[string]interface{})
+			queryResult["stddev_amount_0"] = nil
+
+			parser := &common.QueryResultParser{ParserMap: metricParserMap}
+
+			actual, err := parser.Parse(queryResult, pairs)
+			assert.Empty(t, actual)
+			assert.Nil(t, err)
+		})
 	})
 }
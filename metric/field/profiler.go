@@ -38,23 +38,16 @@ func (f *Profiler) Profile(entry protocol.Entry, profile *job.Profile, metricSpe
 		return nil, err
 	}
 
-	metricSpecsGroup, err := groupMetricSpecsByBranch(tableSpec, metricSpecs)
+	branches, metricSpecsGroup, err := sortedBranches(tableSpec, metricSpecs)
 	if err != nil {
 		return nil, err
 	}
 
-	var branches []*meta.FieldSpec
-	for fieldSpec := range metricSpecsGroup {
-		branches = append(branches, fieldSpec)
-	}
-
-	sort.Sort(meta.ByFieldName(branches))
-
 	var metrics []*metric.Metric
 	for _, branch := range branches {
 		ms := metricSpecsGroup[branch]
 
-		results, err := f.profileFieldGroup(branch, profile, tableSpec, ms)
+		results, err := f.profileFieldGroup(entry, branch, profile, tableSpec, ms)
 		if err != nil {
 			return nil, err
 		}
@@ -64,6 +57,44 @@ func (f *Profiler) Profile(entry protocol.Entry, profile *job.Profile, metricSpe
 	return metrics, nil
 }
 
+//Plan plan a query for every group of field metrics without running them
+func (f *Profiler) Plan(profile *job.Profile, metricSpecs []*metric.Spec) ([]*job.Query, error) {
+	tableSpec, err := f.metadataStore.GetMetadata(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+
+	branches, metricSpecsGroup, err := sortedBranches(tableSpec, metricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []*job.Query
+	for _, branch := range branches {
+		sql, _, err := buildFieldGroupQuery(branch, profile, tableSpec, metricSpecsGroup[branch])
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, &job.Query{URN: profile.URN, Content: sql, Type: job.FieldLevelQuery})
+	}
+	return queries, nil
+}
+
+func sortedBranches(tableSpec *meta.TableSpec, metricSpecs []*metric.Spec) ([]*meta.FieldSpec, map[*meta.FieldSpec][]*metric.Spec, error) {
+	metricSpecsGroup, err := groupMetricSpecsByBranch(tableSpec, metricSpecs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var branches []*meta.FieldSpec
+	for fieldSpec := range metricSpecsGroup {
+		branches = append(branches, fieldSpec)
+	}
+
+	sort.Sort(meta.ByFieldName(branches))
+	return branches, metricSpecsGroup, nil
+}
+
 //groupMetricSpecsByBranch ByClosestRepeatedAncestor
 func groupMetricSpecsByBranch(tableSpec *meta.TableSpec, metricSpecs []*metric.Spec) (map[*meta.FieldSpec][]*metric.Spec, error) {
 	metricSpecsGroup := make(map[*meta.FieldSpec][]*metric.Spec)
@@ -84,12 +115,36 @@ func groupMetricSpecsByBranch(tableSpec *meta.TableSpec, metricSpecs []*metric.S
 	return metricSpecsGroup, nil
 }
 
-func (f *Profiler) profileFieldGroup(branch *meta.FieldSpec, profile *job.Profile, tableSpec *meta.TableSpec, metricSpecs []*metric.Spec) ([]*metric.Metric, error) {
-	metricExpressionsPairs, err := prepareMetricsForQuery(tableSpec, metricSpecs)
+func (f *Profiler) profileFieldGroup(entry protocol.Entry, branch *meta.FieldSpec, profile *job.Profile, tableSpec *meta.TableSpec, metricSpecs []*metric.Spec) ([]*metric.Metric, error) {
+	sql, metricExpressionsPairs, err := buildFieldGroupQuery(branch, profile, tableSpec, metricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := f.queryExecutor.Run(entry, profile, sql, job.FieldLevelQuery)
 	if err != nil {
 		return nil, err
 	}
 
+	var metrics []*metric.Metric
+	for _, r := range result {
+		groupMetrics, err := f.queryResultParser.Parse(r, metricExpressionsPairs)
+		if err != nil {
+			return nil, err
+		}
+
+		metrics = append(metrics, groupMetrics...)
+	}
+
+	return metrics, nil
+}
+
+func buildFieldGroupQuery(branch *meta.FieldSpec, profile *job.Profile, tableSpec *meta.TableSpec, metricSpecs []*metric.Spec) (string, []*common.SpecExpressionPair, error) {
+	metricExpressionsPairs, err := prepareMetricsForQuery(tableSpec, metricSpecs)
+	if err != nil {
+		return "", nil, err
+	}
+
 	var metricExpressions []*query.MetricExpression
 	for _, pair := range metricExpressionsPairs {
 		metricExpressions = append(metricExpressions, pair.MetricExpression)
@@ -106,26 +161,10 @@ func (f *Profiler) profileFieldGroup(branch *meta.FieldSpec, profile *job.Profil
 		From:        fromExpression,
 		Where:       filterExpression,
 		GroupBy:     groupByExpression,
+		Dialect:     query.DialectOf(profile.URN),
 	}
 
-	sql := q.String()
-
-	result, err := f.queryExecutor.Run(profile, sql, job.FieldLevelQuery)
-	if err != nil {
-		return nil, err
-	}
-
-	var metrics []*metric.Metric
-	for _, r := range result {
-		groupMetrics, err := f.queryResultParser.Parse(r, metricExpressionsPairs)
-		if err != nil {
-			return nil, err
-		}
-
-		metrics = append(metrics, groupMetrics...)
-	}
-
-	return metrics, nil
+	return q.String(), metricExpressionsPairs, nil
 }
 
 func generateFromExpression(branch *meta.FieldSpec, spec *meta.TableSpec) *query.FromClause {
@@ -166,6 +205,16 @@ func prepareMetricsForQuery(tableSpec *meta.TableSpec, metricSpecs []*metric.Spe
 		metricTemplateType := query.ParseMetricType(metricSpec.Name, fieldSpec.Mode)
 
 		m := query.NewMetricExpression(arg, alias, metricTemplateType)
+		if metricSpec.Name == metric.Quantile {
+			quantile, ok := metric.GetQuantile(metricSpec.Metadata)
+			if !ok {
+				return nil, fmt.Errorf("quantile of field ID: %s is not configured", metricSpec.FieldID)
+			}
+			m.Quantile = quantile
+		}
+		if err := setConformityRule(m, metricSpec); err != nil {
+			return nil, err
+		}
 
 		pair := &common.SpecExpressionPair{
 			MetricSpec:       metricSpec,
@@ -177,6 +226,33 @@ func prepareMetricsForQuery(tableSpec *meta.TableSpec, metricSpecs []*metric.Spe
 	return pairs, nil
 }
 
+//setConformityRule set rule parameters of conformity count metric from the metric spec metadata
+func setConformityRule(m *query.MetricExpression, metricSpec *metric.Spec) error {
+	switch metricSpec.Name {
+	case metric.UnacceptedCount:
+		acceptedValues, ok := metric.GetAcceptedValues(metricSpec.Metadata)
+		if !ok {
+			return fmt.Errorf("accepted values of field ID: %s is not configured", metricSpec.FieldID)
+		}
+		m.AcceptedValues = acceptedValues
+	case metric.PatternMismatchCount:
+		pattern, ok := metric.GetPattern(metricSpec.Metadata)
+		if !ok {
+			return fmt.Errorf("pattern of field ID: %s is not configured", metricSpec.FieldID)
+		}
+		m.Pattern = pattern
+	case metric.LengthOutOfRangeCount:
+		minLength, hasMin := metric.GetLength(metricSpec.Metadata, metric.MinLength)
+		maxLength, hasMax := metric.GetLength(metricSpec.Metadata, metric.MaxLength)
+		if !hasMin && !hasMax {
+			return fmt.Errorf("length range of field ID: %s is not configured", metricSpec.FieldID)
+		}
+		m.MinLength = minLength
+		m.MaxLength = maxLength
+	}
+	return nil
+}
+
 func getAlias(fieldName string, metricType metric.Type, index int) string {
 	return fmt.Sprintf("%s_%s_%d", metricType.String(), fieldName, index)
 }
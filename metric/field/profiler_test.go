@@ -768,6 +768,47 @@ func TestFieldProfiler(t *testing.T) {
 					},
 				},
 			},
+			{
+				Profile:     profile,
+				Description: "should profile conformity metrics of string field",
+				Spec: &meta.TableSpec{
+					ProjectName: "sample-project",
+					DatasetName: "sample_dataset",
+					TableName:   "sample_table",
+					Labels:      map[string]string{"key": "value"},
+					Fields:      []*meta.FieldSpec{fieldRootB},
+				},
+				Queries: [][]string{
+					{
+						"SELECT `field_grouping` AS __group_value , countif(`field_root_b` is not null and `field_root_b` not in ('A', 'B')) as unacceptedcount_field_root_b_0 , " +
+							"countif(`field_root_b` is not null and not regexp_contains(`field_root_b`, '^[A-Z]$')) as patternmismatchcount_field_root_b_1 , " +
+							"countif(`field_root_b` is not null and (length(`field_root_b`) < 1 or length(`field_root_b`) > 3)) as lengthoutofrangecount_field_root_b_2",
+						"FROM `sample-project.sample_dataset.sample_table`",
+						"WHERE active = true",
+						"GROUP BY `field_grouping`",
+					},
+				},
+				MetricSpecs: []*metric.Spec{
+					{
+						Name:     metric.UnacceptedCount,
+						FieldID:  "field_root_b",
+						TableID:  "sample-project.sample_dataset.sample_table",
+						Metadata: map[string]interface{}{metric.AcceptedValues: []interface{}{"A", "B"}},
+					},
+					{
+						Name:     metric.PatternMismatchCount,
+						FieldID:  "field_root_b",
+						TableID:  "sample-project.sample_dataset.sample_table",
+						Metadata: map[string]interface{}{metric.Pattern: "^[A-Z]$"},
+					},
+					{
+						Name:     metric.LengthOutOfRangeCount,
+						FieldID:  "field_root_b",
+						TableID:  "sample-project.sample_dataset.sample_table",
+						Metadata: map[string]interface{}{metric.MinLength: 1, metric.MaxLength: 3},
+					},
+				},
+			},
 		}
 		for _, test := range suites {
 			t.Run(test.Description, func(t *testing.T) {
@@ -793,6 +834,14 @@ func TestFieldProfiler(t *testing.T) {
 				}
 
 				assert.Equal(t, len(test.Queries), len(queryExecutor.Calls))
+
+				plannedQueries, err := profiler.Plan(test.Profile, test.MetricSpecs)
+				assert.Nil(t, err)
+				assert.Equal(t, len(test.Queries), len(plannedQueries))
+				for i, plannedQuery := range plannedQueries {
+					assert.Equal(t, strings.Join(test.Queries[i], " "), plannedQuery.Content)
+					assert.Equal(t, job.FieldLevelQuery, plannedQuery.Type)
+				}
 			})
 		}
 		t.Run("should return metrics", func(t *testing.T) {
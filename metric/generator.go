@@ -3,12 +3,15 @@ package metric
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/odpf/predator/metric/common"
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
 	"github.com/odpf/predator/protocol/metric"
 	"github.com/odpf/predator/protocol/query"
+	"github.com/odpf/predator/protocol/xlog"
 )
 
 const totalRecordsAlias = "total_records"
@@ -43,41 +46,47 @@ func (m *DefaultGenerator) Generate(entry protocol.Entry, profile *job.Profile)
 	return metrics, nil
 }
 
+//Plan get metric specification and plan the queries to calculate the metrics
+func (m *DefaultGenerator) Plan(profile *job.Profile) ([]*job.Query, error) {
+	metricSpecs, err := m.specGenerator.GenerateMetricSpec(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+	return m.profiler.Plan(profile, metricSpecs)
+}
+
 type DefaultProfileStatisticGenerator struct {
 	metadataStore protocol.MetadataStore
 	queryExecutor protocol.QueryExecutor
 	profileStore  protocol.ProfileStore
+	schemaStore   protocol.SchemaStore
 }
 
 //NewDefaultProfileStatisticGenerator create DefaultProfileStatisticGenerator
-func NewDefaultProfileStatisticGenerator(metadataStore protocol.MetadataStore, queryExecutor protocol.QueryExecutor, profileStore protocol.ProfileStore) *DefaultProfileStatisticGenerator {
-	return &DefaultProfileStatisticGenerator{metadataStore: metadataStore, queryExecutor: queryExecutor, profileStore: profileStore}
+func NewDefaultProfileStatisticGenerator(metadataStore protocol.MetadataStore, queryExecutor protocol.QueryExecutor, profileStore protocol.ProfileStore, schemaStore protocol.SchemaStore) *DefaultProfileStatisticGenerator {
+	return &DefaultProfileStatisticGenerator{metadataStore: metadataStore, queryExecutor: queryExecutor, profileStore: profileStore, schemaStore: schemaStore}
 }
 
-func (d *DefaultProfileStatisticGenerator) Generate(profile *job.Profile) error {
+//Generate snapshot schema of the table and count records to be profiled
+func (d *DefaultProfileStatisticGenerator) Generate(entry protocol.Entry, profile *job.Profile) error {
 	tableMetadata, err := d.metadataStore.GetMetadata(profile.URN)
 	if err != nil {
 		return err
 	}
 
-	var selectExpressions []*query.SelectExpression
-	exp := &query.SelectExpression{
-		Expression: "count(*)",
-		Alias:      totalRecordsAlias,
+	snapshot := &protocol.SchemaSnapshot{
+		ProfileID: profile.ID,
+		URN:       profile.URN,
+		Columns:   meta.SchemaOf(tableMetadata),
+		CreatedAt: time.Now().In(time.UTC),
 	}
-	selectExpressions = append(selectExpressions, exp)
-
-	q := &query.Query{
-		Expressions: selectExpressions,
-		From: &query.FromClause{
-			TableID: profile.URN,
-		},
-		Where: common.GenerateFilterExpression(profile.Filter, tableMetadata),
+	if err := d.schemaStore.Store(snapshot); err != nil {
+		return err
 	}
 
-	queryString := q.String()
+	queryString := d.buildQuery(profile, tableMetadata)
 
-	result, err := d.queryExecutor.Run(profile, queryString, job.StatisticalQuery)
+	result, err := d.queryExecutor.Run(entry, profile, queryString, job.StatisticalQuery)
 	if err != nil {
 		return err
 	}
@@ -97,6 +106,36 @@ func (d *DefaultProfileStatisticGenerator) Generate(profile *job.Profile) error
 	return d.profileStore.Update(profile)
 }
 
+//Plan plan the query to count records to be profiled
+func (d *DefaultProfileStatisticGenerator) Plan(profile *job.Profile) ([]*job.Query, error) {
+	tableMetadata, err := d.metadataStore.GetMetadata(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+	queryString := d.buildQuery(profile, tableMetadata)
+	return []*job.Query{{URN: profile.URN, Content: queryString, Type: job.StatisticalQuery}}, nil
+}
+
+func (d *DefaultProfileStatisticGenerator) buildQuery(profile *job.Profile, tableMetadata *meta.TableSpec) string {
+	var selectExpressions []*query.SelectExpression
+	exp := &query.SelectExpression{
+		Expression: "count(*)",
+		Alias:      totalRecordsAlias,
+	}
+	selectExpressions = append(selectExpressions, exp)
+
+	q := &query.Query{
+		Expressions: selectExpressions,
+		From: &query.FromClause{
+			TableID: profile.URN,
+		},
+		Where:   common.GenerateFilterExpression(profile.Filter, tableMetadata),
+		Dialect: query.DialectOf(profile.URN),
+	}
+
+	return q.String()
+}
+
 //MultistageGenerator metric generator that generate metric from multiple generators
 type MultistageGenerator struct {
 	generators     []protocol.MetricGenerator
@@ -110,7 +149,7 @@ func NewMultistageGenerator(generators []protocol.MetricGenerator, profileStatGe
 
 //Generate generate metric from multiple generator
 func (m *MultistageGenerator) Generate(entry protocol.Entry, profile *job.Profile) (metrics []*metric.Metric, err error) {
-	err = m.profileStatGen.Generate(profile)
+	err = m.profileStatGen.Generate(entry, profile)
 	if err != nil {
 		return nil, err
 	}
@@ -123,3 +162,218 @@ func (m *MultistageGenerator) Generate(entry protocol.Entry, profile *job.Profil
 	}
 	return metrics, nil
 }
+
+//Plan plan queries of all generators
+func (m *MultistageGenerator) Plan(profile *job.Profile) ([]*job.Query, error) {
+	queries, err := m.profileStatGen.Plan(profile)
+	if err != nil {
+		return nil, err
+	}
+	for _, generator := range m.generators {
+		result, err := generator.Plan(profile)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, result...)
+	}
+	return queries, nil
+}
+
+//KindGenerator generate metrics with the generator of the profile kind, profile of other kinds use the default generator
+type KindGenerator struct {
+	defaultGenerator protocol.MetricGenerator
+	generators       map[job.Kind]protocol.MetricGenerator
+}
+
+//NewKindGenerator create KindGenerator
+func NewKindGenerator(defaultGenerator protocol.MetricGenerator, generators map[job.Kind]protocol.MetricGenerator) *KindGenerator {
+	return &KindGenerator{defaultGenerator: defaultGenerator, generators: generators}
+}
+
+//Generate generate metrics with the generator of the profile kind
+func (k *KindGenerator) Generate(entry protocol.Entry, profile *job.Profile) ([]*metric.Metric, error) {
+	return k.generatorOf(profile).Generate(entry, profile)
+}
+
+//Plan plan queries with the generator of the profile kind
+func (k *KindGenerator) Plan(profile *job.Profile) ([]*job.Query, error) {
+	return k.generatorOf(profile).Plan(profile)
+}
+
+func (k *KindGenerator) generatorOf(profile *job.Profile) protocol.MetricGenerator {
+	if generator, ok := k.generators[profile.Kind.OrDefault()]; ok {
+		return generator
+	}
+	return k.defaultGenerator
+}
+
+//IncrementalGenerator generate metrics of a profile in incremental mode
+//only partitions modified since the last completed profile of the same urn, group and filter are profiled,
+//metrics of the other partitions are taken from the last completed profile, so the metrics still cover the whole table
+//profile that is not in incremental mode, not grouped by partition or has no completed profile before is fully profiled,
+//so is profile whose carried metrics would be older than maxCarryForward, that drops partitions that no longer exist
+type IncrementalGenerator struct {
+	generator            protocol.MetricGenerator
+	profileStore         protocol.ProfileStore
+	metricStore          protocol.MetricStore
+	partitionScanner     protocol.PartitionScanner
+	sqlExpressionFactory protocol.SQLExpressionFactory
+	maxCarryForward      time.Duration
+}
+
+//NewIncrementalGenerator create IncrementalGenerator
+func NewIncrementalGenerator(generator protocol.MetricGenerator,
+	profileStore protocol.ProfileStore,
+	metricStore protocol.MetricStore,
+	partitionScanner protocol.PartitionScanner,
+	sqlExpressionFactory protocol.SQLExpressionFactory,
+	maxCarryForward time.Duration) *IncrementalGenerator {
+	return &IncrementalGenerator{
+		generator:            generator,
+		profileStore:         profileStore,
+		metricStore:          metricStore,
+		partitionScanner:     partitionScanner,
+		sqlExpressionFactory: sqlExpressionFactory,
+		maxCarryForward:      maxCarryForward,
+	}
+}
+
+//Generate profile modified partitions and merge the result with metrics of the last completed profile
+func (g *IncrementalGenerator) Generate(entry protocol.Entry, profile *job.Profile) ([]*metric.Metric, error) {
+	incrementalProfile, lastMetrics, err := g.createIncrementalProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	if incrementalProfile == nil {
+		return g.generator.Generate(entry, profile)
+	}
+
+	metrics, err := g.generator.Generate(entry, incrementalProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	unmodifiedMetrics := filterUnmodifiedMetrics(lastMetrics, metrics)
+	if err := g.metricStore.Store(profile, unmodifiedMetrics); err != nil {
+		return nil, err
+	}
+
+	return append(metrics, unmodifiedMetrics...), nil
+}
+
+//Plan plan queries to profile modified partitions
+func (g *IncrementalGenerator) Plan(profile *job.Profile) ([]*job.Query, error) {
+	incrementalProfile, _, err := g.createIncrementalProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+	if incrementalProfile == nil {
+		return g.generator.Plan(profile)
+	}
+	return g.generator.Plan(incrementalProfile)
+}
+
+//createIncrementalProfile create copy of the profile filtered by modified partitions since the last completed profile
+//and return metrics of the last completed profile, return nil profile when all records should be profiled
+func (g *IncrementalGenerator) createIncrementalProfile(profile *job.Profile) (incrementalProfile *job.Profile, lastMetrics []*metric.Metric, err error) {
+	if profile.Mode != job.ModeIncremental {
+		return nil, nil, nil
+	}
+
+	partitionExpression, err := g.sqlExpressionFactory.CreatePartitionExpression(profile.URN)
+	if err != nil || profile.GroupName != partitionExpression {
+		msg := xlog.Format("incremental mode requires profile grouped by partition, profiling all records", xlog.NewValue("profile_id", profile.ID))
+		logger.Println(msg)
+		return nil, nil, nil
+	}
+
+	lastProfile, err := g.profileStore.GetLastCompleted(profile)
+	if err != nil {
+		if err == protocol.ErrProfileNotFound {
+			msg := xlog.Format("no completed profile found, profiling all records", xlog.NewValue("profile_id", profile.ID))
+			logger.Println(msg)
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	lastMetrics, err = g.metricStore.GetMetricsByProfileID(lastProfile.ID)
+	if err != nil && err != protocol.ErrNoProfileMetricFound {
+		return nil, nil, err
+	}
+
+	carryForwardSince := profile.EventTimestamp.Add(-g.maxCarryForward)
+	if isProfiledBefore(lastProfile, lastMetrics, carryForwardSince) {
+		msg := xlog.Format("metrics of the last completed profile exceed max carry forward age, profiling all records",
+			xlog.NewValue("profile_id", profile.ID), xlog.NewValue("last_profile_id", lastProfile.ID))
+		logger.Println(msg)
+		return nil, nil, nil
+	}
+
+	partitionIDs, err := g.partitionScanner.GetAffectedPartition(profile.URN, lastProfile.EventTimestamp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	partitionFilter, err := g.sqlExpressionFactory.CreatePartitionFilterExpression(profile.URN, partitionIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	msg := xlog.Format(fmt.Sprintf("profiling %d modified partitions", len(partitionIDs)),
+		xlog.NewValue("profile_id", profile.ID), xlog.NewValue("last_profile_id", lastProfile.ID))
+	logger.Println(msg)
+
+	p := *profile
+	p.Filter = partitionFilter
+	if profile.Filter != "" {
+		p.Filter = fmt.Sprintf("(%s) AND %s", profile.Filter, partitionFilter)
+	}
+	return &p, lastMetrics, nil
+}
+
+//isProfiledBefore check whether the last profile or any of its metrics was profiled before the time,
+//metrics carried forward keep the time they were profiled
+func isProfiledBefore(lastProfile *job.Profile, lastMetrics []*metric.Metric, since time.Time) bool {
+	if lastProfile.EventTimestamp.Before(since) {
+		return true
+	}
+	for _, m := range lastMetrics {
+		if !m.Timestamp.IsZero() && m.Timestamp.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+//filterUnmodifiedMetrics copy basic metrics of the last profile whose group is not profiled again
+func filterUnmodifiedMetrics(lastMetrics []*metric.Metric, metrics []*metric.Metric) []*metric.Metric {
+	profiledGroups := make(map[string]bool)
+	for _, m := range metrics {
+		profiledGroups[m.GroupValue] = true
+	}
+
+	basicTypes := make(map[metric.Type]bool)
+	for _, t := range metric.TypesBasicMetric {
+		basicTypes[t] = true
+	}
+
+	var unmodifiedMetrics []*metric.Metric
+	for _, m := range lastMetrics {
+		if profiledGroups[m.GroupValue] || !basicTypes[m.Type] {
+			continue
+		}
+		unmodifiedMetrics = append(unmodifiedMetrics, &metric.Metric{
+			FieldID:    m.FieldID,
+			Type:       m.Type,
+			Category:   m.Category,
+			Owner:      m.Owner,
+			GroupValue: m.GroupValue,
+			Value:      m.Value,
+			Condition:  m.Condition,
+			Metadata:   m.Metadata,
+			Timestamp:  m.Timestamp,
+		})
+	}
+	return unmodifiedMetrics
+}
@@ -3,6 +3,7 @@ package metric
 import (
 	"errors"
 	"testing"
+	"time"
 
 	metricmock "github.com/odpf/predator/metric/mock"
 	"github.com/odpf/predator/mock"
@@ -259,6 +260,47 @@ func TestMultistageGenerator(t *testing.T) {
 	})
 }
 
+func TestKindGenerator(t *testing.T) {
+	t.Run("Generate", func(t *testing.T) {
+		metrics := []*metric.Metric{{ID: "1"}}
+
+		t.Run("should generate metrics with generator of the profile kind", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{ID: "1234", Kind: job.KindExploratory}
+
+			defaultGenerator := mock.NewMetricGenerator()
+			defer defaultGenerator.AssertExpectations(t)
+
+			exploratoryGenerator := mock.NewMetricGenerator()
+			defer exploratoryGenerator.AssertExpectations(t)
+			exploratoryGenerator.On("Generate", entry, profile).Return(metrics, nil)
+
+			generator := NewKindGenerator(defaultGenerator, map[job.Kind]protocol.MetricGenerator{job.KindExploratory: exploratoryGenerator})
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, metrics, result)
+		})
+		t.Run("should generate metrics with default generator when the profile kind has no generator", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{ID: "1234"}
+
+			defaultGenerator := mock.NewMetricGenerator()
+			defer defaultGenerator.AssertExpectations(t)
+			defaultGenerator.On("Generate", entry, profile).Return(metrics, nil)
+
+			exploratoryGenerator := mock.NewMetricGenerator()
+			defer exploratoryGenerator.AssertExpectations(t)
+
+			generator := NewKindGenerator(defaultGenerator, map[job.Kind]protocol.MetricGenerator{job.KindExploratory: exploratoryGenerator})
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, metrics, result)
+		})
+	})
+}
+
 func TestDefaultProfileStatisticGenerator(t *testing.T) {
 	t.Run("Generate", func(t *testing.T) {
 		t.Run("should generate total records", func(t *testing.T) {
@@ -270,7 +312,11 @@ func TestDefaultProfileStatisticGenerator(t *testing.T) {
 				URN:       "sample-project.sample_dataset.sample_table",
 			}
 
-			tableMeta := &meta.TableSpec{}
+			tableMeta := &meta.TableSpec{
+				Fields: []*meta.FieldSpec{
+					{Name: "field_status", FieldType: meta.FieldTypeString, Mode: meta.ModeNullable, Level: meta.RootLevel},
+				},
+			}
 
 			queryString := "SELECT count(*) AS total_records FROM `sample-project.sample_dataset.sample_table` WHERE field_status = 'sample_status'"
 			rows := []protocol.Row{
@@ -295,15 +341,24 @@ func TestDefaultProfileStatisticGenerator(t *testing.T) {
 			profileStore := mock.NewProfileStore()
 			defer profileStore.AssertExpectations(t)
 
+			schemaStore := mock.NewSchemaStore()
+			defer schemaStore.AssertExpectations(t)
+
 			metadataStore.On("GetMetadata", profile.URN).Return(tableMeta, nil)
 
+			schemaStore.On("Store", &protocol.SchemaSnapshot{
+				ProfileID: profile.ID,
+				URN:       profile.URN,
+				Columns:   []*meta.ColumnSchema{{Name: "field_status", Type: meta.FieldTypeString, Mode: meta.ModeNullable}},
+			}).Return(nil)
+
 			queryExecutor.On("Run", profile, queryString, job.StatisticalQuery).Return(rows, nil)
 
 			profileStore.On("Update", updatedProfile).Return(nil)
 
-			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore)
+			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore, schemaStore)
 
-			err := statisticGenerator.Generate(profile)
+			err := statisticGenerator.Generate(protocol.NewEntry(), profile)
 
 			assert.Nil(t, err)
 			assert.Equal(t, totalRecords, profile.TotalRecords)
@@ -331,13 +386,18 @@ func TestDefaultProfileStatisticGenerator(t *testing.T) {
 			profileStore := mock.NewProfileStore()
 			defer profileStore.AssertExpectations(t)
 
+			schemaStore := mock.NewSchemaStore()
+			defer schemaStore.AssertExpectations(t)
+
 			metadataStore.On("GetMetadata", profile.URN).Return(tableMeta, nil)
 
+			schemaStore.On("Store", &protocol.SchemaSnapshot{ProfileID: profile.ID, URN: profile.URN}).Return(nil)
+
 			queryExecutor.On("Run", profile, queryString, job.StatisticalQuery).Return(rows, someError)
 
-			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore)
+			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore, schemaStore)
 
-			err := statisticGenerator.Generate(profile)
+			err := statisticGenerator.Generate(protocol.NewEntry(), profile)
 
 			assert.Error(t, err)
 		})
@@ -363,13 +423,18 @@ func TestDefaultProfileStatisticGenerator(t *testing.T) {
 			profileStore := mock.NewProfileStore()
 			defer profileStore.AssertExpectations(t)
 
+			schemaStore := mock.NewSchemaStore()
+			defer schemaStore.AssertExpectations(t)
+
 			metadataStore.On("GetMetadata", profile.URN).Return(tableMeta, nil)
 
+			schemaStore.On("Store", &protocol.SchemaSnapshot{ProfileID: profile.ID, URN: profile.URN}).Return(nil)
+
 			queryExecutor.On("Run", profile, queryString, job.StatisticalQuery).Return(rows, nil)
 
-			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore)
+			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore, schemaStore)
 
-			err := statisticGenerator.Generate(profile)
+			err := statisticGenerator.Generate(protocol.NewEntry(), profile)
 
 			assert.Error(t, err)
 		})
@@ -397,13 +462,18 @@ func TestDefaultProfileStatisticGenerator(t *testing.T) {
 			profileStore := mock.NewProfileStore()
 			defer profileStore.AssertExpectations(t)
 
+			schemaStore := mock.NewSchemaStore()
+			defer schemaStore.AssertExpectations(t)
+
 			metadataStore.On("GetMetadata", profile.URN).Return(tableMeta, nil)
 
+			schemaStore.On("Store", &protocol.SchemaSnapshot{ProfileID: profile.ID, URN: profile.URN}).Return(nil)
+
 			queryExecutor.On("Run", profile, queryString, job.StatisticalQuery).Return(rows, nil)
 
-			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore)
+			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore, schemaStore)
 
-			err := statisticGenerator.Generate(profile)
+			err := statisticGenerator.Generate(protocol.NewEntry(), profile)
 
 			assert.Error(t, err)
 		})
@@ -441,18 +511,48 @@ func TestDefaultProfileStatisticGenerator(t *testing.T) {
 			profileStore := mock.NewProfileStore()
 			defer profileStore.AssertExpectations(t)
 
+			schemaStore := mock.NewSchemaStore()
+			defer schemaStore.AssertExpectations(t)
+
 			metadataStore.On("GetMetadata", profile.URN).Return(tableMeta, nil)
 
+			schemaStore.On("Store", &protocol.SchemaSnapshot{ProfileID: profile.ID, URN: profile.URN}).Return(nil)
+
 			queryExecutor.On("Run", profile, queryString, job.StatisticalQuery).Return(rows, nil)
 
 			profileStore.On("Update", updatedProfile).Return(someError)
 
-			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore)
+			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore, schemaStore)
 
-			err := statisticGenerator.Generate(profile)
+			err := statisticGenerator.Generate(protocol.NewEntry(), profile)
 
 			assert.Error(t, err)
 		})
+		t.Run("should return error when store schema snapshot failed", func(t *testing.T) {
+			someError := errors.New("database error")
+			profile := &job.Profile{
+				ID:        "1234",
+				Filter:    "field_status = 'sample_status'",
+				GroupName: "field_grouping",
+				URN:       "sample-project.sample_dataset.sample_table",
+			}
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			schemaStore := mock.NewSchemaStore()
+			defer schemaStore.AssertExpectations(t)
+
+			metadataStore.On("GetMetadata", profile.URN).Return(&meta.TableSpec{}, nil)
+
+			schemaStore.On("Store", &protocol.SchemaSnapshot{ProfileID: profile.ID, URN: profile.URN}).Return(someError)
+
+			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, mock.NewQueryExecutor(), mock.NewProfileStore(), schemaStore)
+
+			err := statisticGenerator.Generate(protocol.NewEntry(), profile)
+
+			assert.Equal(t, someError, err)
+		})
 		t.Run("should return error when get metadata failed", func(t *testing.T) {
 			someError := errors.New("API error")
 			profile := &job.Profile{
@@ -473,12 +573,285 @@ func TestDefaultProfileStatisticGenerator(t *testing.T) {
 			profileStore := mock.NewProfileStore()
 			defer profileStore.AssertExpectations(t)
 
+			schemaStore := mock.NewSchemaStore()
+			defer schemaStore.AssertExpectations(t)
+
 			metadataStore.On("GetMetadata", profile.URN).Return(tableMeta, someError)
 
-			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore)
+			statisticGenerator := NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore, schemaStore)
+
+			err := statisticGenerator.Generate(protocol.NewEntry(), profile)
+
+			assert.Error(t, err)
+		})
+	})
+}
+
+func TestIncrementalGenerator(t *testing.T) {
+	t.Run("Generate", func(t *testing.T) {
+		urn := "sample-project.sample_dataset.sample_table"
+		partitionExpression := "DATE(field_timestamp,\"UTC\")"
+		lastProfileTime := time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)
+		profileTime := time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)
+		maxCarryForward := 7 * 24 * time.Hour
+
+		t.Run("should profile modified partitions and take the other partitions from the last completed profile", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{
+				ID:             "1234",
+				Filter:         "field_status = 'sample_status'",
+				GroupName:      partitionExpression,
+				Mode:           job.ModeIncremental,
+				URN:            urn,
+				EventTimestamp: profileTime,
+			}
+			lastProfile := &job.Profile{
+				ID:             "1233",
+				Filter:         "field_status = 'sample_status'",
+				GroupName:      partitionExpression,
+				URN:            urn,
+				EventTimestamp: lastProfileTime,
+			}
+			firstProfileTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			partitionIDs := []string{"20210102"}
+			partitionFilter := "(DATE(field_timestamp,\"UTC\") IN (DATE \"2021-01-02\"))"
+
+			incrementalProfile := *profile
+			incrementalProfile.Filter = "(field_status = 'sample_status') AND " + partitionFilter
+
+			metrics := []*metric.Metric{
+				{Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-02", Value: 20},
+			}
+			lastMetrics := []*metric.Metric{
+				{ID: "1", Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-01", Value: 10, Timestamp: firstProfileTime},
+				{ID: "2", Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-02", Value: 15, Timestamp: lastProfileTime},
+				{ID: "3", Type: metric.DuplicationPct, Category: metric.Quality, Owner: metric.Table, GroupValue: "2021-01-01", Value: 0, Timestamp: lastProfileTime},
+			}
+			unmodifiedMetrics := []*metric.Metric{
+				{Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-01", Value: 10, Timestamp: firstProfileTime},
+			}
+
+			expressionFactory := mock.NewSQLExpressionFactory()
+			defer expressionFactory.AssertExpectations(t)
+			expressionFactory.On("CreatePartitionExpression", urn).Return(partitionExpression, nil)
+			expressionFactory.On("CreatePartitionFilterExpression", urn, partitionIDs).Return(partitionFilter, nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			profileStore.On("GetLastCompleted", profile).Return(lastProfile, nil)
+
+			partitionScanner := mock.NewPartitionScanner()
+			defer partitionScanner.AssertExpectations(t)
+			partitionScanner.On("GetAffectedPartition", urn, lastProfileTime).Return(partitionIDs, nil)
+
+			basicMetricGenerator := mock.NewMetricGenerator()
+			defer basicMetricGenerator.AssertExpectations(t)
+			basicMetricGenerator.On("Generate", entry, &incrementalProfile).Return(metrics, nil)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetricsByProfileID", lastProfile.ID).Return(lastMetrics, nil)
+			metricStore.On("Store", profile, unmodifiedMetrics).Return(nil)
+
+			generator := NewIncrementalGenerator(basicMetricGenerator, profileStore, metricStore, partitionScanner, expressionFactory, maxCarryForward)
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, append(metrics, unmodifiedMetrics...), result)
+		})
+		t.Run("should profile all records when mode is complete", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{
+				ID:        "1234",
+				GroupName: partitionExpression,
+				Mode:      job.ModeComplete,
+				URN:       urn,
+			}
+			metrics := []*metric.Metric{
+				{Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-02", Value: 20},
+			}
+
+			basicMetricGenerator := mock.NewMetricGenerator()
+			defer basicMetricGenerator.AssertExpectations(t)
+			basicMetricGenerator.On("Generate", entry, profile).Return(metrics, nil)
+
+			generator := NewIncrementalGenerator(basicMetricGenerator, mock.NewProfileStore(), mock.NewMetricStore(), mock.NewPartitionScanner(), mock.NewSQLExpressionFactory(), maxCarryForward)
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, metrics, result)
+		})
+		t.Run("should profile all records when profile is not grouped by partition", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{
+				ID:        "1234",
+				GroupName: "field_status",
+				Mode:      job.ModeIncremental,
+				URN:       urn,
+			}
+			metrics := []*metric.Metric{
+				{Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "active", Value: 20},
+			}
+
+			expressionFactory := mock.NewSQLExpressionFactory()
+			defer expressionFactory.AssertExpectations(t)
+			expressionFactory.On("CreatePartitionExpression", urn).Return(partitionExpression, nil)
+
+			basicMetricGenerator := mock.NewMetricGenerator()
+			defer basicMetricGenerator.AssertExpectations(t)
+			basicMetricGenerator.On("Generate", entry, profile).Return(metrics, nil)
+
+			generator := NewIncrementalGenerator(basicMetricGenerator, mock.NewProfileStore(), mock.NewMetricStore(), mock.NewPartitionScanner(), expressionFactory, maxCarryForward)
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, metrics, result)
+		})
+		t.Run("should profile all records when there is no completed profile", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{
+				ID:        "1234",
+				GroupName: partitionExpression,
+				Mode:      job.ModeIncremental,
+				URN:       urn,
+			}
+			metrics := []*metric.Metric{
+				{Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-02", Value: 20},
+			}
+
+			expressionFactory := mock.NewSQLExpressionFactory()
+			defer expressionFactory.AssertExpectations(t)
+			expressionFactory.On("CreatePartitionExpression", urn).Return(partitionExpression, nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			profileStore.On("GetLastCompleted", profile).Return(&job.Profile{}, protocol.ErrProfileNotFound)
+
+			basicMetricGenerator := mock.NewMetricGenerator()
+			defer basicMetricGenerator.AssertExpectations(t)
+			basicMetricGenerator.On("Generate", entry, profile).Return(metrics, nil)
+
+			generator := NewIncrementalGenerator(basicMetricGenerator, profileStore, mock.NewMetricStore(), mock.NewPartitionScanner(), expressionFactory, maxCarryForward)
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, metrics, result)
+		})
+		t.Run("should profile all records when the last completed profile is older than max carry forward age", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{
+				ID:             "1234",
+				GroupName:      partitionExpression,
+				Mode:           job.ModeIncremental,
+				URN:            urn,
+				EventTimestamp: profileTime,
+			}
+			lastProfile := &job.Profile{
+				ID:             "1233",
+				EventTimestamp: profileTime.Add(-8 * 24 * time.Hour),
+			}
+			metrics := []*metric.Metric{
+				{Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-02", Value: 20},
+			}
+
+			expressionFactory := mock.NewSQLExpressionFactory()
+			defer expressionFactory.AssertExpectations(t)
+			expressionFactory.On("CreatePartitionExpression", urn).Return(partitionExpression, nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			profileStore.On("GetLastCompleted", profile).Return(lastProfile, nil)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetricsByProfileID", lastProfile.ID).Return([]*metric.Metric(nil), protocol.ErrNoProfileMetricFound)
+
+			basicMetricGenerator := mock.NewMetricGenerator()
+			defer basicMetricGenerator.AssertExpectations(t)
+			basicMetricGenerator.On("Generate", entry, profile).Return(metrics, nil)
+
+			generator := NewIncrementalGenerator(basicMetricGenerator, profileStore, metricStore, mock.NewPartitionScanner(), expressionFactory, maxCarryForward)
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, metrics, result)
+		})
+		t.Run("should profile all records when carried metric is older than max carry forward age", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{
+				ID:             "1234",
+				GroupName:      partitionExpression,
+				Mode:           job.ModeIncremental,
+				URN:            urn,
+				EventTimestamp: profileTime,
+			}
+			lastProfile := &job.Profile{
+				ID:             "1233",
+				EventTimestamp: lastProfileTime,
+			}
+			lastMetrics := []*metric.Metric{
+				{ID: "1", Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2020-12-01", Value: 10, Timestamp: profileTime.Add(-30 * 24 * time.Hour)},
+				{ID: "2", Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-02", Value: 15, Timestamp: lastProfileTime},
+			}
+			metrics := []*metric.Metric{
+				{Type: metric.Count, Category: metric.Basic, Owner: metric.Table, GroupValue: "2021-01-02", Value: 20},
+			}
+
+			expressionFactory := mock.NewSQLExpressionFactory()
+			defer expressionFactory.AssertExpectations(t)
+			expressionFactory.On("CreatePartitionExpression", urn).Return(partitionExpression, nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			profileStore.On("GetLastCompleted", profile).Return(lastProfile, nil)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetricsByProfileID", lastProfile.ID).Return(lastMetrics, nil)
+
+			basicMetricGenerator := mock.NewMetricGenerator()
+			defer basicMetricGenerator.AssertExpectations(t)
+			basicMetricGenerator.On("Generate", entry, profile).Return(metrics, nil)
+
+			generator := NewIncrementalGenerator(basicMetricGenerator, profileStore, metricStore, mock.NewPartitionScanner(), expressionFactory, maxCarryForward)
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, metrics, result)
+		})
+		t.Run("should return error when scan partition failed", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{
+				ID:        "1234",
+				GroupName: partitionExpression,
+				Mode:      job.ModeIncremental,
+				URN:       urn,
+			}
+			lastProfile := &job.Profile{
+				ID:             "1233",
+				EventTimestamp: lastProfileTime,
+			}
+
+			expressionFactory := mock.NewSQLExpressionFactory()
+			defer expressionFactory.AssertExpectations(t)
+			expressionFactory.On("CreatePartitionExpression", urn).Return(partitionExpression, nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			profileStore.On("GetLastCompleted", profile).Return(lastProfile, nil)
+
+			partitionScanner := mock.NewPartitionScanner()
+			defer partitionScanner.AssertExpectations(t)
+			partitionScanner.On("GetAffectedPartition", urn, lastProfileTime).Return([]string{}, errors.New("API error"))
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetricsByProfileID", lastProfile.ID).Return([]*metric.Metric(nil), protocol.ErrNoProfileMetricFound)
 
-			err := statisticGenerator.Generate(profile)
+			generator := NewIncrementalGenerator(mock.NewMetricGenerator(), profileStore, metricStore, partitionScanner, expressionFactory, maxCarryForward)
+			result, err := generator.Generate(entry, profile)
 
+			assert.Nil(t, result)
 			assert.Error(t, err)
 		})
 	})
@@ -9,6 +9,7 @@ import (
 	"github.com/odpf/predator/protocol/xlog"
 	"github.com/odpf/predator/stats"
 	"log"
+	"math"
 	"os"
 	"time"
 )
@@ -51,19 +52,7 @@ func (m *BasicMetricProfiler) Profile(entry protocol.Entry, profile *job.Profile
 
 	resultChan := make(chan *result, 2)
 
-	var fieldMetricSpecs []*metric.Spec
-	for _, spec := range metricSpecs {
-		if spec.Owner == metric.Field {
-			fieldMetricSpecs = append(fieldMetricSpecs, spec)
-		}
-	}
-
-	var tableMetricSpecs []*metric.Spec
-	for _, spec := range metricSpecs {
-		if spec.Owner == metric.Table {
-			tableMetricSpecs = append(tableMetricSpecs, spec)
-		}
-	}
+	tableMetricSpecs, fieldMetricSpecs := splitByOwner(metricSpecs)
 
 	go func() {
 		tableMetrics, err := m.tableProfiler.Profile(entry, profile, tableMetricSpecs)
@@ -107,6 +96,38 @@ func (m *BasicMetricProfiler) Profile(entry protocol.Entry, profile *job.Profile
 	return metrics, err
 }
 
+//Plan plan queries of table and field profiler
+func (m *BasicMetricProfiler) Plan(profile *job.Profile, metricSpecs []*metric.Spec) ([]*job.Query, error) {
+	tableMetricSpecs, fieldMetricSpecs := splitByOwner(metricSpecs)
+
+	tableQueries, err := m.tableProfiler.Plan(profile, tableMetricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldQueries, err := m.fieldProfiler.Plan(profile, fieldMetricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tableQueries, fieldQueries...), nil
+}
+
+func splitByOwner(metricSpecs []*metric.Spec) (tableMetricSpecs []*metric.Spec, fieldMetricSpecs []*metric.Spec) {
+	for _, spec := range metricSpecs {
+		if spec.Owner == metric.Field {
+			fieldMetricSpecs = append(fieldMetricSpecs, spec)
+		}
+	}
+
+	for _, spec := range metricSpecs {
+		if spec.Owner == metric.Table {
+			tableMetricSpecs = append(tableMetricSpecs, spec)
+		}
+	}
+	return tableMetricSpecs, fieldMetricSpecs
+}
+
 type result struct {
 	Value []*metric.Metric
 	Error error
@@ -186,6 +207,12 @@ func (m *QualityMetricProfiler) Profile(entry protocol.Entry, profile *job.Profi
 		}
 	}
 
+	trendMetrics, err := m.calculateTrendInconsistencyMetrics(profile, preCalculatedMetrics, metricSpecs)
+	if err != nil {
+		return nil, err
+	}
+	qualityMetrics = append(qualityMetrics, trendMetrics...)
+
 	msg = xlog.Format("quality metrics calculation finished", xlog.NewValue("profile_id", profile.ID))
 	logger.Println(msg)
 
@@ -198,6 +225,106 @@ func (m *QualityMetricProfiler) Profile(entry protocol.Entry, profile *job.Profi
 	return qualityMetrics, nil
 }
 
+//Plan quality metrics are calculated from stored metrics, no query is run
+func (m *QualityMetricProfiler) Plan(profile *job.Profile, metricSpecs []*metric.Spec) ([]*job.Query, error) {
+	return nil, nil
+}
+
+//calculateTrendInconsistencyMetrics compare count of every group with the same group on previous profiles of the table
+func (m *QualityMetricProfiler) calculateTrendInconsistencyMetrics(profile *job.Profile, metrics []*metric.Metric, metricSpecs []*metric.Spec) ([]*metric.Metric, error) {
+	var trendSpecs []*metric.Spec
+	lookback := 0
+	for _, spec := range metricSpecs {
+		if spec.Name != metric.TrendInconsistencyPct {
+			continue
+		}
+		trendSpecs = append(trendSpecs, spec)
+
+		specLookback := metric.GetTrendLookback(spec.Metadata)
+		if specLookback > lookback {
+			lookback = specLookback
+		}
+	}
+
+	if len(trendSpecs) == 0 {
+		return nil, nil
+	}
+
+	previousMetrics, err := m.metricStore.GetPreviousMetrics(profile, lookback)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get previous metrics of %s ,%w", profile.URN, err)
+	}
+
+	var trendMetrics []*metric.Metric
+	for _, spec := range trendSpecs {
+		trendMetrics = append(trendMetrics, calculateTrendInconsistencyMetric(spec, metrics, previousMetrics))
+	}
+	return trendMetrics, nil
+}
+
+//calculateTrendInconsistencyMetric calculate percentage of groups which count deviates from the average count
+//of the same group on previous profiles more than the threshold, groups without history are not compared
+func calculateTrendInconsistencyMetric(spec *metric.Spec, metrics []*metric.Metric, previousMetrics []*metric.Metric) *metric.Metric {
+	thresholdPct := metric.GetTrendThresholdPct(spec.Metadata)
+
+	currentCounts := metric.NewFinder(metrics).
+		WithOwner(spec.Owner).
+		WithFieldID(spec.FieldID).
+		WithType(metric.Count).
+		Find()
+
+	previousCountsByGroup := make(map[string][]*metric.Metric)
+	previousCounts := metric.NewFinder(previousMetrics).
+		WithOwner(spec.Owner).
+		WithFieldID(spec.FieldID).
+		WithType(metric.Count).
+		Find()
+	for _, c := range previousCounts {
+		previousCountsByGroup[c.GroupValue] = append(previousCountsByGroup[c.GroupValue], c)
+	}
+
+	var compared, inconsistent int
+	for _, current := range currentCounts {
+		history, ok := previousCountsByGroup[current.GroupValue]
+		if !ok {
+			continue
+		}
+		compared++
+
+		var total float64
+		for _, h := range history {
+			total += h.Value
+		}
+		baseline := total / float64(len(history))
+
+		if baseline == 0 {
+			if current.Value != 0 {
+				inconsistent++
+			}
+			continue
+		}
+
+		deviationPct := math.Abs(current.Value-baseline) / baseline * 100
+		if deviationPct > thresholdPct {
+			inconsistent++
+		}
+	}
+
+	var value float64
+	if compared > 0 {
+		value = float64(inconsistent) / float64(compared) * 100
+	}
+
+	return &metric.Metric{
+		FieldID:  spec.FieldID,
+		Type:     metric.TrendInconsistencyPct,
+		Category: metric.Quality,
+		Owner:    spec.Owner,
+		Metadata: spec.Metadata,
+		Value:    value,
+	}
+}
+
 func calculateQualityMetric(metrics []*metric.Metric, metricSpecs []*metric.Spec) ([]*metric.Metric, error) {
 	var qualityMetrics []*metric.Metric
 
@@ -266,6 +393,16 @@ func calculateTableQualityMetric(metrics []*metric.Metric, metricSpecs []*metric
 		case metric.RowCount:
 			rowCountMetric := calculateRowCountMetric(recordCountMetric)
 			qualityMetrics = append(qualityMetrics, rowCountMetric)
+		case metric.OrphanPct:
+			orphanMetric, err := calculateOrphanMetric(ms, tableMetrics, recordCountMetric)
+			if err != nil {
+				return nil, fmt.Errorf("unable to calculate %v ,%w", ms, err)
+			}
+			qualityMetrics = append(qualityMetrics, orphanMetric)
+		case metric.FreshnessLagSeconds:
+			if freshnessMetric := calculateFreshnessMetric(ms, tableMetrics); freshnessMetric != nil {
+				qualityMetrics = append(qualityMetrics, freshnessMetric)
+			}
 		}
 	}
 
@@ -298,6 +435,17 @@ func calculateFieldQualityMetric(metrics []*metric.Metric, metricSpecs []*metric
 		}
 	}
 
+	for _, spec := range metricSpecs {
+		if !metric.IsConformity(spec.Name) {
+			continue
+		}
+		conformityMetric, err := calculateConformityMetric(spec, metrics)
+		if err != nil {
+			return nil, fmt.Errorf("unable to calculate %v ,%w", spec, err)
+		}
+		qualityMetrics = append(qualityMetrics, conformityMetric)
+	}
+
 	var invalidityMetricSpecs []*metric.Spec
 	for _, ms := range metricSpecs {
 		if ms.Name == metric.InvalidPct {
@@ -316,6 +464,42 @@ func calculateFieldQualityMetric(metrics []*metric.Metric, metricSpecs []*metric
 	return qualityMetrics, nil
 }
 
+//calculateConformityMetric calculate percentage of values that do not conform the rule, over the non null count of the field
+func calculateConformityMetric(spec *metric.Spec, metrics []*metric.Metric) (*metric.Metric, error) {
+	countType, _ := metric.GetConformityCountType(spec.Name)
+	countMetric := metric.NewFinder(metrics).
+		WithOwner(metric.Field).
+		WithFieldID(spec.FieldID).
+		WithType(countType).
+		FindOne()
+	if countMetric == nil {
+		return nil, fmt.Errorf("unable to get %s", countType)
+	}
+
+	nonNullCountMetric := metric.NewFinder(metrics).
+		WithOwner(metric.Field).
+		WithFieldID(spec.FieldID).
+		WithType(metric.Count).
+		FindOne()
+	if nonNullCountMetric == nil {
+		return nil, fmt.Errorf("unable to get %s of field %s", metric.Count, spec.FieldID)
+	}
+
+	var value float64
+	if nonNullCountMetric.Value != 0.0 {
+		value = countMetric.Value / nonNullCountMetric.Value * 100
+	}
+
+	return &metric.Metric{
+		FieldID:  spec.FieldID,
+		Type:     spec.Name,
+		Category: metric.Quality,
+		Owner:    metric.Field,
+		Metadata: spec.Metadata,
+		Value:    value,
+	}, nil
+}
+
 func calculateNullnessMetric(nullCountMetric *metric.Metric, recordCountMetric *metric.Metric) *metric.Metric {
 
 	var metricValue float64
@@ -332,6 +516,54 @@ func calculateNullnessMetric(nullCountMetric *metric.Metric, recordCountMetric *
 	}
 }
 
+//calculateOrphanMetric calculate percentage of rows which join keys are not found on the referenced table
+func calculateOrphanMetric(spec *metric.Spec, tableMetrics []*metric.Metric, recordCountMetric *metric.Metric) (*metric.Metric, error) {
+	reference, ok := metric.GetReference(spec.Metadata)
+	if !ok {
+		return nil, fmt.Errorf("reference of %s is not configured", spec.Name)
+	}
+
+	orphanCountMetric := metric.NewFinder(tableMetrics).
+		WithType(metric.OrphanCount).
+		WithReference(reference).
+		FindOne()
+	if orphanCountMetric == nil {
+		return nil, fmt.Errorf("unable to get %s of %s", metric.OrphanCount, reference.URN)
+	}
+
+	var value float64
+	if recordCountMetric.Value != 0.0 {
+		value = orphanCountMetric.Value / recordCountMetric.Value * 100
+	}
+
+	return &metric.Metric{
+		Type:     metric.OrphanPct,
+		Category: metric.Quality,
+		Owner:    metric.Table,
+		Metadata: spec.Metadata,
+		Value:    value,
+	}, nil
+}
+
+//calculateFreshnessMetric nil when the group has no timestamp value to measure the lag
+func calculateFreshnessMetric(spec *metric.Spec, tableMetrics []*metric.Metric) *metric.Metric {
+	freshnessLagMetric := metric.NewFinder(tableMetrics).
+		WithType(metric.FreshnessLag).
+		WithTimestampField(metric.GetTimestampField(spec.Metadata)).
+		FindOne()
+	if freshnessLagMetric == nil {
+		return nil
+	}
+
+	return &metric.Metric{
+		Type:     metric.FreshnessLagSeconds,
+		Category: metric.Quality,
+		Owner:    metric.Table,
+		Metadata: spec.Metadata,
+		Value:    freshnessLagMetric.Value,
+	}
+}
+
 func calculateRowCountMetric(recordCountMetric *metric.Metric) *metric.Metric {
 
 	return &metric.Metric{
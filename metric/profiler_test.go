@@ -396,6 +396,94 @@ func TestQualityMetricProfiler(t *testing.T) {
 			assert.Nil(t, err)
 			assert.ElementsMatch(t, expected, result)
 		})
+		t.Run("should calculate trend inconsistency metric using previous metrics", func(t *testing.T) {
+			urn := "sample-project.sample_dataset.sample_table"
+			profile := &job.Profile{
+				ID:           "job-1234",
+				URN:          urn,
+				TotalRecords: 20,
+			}
+			label := &protocol.Label{
+				Project: "sample-project",
+				Dataset: "sample_dataset",
+				Table:   "sample_table",
+			}
+			metricSpecs := []*metric.Spec{
+				{
+					Name:    metric.TrendInconsistencyPct,
+					TableID: urn,
+					Owner:   metric.Table,
+					Metadata: map[string]interface{}{
+						metric.TrendLookback: 3,
+					},
+				},
+			}
+
+			metrics := []*metric.Metric{
+				{
+					Category:   metric.Basic,
+					Owner:      metric.Table,
+					Type:       metric.Count,
+					Value:      200.0,
+					GroupValue: "1",
+				},
+				{
+					Category:   metric.Basic,
+					Owner:      metric.Table,
+					Type:       metric.Count,
+					Value:      10.0,
+					GroupValue: "2",
+				},
+			}
+			previousMetrics := []*metric.Metric{
+				{
+					Category:   metric.Basic,
+					Owner:      metric.Table,
+					Type:       metric.Count,
+					Value:      190.0,
+					GroupValue: "1",
+				},
+				{
+					Category:   metric.Basic,
+					Owner:      metric.Table,
+					Type:       metric.Count,
+					Value:      200.0,
+					GroupValue: "2",
+				},
+			}
+
+			expected := []*metric.Metric{
+				{
+					Category: metric.Quality,
+					Owner:    metric.Table,
+					Type:     metric.TrendInconsistencyPct,
+					Metadata: metricSpecs[0].Metadata,
+					Value:    50,
+				},
+			}
+
+			entry := protocol.NewEntry()
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetricsByProfileID", profile.ID).Return(metrics, nil)
+			metricStore.On("GetPreviousMetrics", profile, 3).Return(previousMetrics, nil)
+
+			profileStore := mock.NewProfileStoreStub()
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			statsClient := mock.NewDummyStats()
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			profiler := NewQualityMetricProfiler(metricStore, profileStore, statsClientBuilder)
+			result, err := profiler.Profile(entry, profile, metricSpecs)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
 		t.Run("should return error when get required metrics failed", func(t *testing.T) {
 			someError := errors.New("some error")
 			urn := "sample-project.sample_dataset.sample_table"
@@ -480,6 +568,63 @@ func TestQualityMetricProfiler(t *testing.T) {
 			assert.Nil(t, result)
 		})
 	})
+	t.Run("calculateTrendInconsistencyMetric", func(t *testing.T) {
+		t.Run("should return percentage of groups that deviate from previous profiles", func(t *testing.T) {
+			spec := &metric.Spec{
+				Name:  metric.TrendInconsistencyPct,
+				Owner: metric.Table,
+				Metadata: map[string]interface{}{
+					metric.TrendThresholdPct: 20,
+				},
+			}
+			metrics := []*metric.Metric{
+				{Owner: metric.Table, Type: metric.Count, Category: metric.Basic, GroupValue: "2021-01-01", Value: 100},
+				{Owner: metric.Table, Type: metric.Count, Category: metric.Basic, GroupValue: "2021-01-02", Value: 10},
+				{Owner: metric.Table, Type: metric.Count, Category: metric.Basic, GroupValue: "2021-01-03", Value: 50},
+				{Owner: metric.Table, Type: metric.UniqueCount, Category: metric.Basic, GroupValue: "2021-01-03", Value: 5},
+			}
+			previousMetrics := []*metric.Metric{
+				{Owner: metric.Table, Type: metric.Count, Category: metric.Basic, GroupValue: "2021-01-01", Value: 90},
+				{Owner: metric.Table, Type: metric.Count, Category: metric.Basic, GroupValue: "2021-01-01", Value: 110},
+				{Owner: metric.Table, Type: metric.Count, Category: metric.Basic, GroupValue: "2021-01-02", Value: 100},
+				{Owner: metric.Field, FieldID: "field1", Type: metric.Count, Category: metric.Basic, GroupValue: "2021-01-02", Value: 10},
+			}
+
+			expected := &metric.Metric{
+				Type:     metric.TrendInconsistencyPct,
+				Category: metric.Quality,
+				Owner:    metric.Table,
+				Metadata: spec.Metadata,
+				Value:    50,
+			}
+
+			result := calculateTrendInconsistencyMetric(spec, metrics, previousMetrics)
+
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should return zero when no previous profile found", func(t *testing.T) {
+			spec := &metric.Spec{
+				Name:    metric.TrendInconsistencyPct,
+				FieldID: "field1",
+				Owner:   metric.Field,
+			}
+			metrics := []*metric.Metric{
+				{Owner: metric.Field, FieldID: "field1", Type: metric.Count, Category: metric.Basic, Value: 100},
+			}
+
+			expected := &metric.Metric{
+				FieldID:  "field1",
+				Type:     metric.TrendInconsistencyPct,
+				Category: metric.Quality,
+				Owner:    metric.Field,
+				Value:    0,
+			}
+
+			result := calculateTrendInconsistencyMetric(spec, metrics, nil)
+
+			assert.Equal(t, expected, result)
+		})
+	})
 	t.Run("calculateQualityMetric", func(t *testing.T) {
 		t.Run("should return quality metrics", func(t *testing.T) {
 			fieldID := "field1"
@@ -658,5 +803,320 @@ func TestQualityMetricProfiler(t *testing.T) {
 			assert.Equal(t, qualityMetrics, result)
 			assert.Nil(t, err)
 		})
+		t.Run("should return conformity metrics over the non null count of the field", func(t *testing.T) {
+			acceptedValuesMetadata := map[string]interface{}{metric.AcceptedValues: []string{"A", "B"}}
+			lengthMetadata := map[string]interface{}{metric.MaxLength: 3}
+
+			metricSpecs := []*metric.Spec{
+				{
+					Name:     metric.AcceptedValuesPct,
+					FieldID:  "status",
+					Owner:    metric.Field,
+					Metadata: acceptedValuesMetadata,
+				},
+				{
+					Name:     metric.LengthOutOfRangePct,
+					FieldID:  "status",
+					Owner:    metric.Field,
+					Metadata: lengthMetadata,
+				},
+			}
+
+			metrics := []*metric.Metric{
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.Count,
+					Value:    200.0,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.Count,
+					Value:    100.0,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.UnacceptedCount,
+					Value:    50.0,
+					Metadata: acceptedValuesMetadata,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.LengthOutOfRangeCount,
+					Value:    10.0,
+					Metadata: lengthMetadata,
+				},
+			}
+
+			expected := []*metric.Metric{
+				{
+					Category: metric.Quality,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.AcceptedValuesPct,
+					Value:    50.0,
+					Metadata: acceptedValuesMetadata,
+				},
+				{
+					Category: metric.Quality,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.LengthOutOfRangePct,
+					Value:    10.0,
+					Metadata: lengthMetadata,
+				},
+			}
+
+			result, err := calculateQualityMetric(metrics, metricSpecs)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should return orphan metric of each reference", func(t *testing.T) {
+			customerReference := map[string]interface{}{
+				metric.ReferenceURN:    "project.dataset.customers",
+				metric.JoinFields:      []interface{}{"customer_id"},
+				metric.ReferenceFields: []interface{}{"id"},
+			}
+			productReference := map[string]interface{}{
+				metric.ReferenceURN:    "project.dataset.products",
+				metric.JoinFields:      []interface{}{"product_id"},
+				metric.ReferenceFields: []interface{}{"id"},
+			}
+
+			metricSpecs := []*metric.Spec{
+				{
+					Name:     metric.OrphanPct,
+					Owner:    metric.Table,
+					Metadata: customerReference,
+				},
+				{
+					Name:     metric.OrphanPct,
+					Owner:    metric.Table,
+					Metadata: productReference,
+				},
+			}
+
+			metrics := []*metric.Metric{
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.Count,
+					Value:    200.0,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.OrphanCount,
+					Value:    10.0,
+					Metadata: productReference,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.OrphanCount,
+					Value:    2.0,
+					Metadata: customerReference,
+				},
+			}
+
+			expected := []*metric.Metric{
+				{
+					Category: metric.Quality,
+					Owner:    metric.Table,
+					Type:     metric.OrphanPct,
+					Value:    1.0,
+					Metadata: customerReference,
+				},
+				{
+					Category: metric.Quality,
+					Owner:    metric.Table,
+					Type:     metric.OrphanPct,
+					Value:    5.0,
+					Metadata: productReference,
+				},
+			}
+
+			result, err := calculateQualityMetric(metrics, metricSpecs)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should return freshness metric of each timestamp field and skip when lag is not available", func(t *testing.T) {
+			timestampMetadata := map[string]interface{}{metric.TimestampField: "event_timestamp"}
+			updatedMetadata := map[string]interface{}{metric.TimestampField: "updated_at"}
+
+			metricSpecs := []*metric.Spec{
+				{
+					Name:  metric.FreshnessLagSeconds,
+					Owner: metric.Table,
+				},
+				{
+					Name:     metric.FreshnessLagSeconds,
+					Owner:    metric.Table,
+					Metadata: timestampMetadata,
+				},
+				{
+					Name:     metric.FreshnessLagSeconds,
+					Owner:    metric.Table,
+					Metadata: updatedMetadata,
+				},
+			}
+
+			metrics := []*metric.Metric{
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.Count,
+					Value:    200.0,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.FreshnessLag,
+					Value:    5400.0,
+					Metadata: timestampMetadata,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.FreshnessLag,
+					Value:    7200.0,
+				},
+			}
+
+			expected := []*metric.Metric{
+				{
+					Category: metric.Quality,
+					Owner:    metric.Table,
+					Type:     metric.FreshnessLagSeconds,
+					Value:    7200.0,
+				},
+				{
+					Category: metric.Quality,
+					Owner:    metric.Table,
+					Type:     metric.FreshnessLagSeconds,
+					Value:    5400.0,
+					Metadata: timestampMetadata,
+				},
+			}
+
+			result, err := calculateQualityMetric(metrics, metricSpecs)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should return zero conformity metric when every value of the field is null", func(t *testing.T) {
+			patternMetadata := map[string]interface{}{metric.Pattern: "^[A-Z]$"}
+			metricSpecs := []*metric.Spec{
+				{
+					Name:     metric.PatternMismatchPct,
+					FieldID:  "status",
+					Owner:    metric.Field,
+					Metadata: patternMetadata,
+				},
+			}
+
+			metrics := []*metric.Metric{
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.Count,
+					Value:    200.0,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.Count,
+					Value:    0.0,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.PatternMismatchCount,
+					Value:    0.0,
+					Metadata: patternMetadata,
+				},
+			}
+
+			expected := []*metric.Metric{
+				{
+					Category: metric.Quality,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.PatternMismatchPct,
+					Value:    0.0,
+					Metadata: patternMetadata,
+				},
+			}
+
+			result, err := calculateQualityMetric(metrics, metricSpecs)
+
+			assert.Equal(t, expected, result)
+			assert.Nil(t, err)
+		})
+		t.Run("should return error when non null count of the field is not found", func(t *testing.T) {
+			metricSpecs := []*metric.Spec{
+				{
+					Name:     metric.PatternMismatchPct,
+					FieldID:  "status",
+					Owner:    metric.Field,
+					Metadata: map[string]interface{}{metric.Pattern: "^[A-Z]$"},
+				},
+			}
+
+			metrics := []*metric.Metric{
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.Count,
+					Value:    200.0,
+				},
+				{
+					Category: metric.Basic,
+					Owner:    metric.Field,
+					FieldID:  "status",
+					Type:     metric.PatternMismatchCount,
+					Value:    10.0,
+				},
+			}
+
+			result, err := calculateQualityMetric(metrics, metricSpecs)
+
+			assert.Nil(t, result)
+			assert.NotNil(t, err)
+		})
+		t.Run("should return error when conformity count metric is not found", func(t *testing.T) {
+			metricSpecs := []*metric.Spec{
+				{
+					Name:     metric.PatternMismatchPct,
+					FieldID:  "status",
+					Owner:    metric.Field,
+					Metadata: map[string]interface{}{metric.Pattern: "^[A-Z]$"},
+				},
+			}
+
+			metrics := []*metric.Metric{
+				{
+					Category: metric.Basic,
+					Owner:    metric.Table,
+					Type:     metric.Count,
+					Value:    200.0,
+				},
+			}
+
+			result, err := calculateQualityMetric(metrics, metricSpecs)
+
+			assert.Nil(t, result)
+			assert.NotNil(t, err)
+		})
 	})
 }
@@ -35,7 +35,13 @@ func (b *BasicMetricSpecGenerator) GenerateMetricSpec(urn string) ([]*metric.Spe
 	return b.Generate(tableSpec, toleranceSpec.Tolerances)
 }
 
+//Generate generate metric specs of the tolerances, tolerances with field selector are expanded to the selected fields of the table
 func (b *BasicMetricSpecGenerator) Generate(tableSpec *meta.TableSpec, tolerances []*protocol.Tolerance) ([]*metric.Spec, error) {
+	tolerances, err := protocol.ExpandFieldSelectors(tableSpec, tolerances)
+	if err != nil {
+		return nil, err
+	}
+
 	metricSpecs := b.generatePreRequisiteMetrics(tableSpec)
 
 	tableSpecs, err := b.generateTableMetricSpecs(tolerances)
@@ -78,6 +84,12 @@ func (b *BasicMetricSpecGenerator) generateTableMetricSpecs(tolerances []*protoc
 		if tolerance.MetricName == metric.InvalidPct {
 			specs = append(specs, generateInvalidCountMetric(tolerance))
 		}
+		if tolerance.MetricName == metric.OrphanPct {
+			specs = append(specs, generateOrphanCountMetric(tolerance))
+		}
+		if tolerance.MetricName == metric.FreshnessLagSeconds {
+			specs = append(specs, generateFreshnessLagMetric(tolerance))
+		}
 	}
 
 	return specs, nil
@@ -101,10 +113,13 @@ func (b *BasicMetricSpecGenerator) generateFieldMetricSpecs(tableSpec *meta.Tabl
 		if tolerance.MetricName == metric.NullnessPct {
 			specs = append(specs, generateNullCountMetric(tolerance))
 		}
-		if tolerance.MetricName == metric.Sum {
-			var sumSpecs = generateSumMetric(tableSpec, tolerance)
-			if sumSpecs != nil {
-				specs = append(specs, sumSpecs)
+		if metric.IsConformity(tolerance.MetricName) {
+			specs = append(specs, generateConformityCountMetric(tolerance))
+		}
+		if tolerance.MetricName == metric.Sum || metric.IsStatistical(tolerance.MetricName) {
+			var numericSpecs = generateNumericMetric(tableSpec, tolerance)
+			if numericSpecs != nil {
+				specs = append(specs, numericSpecs)
 			}
 		}
 	}
@@ -121,7 +136,8 @@ func generateCountMetric(tolerance *protocol.Tolerance) *metric.Spec {
 	}
 }
 
-func generateSumMetric(tableSpec *meta.TableSpec, tolerance *protocol.Tolerance) *metric.Spec {
+//generateNumericMetric generate spec of metric that only calculated on numeric field, such as sum and statistical metrics
+func generateNumericMetric(tableSpec *meta.TableSpec, tolerance *protocol.Tolerance) *metric.Spec {
 	var fieldsSpec []*meta.FieldSpec = tableSpec.Fields
 	var isValidField = false
 	for i := range fieldsSpec {
@@ -131,17 +147,21 @@ func generateSumMetric(tableSpec *meta.TableSpec, tolerance *protocol.Tolerance)
 			break
 		}
 	}
-	println(isValidField)
 	if !isValidField {
-		xlog.Info("Unable to calculate sum metric for non numeric field " + tolerance.FieldID)
+		xlog.Info(fmt.Sprintf("Unable to calculate %s metric for non numeric field %s", tolerance.MetricName, tolerance.FieldID))
 		return nil
 	}
-	return &metric.Spec{
-		Name:    metric.Sum,
+
+	spec := &metric.Spec{
+		Name:    tolerance.MetricName,
 		TableID: tolerance.TableURN,
 		FieldID: tolerance.FieldID,
 		Owner:   getOwner(tolerance),
 	}
+	if tolerance.MetricName == metric.Quantile {
+		spec.Metadata = tolerance.Metadata
+	}
+	return spec
 }
 
 func generateNullCountMetric(tolerance *protocol.Tolerance) *metric.Spec {
@@ -153,6 +173,38 @@ func generateNullCountMetric(tolerance *protocol.Tolerance) *metric.Spec {
 	}
 }
 
+//generateConformityCountMetric generate count of values that do not conform the rule in the tolerance metadata
+func generateConformityCountMetric(tolerance *protocol.Tolerance) *metric.Spec {
+	countType, _ := metric.GetConformityCountType(tolerance.MetricName)
+	return &metric.Spec{
+		Name:     countType,
+		TableID:  tolerance.TableURN,
+		FieldID:  tolerance.FieldID,
+		Metadata: tolerance.Metadata,
+		Owner:    metric.Field,
+	}
+}
+
+//generateOrphanCountMetric generate count of rows which join keys are not found on the referenced table in the tolerance metadata
+func generateOrphanCountMetric(tolerance *protocol.Tolerance) *metric.Spec {
+	return &metric.Spec{
+		Name:     metric.OrphanCount,
+		TableID:  tolerance.TableURN,
+		Metadata: tolerance.Metadata,
+		Owner:    metric.Table,
+	}
+}
+
+//generateFreshnessLagMetric generate lag of the timestamp field in the tolerance metadata, or of the table last modified time
+func generateFreshnessLagMetric(tolerance *protocol.Tolerance) *metric.Spec {
+	return &metric.Spec{
+		Name:     metric.FreshnessLag,
+		TableID:  tolerance.TableURN,
+		Metadata: tolerance.Metadata,
+		Owner:    metric.Table,
+	}
+}
+
 func generateInvalidCountMetric(tolerance *protocol.Tolerance) *metric.Spec {
 	return &metric.Spec{
 		Name:      metric.InvalidCount,
@@ -196,6 +248,11 @@ func NewQualityMetricSpecGenerator(metadataStore protocol.MetadataStore, toleran
 }
 
 func (q *QualityMetricSpecGenerator) Generate(tableSpec *meta.TableSpec, tolerances []*protocol.Tolerance) ([]*metric.Spec, error) {
+	tolerances, err := protocol.ExpandFieldSelectors(tableSpec, tolerances)
+	if err != nil {
+		return nil, err
+	}
+
 	metricSpecs := generateMetricSpec(tolerances)
 	return metricSpecs, nil
 }
@@ -246,6 +303,15 @@ func generateTableMetricSpecs(tolerances []*protocol.Tolerance) []*metric.Spec {
 		if tolerance.MetricName == metric.InvalidPct {
 			specs = append(specs, generateInvalidityPctMetric(tolerance))
 		}
+		if tolerance.MetricName == metric.TrendInconsistencyPct {
+			specs = append(specs, generateTrendInconsistencyMetric(tolerance))
+		}
+		if tolerance.MetricName == metric.OrphanPct {
+			specs = append(specs, generateOrphanPctMetric(tolerance))
+		}
+		if tolerance.MetricName == metric.FreshnessLagSeconds {
+			specs = append(specs, generateFreshnessLagSecondsMetric(tolerance))
+		}
 	}
 
 	return specs
@@ -267,6 +333,9 @@ func generateFieldMetricSpecs(tolerances []*protocol.Tolerance) []*metric.Spec {
 		if tolerance.MetricName == metric.InvalidPct {
 			specs = append(specs, generateInvalidityPctMetric(tolerance))
 		}
+		if metric.IsConformity(tolerance.MetricName) {
+			specs = append(specs, generateConformityPctMetric(tolerance))
+		}
 	}
 	return specs
 }
@@ -301,8 +370,9 @@ func generateTrendInconsistencyMetric(tolerance *protocol.Tolerance) *metric.Spe
 		Name:     metric.TrendInconsistencyPct,
 		TableID:  tolerance.TableURN,
 		FieldID:  tolerance.FieldID,
+		Metadata: tolerance.Metadata,
 		Optional: true,
-		Owner:    metric.Field,
+		Owner:    getOwner(tolerance),
 	}
 }
 
@@ -316,6 +386,80 @@ func generateInvalidityPctMetric(tolerance *protocol.Tolerance) *metric.Spec {
 	}
 }
 
+func generateOrphanPctMetric(tolerance *protocol.Tolerance) *metric.Spec {
+	return &metric.Spec{
+		Name:     metric.OrphanPct,
+		TableID:  tolerance.TableURN,
+		Metadata: tolerance.Metadata,
+		Owner:    metric.Table,
+	}
+}
+
+func generateFreshnessLagSecondsMetric(tolerance *protocol.Tolerance) *metric.Spec {
+	return &metric.Spec{
+		Name:     metric.FreshnessLagSeconds,
+		TableID:  tolerance.TableURN,
+		Metadata: tolerance.Metadata,
+		Owner:    metric.Table,
+	}
+}
+
+func generateConformityPctMetric(tolerance *protocol.Tolerance) *metric.Spec {
+	return &metric.Spec{
+		Name:     tolerance.MetricName,
+		TableID:  tolerance.TableURN,
+		FieldID:  tolerance.FieldID,
+		Metadata: tolerance.Metadata,
+		Owner:    metric.Field,
+	}
+}
+
+//CustomSQLSpecGenerator generate spec of custom sql metrics configured on the tolerance spec
+type CustomSQLSpecGenerator struct {
+	metadataStore  protocol.MetadataStore
+	toleranceStore protocol.ToleranceStore
+}
+
+//NewCustomSQLSpecGenerator create CustomSQLSpecGenerator
+func NewCustomSQLSpecGenerator(metadataStore protocol.MetadataStore, toleranceStore protocol.ToleranceStore) *CustomSQLSpecGenerator {
+	return &CustomSQLSpecGenerator{metadataStore: metadataStore, toleranceStore: toleranceStore}
+}
+
+//Generate generate a spec for each custom sql tolerance
+func (c *CustomSQLSpecGenerator) Generate(tableSpec *meta.TableSpec, tolerances []*protocol.Tolerance) ([]*metric.Spec, error) {
+	var specs []*metric.Spec
+	for _, tolerance := range tolerances {
+		if tolerance.MetricName != metric.CustomSQL {
+			continue
+		}
+		specs = append(specs, &metric.Spec{
+			Name:     metric.CustomSQL,
+			TableID:  tolerance.TableURN,
+			Metadata: tolerance.Metadata,
+			Owner:    metric.Table,
+		})
+	}
+	return specs, nil
+}
+
+func (c *CustomSQLSpecGenerator) GenerateMetricSpec(urn string) ([]*metric.Spec, error) {
+	toleranceSpec, err := c.toleranceStore.GetByTableID(urn)
+	if err != nil {
+		e := fmt.Errorf("failed to try to get toleranceSpec for table %s ,%w", urn, err)
+		logger.Println(e)
+		return nil, e
+	}
+
+	tableSpec, err := c.metadataStore.GetMetadata(urn)
+	if err != nil {
+		e := fmt.Errorf("failed to try to get metadata for table %s ,%w", urn, err)
+		logger.Println(e)
+		return nil, e
+	}
+
+	return c.Generate(tableSpec, toleranceSpec.Tolerances)
+}
+
 func getOwner(tolerance *protocol.Tolerance) metric.Owner {
 	if tolerance.FieldID == "" {
 		return metric.Table
@@ -227,6 +227,63 @@ func TestMetricSpecGenerator(t *testing.T) {
 				gms := &BasicMetricSpecGenerator{}
 				actualSpecs, err := gms.Generate(tableSpec, tolerances)
 
+				assert.Equal(t, expectedSpecs, actualSpecs)
+				assert.Nil(t, err)
+			})
+			t.Run("should return field metric of fields selected by field selector", func(t *testing.T) {
+				tableSpec := &meta.TableSpec{
+					ProjectName: projectName,
+					DatasetName: datasetName,
+					TableName:   tableName,
+					Fields: []*meta.FieldSpec{
+						{Name: "user_id", FieldType: meta.FieldTypeString, Level: 1},
+						{Name: "name", FieldType: meta.FieldTypeString, Level: 1},
+						{Name: "order_id", FieldType: meta.FieldTypeString, Level: 1},
+					},
+				}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:      tableSpec.TableID(),
+						MetricName:    metric.NullnessPct,
+						FieldSelector: &protocol.FieldSelector{FieldID: "*_id"},
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						TableID: tableID,
+						Name:    metric.Count,
+						Owner:   metric.Table,
+					},
+					{
+						FieldID: "user_id",
+						TableID: tableID,
+						Name:    metric.Count,
+						Owner:   metric.Field,
+					},
+					{
+						FieldID: "user_id",
+						TableID: tableID,
+						Name:    metric.NullCount,
+						Owner:   metric.Field,
+					},
+					{
+						FieldID: "order_id",
+						TableID: tableID,
+						Name:    metric.Count,
+						Owner:   metric.Field,
+					},
+					{
+						FieldID: "order_id",
+						TableID: tableID,
+						Name:    metric.NullCount,
+						Owner:   metric.Field,
+					},
+				}
+
+				gms := &BasicMetricSpecGenerator{}
+				actualSpecs, err := gms.Generate(tableSpec, tolerances)
+
 				assert.Equal(t, expectedSpecs, actualSpecs)
 				assert.Nil(t, err)
 			})
@@ -299,6 +356,78 @@ func TestMetricSpecGenerator(t *testing.T) {
 				result := gms.generateFieldMetricSpecs(tableSpec, tolerances)
 				assert.Equal(t, expectedSpecs, result)
 			})
+			t.Run("should return statistical metric specs of numeric fields", func(t *testing.T) {
+				tableSpec := &meta.TableSpec{
+					ProjectName: projectName,
+					DatasetName: datasetName,
+					TableName:   tableName,
+					Fields: []*meta.FieldSpec{
+						{
+							Name:      "field1",
+							FieldType: meta.FieldTypeString,
+							Mode:      meta.ModeNullable,
+							Level:     1,
+						},
+						{
+							Name:      "field2",
+							FieldType: meta.FieldTypeFloat,
+							Mode:      meta.ModeNullable,
+							Level:     1,
+						},
+					},
+				}
+				quantileMetadata := map[string]interface{}{metric.QuantileFraction: 0.99}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:   tableSpec.TableID(),
+						MetricName: metric.Max,
+						FieldID:    "field1",
+					},
+					{
+						TableURN:   tableSpec.TableID(),
+						MetricName: metric.StdDev,
+						FieldID:    "field2",
+					},
+					{
+						TableURN:   tableSpec.TableID(),
+						MetricName: metric.Quantile,
+						FieldID:    "field2",
+						Metadata:   quantileMetadata,
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						FieldID: "field1",
+						TableID: tableID,
+						Name:    metric.Count,
+						Owner:   metric.Field,
+					},
+					{
+						FieldID: "field2",
+						TableID: tableID,
+						Name:    metric.Count,
+						Owner:   metric.Field,
+					},
+					{
+						FieldID: "field2",
+						TableID: tableID,
+						Name:    metric.StdDev,
+						Owner:   metric.Field,
+					},
+					{
+						FieldID:  "field2",
+						TableID:  tableID,
+						Name:     metric.Quantile,
+						Owner:    metric.Field,
+						Metadata: quantileMetadata,
+					},
+				}
+
+				gms := &BasicMetricSpecGenerator{}
+				result := gms.generateFieldMetricSpecs(tableSpec, tolerances)
+				assert.Equal(t, expectedSpecs, result)
+			})
 			t.Run("should return metric specs for child fields", func(t *testing.T) {
 				tableSpec := &meta.TableSpec{
 					ProjectName:    projectName,
@@ -458,6 +587,69 @@ func TestMetricSpecGenerator(t *testing.T) {
 
 				assert.Equal(t, expectedSpecs, actualSpecs)
 			})
+			t.Run("should return conformity count metrics with the rule metadata", func(t *testing.T) {
+				tableSpec := &meta.TableSpec{
+					ProjectName: projectName,
+					DatasetName: datasetName,
+					TableName:   tableName,
+					Fields: []*meta.FieldSpec{
+						{
+							Name:      "status",
+							FieldType: meta.FieldTypeString,
+							Mode:      meta.ModeNullable,
+							Level:     1,
+						},
+					},
+				}
+
+				tableID := tableSpec.TableID()
+				acceptedValuesMetadata := map[string]interface{}{metric.AcceptedValues: []string{"A", "B"}}
+				patternMetadata := map[string]interface{}{metric.Pattern: "^[A-Z]$"}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:       tableID,
+						FieldID:        "status",
+						MetricName:     metric.AcceptedValuesPct,
+						Metadata:       acceptedValuesMetadata,
+						ToleranceRules: []protocol.ToleranceRule{lessThanOrEqZeroRule},
+					},
+					{
+						TableURN:       tableID,
+						FieldID:        "status",
+						MetricName:     metric.PatternMismatchPct,
+						Metadata:       patternMetadata,
+						ToleranceRules: []protocol.ToleranceRule{lessThanOrEqZeroRule},
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						FieldID: "status",
+						TableID: tableID,
+						Name:    metric.Count,
+						Owner:   metric.Field,
+					},
+					{
+						FieldID:  "status",
+						TableID:  tableID,
+						Name:     metric.UnacceptedCount,
+						Metadata: acceptedValuesMetadata,
+						Owner:    metric.Field,
+					},
+					{
+						FieldID:  "status",
+						TableID:  tableID,
+						Name:     metric.PatternMismatchCount,
+						Metadata: patternMetadata,
+						Owner:    metric.Field,
+					},
+				}
+
+				gms := &BasicMetricSpecGenerator{}
+				actualSpecs := gms.generateFieldMetricSpecs(tableSpec, tolerances)
+
+				assert.Equal(t, expectedSpecs, actualSpecs)
+			})
 			t.Run("shouldn't return sum result if field in non-numeric", func(t *testing.T) {
 				tableSpec := &meta.TableSpec{
 					ProjectName:    projectName,
@@ -648,6 +840,62 @@ func TestMetricSpecGenerator(t *testing.T) {
 				gms := &BasicMetricSpecGenerator{}
 				actualSpecs, err := gms.generateTableMetricSpecs(tolerances)
 
+				assert.Equal(t, expectedSpecs, actualSpecs)
+				assert.Nil(t, err)
+			})
+			t.Run("should return orphan count metric with the reference metadata", func(t *testing.T) {
+				referenceMetadata := map[string]interface{}{
+					metric.ReferenceURN:    "project.dataset.customers",
+					metric.JoinFields:      []string{"customer_id"},
+					metric.ReferenceFields: []string{"id"},
+				}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:       tableID,
+						MetricName:     metric.OrphanPct,
+						Metadata:       referenceMetadata,
+						ToleranceRules: []protocol.ToleranceRule{lessThanOrEqZeroRule},
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						TableID:  tableID,
+						Name:     metric.OrphanCount,
+						Metadata: referenceMetadata,
+						Owner:    metric.Table,
+					},
+				}
+
+				gms := &BasicMetricSpecGenerator{}
+				actualSpecs, err := gms.generateTableMetricSpecs(tolerances)
+
+				assert.Equal(t, expectedSpecs, actualSpecs)
+				assert.Nil(t, err)
+			})
+			t.Run("should return freshness lag metric with the timestamp field metadata", func(t *testing.T) {
+				timestampMetadata := map[string]interface{}{metric.TimestampField: "event_timestamp"}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:       tableID,
+						MetricName:     metric.FreshnessLagSeconds,
+						Metadata:       timestampMetadata,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThan, Value: 3600}},
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						TableID:  tableID,
+						Name:     metric.FreshnessLag,
+						Metadata: timestampMetadata,
+						Owner:    metric.Table,
+					},
+				}
+
+				gms := &BasicMetricSpecGenerator{}
+				actualSpecs, err := gms.generateTableMetricSpecs(tolerances)
+
 				assert.Equal(t, expectedSpecs, actualSpecs)
 				assert.Nil(t, err)
 			})
@@ -766,6 +1014,81 @@ func TestMetricSpecGenerator(t *testing.T) {
 				assert.Equal(t, expectedErr, err)
 			})
 		})
+		t.Run("generateTableMetricSpecs", func(t *testing.T) {
+			t.Run("should generate table trend inconsistency metric spec with metadata", func(t *testing.T) {
+				trendMetadata := map[string]interface{}{
+					metric.TrendLookback: 14,
+				}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:   tableID,
+						MetricName: metric.TrendInconsistencyPct,
+						Metadata:   trendMetadata,
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						TableID:  tableID,
+						Name:     metric.TrendInconsistencyPct,
+						Metadata: trendMetadata,
+						Optional: true,
+						Owner:    metric.Table,
+					},
+				}
+
+				actualSpecs := generateTableMetricSpecs(tolerances)
+
+				assert.Equal(t, expectedSpecs, actualSpecs)
+			})
+			t.Run("should generate table orphan metric spec with metadata", func(t *testing.T) {
+				referenceMetadata := map[string]interface{}{
+					metric.ReferenceURN:    "project.dataset.customers",
+					metric.JoinFields:      []string{"customer_id"},
+					metric.ReferenceFields: []string{"id"},
+				}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:   tableID,
+						MetricName: metric.OrphanPct,
+						Metadata:   referenceMetadata,
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						TableID:  tableID,
+						Name:     metric.OrphanPct,
+						Metadata: referenceMetadata,
+						Owner:    metric.Table,
+					},
+				}
+
+				actualSpecs := generateTableMetricSpecs(tolerances)
+
+				assert.Equal(t, expectedSpecs, actualSpecs)
+			})
+			t.Run("should generate table freshness metric spec", func(t *testing.T) {
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:   tableID,
+						MetricName: metric.FreshnessLagSeconds,
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						TableID: tableID,
+						Name:    metric.FreshnessLagSeconds,
+						Owner:   metric.Table,
+					},
+				}
+
+				actualSpecs := generateTableMetricSpecs(tolerances)
+
+				assert.Equal(t, expectedSpecs, actualSpecs)
+			})
+		})
 		t.Run("generateFieldMetricSpec", func(t *testing.T) {
 			t.Run("should generate field metric spec", func(t *testing.T) {
 				tableSpec := &meta.TableSpec{
@@ -829,6 +1152,31 @@ func TestMetricSpecGenerator(t *testing.T) {
 
 				assert.Equal(t, expectedSpecs, actualSpecs)
 			})
+			t.Run("should generate conformity metric spec with the rule metadata", func(t *testing.T) {
+				lengthMetadata := map[string]interface{}{metric.MinLength: 1, metric.MaxLength: 3}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:   tableID,
+						MetricName: metric.LengthOutOfRangePct,
+						FieldID:    "field1",
+						Metadata:   lengthMetadata,
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						FieldID:  "field1",
+						TableID:  tableID,
+						Name:     metric.LengthOutOfRangePct,
+						Metadata: lengthMetadata,
+						Owner:    metric.Field,
+					},
+				}
+
+				actualSpecs := generateFieldMetricSpecs(tolerances)
+
+				assert.Equal(t, expectedSpecs, actualSpecs)
+			})
 			t.Run("should generate field metric spec if only specified in tolerance", func(t *testing.T) {
 				tableSpec := &meta.TableSpec{
 					ProjectName: projectName,
@@ -869,4 +1217,37 @@ func TestMetricSpecGenerator(t *testing.T) {
 			})
 		})
 	})
+	t.Run("CustomSQLSpecGenerator", func(t *testing.T) {
+		t.Run("Generate", func(t *testing.T) {
+			t.Run("should generate table level spec of custom sql tolerances only", func(t *testing.T) {
+				customMetadata := map[string]interface{}{
+					metric.CustomName:       "refund_over_payment",
+					metric.CustomExpression: "COUNTIF(refund_amount > payment_amount)",
+				}
+				tolerances := []*protocol.Tolerance{
+					duplicationTolerance,
+					{
+						TableURN:   tableID,
+						MetricName: metric.CustomSQL,
+						Metadata:   customMetadata,
+					},
+				}
+
+				expectedSpecs := []*metric.Spec{
+					{
+						TableID:  tableID,
+						Name:     metric.CustomSQL,
+						Metadata: customMetadata,
+						Owner:    metric.Table,
+					},
+				}
+
+				gen := NewCustomSQLSpecGenerator(mock.NewMetadataStore(), mock.NewToleranceStore())
+				actualSpecs, err := gen.Generate(&meta.TableSpec{}, tolerances)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expectedSpecs, actualSpecs)
+			})
+		})
+	})
 }
@@ -10,6 +10,8 @@ var metricParserMap = map[metric.Type]common.RowParserType{
 	metric.InvalidCount: getInvalidCountMetric,
 	metric.Count:        getCountMetric,
 	metric.UniqueCount:  getUniqueCountMetric,
+	metric.OrphanCount:  getOrphanCountMetric,
+	metric.FreshnessLag: getFreshnessLagMetric,
 }
 
 func getCountMetric(result map[string]interface{}, alias string, metricSpec *metric.Spec) (*metric.Metric, error) {
@@ -72,3 +74,49 @@ func getInvalidCountMetric(result map[string]interface{}, alias string, metricSp
 	}
 	return invalidCountMetric, nil
 }
+
+func getOrphanCountMetric(result map[string]interface{}, alias string, metricSpec *metric.Spec) (*metric.Metric, error) {
+	value, ok := result[alias]
+	if !ok {
+		return nil, errors.New("get orphan count value failed")
+	}
+
+	orphanCount, ok := value.(int64)
+	if !ok {
+		return nil, errors.New("parse orphan count value to int64 failed")
+	}
+
+	orphanCountMetric := &metric.Metric{
+		Type:     metricSpec.Name,
+		Category: metric.Basic,
+		Owner:    metricSpec.Owner,
+		Value:    float64(orphanCount),
+		Metadata: metricSpec.Metadata,
+	}
+	return orphanCountMetric, nil
+}
+
+//getFreshnessLagMetric null lag means no timestamp value in the group, no metric is produced
+func getFreshnessLagMetric(result map[string]interface{}, alias string, metricSpec *metric.Spec) (*metric.Metric, error) {
+	value, ok := result[alias]
+	if !ok {
+		return nil, errors.New("get freshness lag value failed")
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	lag, ok := value.(int64)
+	if !ok {
+		return nil, errors.New("parse freshness lag value to int64 failed")
+	}
+
+	freshnessLagMetric := &metric.Metric{
+		Type:     metricSpec.Name,
+		Category: metric.Basic,
+		Owner:    metricSpec.Owner,
+		Value:    float64(lag),
+		Metadata: metricSpec.Metadata,
+	}
+	return freshnessLagMetric, nil
+}
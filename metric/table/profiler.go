@@ -2,6 +2,7 @@ package table
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/odpf/predator/metric/common"
@@ -31,57 +32,185 @@ func New(queryExecutor protocol.QueryExecutor, metadataStore protocol.MetadataSt
 
 //ProfileFullScan to do full scan table profiling
 func (t *Profiler) Profile(entry protocol.Entry, profile *job.Profile, metricSpecs []*metric.Spec) ([]*metric.Metric, error) {
-	tableSpec, err := t.metadataStore.GetMetadata(profile.URN)
+	queries, err := t.buildQueries(profile, metricSpecs)
 	if err != nil {
 		return nil, err
 	}
 
-	metricPairs, err := t.prepareMetrics(tableSpec, metricSpecs)
+	var metrics []*metric.Metric
+	for _, q := range queries {
+		result, err := t.queryExecutor.Run(entry, profile, q.sql, job.TableLevelQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range result {
+			groupMetrics, err := t.queryResultParser.Parse(row, q.metricPairs)
+			if err != nil {
+				return nil, err
+			}
+
+			metrics = append(metrics, groupMetrics...)
+		}
+	}
+
+	lastModifiedMetrics, err := t.calculateLastModifiedLag(profile, metricSpecs, metrics)
 	if err != nil {
 		return nil, err
 	}
-	var metricExpressions []*query.MetricExpression
-	for _, pair := range metricPairs {
-		metricExpressions = append(metricExpressions, pair.MetricExpression)
+	metrics = append(metrics, lastModifiedMetrics...)
+
+	return metrics, nil
+}
+
+//calculateLastModifiedLag calculate freshness lag of each group from the table last modified time, no query is run
+func (t *Profiler) calculateLastModifiedLag(profile *job.Profile, metricSpecs []*metric.Spec, metrics []*metric.Metric) ([]*metric.Metric, error) {
+	var specs []*metric.Spec
+	for _, spec := range metricSpecs {
+		if isLastModifiedLag(spec) {
+			specs = append(specs, spec)
+		}
+	}
+	if len(specs) == 0 {
+		return nil, nil
 	}
 
-	fromExpression := &query.FromClause{
-		TableID: profile.URN,
+	tableSpec, err := t.metadataStore.GetMetadata(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+	if tableSpec.LastModifiedTime.IsZero() {
+		return nil, fmt.Errorf("last modified time of table %s is not available", profile.URN)
 	}
+	lag := math.Round(profile.AuditTimestamp.Sub(tableSpec.LastModifiedTime).Seconds())
 
-	filterExpression := common.GenerateFilterExpression(profile.Filter, tableSpec)
-	groupByExpression := common.GenerateGroupExpression(profile.GroupName)
-	selectExpressions := common.GenerateSelectExpression(profile.GroupName)
+	groupValues := make(map[string]bool)
+	var lagMetrics []*metric.Metric
+	for _, m := range metrics {
+		if groupValues[m.GroupValue] {
+			continue
+		}
+		groupValues[m.GroupValue] = true
 
-	q := &query.Query{
-		Expressions: selectExpressions,
-		Metrics:     metricExpressions,
-		From:        fromExpression,
-		Where:       filterExpression,
-		GroupBy:     groupByExpression,
+		for _, spec := range specs {
+			lagMetrics = append(lagMetrics, &metric.Metric{
+				Type:       spec.Name,
+				Category:   metric.Basic,
+				Owner:      spec.Owner,
+				Value:      lag,
+				Metadata:   spec.Metadata,
+				GroupValue: m.GroupValue,
+			})
+		}
 	}
+	return lagMetrics, nil
+}
 
-	queryString := q.String()
+//isLastModifiedLag freshness lag without timestamp field is calculated from table last modified time
+func isLastModifiedLag(spec *metric.Spec) bool {
+	return spec.Name == metric.FreshnessLag && metric.GetTimestampField(spec.Metadata) == ""
+}
 
-	result, err := t.queryExecutor.Run(profile, queryString, job.TableLevelQuery)
+//Plan plan the table level queries without running them
+func (t *Profiler) Plan(profile *job.Profile, metricSpecs []*metric.Spec) ([]*job.Query, error) {
+	queries, err := t.buildQueries(profile, metricSpecs)
 	if err != nil {
 		return nil, err
 	}
 
-	var metrics []*metric.Metric
-	for _, row := range result {
-		groupMetrics, err := t.queryResultParser.Parse(row, metricPairs)
+	var planned []*job.Query
+	for _, q := range queries {
+		planned = append(planned, &job.Query{URN: profile.URN, Content: q.sql, Type: job.TableLevelQuery})
+	}
+	return planned, nil
+}
+
+type tableQuery struct {
+	sql         string
+	metricPairs []*common.SpecExpressionPair
+}
+
+//buildQueries build a query of table metrics, and a query for each orphan metric because it joins the referenced table
+func (t *Profiler) buildQueries(profile *job.Profile, metricSpecs []*metric.Spec) ([]*tableQuery, error) {
+	tableSpec, err := t.metadataStore.GetMetadata(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []*metric.Spec
+	var orphanSpecs []*metric.Spec
+	for _, spec := range metricSpecs {
+		if isLastModifiedLag(spec) {
+			continue
+		}
+		if spec.Name == metric.OrphanCount {
+			orphanSpecs = append(orphanSpecs, spec)
+			continue
+		}
+		specs = append(specs, spec)
+	}
+
+	var queries []*tableQuery
+	if len(specs) > 0 || len(orphanSpecs) == 0 {
+		metricPairs, err := t.prepareMetrics(profile, tableSpec, specs)
 		if err != nil {
 			return nil, err
 		}
+		from := &query.FromClause{
+			TableID: profile.URN,
+		}
+		queries = append(queries, buildQuery(profile, tableSpec, from, metricPairs))
+	}
 
-		metrics = append(metrics, groupMetrics...)
+	for _, spec := range orphanSpecs {
+		reference, ok := metric.GetReference(spec.Metadata)
+		if !ok {
+			return nil, fmt.Errorf("reference of %s metric of table %s is not configured", spec.Name, profile.URN)
+		}
+		join := &query.ReferenceJoin{
+			ReferenceTableID: reference.URN,
+			Fields:           reference.Fields,
+			ReferenceFields:  reference.ReferenceFields,
+		}
+		from := &query.FromClause{
+			TableID: profile.URN,
+			Join:    join,
+		}
+		metricPairs := []*common.SpecExpressionPair{
+			{
+				MetricExpression: query.NewMetricExpression(join.OrphanCondition(), createAlias(spec.Name.String(), 0), query.MetricTypeOrphanCount),
+				MetricSpec:       spec,
+			},
+		}
+		queries = append(queries, buildQuery(profile, tableSpec, from, metricPairs))
 	}
 
-	return metrics, nil
+	return queries, nil
 }
 
-func (t *Profiler) prepareMetrics(tableSpec *meta.TableSpec, metricSpecs []*metric.Spec) ([]*common.SpecExpressionPair, error) {
+func buildQuery(profile *job.Profile, tableSpec *meta.TableSpec, fromExpression *query.FromClause, metricPairs []*common.SpecExpressionPair) *tableQuery {
+	var metricExpressions []*query.MetricExpression
+	for _, pair := range metricPairs {
+		metricExpressions = append(metricExpressions, pair.MetricExpression)
+	}
+
+	filterExpression := common.GenerateFilterExpression(profile.Filter, tableSpec)
+	groupByExpression := common.GenerateGroupExpression(profile.GroupName)
+	selectExpressions := common.GenerateSelectExpression(profile.GroupName)
+
+	q := &query.Query{
+		Expressions: selectExpressions,
+		Metrics:     metricExpressions,
+		From:        fromExpression,
+		Where:       filterExpression,
+		GroupBy:     groupByExpression,
+		Dialect:     query.DialectOf(profile.URN),
+	}
+
+	return &tableQuery{sql: q.String(), metricPairs: metricPairs}
+}
+
+func (t *Profiler) prepareMetrics(profile *job.Profile, tableSpec *meta.TableSpec, metricSpecs []*metric.Spec) ([]*common.SpecExpressionPair, error) {
 	var pairs []*common.SpecExpressionPair
 
 	for i, metricSpec := range metricSpecs {
@@ -103,6 +232,10 @@ func (t *Profiler) prepareMetrics(tableSpec *meta.TableSpec, metricSpecs []*metr
 		case metric.InvalidCount:
 			m = query.NewMetricExpression(metricSpec.Condition, alias, query.MetricTypeInvalidCount)
 
+		case metric.FreshnessLag:
+			m = query.NewMetricExpression(metric.GetTimestampField(metricSpec.Metadata), alias, query.MetricTypeFreshnessLag)
+			m.AuditTime = profile.AuditTimestamp
+
 		default:
 			return nil, fmt.Errorf("unsupported metric type %s", metricSpec.Name)
 		}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/odpf/predator/metric/common"
 	"github.com/odpf/predator/protocol/job"
@@ -472,4 +473,246 @@ func TestTableProfiler(t *testing.T) {
 			})
 		}
 	})
+	t.Run("should return orphan count metric from separate query joined to the referenced table", func(t *testing.T) {
+		referenceMetadata := map[string]interface{}{
+			metric.ReferenceURN:    "sample-project.sample_dataset.customers",
+			metric.JoinFields:      []interface{}{"customer_id"},
+			metric.ReferenceFields: []interface{}{"id"},
+		}
+		metricSpecs := []*metric.Spec{
+			{
+				Name:    metric.Count,
+				TableID: "sample-project.sample_dataset.sample_table",
+				Owner:   metric.Table,
+			},
+			{
+				Name:     metric.OrphanCount,
+				TableID:  "sample-project.sample_dataset.sample_table",
+				Owner:    metric.Table,
+				Metadata: referenceMetadata,
+			},
+		}
+
+		spec := &meta.TableSpec{
+			ProjectName: "sample-project",
+			DatasetName: "sample_dataset",
+			TableName:   "sample_table",
+		}
+
+		countQuery := "SELECT grouping_field AS __group_value , count(1) as count_0 FROM `sample-project.sample_dataset.sample_table` WHERE active = true GROUP BY grouping_field"
+		orphanQuery := "SELECT grouping_field AS __group_value , countif(customer_id is not null and __reference.__reference_key_0 is null) as orphancount_0 " +
+			"FROM `sample-project.sample_dataset.sample_table` LEFT JOIN (SELECT DISTINCT id AS __reference_key_0 FROM `sample-project.sample_dataset.customers`) AS __reference " +
+			"ON customer_id = __reference.__reference_key_0 WHERE active = true GROUP BY grouping_field"
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		queryExecutor := mock.NewQueryExecutor()
+		defer queryExecutor.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", profile.URN).Return(spec, nil)
+		queryExecutor.On("Run", testifyMock.Anything, countQuery, job.TableLevelQuery).Return([]protocol.Row{{"count_0": int64(300), common.GroupAlias: "ID"}}, nil)
+		queryExecutor.On("Run", testifyMock.Anything, orphanQuery, job.TableLevelQuery).Return([]protocol.Row{{"orphancount_0": int64(3), common.GroupAlias: "ID"}}, nil)
+
+		expected := []*metric.Metric{
+			{
+				Type:       metric.Count,
+				Category:   metric.Basic,
+				Owner:      metric.Table,
+				Value:      300,
+				GroupValue: "ID",
+			},
+			{
+				Type:       metric.OrphanCount,
+				Category:   metric.Basic,
+				Owner:      metric.Table,
+				Value:      3,
+				GroupValue: "ID",
+				Metadata:   referenceMetadata,
+			},
+		}
+
+		profiler := New(queryExecutor, metadataStore)
+		metrics, err := profiler.Profile(entry, profile, metricSpecs)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, metrics)
+
+		plannedQueries, err := profiler.Plan(profile, metricSpecs)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*job.Query{
+			{URN: profile.URN, Content: countQuery, Type: job.TableLevelQuery},
+			{URN: profile.URN, Content: orphanQuery, Type: job.TableLevelQuery},
+		}, plannedQueries)
+	})
+	t.Run("should return freshness lag metric from timestamp field and from table last modified time", func(t *testing.T) {
+		auditTime := time.Date(2021, 3, 4, 10, 30, 0, 0, time.UTC)
+		freshnessProfile := &job.Profile{
+			Filter:         "active = true",
+			GroupName:      "grouping_field",
+			URN:            "sample-project.sample_dataset.sample_table",
+			AuditTimestamp: auditTime,
+		}
+		timestampMetadata := map[string]interface{}{metric.TimestampField: "event_timestamp"}
+		metricSpecs := []*metric.Spec{
+			{
+				Name:    metric.Count,
+				TableID: "sample-project.sample_dataset.sample_table",
+				Owner:   metric.Table,
+			},
+			{
+				Name:     metric.FreshnessLag,
+				TableID:  "sample-project.sample_dataset.sample_table",
+				Owner:    metric.Table,
+				Metadata: timestampMetadata,
+			},
+			{
+				Name:    metric.FreshnessLag,
+				TableID: "sample-project.sample_dataset.sample_table",
+				Owner:   metric.Table,
+			},
+		}
+
+		spec := &meta.TableSpec{
+			ProjectName:      "sample-project",
+			DatasetName:      "sample_dataset",
+			TableName:        "sample_table",
+			LastModifiedTime: auditTime.Add(-2 * time.Hour),
+		}
+
+		sql := "SELECT grouping_field AS __group_value , count(1) as count_0 , " +
+			"timestamp_diff(timestamp '2021-03-04 10:30:00 UTC', max(event_timestamp), second) as freshnesslag_1 " +
+			"FROM `sample-project.sample_dataset.sample_table` WHERE active = true GROUP BY grouping_field"
+		rows := []protocol.Row{
+			{"count_0": int64(300), "freshnesslag_1": int64(5400), common.GroupAlias: "ID"},
+			{"count_0": int64(10), "freshnesslag_1": nil, common.GroupAlias: "SG"},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		queryExecutor := mock.NewQueryExecutor()
+		defer queryExecutor.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", freshnessProfile.URN).Return(spec, nil)
+		queryExecutor.On("Run", testifyMock.Anything, sql, job.TableLevelQuery).Return(rows, nil)
+
+		expected := []*metric.Metric{
+			{
+				Type:       metric.Count,
+				Category:   metric.Basic,
+				Owner:      metric.Table,
+				Value:      300,
+				GroupValue: "ID",
+			},
+			{
+				Type:       metric.FreshnessLag,
+				Category:   metric.Basic,
+				Owner:      metric.Table,
+				Value:      5400,
+				GroupValue: "ID",
+				Metadata:   timestampMetadata,
+			},
+			{
+				Type:       metric.Count,
+				Category:   metric.Basic,
+				Owner:      metric.Table,
+				Value:      10,
+				GroupValue: "SG",
+			},
+			{
+				Type:       metric.FreshnessLag,
+				Category:   metric.Basic,
+				Owner:      metric.Table,
+				Value:      7200,
+				GroupValue: "ID",
+			},
+			{
+				Type:       metric.FreshnessLag,
+				Category:   metric.Basic,
+				Owner:      metric.Table,
+				Value:      7200,
+				GroupValue: "SG",
+			},
+		}
+
+		profiler := New(queryExecutor, metadataStore)
+		metrics, err := profiler.Profile(entry, freshnessProfile, metricSpecs)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, metrics)
+	})
+	t.Run("should return error when freshness lag is based on table last modified time that is not available", func(t *testing.T) {
+		metricSpecs := []*metric.Spec{
+			{
+				Name:    metric.Count,
+				TableID: "postgres:mart.public.orders",
+				Owner:   metric.Table,
+			},
+			{
+				Name:    metric.FreshnessLag,
+				TableID: "postgres:mart.public.orders",
+				Owner:   metric.Table,
+			},
+		}
+		postgresProfile := &job.Profile{URN: "postgres:mart.public.orders"}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		queryExecutor := mock.NewQueryExecutor()
+		defer queryExecutor.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", postgresProfile.URN).Return(&meta.TableSpec{Warehouse: meta.WarehousePostgres}, nil)
+		queryExecutor.On("Run", testifyMock.Anything, testifyMock.AnythingOfType("string"), job.TableLevelQuery).Return([]protocol.Row{{"count_0": int64(300)}}, nil)
+
+		profiler := New(queryExecutor, metadataStore)
+		metrics, err := profiler.Profile(entry, postgresProfile, metricSpecs)
+
+		assert.Nil(t, metrics)
+		assert.EqualError(t, err, "last modified time of table postgres:mart.public.orders is not available")
+	})
+	t.Run("Plan", func(t *testing.T) {
+		t.Run("should return the query run by Profile", func(t *testing.T) {
+			metricSpecs := []*metric.Spec{
+				{
+					Name:    metric.Count,
+					TableID: "sample-project.sample_dataset.sample_table",
+					Owner:   metric.Table,
+				},
+			}
+
+			spec := &meta.TableSpec{
+				ProjectName: "sample-project",
+				DatasetName: "sample_dataset",
+				TableName:   "sample_table",
+			}
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			queryExecutor := mock.NewQueryExecutor()
+			defer queryExecutor.AssertExpectations(t)
+
+			queryExecutor.On("Run", profile, testifyMock.AnythingOfType("string"), job.TableLevelQuery).Return([]protocol.Row{}, nil)
+			metadataStore.On("GetMetadata", profile.URN).Return(spec, nil)
+
+			profiler := New(queryExecutor, metadataStore)
+			_, err := profiler.Profile(entry, profile, metricSpecs)
+			assert.Nil(t, err)
+
+			plannedQueries, err := profiler.Plan(profile, metricSpecs)
+
+			expected := []*job.Query{
+				{
+					URN:     profile.URN,
+					Content: queryExecutor.Calls[0].Arguments.String(1),
+					Type:    job.TableLevelQuery,
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, plannedQueries)
+		})
+	})
 }
@@ -20,6 +20,16 @@ func (m *mockAuditService) RunAudit(profileID string) (*protocol.AuditResult, er
 	return args.Get(0).(*protocol.AuditResult), args.Error(1)
 }
 
+func (m *mockAuditService) GetAudit(ID string) (*protocol.AuditResult, error) {
+	args := m.Called(ID)
+	return args.Get(0).(*protocol.AuditResult), args.Error(1)
+}
+
+func (m *mockAuditService) GetAudits(filter *protocol.AuditFilter) ([]*protocol.AuditResult, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*protocol.AuditResult), args.Error(1)
+}
+
 type mockAuditor struct {
 	mock.Mock
 }
@@ -58,6 +68,16 @@ func (m *mockAuditStore) UpdateAudit(audit *job.Audit) error {
 	return args.Error(0)
 }
 
+func (m *mockAuditStore) GetAudit(ID string) (*job.Audit, error) {
+	args := m.Called(ID)
+	return args.Get(0).(*job.Audit), args.Error(1)
+}
+
+func (m *mockAuditStore) GetAudits(filter *protocol.AuditFilter) ([]*protocol.AuditResult, error) {
+	args := m.Called(filter)
+	return args.Get(0).([]*protocol.AuditResult), args.Error(1)
+}
+
 type mockResultStore struct {
 	mock.Mock
 }
@@ -73,6 +93,11 @@ func (r *mockResultStore) StoreResults(results []*protocol.AuditReport) error {
 	return args.Error(0)
 }
 
+func (r *mockResultStore) GetResultsByAuditID(auditID string, fieldID string) ([]*protocol.AuditReport, error) {
+	args := r.Called(auditID, fieldID)
+	return args.Get(0).([]*protocol.AuditReport), args.Error(1)
+}
+
 type mockAuditSummaryFactory struct {
 	mock.Mock
 }
@@ -85,3 +110,8 @@ func (m *mockAuditSummaryFactory) Create(auditResults []*protocol.AuditReport, a
 func NewAuditSummaryFactory() *mockAuditSummaryFactory {
 	return &mockAuditSummaryFactory{}
 }
+
+func (r *mockResultStore) GetResultsByAuditIDs(auditIDs []string, fieldID string) ([]*protocol.AuditReport, error) {
+	args := r.Called(auditIDs, fieldID)
+	return args.Get(0).([]*protocol.AuditReport), args.Error(1)
+}
@@ -47,12 +47,14 @@ func (cli *BQClientMock) Query(query string) bqiface.Query {
 	return args.Get(0).(bqiface.Query)
 }
 
-func (cli *BQClientMock) JobFromID(context.Context, string) (bqiface.Job, error) {
-	panic("not implemented")
+func (cli *BQClientMock) JobFromID(ctx context.Context, ID string) (bqiface.Job, error) {
+	args := cli.Called(ctx, ID)
+	return args.Get(0).(bqiface.Job), args.Error(1)
 }
 
-func (cli *BQClientMock) JobFromIDLocation(context.Context, string, string) (bqiface.Job, error) {
-	panic("not implemented")
+func (cli *BQClientMock) JobFromIDLocation(ctx context.Context, ID string, location string) (bqiface.Job, error) {
+	args := cli.Called(ctx, ID, location)
+	return args.Get(0).(bqiface.Job), args.Error(1)
 }
 
 func (cli *BQClientMock) Jobs(context.Context) bqiface.JobIterator {
@@ -98,7 +100,7 @@ func (j *JobMock) ID() string {
 }
 
 func (j *JobMock) Location() string {
-	panic("not implemented")
+	return j.Called().String(0)
 }
 
 func (j *JobMock) Config() (bigquery.JobConfig, error) {
@@ -111,11 +113,13 @@ func (j *JobMock) Status(ctx context.Context) (*bigquery.JobStatus, error) {
 }
 
 func (j *JobMock) LastStatus() *bigquery.JobStatus {
-	panic("not implemented")
+	args := j.Called()
+	return args.Get(0).(*bigquery.JobStatus)
 }
 
-func (j *JobMock) Cancel(_ context.Context) error {
-	panic("not implemented")
+func (j *JobMock) Cancel(ctx context.Context) error {
+	args := j.Called(ctx)
+	return args.Error(0)
 }
 
 func (j *JobMock) Wait(_ context.Context) (*bigquery.JobStatus, error) {
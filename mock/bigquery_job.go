@@ -14,6 +14,11 @@ func (m *mockBigqueryJobStore) Store(bigqueryJob *protocol.BigqueryJob) error {
 	return args.Error(0)
 }
 
+func (m *mockBigqueryJobStore) GetByProfileID(profileID string) ([]*protocol.BigqueryJob, error) {
+	args := m.Called(profileID)
+	return args.Get(0).([]*protocol.BigqueryJob), args.Error(1)
+}
+
 func NewBigqueryJobStore() *mockBigqueryJobStore {
 	return &mockBigqueryJobStore{}
 }
@@ -14,3 +14,8 @@ func (m *mockSQLExpressionFactory) CreatePartitionExpression(urn string) (string
 	args := m.Called(urn)
 	return args.String(0), args.Error(1)
 }
+
+func (m *mockSQLExpressionFactory) CreatePartitionFilterExpression(urn string, partitionIDs []string) (string, error) {
+	args := m.Called(urn, partitionIDs)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"context"
+	"time"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockJobQueue struct {
+	mock.Mock
+}
+
+//NewJobQueue create mock of job queue
+func NewJobQueue() *mockJobQueue {
+	return &mockJobQueue{}
+}
+
+func (m *mockJobQueue) Enqueue(jobID string, jobType job.Type) error {
+	args := m.Called(jobID, jobType)
+	return args.Error(0)
+}
+
+func (m *mockJobQueue) Claim(owner string, lease time.Duration, maxAttempts int) (*protocol.QueuedJob, error) {
+	args := m.Called(owner, lease, maxAttempts)
+	return args.Get(0).(*protocol.QueuedJob), args.Error(1)
+}
+
+func (m *mockJobQueue) Extend(job *protocol.QueuedJob, lease time.Duration) error {
+	args := m.Called(job, lease)
+	return args.Error(0)
+}
+
+func (m *mockJobQueue) Cancel(jobID string, jobType job.Type) error {
+	args := m.Called(jobID, jobType)
+	return args.Error(0)
+}
+
+func (m *mockJobQueue) Complete(job *protocol.QueuedJob) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *mockJobQueue) FailExpired(maxAttempts int) ([]*protocol.QueuedJob, error) {
+	args := m.Called(maxAttempts)
+	return args.Get(0).([]*protocol.QueuedJob), args.Error(1)
+}
+
+type mockJobHandler struct {
+	mock.Mock
+}
+
+//NewJobHandler create mock of job handler
+func NewJobHandler() *mockJobHandler {
+	return &mockJobHandler{}
+}
+
+func (m *mockJobHandler) Run(ctx context.Context, queuedJob *protocol.QueuedJob) error {
+	args := m.Called(ctx, queuedJob)
+	return args.Error(0)
+}
+
+func (m *mockJobHandler) Fail(queuedJob *protocol.QueuedJob) error {
+	args := m.Called(queuedJob)
+	return args.Error(0)
+}
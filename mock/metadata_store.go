@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"time"
+
+	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/meta"
 	"github.com/stretchr/testify/mock"
 )
@@ -22,3 +25,29 @@ func (spy *MetadataStore) GetUniqueConstraints(tableId string) ([]string, error)
 	args := spy.Called(tableId)
 	return args.Get(0).([]string), args.Error(1)
 }
+
+type mockSchemaStore struct {
+	mock.Mock
+}
+
+//NewSchemaStore create mock of schema store
+func NewSchemaStore() *mockSchemaStore {
+	return &mockSchemaStore{}
+}
+
+func (m *mockSchemaStore) Store(snapshot *protocol.SchemaSnapshot) error {
+	withoutCreatedAt := *snapshot
+	withoutCreatedAt.CreatedAt = time.Time{}
+	args := m.Called(&withoutCreatedAt)
+	return args.Error(0)
+}
+
+func (m *mockSchemaStore) GetByProfileID(profileID string) (*protocol.SchemaSnapshot, error) {
+	args := m.Called(profileID)
+	return args.Get(0).(*protocol.SchemaSnapshot), args.Error(1)
+}
+
+func (m *mockSchemaStore) GetPrevious(snapshot *protocol.SchemaSnapshot) (*protocol.SchemaSnapshot, error) {
+	args := m.Called(snapshot)
+	return args.Get(0).(*protocol.SchemaSnapshot), args.Error(1)
+}
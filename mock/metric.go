@@ -26,6 +26,21 @@ func (m *mockMetricStore) GetMetricsByProfileID(ID string) ([]*metric.Metric, er
 	return args.Get(0).([]*metric.Metric), args.Error(1)
 }
 
+func (m *mockMetricStore) DeleteByProfileID(ID string) error {
+	args := m.Called(ID)
+	return args.Error(0)
+}
+
+func (m *mockMetricStore) GetPreviousMetrics(profile *job.Profile, limit int) ([]*metric.Metric, error) {
+	args := m.Called(profile, limit)
+	return args.Get(0).([]*metric.Metric), args.Error(1)
+}
+
+func (m *mockMetricStore) GetMetrics(query *protocol.MetricQuery) ([]*protocol.ProfileMetric, error) {
+	args := m.Called(query)
+	return args.Get(0).([]*protocol.ProfileMetric), args.Error(1)
+}
+
 type mockMetricGenerator struct {
 	mock.Mock
 	protocol.MetricGenerator
@@ -40,6 +55,11 @@ func (m *mockMetricGenerator) Generate(entry protocol.Entry, config *job.Profile
 	return args.Get(0).([]*metric.Metric), args.Error(1)
 }
 
+func (m *mockMetricGenerator) Plan(profile *job.Profile) ([]*job.Query, error) {
+	args := m.Called(profile)
+	return args.Get(0).([]*job.Query), args.Error(1)
+}
+
 type mockProfiler struct {
 	mock.Mock
 }
@@ -53,3 +73,8 @@ func (m *mockProfiler) Profile(entry protocol.Entry, profile *job.Profile, metri
 	arguments := m.Called(entry, profile, metricSpecs)
 	return arguments.Get(0).([]*metric.Metric), arguments.Error(1)
 }
+
+func (m *mockProfiler) Plan(profile *job.Profile, metricSpecs []*metric.Spec) ([]*job.Query, error) {
+	arguments := m.Called(profile, metricSpecs)
+	return arguments.Get(0).([]*job.Query), arguments.Error(1)
+}
@@ -0,0 +1,21 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type mockPartitionScanner struct {
+	mock.Mock
+}
+
+//NewPartitionScanner create mock of partition scanner
+func NewPartitionScanner() *mockPartitionScanner {
+	return &mockPartitionScanner{}
+}
+
+func (m *mockPartitionScanner) GetAffectedPartition(tableURN string, lastModifiedTimestamp time.Time) ([]string, error) {
+	args := m.Called(tableURN, lastModifiedTimestamp)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -29,6 +29,20 @@ func (m *mockProfileService) CreateProfile(profile *job.Profile) (*job.Profile,
 	return arguments.Get(0).(*job.Profile), arguments.Error(1)
 }
 
+func (m *mockProfileService) Cancel(ID string) (*job.Profile, error) {
+	args := m.Called(ID)
+	return args.Get(0).(*job.Profile), args.Error(1)
+}
+
+func (m *mockProfileService) Estimate(profile *job.Profile) (*protocol.CostEstimate, error) {
+	args := m.Called(profile)
+	return args.Get(0).(*protocol.CostEstimate), args.Error(1)
+}
+
+func (m *mockProfileService) Start() {
+	m.Called()
+}
+
 func (m *mockProfileService) WaitAll(ctx context.Context) error {
 	arguments := m.Called(ctx)
 	return arguments.Error(0)
@@ -43,11 +57,35 @@ type mockProfileStatisticGenerator struct {
 	mock.Mock
 }
 
-func (m *mockProfileStatisticGenerator) Generate(profile *job.Profile) error {
+func (m *mockProfileStatisticGenerator) Generate(entry protocol.Entry, profile *job.Profile) error {
 	args := m.Called(profile)
 	return args.Error(0)
 }
 
+func (m *mockProfileStatisticGenerator) Plan(profile *job.Profile) ([]*job.Query, error) {
+	args := m.Called(profile)
+	return args.Get(0).([]*job.Query), args.Error(1)
+}
+
 func NewProfileStatisticGenerator() *mockProfileStatisticGenerator {
 	return &mockProfileStatisticGenerator{}
 }
+
+type mockCostEstimator struct {
+	mock.Mock
+}
+
+//NewCostEstimator create mock CostEstimator
+func NewCostEstimator() *mockCostEstimator {
+	return &mockCostEstimator{}
+}
+
+func (m *mockCostEstimator) Estimate(entry protocol.Entry, profile *job.Profile) (*protocol.CostEstimate, error) {
+	args := m.Called(profile)
+	return args.Get(0).(*protocol.CostEstimate), args.Error(1)
+}
+
+func (m *mockCostEstimator) EstimateQueries(entry protocol.Entry, profile *job.Profile, plannedQueries []*job.Query) (*protocol.CostEstimate, error) {
+	args := m.Called(profile, plannedQueries)
+	return args.Get(0).(*protocol.CostEstimate), args.Error(1)
+}
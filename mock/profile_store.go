@@ -24,6 +24,7 @@ func (m *mockProfileStore) Create(profile *job.Profile) (*job.Profile, error) {
 		GroupName:    profile.GroupName,
 		Filter:       profile.Filter,
 		URN:          profile.URN,
+		Kind:         profile.Kind,
 		TotalRecords: profile.TotalRecords,
 	})
 	return args.Get(0).(*job.Profile), args.Error(1)
@@ -48,6 +49,11 @@ func (m *mockProfileStore) Get(ID string) (*job.Profile, error) {
 	return args.Get(0).(*job.Profile), args.Error(1)
 }
 
+func (m *mockProfileStore) GetLastCompleted(profile *job.Profile) (*job.Profile, error) {
+	args := m.Called(profile)
+	return args.Get(0).(*job.Profile), args.Error(1)
+}
+
 type stubProfileStore struct {
 }
 
@@ -63,6 +69,10 @@ func (s *stubProfileStore) Get(ID string) (*job.Profile, error) {
 	return &job.Profile{}, nil
 }
 
+func (s *stubProfileStore) GetLastCompleted(profile *job.Profile) (*job.Profile, error) {
+	return nil, protocol.ErrProfileNotFound
+}
+
 func NewProfileStoreStub() protocol.ProfileStore {
 	return &stubProfileStore{}
 }
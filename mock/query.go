@@ -15,7 +15,49 @@ func NewQueryExecutor() *mockQueryExecutor {
 	return &mockQueryExecutor{}
 }
 
-func (m *mockQueryExecutor) Run(profile *job.Profile, query string, queryType job.QueryType) ([]protocol.Row, error) {
+func (m *mockQueryExecutor) Run(entry protocol.Entry, profile *job.Profile, query string, queryType job.QueryType) ([]protocol.Row, error) {
 	args := m.Called(profile, query, queryType)
 	return args.Get(0).([]protocol.Row), args.Error(1)
 }
+
+type mockQueryCanceller struct {
+	mock.Mock
+}
+
+//NewQueryCanceller create mock QueryCanceller
+func NewQueryCanceller() *mockQueryCanceller {
+	return &mockQueryCanceller{}
+}
+
+func (m *mockQueryCanceller) Cancel(profile *job.Profile) error {
+	args := m.Called(profile)
+	return args.Error(0)
+}
+
+type mockQueryEstimator struct {
+	mock.Mock
+}
+
+//NewQueryEstimator create mock QueryEstimator
+func NewQueryEstimator() *mockQueryEstimator {
+	return &mockQueryEstimator{}
+}
+
+func (m *mockQueryEstimator) Estimate(entry protocol.Entry, profile *job.Profile, query string) (int64, error) {
+	args := m.Called(profile, query)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type mockBytesLimitResolver struct {
+	mock.Mock
+}
+
+//NewBytesLimitResolver create mock BytesLimitResolver
+func NewBytesLimitResolver() *mockBytesLimitResolver {
+	return &mockBytesLimitResolver{}
+}
+
+func (m *mockBytesLimitResolver) Resolve(urn string) (int64, error) {
+	args := m.Called(urn)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,59 @@
+package mock
+
+import (
+	"time"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockReconciliationStore struct {
+	mock.Mock
+}
+
+//NewReconciliationStore create mock of reconciliation store
+func NewReconciliationStore() *mockReconciliationStore {
+	return &mockReconciliationStore{}
+}
+
+func (m *mockReconciliationStore) Create(reconciliation *job.Reconciliation) (*job.Reconciliation, error) {
+	args := m.Called(withoutTimestamp(reconciliation))
+	return args.Get(0).(*job.Reconciliation), args.Error(1)
+}
+
+func (m *mockReconciliationStore) Update(reconciliation *job.Reconciliation) error {
+	args := m.Called(withoutTimestamp(reconciliation))
+	return args.Error(0)
+}
+
+func (m *mockReconciliationStore) Get(ID string) (*job.Reconciliation, error) {
+	args := m.Called(ID)
+	return args.Get(0).(*job.Reconciliation), args.Error(1)
+}
+
+//withoutTimestamp copy reconciliation without its event timestamp, the copy keep the state at the time of the call
+func withoutTimestamp(reconciliation *job.Reconciliation) *job.Reconciliation {
+	snapshot := *reconciliation
+	snapshot.EventTimestamp = time.Time{}
+	return &snapshot
+}
+
+type mockReconciliationService struct {
+	mock.Mock
+}
+
+//NewReconciliationService create mock of reconciliation service
+func NewReconciliationService() *mockReconciliationService {
+	return &mockReconciliationService{}
+}
+
+func (m *mockReconciliationService) Reconcile(reconciliation *job.Reconciliation) (*protocol.ReconciliationResult, error) {
+	args := m.Called(reconciliation)
+	return args.Get(0).(*protocol.ReconciliationResult), args.Error(1)
+}
+
+func (m *mockReconciliationService) Get(ID string) (*protocol.ReconciliationResult, error) {
+	args := m.Called(ID)
+	return args.Get(0).(*protocol.ReconciliationResult), args.Error(1)
+}
@@ -0,0 +1,40 @@
+package mock
+
+import (
+	"github.com/odpf/predator/protocol"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockScheduleRunStore struct {
+	mock.Mock
+}
+
+//NewScheduleRunStore create mock of schedule run store
+func NewScheduleRunStore() *mockScheduleRunStore {
+	return &mockScheduleRunStore{}
+}
+
+func (m *mockScheduleRunStore) Create(run *protocol.ScheduleRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRunStore) Update(run *protocol.ScheduleRun, previous protocol.ScheduleRunStatus) error {
+	args := m.Called(run, previous)
+	return args.Error(0)
+}
+
+func (m *mockScheduleRunStore) GetLatest(urn string) (*protocol.ScheduleRun, error) {
+	args := m.Called(urn)
+	return args.Get(0).(*protocol.ScheduleRun), args.Error(1)
+}
+
+func (m *mockScheduleRunStore) GetByStatus(status protocol.ScheduleRunStatus) ([]*protocol.ScheduleRun, error) {
+	args := m.Called(status)
+	return args.Get(0).([]*protocol.ScheduleRun), args.Error(1)
+}
+
+func (m *mockScheduleRunStore) GetByURN(urn string) ([]*protocol.ScheduleRun, error) {
+	args := m.Called(urn)
+	return args.Get(0).([]*protocol.ScheduleRun), args.Error(1)
+}
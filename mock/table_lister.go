@@ -0,0 +1,19 @@
+package mock
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTableLister struct {
+	mock.Mock
+}
+
+//NewTableLister create mock of table lister
+func NewTableLister() *mockTableLister {
+	return &mockTableLister{}
+}
+
+func (m *mockTableLister) ListTables(project string, dataset string) ([]string, error) {
+	args := m.Called(project, dataset)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -74,3 +74,22 @@ func (m *mockSpecValidator) Validate(spec *protocol.ToleranceSpec) error {
 func NewSpecValidator() *mockSpecValidator {
 	return &mockSpecValidator{}
 }
+
+type mockSpecSuggester struct {
+	mock.Mock
+}
+
+func (m *mockSpecSuggester) Suggest(urn string, filter string) (*protocol.SpecSuggestion, error) {
+	args := m.Called(urn, filter)
+	return args.Get(0).(*protocol.SpecSuggestion), args.Error(1)
+}
+
+func (m *mockSpecSuggester) GetSuggestion(profileID string) (*protocol.SpecSuggestion, error) {
+	args := m.Called(profileID)
+	return args.Get(0).(*protocol.SpecSuggestion), args.Error(1)
+}
+
+//NewSpecSuggester create mock of spec suggester
+func NewSpecSuggester() *mockSpecSuggester {
+	return &mockSpecSuggester{}
+}
@@ -13,8 +13,8 @@ func NewMockUploadFactory() *mockUploadFactory {
 	return &mockUploadFactory{}
 }
 
-func (m *mockUploadFactory) Create(gitRepo *protocol.GitInfo) (protocol.Task, error) {
-	args := m.Called(gitRepo)
+func (m *mockUploadFactory) Create(gitRepo *protocol.GitInfo, dryRun bool) (protocol.Task, error) {
+	args := m.Called(gitRepo, dryRun)
 
 	return args.Get(0).(protocol.Task), args.Error(1)
 }
@@ -2,6 +2,7 @@ package profile
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -72,14 +73,21 @@ func (m *metricRecord) toMetric() (*metric.Metric, error) {
 	}, nil
 }
 
+const (
+	profileTableName = "profile"
+	statusTableName  = "status"
+)
+
 type MetricStore struct {
-	db *gorm.DB
+	db        *gorm.DB
+	tableName string
 }
 
 //NewMetricStore is constructor of MetricStore
 func NewMetricStore(db *gorm.DB, tableName string) *MetricStore {
 	return &MetricStore{
-		db: db.Table(tableName),
+		db:        db.Table(tableName),
+		tableName: tableName,
 	}
 }
 
@@ -129,3 +137,133 @@ func (m *MetricStore) GetMetricsByProfileID(ID string) ([]*metric.Metric, error)
 
 	return metrics, nil
 }
+
+//DeleteByProfileID delete metrics stored by the profile
+func (m *MetricStore) DeleteByProfileID(ID string) error {
+	return m.db.Where("profile_id = ?", ID).Delete(&metricRecord{}).Error
+}
+
+//GetPreviousMetrics get metrics of at most limit latest completed standard profiles of the same urn and group created before the profile
+//filter is not compared, scheduled profiles render a different dated filter on every run
+func (m *MetricStore) GetPreviousMetrics(profile *job.Profile, limit int) ([]*metric.Metric, error) {
+	completed := m.db.New().
+		Table(statusTableName+" s").
+		Select("1").
+		Where("s.job_id = CAST(p.id AS TEXT) AND s.job_type = ? AND s.status = ?", job.TypeProfile.String(), job.StateCompleted.String()).
+		SubQuery()
+
+	previousProfiles := m.db.New().
+		Table(profileTableName+" p").
+		Select("p.id").
+		Where("p.urn = ? AND p.group_name = ? AND p.kind = ?", profile.URN, profile.GroupName, job.KindStandard.String()).
+		Where("p.id <> ? AND p.event_timestamp < ?", profile.ID, profile.EventTimestamp).
+		Where("EXISTS ?", completed).
+		Order("p.event_timestamp DESC").
+		Limit(limit).
+		SubQuery()
+
+	var records []*metricRecord
+	handler := m.db.Where("profile_id IN ?", previousProfiles).Find(&records)
+
+	if err := handler.Error; err != nil {
+		return nil, err
+	}
+
+	var metrics []*metric.Metric
+	for _, rec := range records {
+		mt, err := rec.toMetric()
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, mt)
+	}
+
+	return metrics, nil
+}
+
+type profileMetricRecord struct {
+	ID             string
+	ProfileID      string
+	URN            string
+	GroupValue     string
+	FieldID        string
+	OwnerType      metric.Owner
+	Category       metric.Category
+	Condition      string
+	MetricName     metric.Type
+	MetricValue    float64
+	EventTimestamp time.Time
+}
+
+func (r *profileMetricRecord) toProfileMetric() *protocol.ProfileMetric {
+	return &protocol.ProfileMetric{
+		ID:             r.ID,
+		ProfileID:      r.ProfileID,
+		TableURN:       r.URN,
+		Partition:      r.GroupValue,
+		FieldID:        r.FieldID,
+		OwnerType:      r.OwnerType,
+		Category:       r.Category,
+		Condition:      r.Condition,
+		MetricName:     r.MetricName,
+		MetricValue:    r.MetricValue,
+		EventTimestamp: r.EventTimestamp,
+	}
+}
+
+//GetMetrics get metrics of completed standard profiles matching the query ordered by profile event timestamp
+//only the latest metrics within the query limit are returned, MaxMetricQueryLimit is used when the query has no limit
+func (m *MetricStore) GetMetrics(query *protocol.MetricQuery) ([]*protocol.ProfileMetric, error) {
+	columns := "m.id, m.profile_id, p.urn, m.group_value, m.field_id, m.owner_type, m.category, " +
+		"m.condition, m.metric_name, m.metric_value, p.event_timestamp"
+	completed := fmt.Sprintf("EXISTS (SELECT 1 FROM %s s WHERE s.job_id = CAST(p.id AS TEXT) AND s.job_type = ? AND s.status = ?)", statusTableName)
+
+	handler := m.db.Table(fmt.Sprintf("%s m", m.tableName)).
+		Select(columns).
+		Joins(fmt.Sprintf("JOIN %s p ON p.id = m.profile_id", profileTableName)).
+		Where(completed, job.TypeProfile.String(), job.StateCompleted.String()).
+		Where("p.kind = ?", job.KindStandard.String())
+
+	if query.URN != "" {
+		handler = handler.Where("p.urn = ?", query.URN)
+	}
+	if query.ProfileID != "" {
+		handler = handler.Where("m.profile_id = ?", query.ProfileID)
+	}
+	if query.Partition != "" {
+		handler = handler.Where("m.group_value = ?", query.Partition)
+	}
+	if query.FieldID != "" {
+		handler = handler.Where("m.field_id = ?", query.FieldID)
+	}
+	if len(query.MetricTypes) > 0 {
+		var metricNames []string
+		for _, metricType := range query.MetricTypes {
+			metricNames = append(metricNames, metricType.String())
+		}
+		handler = handler.Where("m.metric_name IN (?)", metricNames)
+	}
+	if !query.From.IsZero() {
+		handler = handler.Where("p.event_timestamp >= ?", query.From)
+	}
+	if !query.To.IsZero() {
+		handler = handler.Where("p.event_timestamp < ?", query.To)
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > protocol.MaxMetricQueryLimit {
+		limit = protocol.MaxMetricQueryLimit
+	}
+
+	var records []*profileMetricRecord
+	handler = handler.Order("p.event_timestamp DESC, m.group_value DESC, m.field_id DESC, m.metric_name DESC").Limit(limit).Scan(&records)
+	if err := handler.Error; err != nil && !handler.RecordNotFound() {
+		return nil, err
+	}
+
+	metrics := make([]*protocol.ProfileMetric, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		metrics = append(metrics, records[i].toProfileMetric())
+	}
+	return metrics, nil
+}
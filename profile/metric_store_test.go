@@ -2,6 +2,7 @@ package profile
 
 import (
 	"encoding/json"
+	"strconv"
 	"testing"
 	"time"
 
@@ -30,6 +31,7 @@ func GetMockDB() (*gorm.DB, func()) {
 }
 
 func TestMetricStore(t *testing.T) {
+	standard := job.KindStandard.String()
 	t.Run("Store", func(t *testing.T) {
 		t.Run("should store metric", func(t *testing.T) {
 			db, clear := GetMockDB()
@@ -270,4 +272,283 @@ func TestMetricStore(t *testing.T) {
 			assert.Nil(t, result)
 		})
 	})
+	t.Run("DeleteByProfileID", func(t *testing.T) {
+		t.Run("should delete only metrics of the profile", func(t *testing.T) {
+			db, clear := GetMockDB()
+			defer clear()
+
+			store := NewMetricStore(db, "metric_records")
+			for i, profileID := range []string{"profile-1", "profile-1", "profile-2"} {
+				m := &metric.Metric{ID: strconv.Itoa(i + 1), Type: metric.Count, Owner: metric.Table, Value: 1}
+				assert.Nil(t, store.Store(&job.Profile{ID: profileID}, []*metric.Metric{m}))
+			}
+
+			err := store.DeleteByProfileID("profile-1")
+
+			assert.Nil(t, err)
+			_, err = store.GetMetricsByProfileID("profile-1")
+			assert.Equal(t, protocol.ErrNoProfileMetricFound, err)
+			remaining, err := store.GetMetricsByProfileID("profile-2")
+			assert.Nil(t, err)
+			assert.Len(t, remaining, 1)
+		})
+	})
+	t.Run("GetPreviousMetrics", func(t *testing.T) {
+		t.Run("should return metrics of latest completed standard profiles of the same urn and group", func(t *testing.T) {
+			db, clear := GetMockDB()
+			defer clear()
+
+			db.Table(profileTableName).CreateTable(&profileRecord{})
+			db.Exec("CREATE TABLE status (id INTEGER PRIMARY KEY, job_id TEXT, job_type TEXT, status TEXT, message TEXT, created_at DATETIME)")
+			defer db.DropTableIfExists(profileTableName, statusTableName)
+
+			urn := "project.dataset.table"
+			now := time.Now().In(time.UTC)
+
+			profiles := []*profileRecord{
+				{ID: "profile-1", URN: urn, Kind: standard, EventTimestamp: now.Add(-72 * time.Hour)},
+				{ID: "profile-2", URN: urn, Kind: standard, EventTimestamp: now.Add(-48 * time.Hour)},
+				{ID: "profile-3", URN: urn, Kind: standard, EventTimestamp: now.Add(-24 * time.Hour)},
+				{ID: "profile-failed", URN: urn, Kind: standard, EventTimestamp: now.Add(-12 * time.Hour)},
+				{ID: "profile-other", URN: "project.dataset.other", Kind: standard, EventTimestamp: now.Add(-12 * time.Hour)},
+				{ID: "profile-reconciliation", URN: urn, Kind: job.KindReconciliation.String(), EventTimestamp: now.Add(-6 * time.Hour)},
+				{ID: "profile-other-group", URN: urn, GroupName: "created_date", Kind: standard, EventTimestamp: now.Add(-5 * time.Hour)},
+				{ID: "profile-current", URN: urn, Kind: standard, EventTimestamp: now},
+			}
+			for _, p := range profiles {
+				db.Table(profileTableName).Create(p)
+				db.Exec("INSERT INTO status (job_id, job_type, status) VALUES (?, ?, ?)", p.ID, job.TypeProfile, job.StateInProgress)
+				if p.ID != "profile-failed" && p.ID != "profile-current" {
+					db.Exec("INSERT INTO status (job_id, job_type, status) VALUES (?, ?, ?)", p.ID, job.TypeProfile, job.StateCompleted)
+				}
+			}
+
+			store := NewMetricStore(db, "metric_records")
+			for i, p := range profiles {
+				m := &metric.Metric{
+					ID:         strconv.Itoa(i + 1),
+					Type:       metric.Count,
+					Category:   metric.Basic,
+					Owner:      metric.Table,
+					GroupValue: "2020-01-01",
+					Value:      float64(i + 1),
+					Timestamp:  p.EventTimestamp,
+				}
+				err := store.Store(&job.Profile{ID: p.ID}, []*metric.Metric{m})
+				assert.Nil(t, err)
+			}
+
+			current := &job.Profile{
+				ID:             "profile-current",
+				URN:            urn,
+				EventTimestamp: now,
+			}
+
+			result, err := store.GetPreviousMetrics(current, 2)
+
+			var values []float64
+			for _, m := range result {
+				values = append(values, m.Value)
+			}
+
+			assert.Nil(t, err)
+			assert.ElementsMatch(t, []float64{2, 3}, values)
+		})
+		t.Run("should return metrics of previous profile with different rendered filter", func(t *testing.T) {
+			db, clear := GetMockDB()
+			defer clear()
+
+			db.Table(profileTableName).CreateTable(&profileRecord{})
+			db.Exec("CREATE TABLE status (id INTEGER PRIMARY KEY, job_id TEXT, job_type TEXT, status TEXT, message TEXT, created_at DATETIME)")
+			defer db.DropTableIfExists(profileTableName, statusTableName)
+
+			urn := "project.dataset.table"
+			now := time.Now().In(time.UTC)
+
+			previous := &profileRecord{ID: "profile-previous", URN: urn, Filter: "__PARTITION__ = '2021-01-01'", Kind: standard, EventTimestamp: now.Add(-24 * time.Hour)}
+			db.Table(profileTableName).Create(previous)
+			db.Exec("INSERT INTO status (job_id, job_type, status) VALUES (?, ?, ?)", previous.ID, job.TypeProfile, job.StateCompleted)
+
+			store := NewMetricStore(db, "metric_records")
+			m := &metric.Metric{
+				ID:         "1",
+				Type:       metric.Count,
+				Category:   metric.Basic,
+				Owner:      metric.Table,
+				GroupValue: "2021-01-01",
+				Value:      10,
+				Timestamp:  previous.EventTimestamp,
+			}
+			err := store.Store(&job.Profile{ID: previous.ID}, []*metric.Metric{m})
+			assert.Nil(t, err)
+
+			current := &job.Profile{
+				ID:             "profile-current",
+				URN:            urn,
+				Filter:         "__PARTITION__ = '2021-01-02'",
+				EventTimestamp: now,
+			}
+
+			result, err := store.GetPreviousMetrics(current, 7)
+
+			assert.Nil(t, err)
+			assert.Len(t, result, 1)
+			assert.Equal(t, 10.0, result[0].Value)
+		})
+		t.Run("should return error when db failed", func(t *testing.T) {
+			db, clear := GetMockDB()
+			defer clear()
+
+			store := NewMetricStore(db, "metric_records")
+
+			result, err := store.GetPreviousMetrics(&job.Profile{ID: "profile-abcd"}, 7)
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	})
+	t.Run("GetMetrics", func(t *testing.T) {
+		urn := "project.dataset.table"
+		day := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		setup := func(t *testing.T) (*MetricStore, func()) {
+			db, clear := GetMockDB()
+
+			db.Table(profileTableName).CreateTable(&profileRecord{})
+			db.Exec("CREATE TABLE status (id INTEGER PRIMARY KEY, job_id TEXT, job_type TEXT, status TEXT, message TEXT, created_at DATETIME)")
+
+			profiles := []*profileRecord{
+				{ID: "profile-1", URN: urn, Kind: standard, EventTimestamp: day},
+				{ID: "profile-2", URN: urn, Kind: standard, EventTimestamp: day.Add(24 * time.Hour)},
+				{ID: "profile-3", URN: urn, Kind: standard, EventTimestamp: day.Add(48 * time.Hour)},
+				{ID: "profile-failed", URN: urn, Kind: standard, EventTimestamp: day.Add(72 * time.Hour)},
+				{ID: "profile-other", URN: "project.dataset.other", Kind: standard, EventTimestamp: day},
+				{ID: "profile-exploratory", URN: urn, Kind: job.KindExploratory.String(), EventTimestamp: day.Add(96 * time.Hour)},
+			}
+			for _, p := range profiles {
+				db.Table(profileTableName).Create(p)
+				db.Exec("INSERT INTO status (job_id, job_type, status) VALUES (?, ?, ?)", p.ID, job.TypeProfile, job.StateInProgress)
+				if p.ID != "profile-failed" {
+					db.Exec("INSERT INTO status (job_id, job_type, status) VALUES (?, ?, ?)", p.ID, job.TypeProfile, job.StateCompleted)
+				}
+			}
+
+			store := NewMetricStore(db, "metric_records")
+			id := 0
+			for i, p := range profiles {
+				var metrics []*metric.Metric
+				for _, fieldID := range []string{"field_a", "field_b"} {
+					for _, metricType := range []metric.Type{metric.NullnessPct, metric.Count} {
+						id++
+						metrics = append(metrics, &metric.Metric{
+							ID:         strconv.Itoa(id),
+							FieldID:    fieldID,
+							Type:       metricType,
+							Category:   metric.GetCategory(metricType),
+							Owner:      metric.Field,
+							GroupValue: p.EventTimestamp.Format("2006-01-02"),
+							Value:      float64(i + 1),
+						})
+					}
+				}
+				err := store.Store(&job.Profile{ID: p.ID}, metrics)
+				assert.Nil(t, err)
+			}
+
+			return store, func() {
+				db.DropTableIfExists(profileTableName, statusTableName)
+				clear()
+			}
+		}
+
+		t.Run("should return time series of metric of completed standard profiles of the urn", func(t *testing.T) {
+			store, clear := setup(t)
+			defer clear()
+
+			query := &protocol.MetricQuery{
+				URN:         urn,
+				FieldID:     "field_a",
+				MetricTypes: []metric.Type{metric.NullnessPct},
+			}
+
+			result, err := store.GetMetrics(query)
+
+			expected := []*protocol.ProfileMetric{
+				{ID: "1", ProfileID: "profile-1", TableURN: urn, Partition: "2021-01-01", FieldID: "field_a", OwnerType: metric.Field, Category: metric.Quality, MetricName: metric.NullnessPct, MetricValue: 1, EventTimestamp: day},
+				{ID: "5", ProfileID: "profile-2", TableURN: urn, Partition: "2021-01-02", FieldID: "field_a", OwnerType: metric.Field, Category: metric.Quality, MetricName: metric.NullnessPct, MetricValue: 2, EventTimestamp: day.Add(24 * time.Hour)},
+				{ID: "9", ProfileID: "profile-3", TableURN: urn, Partition: "2021-01-03", FieldID: "field_a", OwnerType: metric.Field, Category: metric.Quality, MetricName: metric.NullnessPct, MetricValue: 3, EventTimestamp: day.Add(48 * time.Hour)},
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, len(expected), len(result))
+			for i := range expected {
+				assert.Equal(t, expected[i].ID, result[i].ID)
+				assert.Equal(t, expected[i].ProfileID, result[i].ProfileID)
+				assert.Equal(t, expected[i].TableURN, result[i].TableURN)
+				assert.Equal(t, expected[i].Partition, result[i].Partition)
+				assert.Equal(t, expected[i].FieldID, result[i].FieldID)
+				assert.Equal(t, expected[i].MetricName, result[i].MetricName)
+				assert.Equal(t, expected[i].MetricValue, result[i].MetricValue)
+				assert.True(t, expected[i].EventTimestamp.Equal(result[i].EventTimestamp))
+			}
+		})
+		t.Run("should filter metrics by time range and group", func(t *testing.T) {
+			store, clear := setup(t)
+			defer clear()
+
+			testCases := []struct {
+				description string
+				query       *protocol.MetricQuery
+				profileIDs  []string
+			}{
+				{
+					description: "from is inclusive and to is exclusive",
+					query:       &protocol.MetricQuery{URN: urn, From: day.Add(24 * time.Hour), To: day.Add(48 * time.Hour)},
+					profileIDs:  []string{"profile-2", "profile-2", "profile-2", "profile-2"},
+				},
+				{
+					description: "group value",
+					query:       &protocol.MetricQuery{URN: urn, Partition: "2021-01-03", MetricTypes: []metric.Type{metric.Count}},
+					profileIDs:  []string{"profile-3", "profile-3"},
+				},
+				{
+					description: "profile id",
+					query:       &protocol.MetricQuery{ProfileID: "profile-other", FieldID: "field_b"},
+					profileIDs:  []string{"profile-other", "profile-other"},
+				},
+				{
+					description: "no metric found",
+					query:       &protocol.MetricQuery{URN: "project.dataset.unknown"},
+					profileIDs:  []string{},
+				},
+				{
+					description: "latest metrics within the limit",
+					query:       &protocol.MetricQuery{URN: urn, FieldID: "field_a", MetricTypes: []metric.Type{metric.NullnessPct}, Limit: 2},
+					profileIDs:  []string{"profile-2", "profile-3"},
+				},
+			}
+			for _, test := range testCases {
+				result, err := store.GetMetrics(test.query)
+
+				profileIDs := []string{}
+				for _, m := range result {
+					profileIDs = append(profileIDs, m.ProfileID)
+				}
+
+				assert.Nil(t, err, test.description)
+				assert.Equal(t, test.profileIDs, profileIDs, test.description)
+			}
+		})
+		t.Run("should return error when db failed", func(t *testing.T) {
+			db, clear := GetMockDB()
+			defer clear()
+
+			store := NewMetricStore(db, "metric_records")
+
+			result, err := store.GetMetrics(&protocol.MetricQuery{URN: urn})
+
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	})
 }
@@ -0,0 +1,200 @@
+package profile
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+)
+
+const (
+	queuedJobPending   = "pending"
+	queuedJobLeased    = "leased"
+	queuedJobDone      = "done"
+	queuedJobFailed    = "failed"
+	queuedJobCancelled = "cancelled"
+)
+
+type queuedJobRecord struct {
+	ID             int    `gorm:"primary_key"`
+	JobID          string `gorm:"not null"`
+	JobType        string `gorm:"not null"`
+	Status         string `gorm:"not null"`
+	Attempts       int
+	LeaseOwner     string
+	LeaseExpiresAt *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (r *queuedJobRecord) toQueuedJob() *protocol.QueuedJob {
+	var leaseExpiresAt time.Time
+	if r.LeaseExpiresAt != nil {
+		leaseExpiresAt = *r.LeaseExpiresAt
+	}
+	return &protocol.QueuedJob{
+		ID:             r.ID,
+		JobID:          r.JobID,
+		JobType:        job.Type(r.JobType),
+		Attempts:       r.Attempts,
+		LeaseOwner:     r.LeaseOwner,
+		LeaseExpiresAt: leaseExpiresAt,
+	}
+}
+
+//Queue is postgres backed job queue of profile and reconciliation jobs
+//jobs are claimed with row lock that skip locked rows, so replicas never claim the same job at the same time
+type Queue struct {
+	db *gorm.DB
+}
+
+//NewQueue create job queue
+func NewQueue(db *gorm.DB, tableName string) protocol.JobQueue {
+	return &Queue{db: db.Table(tableName)}
+}
+
+//Enqueue add job of the type to the queue
+func (q *Queue) Enqueue(jobID string, jobType job.Type) error {
+	record := &queuedJobRecord{
+		JobID:   jobID,
+		JobType: jobType.String(),
+		Status:  queuedJobPending,
+	}
+	return q.db.Create(record).Error
+}
+
+//Claim lease the oldest available job to the owner
+func (q *Queue) Claim(owner string, lease time.Duration, maxAttempts int) (*protocol.QueuedJob, error) {
+	now := time.Now().In(time.UTC)
+
+	tx := q.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	var records []*queuedJobRecord
+	handle := lockSkipLocked(tx).
+		Where("status = ? OR (status = ? AND lease_expires_at < ? AND attempts < ?)", queuedJobPending, queuedJobLeased, now, maxAttempts).
+		Order("id").
+		Limit(1).
+		Find(&records)
+	if err := handle.Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		tx.Rollback()
+		return nil, protocol.ErrJobQueueEmpty
+	}
+
+	record := records[0]
+	leaseExpiresAt := now.Add(lease)
+	handle = tx.Model(record).Updates(map[string]interface{}{
+		"status":           queuedJobLeased,
+		"attempts":         record.Attempts + 1,
+		"lease_owner":      owner,
+		"lease_expires_at": leaseExpiresAt,
+	})
+	if err := handle.Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return record.toQueuedJob(), nil
+}
+
+//Extend renew lease of a claimed job
+func (q *Queue) Extend(queuedJob *protocol.QueuedJob, lease time.Duration) error {
+	leaseExpiresAt := time.Now().In(time.UTC).Add(lease)
+	if err := q.updateClaimed(queuedJob, map[string]interface{}{"lease_expires_at": leaseExpiresAt}); err != nil {
+		return err
+	}
+	queuedJob.LeaseExpiresAt = leaseExpiresAt
+	return nil
+}
+
+//Cancel mark job that is not finished yet as cancelled
+func (q *Queue) Cancel(jobID string, jobType job.Type) error {
+	handle := q.db.Model(&queuedJobRecord{}).
+		Where("job_id = ? AND job_type = ? AND status IN (?)", jobID, jobType.String(), []string{queuedJobPending, queuedJobLeased}).
+		Update("status", queuedJobCancelled)
+	return handle.Error
+}
+
+//Complete mark claimed job as done
+func (q *Queue) Complete(queuedJob *protocol.QueuedJob) error {
+	return q.updateClaimed(queuedJob, map[string]interface{}{"status": queuedJobDone})
+}
+
+//updateClaimed update job that is still leased by the claim, the claim is identified by its owner and attempt
+//so a stale worker can not update a job claimed again by a worker of the same replica
+func (q *Queue) updateClaimed(queuedJob *protocol.QueuedJob, fields map[string]interface{}) error {
+	handle := q.db.Model(&queuedJobRecord{}).
+		Where("id = ? AND status = ? AND lease_owner = ? AND attempts = ?", queuedJob.ID, queuedJobLeased, queuedJob.LeaseOwner, queuedJob.Attempts).
+		Updates(fields)
+	if err := handle.Error; err != nil {
+		return err
+	}
+	if handle.RowsAffected == 0 {
+		return protocol.ErrJobLeaseLost
+	}
+	return nil
+}
+
+//FailExpired mark jobs with expired lease that already reach max attempts as failed
+func (q *Queue) FailExpired(maxAttempts int) ([]*protocol.QueuedJob, error) {
+	now := time.Now().In(time.UTC)
+
+	tx := q.db.Begin()
+	if err := tx.Error; err != nil {
+		return nil, err
+	}
+
+	var records []*queuedJobRecord
+	handle := lockSkipLocked(tx).
+		Where("status = ? AND lease_expires_at < ? AND attempts >= ?", queuedJobLeased, now, maxAttempts).
+		Order("id").
+		Find(&records)
+	if err := handle.Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		tx.Rollback()
+		return nil, nil
+	}
+
+	var ids []int
+	var jobs []*protocol.QueuedJob
+	for _, record := range records {
+		ids = append(ids, record.ID)
+		jobs = append(jobs, record.toQueuedJob())
+	}
+
+	handle = tx.Model(&queuedJobRecord{}).Where("id IN (?)", ids).Update("status", queuedJobFailed)
+	if err := handle.Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+//lockSkipLocked lock selected rows and skip rows locked by other transaction, only supported on postgres
+func lockSkipLocked(tx *gorm.DB) *gorm.DB {
+	if tx.Dialect().GetName() != "postgres" {
+		return tx
+	}
+	return tx.Set("gorm:query_option", "FOR UPDATE SKIP LOCKED")
+}
@@ -0,0 +1,207 @@
+package profile
+
+import (
+	"testing"
+	"time"
+
+	pmock "github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	tableName := "queued_job_records"
+	t.Run("Claim", func(t *testing.T) {
+		t.Run("should claim the oldest pending job", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			assert.Nil(t, queue.Enqueue("profile-2", job.TypeProfile))
+
+			claimed, err := queue.Claim("replica-1", time.Minute, 3)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "profile-1", claimed.JobID)
+			assert.Equal(t, 1, claimed.Attempts)
+			assert.Equal(t, "replica-1", claimed.LeaseOwner)
+			assert.True(t, claimed.LeaseExpiresAt.After(time.Now()))
+
+			next, err := queue.Claim("replica-2", time.Minute, 3)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "profile-2", next.JobID)
+		})
+		t.Run("should claim jobs of every type in enqueued order", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("reconciliation-1", job.TypeReconciliation))
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+
+			first, err := queue.Claim("replica-1", time.Minute, 3)
+			assert.Nil(t, err)
+			second, err := queue.Claim("replica-1", time.Minute, 3)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "reconciliation-1", first.JobID)
+			assert.Equal(t, job.TypeReconciliation, first.JobType)
+			assert.Equal(t, "profile-1", second.JobID)
+			assert.Equal(t, job.TypeProfile, second.JobType)
+		})
+		t.Run("should return error when no job available", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			_, err := queue.Claim("replica-1", time.Minute, 3)
+			assert.Nil(t, err)
+
+			claimed, err := queue.Claim("replica-2", time.Minute, 3)
+
+			assert.Nil(t, claimed)
+			assert.Equal(t, protocol.ErrJobQueueEmpty, err)
+		})
+		t.Run("should claim job with expired lease again", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			_, err := queue.Claim("replica-1", -time.Minute, 3)
+			assert.Nil(t, err)
+
+			claimed, err := queue.Claim("replica-2", time.Minute, 3)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "profile-1", claimed.JobID)
+			assert.Equal(t, 2, claimed.Attempts)
+			assert.Equal(t, "replica-2", claimed.LeaseOwner)
+		})
+		t.Run("should not claim job with expired lease that reach max attempts", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			_, err := queue.Claim("replica-1", -time.Minute, 1)
+			assert.Nil(t, err)
+
+			claimed, err := queue.Claim("replica-2", time.Minute, 1)
+
+			assert.Nil(t, claimed)
+			assert.Equal(t, protocol.ErrJobQueueEmpty, err)
+		})
+	})
+	t.Run("Complete", func(t *testing.T) {
+		t.Run("should mark job as done", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			claimed, _ := queue.Claim("replica-1", time.Minute, 3)
+
+			err := queue.Complete(claimed)
+
+			var record queuedJobRecord
+			db.Table(tableName).First(&record)
+
+			assert.Nil(t, err)
+			assert.Equal(t, queuedJobDone, record.Status)
+		})
+		t.Run("should return error when lease is taken by another worker", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			expired, _ := queue.Claim("replica-1", -time.Minute, 3)
+			_, _ = queue.Claim("replica-2", time.Minute, 3)
+
+			err := queue.Complete(expired)
+
+			assert.Equal(t, protocol.ErrJobLeaseLost, err)
+		})
+		t.Run("should return error when lease is taken by another worker of the same owner", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			expired, _ := queue.Claim("replica-1", -time.Minute, 3)
+			reclaimed, _ := queue.Claim("replica-1", time.Minute, 3)
+
+			assert.Equal(t, protocol.ErrJobLeaseLost, queue.Extend(expired, time.Minute))
+			assert.Equal(t, protocol.ErrJobLeaseLost, queue.Complete(expired))
+			assert.Nil(t, queue.Complete(reclaimed))
+		})
+	})
+	t.Run("Extend", func(t *testing.T) {
+		t.Run("should renew lease of claimed job", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			claimed, _ := queue.Claim("replica-1", -time.Minute, 3)
+
+			err := queue.Extend(claimed, time.Minute)
+
+			assert.Nil(t, err)
+			assert.True(t, claimed.LeaseExpiresAt.After(time.Now()))
+
+			next, err := queue.Claim("replica-2", time.Minute, 3)
+
+			assert.Nil(t, next)
+			assert.Equal(t, protocol.ErrJobQueueEmpty, err)
+		})
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		t.Run("should cancel pending job and make running job lose its lease", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			assert.Nil(t, queue.Enqueue("profile-2", job.TypeProfile))
+			running, _ := queue.Claim("replica-1", time.Minute, 3)
+
+			assert.Nil(t, queue.Cancel("profile-1", job.TypeProfile))
+			assert.Nil(t, queue.Cancel("profile-2", job.TypeProfile))
+
+			claimed, err := queue.Claim("replica-1", time.Minute, 3)
+
+			assert.Nil(t, claimed)
+			assert.Equal(t, protocol.ErrJobQueueEmpty, err)
+			assert.Equal(t, protocol.ErrJobLeaseLost, queue.Extend(running, time.Minute))
+		})
+	})
+	t.Run("FailExpired", func(t *testing.T) {
+		t.Run("should mark expired jobs that reach max attempts as failed", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(queuedJobRecord))
+			defer clearDb()
+
+			queue := NewQueue(db, tableName)
+			assert.Nil(t, queue.Enqueue("profile-1", job.TypeProfile))
+			assert.Nil(t, queue.Enqueue("profile-2", job.TypeProfile))
+			_, _ = queue.Claim("replica-1", -time.Minute, 1)
+			_, _ = queue.Claim("replica-1", time.Minute, 1)
+
+			failed, err := queue.FailExpired(1)
+
+			var records []*queuedJobRecord
+			db.Table(tableName).Order("id").Find(&records)
+
+			assert.Nil(t, err)
+			assert.Len(t, failed, 1)
+			assert.Equal(t, "profile-1", failed[0].JobID)
+			assert.Equal(t, queuedJobFailed, records[0].Status)
+			assert.Equal(t, queuedJobLeased, records[1].Status)
+		})
+	})
+}
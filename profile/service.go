@@ -4,23 +4,53 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/odpf/predator/stats"
+	"log"
+	"os"
 	"sync"
 	"time"
 
+	"github.com/odpf/predator/stats"
+
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/job"
 )
 
+var logger = log.New(os.Stdout, "INFO: ", log.Lshortfile|log.LstdFlags)
+
+//WorkerConfig is configuration of workers that run profile jobs from the job queue
+type WorkerConfig struct {
+	//Owner is unique identity of the replica, used as owner of job lease
+	Owner string
+	//Count is number of workers on the replica
+	Count int
+	//LeaseDuration is duration of job lease, the lease is renewed periodically while the job is running
+	LeaseDuration time.Duration
+	//MaxAttempts is max number a job claimed before it marked as failed when the lease keep expiring
+	MaxAttempts int
+	//PollInterval is wait duration of idle worker before claiming the next job
+	PollInterval time.Duration
+}
+
 //Service is profile service
 type Service struct {
 	wg                    sync.WaitGroup
+	stop                  chan struct{}
+	stopOnce              sync.Once
 	profileStore          protocol.ProfileStore
+	metricStore           protocol.MetricStore
 	metricGenerator       protocol.MetricGenerator
 	publisher             protocol.Publisher
 	messageBuilderFactory protocol.MessageProviderFactory
 	statusStore           protocol.StatusStore
 	statsClientBuilder    stats.ClientBuilder
+	jobQueue              protocol.JobQueue
+	queryCanceller        protocol.QueryCanceller
+	costEstimator         protocol.CostEstimator
+	workerConfig          *WorkerConfig
+	handlers              map[job.Type]protocol.JobHandler
+
+	runningMu sync.Mutex
+	running   map[string]context.CancelFunc
 }
 
 //Get to get profile
@@ -30,98 +60,418 @@ func (s *Service) Get(ID string) (*job.Profile, error) {
 
 //NewService to construct profile service
 func NewService(profileStore protocol.ProfileStore,
+	metricStore protocol.MetricStore,
 	metricGenerator protocol.MetricGenerator,
 	publisher protocol.Publisher,
 	messageBuilderFactory protocol.MessageProviderFactory,
 	statusStore protocol.StatusStore,
-	statsFactory stats.ClientBuilder) *Service {
+	statsFactory stats.ClientBuilder,
+	jobQueue protocol.JobQueue,
+	queryCanceller protocol.QueryCanceller,
+	costEstimator protocol.CostEstimator,
+	workerConfig *WorkerConfig) *Service {
 	return &Service{
+		stop:                  make(chan struct{}),
 		profileStore:          profileStore,
+		metricStore:           metricStore,
 		metricGenerator:       metricGenerator,
 		publisher:             publisher,
 		messageBuilderFactory: messageBuilderFactory,
 		statusStore:           statusStore,
 		statsClientBuilder:    statsFactory,
+		jobQueue:              jobQueue,
+		queryCanceller:        queryCanceller,
+		costEstimator:         costEstimator,
+		workerConfig:          workerConfig,
+		handlers:              make(map[job.Type]protocol.JobHandler),
+		running:               make(map[string]context.CancelFunc),
 	}
 }
 
-//CreateProfile to create profile
+//CreateProfile to create profile, the profile is enqueued and run by any available worker
 func (s *Service) CreateProfile(profile *job.Profile) (*job.Profile, error) {
 	createdProfile, err := s.profileStore.Create(profile)
 	if err != nil {
 		return nil, err
 	}
 
-	label, err := protocol.ParseLabel(profile.URN)
+	statsClient, err := s.buildStatsClient(createdProfile)
 	if err != nil {
 		return nil, err
 	}
 
-	clientBuilder := s.statsClientBuilder.WithURN(label)
-	statsClient, err := clientBuilder.Build()
-	if err != nil {
+	if err := s.jobQueue.Enqueue(createdProfile.ID, job.TypeProfile); err != nil {
 		return nil, err
 	}
 
 	m := stats.Metric("profile.job.created.count")
 	statsClient.Increment(m)
 
-	s.wg.Add(1)
-	go func() {
-		var err error
-		defer func() {
+	return createdProfile, nil
+}
+
+//Cancel to cancel profile that is not finished yet, queries of the profile that still running on the warehouse are cancelled as well
+func (s *Service) Cancel(ID string) (*job.Profile, error) {
+	profile, err := s.profileStore.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.Status.IsFinished() {
+		return nil, protocol.ErrProfileFinished
+	}
+
+	if err := s.jobQueue.Cancel(ID, job.TypeProfile); err != nil {
+		return nil, err
+	}
+
+	s.cancelRunning(ID)
+
+	//the state is kept when the queries can not be cancelled, so the cancellation can be retried
+	if err := s.queryCanceller.Cancel(profile); err != nil {
+		return nil, fmt.Errorf("unable to cancel running queries of profile %s, %w", ID, err)
+	}
+
+	profile.Status = job.StateCancelled
+	profile.Message = "profile cancelled"
+	if err := s.profileStore.Update(profile); err != nil {
+		return nil, err
+	}
+
+	statsClient, err := s.buildStatsClient(profile)
+	if err != nil {
+		return nil, err
+	}
+	m := stats.Metric("profile.job.cancelled.count")
+	statsClient.Increment(m)
+
+	return profile, nil
+}
+
+//Estimate estimate bytes processed by the profile without running it
+func (s *Service) Estimate(profile *job.Profile) (*protocol.CostEstimate, error) {
+	return s.costEstimator.Estimate(protocol.NewEntry(), profile)
+}
+
+//cancelRunning cancel context of the profile job when it is running on this replica
+//job running on other replica is stopped when its lease renewal fails
+func (s *Service) cancelRunning(profileID string) {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+	if cancel, ok := s.running[profileID]; ok {
+		cancel()
+	}
+}
+
+func (s *Service) trackRunning(profileID string, cancel context.CancelFunc) (untrack func()) {
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+	s.running[profileID] = cancel
+	return func() {
+		s.runningMu.Lock()
+		defer s.runningMu.Unlock()
+		delete(s.running, profileID)
+	}
+}
+
+func (s *Service) buildStatsClient(profile *job.Profile) (stats.Client, error) {
+	label, err := protocol.ParseLabel(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+
+	clientBuilder := s.statsClientBuilder.WithURN(label)
+	return clientBuilder.Build()
+}
+
+//Handle run claimed jobs of the job type with the handler, handlers should be registered before Start
+func (s *Service) Handle(jobType job.Type, handler protocol.JobHandler) {
+	s.handlers[jobType] = handler
+}
+
+//Start to start workers that claim and run profile jobs and jobs of the registered handlers from the job queue
+func (s *Service) Start() {
+	for i := 0; i < s.workerConfig.Count; i++ {
+		s.wg.Add(1)
+		go s.work()
+	}
+}
+
+func (s *Service) work() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		claimed, err := s.poll()
+		if err != nil {
+			logger.Printf("failed to run job: %v\n", err)
+		}
+		if claimed {
+			continue
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(s.workerConfig.PollInterval):
+		}
+	}
+}
+
+//poll fail jobs that keep expiring and run the next available job, return false when no job is claimed
+func (s *Service) poll() (bool, error) {
+	if err := s.failExpiredJobs(); err != nil {
+		return false, err
+	}
+
+	queuedJob, err := s.jobQueue.Claim(s.workerConfig.Owner, s.workerConfig.LeaseDuration, s.workerConfig.MaxAttempts)
+	if err != nil {
+		if err == protocol.ErrJobQueueEmpty {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, s.runJob(queuedJob)
+}
+
+func (s *Service) failExpiredJobs() error {
+	expiredJobs, err := s.jobQueue.FailExpired(s.workerConfig.MaxAttempts)
+	if err != nil {
+		return err
+	}
+
+	for _, expiredJob := range expiredJobs {
+		if expiredJob.JobType != job.TypeProfile {
+			handler, err := s.getHandler(expiredJob)
 			if err != nil {
-				createdProfile.Status = job.StateFailed
-				createdProfile.Message = fmt.Sprintf("profile failed because %s", err.Error())
-				err = s.profileStore.Update(createdProfile)
-
-				m := stats.Metric("profile.job.failed.count")
-				statsClient.Increment(m)
-			} else {
-				createdProfile.Status = job.StateCompleted
-				createdProfile.Message = "profile completed"
-				err = s.profileStore.Update(createdProfile)
-
-				m := stats.Metric("profile.job.completed.count")
-				statsClient.Increment(m)
+				return err
 			}
-			s.wg.Done()
-		}()
+			if err := handler.Fail(expiredJob); err != nil {
+				return err
+			}
+			continue
+		}
 
-		createdProfile.Status = job.StateInProgress
-		createdProfile.Message = "profile in progress"
-		err = s.profileStore.Update(createdProfile)
+		profile, err := s.profileStore.Get(expiredJob.JobID)
 		if err != nil {
-			return
+			return err
 		}
 
-		m := stats.Metric("profile.job.inprogress.count")
-		statsClient.Increment(m)
+		profile.Status = job.StateFailed
+		profile.Message = fmt.Sprintf("profile failed because job lease expired after %d attempts", expiredJob.Attempts)
+		if err := s.profileStore.Update(profile); err != nil {
+			return err
+		}
 
-		metrics, err := s.metricGenerator.Generate(protocol.NewEntry(), createdProfile)
+		statsClient, err := s.buildStatsClient(profile)
 		if err != nil {
+			return err
+		}
+		m := stats.Metric("profile.job.failed.count")
+		statsClient.Increment(m)
+	}
+	return nil
+}
+
+//runJob run claimed job, when the replica is killed the lease will expire and the job is claimed again by other worker
+func (s *Service) runJob(queuedJob *protocol.QueuedJob) error {
+	if queuedJob.JobType != job.TypeProfile {
+		return s.runHandledJob(queuedJob)
+	}
+
+	profile, err := s.profileStore.Get(queuedJob.JobID)
+	if err != nil {
+		return err
+	}
+
+	statsClient, err := s.buildStatsClient(profile)
+	if err != nil {
+		return err
+	}
+
+	//metrics stored by the previous attempt are removed, so the retried job does not store them twice
+	if queuedJob.Attempts > 1 {
+		if err := s.metricStore.DeleteByProfileID(profile.ID); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	untrack := s.trackRunning(profile.ID, cancel)
+	defer untrack()
+
+	release := s.keepLeased(queuedJob, cancel)
+	stopped := s.runProfile(protocol.NewEntryWithContext(ctx), profile, statsClient)
+	release()
+
+	if stopped {
+		logger.Printf("profile %s is stopped\n", profile.ID)
+		return nil
+	}
+	return s.jobQueue.Complete(queuedJob)
+}
+
+//runHandledJob run claimed job of other type than profile with its handler, the job is completed unless it is stopped
+func (s *Service) runHandledJob(queuedJob *protocol.QueuedJob) error {
+	handler, err := s.getHandler(queuedJob)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := s.keepLeased(queuedJob, cancel)
+	err = handler.Run(ctx, queuedJob)
+	release()
+	if err != nil {
+		return err
+	}
+
+	if ctx.Err() != nil {
+		logger.Printf("%s %s is stopped\n", queuedJob.JobType, queuedJob.JobID)
+		return nil
+	}
+	return s.jobQueue.Complete(queuedJob)
+}
+
+func (s *Service) getHandler(queuedJob *protocol.QueuedJob) (protocol.JobHandler, error) {
+	handler, ok := s.handlers[queuedJob.JobType]
+	if !ok {
+		return nil, fmt.Errorf("no handler of %s job %s", queuedJob.JobType, queuedJob.JobID)
+	}
+	return handler, nil
+}
+
+//keepLeased periodically renew lease of the running job until released, the job is cancelled when the lease is lost
+func (s *Service) keepLeased(queuedJob *protocol.QueuedJob, cancel context.CancelFunc) (release func()) {
+	done := make(chan struct{})
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		ticker := time.NewTicker(s.workerConfig.LeaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				err := s.jobQueue.Extend(queuedJob, s.workerConfig.LeaseDuration)
+				if err == protocol.ErrJobLeaseLost {
+					cancel()
+					return
+				}
+				if err != nil {
+					logger.Printf("failed to renew lease of %s %s: %v\n", queuedJob.JobType, queuedJob.JobID, err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-renewed
+	}
+}
+
+//runProfile run the profile, return true when the profile is stopped because it is cancelled or its job is taken over
+func (s *Service) runProfile(entry protocol.Entry, profile *job.Profile, statsClient stats.Client) (stopped bool) {
+	var err error
+	defer func() {
+		if entry.Context().Err() != nil || errors.Is(err, protocol.ErrProfileCancelled) {
+			stopped = true
 			return
 		}
+		if err != nil {
+			profile.Status = job.StateFailed
+			profile.Message = fmt.Sprintf("profile failed because %s", err.Error())
+			err = s.profileStore.Update(profile)
+
+			m := stats.Metric("profile.job.failed.count")
+			statsClient.Increment(m)
+		} else {
+			profile.Status = job.StateCompleted
+			profile.Message = "profile completed"
+			err = s.profileStore.Update(profile)
+
+			m := stats.Metric("profile.job.completed.count")
+			statsClient.Increment(m)
+		}
+	}()
+
+	profile.Status = job.StateInProgress
+	profile.Message = "profile in progress"
+	err = s.profileStore.Update(profile)
+	if err != nil {
+		return
+	}
+
+	m := stats.Metric("profile.job.inprogress.count")
+	statsClient.Increment(m)
 
-		messageProviders := s.messageBuilderFactory.CreateProfileMessage(createdProfile, metrics)
+	entry, err = s.applyCostLimit(entry, profile, statsClient)
+	if err != nil {
+		return
+	}
+
+	metrics, err := s.metricGenerator.Generate(entry, profile)
+	if err != nil {
+		return
+	}
+
+	//only metrics of standard profile describe the table data quality
+	if profile.Kind.OrDefault() == job.KindStandard {
+		messageProviders := s.messageBuilderFactory.CreateProfileMessage(profile, metrics)
 		for _, messageProvider := range messageProviders {
 			err = s.publisher.Publish(messageProvider)
 			if err != nil {
 				return
 			}
 		}
+	}
 
-		jobDurationStat := stats.Metric("profile.job.time")
-		start := createdProfile.EventTimestamp
-		end := time.Now().In(time.UTC)
-		statsClient.DurationOf(jobDurationStat, start, end)
-	}()
+	jobDurationStat := stats.Metric("profile.job.time")
+	start := profile.EventTimestamp
+	end := time.Now().In(time.UTC)
+	statsClient.DurationOf(jobDurationStat, start, end)
+	return
+}
 
-	return createdProfile, err
+//applyCostLimit reject the profile when the estimated bytes processed exceed the limit
+//otherwise the limit is applied as max bytes billed of every query run by the profile
+func (s *Service) applyCostLimit(entry protocol.Entry, profile *job.Profile, statsClient stats.Client) (protocol.Entry, error) {
+	estimate, err := s.costEstimator.Estimate(entry, profile)
+	if err != nil {
+		return entry, err
+	}
+
+	limitedEntry, err := estimate.Limit(entry)
+	if err != nil {
+		m := stats.Metric("profile.job.rejected.count")
+		statsClient.Increment(m)
+		return entry, err
+	}
+
+	profile.Message = fmt.Sprintf("estimated bytes to be processed: %d", estimate.TotalBytes)
+	if err := s.profileStore.Update(profile); err != nil {
+		return entry, err
+	}
+
+	return limitedEntry, nil
 }
 
-//WaitAll to wait until task finished
+//WaitAll to stop claiming new job and wait until running jobs finished
 func (s *Service) WaitAll(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
 	waitChan := make(chan bool)
 	go func() {
 		s.wg.Wait()
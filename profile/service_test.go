@@ -14,35 +14,154 @@ import (
 	"github.com/odpf/predator/protocol/metric"
 	"github.com/odpf/predator/publisher/message"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 )
 
 func TestProfileService(t *testing.T) {
-	t.Run("Profile", func(t *testing.T) {
-		t.Run("should run profile job", func(t *testing.T) {
+	workerConfig := &WorkerConfig{
+		Owner:         "replica-1",
+		Count:         1,
+		LeaseDuration: time.Minute,
+		MaxAttempts:   3,
+		PollInterval:  time.Millisecond,
+	}
+	label := &protocol.Label{
+		Project: "a",
+		Dataset: "b",
+		Table:   "c",
+	}
+	t.Run("CreateProfile", func(t *testing.T) {
+		t.Run("should create and enqueue profile job", func(t *testing.T) {
 			profile := &job.Profile{
 				Status:  job.StateCreated,
 				Message: "profile started",
 				URN:     "a.b.c",
 			}
+			createdProfile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateCreated,
+				Message: "profile started",
+				URN:     "a.b.c",
+			}
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			profileStore.On("Create", profile).Return(createdProfile, nil)
+			jobQueue.On("Enqueue", "profile-1", job.TypeProfile).Return(nil)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			statsClient := mock.NewDummyStats()
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			s := NewService(profileStore, nil, nil, nil, nil, nil, statsClientBuilder, jobQueue, nil, nil, workerConfig)
+
+			result, err := s.CreateProfile(profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, createdProfile, result)
+		})
+		t.Run("should return error when create profile failed", func(t *testing.T) {
+			someError := errors.New("network error")
+			profile := &job.Profile{
+				Status:  job.StateCreated,
+				Message: "profile started",
+				URN:     "a.b.c",
+			}
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			profileStore.On("Create", profile).Return(&job.Profile{}, someError)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			s := NewService(profileStore, nil, nil, nil, nil, nil, statsClientBuilder, jobQueue, nil, nil, workerConfig)
+
+			_, err := s.CreateProfile(profile)
+
+			assert.Error(t, err)
+		})
+		t.Run("should return error when enqueue profile job failed", func(t *testing.T) {
+			someError := errors.New("network error")
+			profile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateCreated,
+				Message: "profile started",
+				URN:     "a.b.c",
+			}
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			profileStore.On("Create", profile).Return(profile, nil)
+			jobQueue.On("Enqueue", "profile-1", job.TypeProfile).Return(someError)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			statsClient := mock.NewDummyStats()
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			s := NewService(profileStore, nil, nil, nil, nil, nil, statsClientBuilder, jobQueue, nil, nil, workerConfig)
+
+			result, err := s.CreateProfile(profile)
+
+			assert.Nil(t, result)
+			assert.Equal(t, someError, err)
+		})
+	})
+	t.Run("poll", func(t *testing.T) {
+		queuedJob := &protocol.QueuedJob{
+			ID:         1,
+			JobID:      "profile-1",
+			JobType:    job.TypeProfile,
+			Attempts:   1,
+			LeaseOwner: "replica-1",
+		}
+		t.Run("should run claimed profile job", func(t *testing.T) {
+			profile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateCreated,
+				Message: "profile started",
+				URN:     "a.b.c",
+			}
 
 			inProgressProfile := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateInProgress,
 				Message: "profile in progress",
 				URN:     "a.b.c",
 			}
 
+			estimatedProfile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateInProgress,
+				Message: "estimated bytes to be processed: 100",
+				URN:     "a.b.c",
+			}
+
 			completedProfile := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateCompleted,
 				Message: "profile completed",
 				URN:     "a.b.c",
 			}
 
-			label := &protocol.Label{
-				Project: "a",
-				Dataset: "b",
-				Table:   "c",
-			}
-
 			metrics := []*metric.Metric{
 				{
 					GroupValue: "2019-01-01",
@@ -59,17 +178,30 @@ func TestProfileService(t *testing.T) {
 			metricGenerator := mock.NewMetricGenerator()
 			defer metricGenerator.AssertExpectations(t)
 
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
+
 			publisher := mock.NewPublisher()
 			defer publisher.AssertExpectations(t)
 
 			metricProviderFactory := mock.NewMessageProviderFactory()
 			defer metricProviderFactory.AssertExpectations(t)
 
-			profileStore.On("Create", profile).Return(profile, nil)
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(queuedJob, nil)
+			jobQueue.On("Complete", queuedJob).Return(nil)
+
+			profileStore.On("Get", "profile-1").Return(profile, nil)
 			profileStore.On("Update", inProgressProfile).Return(nil)
 
-			metricGenerator.On("Generate", protocol.NewEntry(), inProgressProfile).Return(metrics, nil)
-			metricProviderFactory.On("CreateProfileMessage", inProgressProfile, metrics).Return(messageProviders)
+			costEstimator.On("Estimate", inProgressProfile).Return(&protocol.CostEstimate{TotalBytes: 100}, nil)
+			profileStore.On("Update", estimatedProfile).Return(nil)
+
+			metricGenerator.On("Generate", testifyMock.Anything, estimatedProfile).Return(metrics, nil)
+			metricProviderFactory.On("CreateProfileMessage", estimatedProfile, metrics).Return(messageProviders)
 			publisher.On("Publish", messageProviders[0]).Return(nil)
 
 			profileStore.On("Update", completedProfile).Return(nil)
@@ -81,40 +213,113 @@ func TestProfileService(t *testing.T) {
 			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
 			statsClientBuilder.On("Build").Return(statsClient, nil)
 
-			s := NewService(profileStore, metricGenerator, publisher, metricProviderFactory, nil, statsClientBuilder)
+			s := NewService(profileStore, nil, metricGenerator, publisher, metricProviderFactory, nil, statsClientBuilder, jobQueue, nil, costEstimator, workerConfig)
 
-			result, _ := s.CreateProfile(profile)
+			claimed, err := s.poll()
 
-			_ = s.WaitAll(context.Background())
+			assert.Nil(t, err)
+			assert.True(t, claimed)
+			assert.Equal(t, completedProfile, profile)
+		})
+		t.Run("should not publish metrics of exploratory profile", func(t *testing.T) {
+			newProfile := func(status job.State, message string) *job.Profile {
+				return &job.Profile{
+					ID:      "profile-1",
+					Status:  status,
+					Message: message,
+					URN:     "a.b.c",
+				}
+			}
+			profile := newProfile(job.StateCreated, "exploratory profile to suggest tolerance spec")
+			profile.Kind = job.KindExploratory
+			inProgressProfile := newProfile(job.StateInProgress, "profile in progress")
+			estimatedProfile := newProfile(job.StateInProgress, "estimated bytes to be processed: 100")
+			completedProfile := newProfile(job.StateCompleted, "profile completed")
+
+			metrics := []*metric.Metric{
+				{
+					Type: metric.Count,
+				},
+			}
 
-			assert.Equal(t, completedProfile, result)
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			metricGenerator := mock.NewMetricGenerator()
+			defer metricGenerator.AssertExpectations(t)
+
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
+
+			publisher := mock.NewPublisher()
+			defer publisher.AssertExpectations(t)
+
+			metricProviderFactory := mock.NewMessageProviderFactory()
+			defer metricProviderFactory.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(queuedJob, nil)
+			jobQueue.On("Complete", queuedJob).Return(nil)
+
+			profileStore.On("Get", "profile-1").Return(profile, nil)
+			profileStore.On("Update", inProgressProfile).Return(nil)
+
+			costEstimator.On("Estimate", testifyMock.Anything).Return(&protocol.CostEstimate{TotalBytes: 100}, nil)
+			profileStore.On("Update", estimatedProfile).Return(nil)
+
+			metricGenerator.On("Generate", testifyMock.Anything, testifyMock.Anything).Return(metrics, nil)
+
+			profileStore.On("Update", completedProfile).Return(nil)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			statsClient := mock.NewDummyStats()
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			s := NewService(profileStore, nil, metricGenerator, publisher, metricProviderFactory, nil, statsClientBuilder, jobQueue, nil, costEstimator, workerConfig)
+
+			claimed, err := s.poll()
+
+			assert.Nil(t, err)
+			assert.True(t, claimed)
+			assert.Equal(t, job.StateCompleted, profile.Status)
+			metricProviderFactory.AssertNotCalled(t, "CreateProfileMessage", testifyMock.Anything, testifyMock.Anything)
 		})
-		t.Run("should failed when generate metrics return error", func(t *testing.T) {
+		t.Run("should mark profile failed when generate metrics return error", func(t *testing.T) {
 			someError := errors.New("network error")
 			profile := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateCreated,
 				Message: "profile started",
 				URN:     "a.b.c",
 			}
 
 			inProgressProfile := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateInProgress,
 				Message: "profile in progress",
 				URN:     "a.b.c",
 			}
 
+			estimatedProfile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateInProgress,
+				Message: "estimated bytes to be processed: 100",
+				URN:     "a.b.c",
+			}
+
 			endProfileState := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateFailed,
 				Message: fmt.Sprintf("profile failed because %s", someError.Error()),
 				URN:     "a.b.c",
 			}
 
-			label := &protocol.Label{
-				Project: "a",
-				Dataset: "b",
-				Table:   "c",
-			}
-
 			var metrics []*metric.Metric
 
 			profileStore := mock.NewProfileStore()
@@ -123,13 +328,26 @@ func TestProfileService(t *testing.T) {
 			metricGenerator := mock.NewMetricGenerator()
 			defer metricGenerator.AssertExpectations(t)
 
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
+
 			publisher := mock.NewPublisher()
 			defer publisher.AssertExpectations(t)
 
-			profileStore.On("Create", profile).Return(profile, nil)
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(queuedJob, nil)
+			jobQueue.On("Complete", queuedJob).Return(nil)
+
+			profileStore.On("Get", "profile-1").Return(profile, nil)
 			profileStore.On("Update", inProgressProfile).Return(nil)
 
-			metricGenerator.On("Generate", protocol.NewEntry(), inProgressProfile).Return(metrics, someError)
+			costEstimator.On("Estimate", inProgressProfile).Return(&protocol.CostEstimate{TotalBytes: 100}, nil)
+			profileStore.On("Update", estimatedProfile).Return(nil)
+
+			metricGenerator.On("Generate", testifyMock.Anything, estimatedProfile).Return(metrics, someError)
 
 			profileStore.On("Update", endProfileState).Return(nil)
 
@@ -140,29 +358,101 @@ func TestProfileService(t *testing.T) {
 			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
 			statsClientBuilder.On("Build").Return(statsClient, nil)
 
-			s := NewService(profileStore, metricGenerator, publisher, nil, nil, statsClientBuilder)
+			s := NewService(profileStore, nil, metricGenerator, publisher, nil, nil, statsClientBuilder, jobQueue, nil, costEstimator, workerConfig)
 
-			result, _ := s.CreateProfile(profile)
+			claimed, err := s.poll()
 
-			_ = s.WaitAll(context.Background())
+			assert.Nil(t, err)
+			assert.True(t, claimed)
+			assert.Equal(t, endProfileState, profile)
+		})
+		t.Run("should reject profile when estimated bytes exceed max bytes billed", func(t *testing.T) {
+			profile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateCreated,
+				Message: "profile started",
+				URN:     "a.b.c",
+			}
+
+			inProgressProfile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateInProgress,
+				Message: "profile in progress",
+				URN:     "a.b.c",
+			}
 
-			assert.Equal(t, endProfileState, result)
+			rejectedProfile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateFailed,
+				Message: "profile failed because estimated bytes processed exceed max bytes billed, estimated 2000 bytes while the limit is 1000 bytes",
+				URN:     "a.b.c",
+			}
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			metricGenerator := mock.NewMetricGenerator()
+			defer metricGenerator.AssertExpectations(t)
+
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(queuedJob, nil)
+			jobQueue.On("Complete", queuedJob).Return(nil)
+
+			profileStore.On("Get", "profile-1").Return(profile, nil)
+			profileStore.On("Update", inProgressProfile).Return(nil)
+
+			estimate := &protocol.CostEstimate{TotalBytes: 2000, MaxBytesBilled: 1000}
+			costEstimator.On("Estimate", inProgressProfile).Return(estimate, nil)
+
+			profileStore.On("Update", rejectedProfile).Return(nil)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			statsClient := mock.NewDummyStats()
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			s := NewService(profileStore, nil, metricGenerator, nil, nil, nil, statsClientBuilder, jobQueue, nil, costEstimator, workerConfig)
+
+			claimed, err := s.poll()
+
+			assert.Nil(t, err)
+			assert.True(t, claimed)
+			assert.Equal(t, rejectedProfile, profile)
+			metricGenerator.AssertNotCalled(t, "Generate", testifyMock.Anything, testifyMock.Anything)
 		})
-		t.Run("should failed when publish metrics return error", func(t *testing.T) {
+		t.Run("should mark profile failed when publish metrics return error", func(t *testing.T) {
 			someError := errors.New("network error")
 			profile := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateCreated,
 				Message: "profile started",
 				URN:     "a.b.c",
 			}
 
 			inProgressProfile := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateInProgress,
 				Message: "profile in progress",
 				URN:     "a.b.c",
 			}
 
+			estimatedProfile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateInProgress,
+				Message: "estimated bytes to be processed: 100",
+				URN:     "a.b.c",
+			}
+
 			endProfileState := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateFailed,
 				Message: fmt.Sprintf("profile failed because %s", someError.Error()),
 				URN:     "a.b.c",
@@ -174,12 +464,6 @@ func TestProfileService(t *testing.T) {
 				},
 			}
 
-			label := &protocol.Label{
-				Project: "a",
-				Dataset: "b",
-				Table:   "c",
-			}
-
 			messageProviders := []protocol.MessageProvider{
 				&message.Provider{},
 			}
@@ -193,15 +477,28 @@ func TestProfileService(t *testing.T) {
 			metricGenerator := mock.NewMetricGenerator()
 			defer metricGenerator.AssertExpectations(t)
 
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
+
 			publisher := mock.NewPublisher()
 			defer publisher.AssertExpectations(t)
 
-			profileStore.On("Create", profile).Return(profile, nil)
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(queuedJob, nil)
+			jobQueue.On("Complete", queuedJob).Return(nil)
+
+			profileStore.On("Get", "profile-1").Return(profile, nil)
 			profileStore.On("Update", inProgressProfile).Return(nil)
 			profileStore.On("Update", endProfileState).Return(nil)
 
-			metricGenerator.On("Generate", protocol.NewEntry(), inProgressProfile).Return(metrics, nil)
-			messageProviderFactory.On("CreateProfileMessage", inProgressProfile, metrics).Return(messageProviders)
+			costEstimator.On("Estimate", inProgressProfile).Return(&protocol.CostEstimate{TotalBytes: 100}, nil)
+			profileStore.On("Update", estimatedProfile).Return(nil)
+
+			metricGenerator.On("Generate", testifyMock.Anything, estimatedProfile).Return(metrics, nil)
+			messageProviderFactory.On("CreateProfileMessage", estimatedProfile, metrics).Return(messageProviders)
 			publisher.On("Publish", messageProviders[0]).Return(someError)
 
 			statsClientBuilder := mock.NewStatBuilder()
@@ -211,43 +508,375 @@ func TestProfileService(t *testing.T) {
 			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
 			statsClientBuilder.On("Build").Return(statsClient, nil)
 
-			s := NewService(profileStore, metricGenerator, publisher, messageProviderFactory, nil, statsClientBuilder)
+			s := NewService(profileStore, nil, metricGenerator, publisher, messageProviderFactory, nil, statsClientBuilder, jobQueue, nil, costEstimator, workerConfig)
 
-			result, _ := s.CreateProfile(profile)
+			claimed, err := s.poll()
 
-			_ = s.WaitAll(context.Background())
+			assert.Nil(t, err)
+			assert.True(t, claimed)
+			assert.Equal(t, endProfileState, profile)
+		})
+		t.Run("should delete metrics stored by the previous attempt before running retried job", func(t *testing.T) {
+			retriedJob := &protocol.QueuedJob{
+				ID:         1,
+				JobID:      "profile-1",
+				JobType:    job.TypeProfile,
+				Attempts:   2,
+				LeaseOwner: "replica-1",
+			}
+			profile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateInProgress,
+				Message: "profile in progress",
+				URN:     "a.b.c",
+			}
+			someError := errors.New("db error")
 
-			assert.Equal(t, endProfileState, result)
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(retriedJob, nil)
+
+			profileStore.On("Get", "profile-1").Return(profile, nil)
+			metricStore.On("DeleteByProfileID", "profile-1").Return(someError)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(mock.NewDummyStats(), nil)
+
+			s := NewService(profileStore, metricStore, nil, nil, nil, nil, statsClientBuilder, jobQueue, nil, nil, workerConfig)
+
+			claimed, err := s.poll()
+
+			assert.True(t, claimed)
+			assert.Equal(t, someError, err)
+			profileStore.AssertNotCalled(t, "Update", testifyMock.Anything)
 		})
-		t.Run("should return error when create profile failed", func(t *testing.T) {
-			someError := errors.New("network error")
+		t.Run("should run claimed job of other type with its handler", func(t *testing.T) {
+			reconciliationJob := &protocol.QueuedJob{
+				ID:         3,
+				JobID:      "reconciliation-1",
+				JobType:    job.TypeReconciliation,
+				Attempts:   1,
+				LeaseOwner: "replica-1",
+			}
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(reconciliationJob, nil)
+			jobQueue.On("Complete", reconciliationJob).Return(nil)
+
+			handler := mock.NewJobHandler()
+			defer handler.AssertExpectations(t)
+			handler.On("Run", testifyMock.Anything, reconciliationJob).Return(nil)
+
+			s := NewService(nil, nil, nil, nil, nil, nil, nil, jobQueue, nil, nil, workerConfig)
+			s.Handle(job.TypeReconciliation, handler)
+
+			claimed, err := s.poll()
+
+			assert.Nil(t, err)
+			assert.True(t, claimed)
+		})
+		t.Run("should mark job of other type failed with its handler when job lease expired after max attempts", func(t *testing.T) {
+			expiredJob := &protocol.QueuedJob{
+				ID:         3,
+				JobID:      "reconciliation-1",
+				JobType:    job.TypeReconciliation,
+				Attempts:   3,
+				LeaseOwner: "replica-2",
+			}
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			var noJob *protocol.QueuedJob
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob{expiredJob}, nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(noJob, protocol.ErrJobQueueEmpty)
+
+			handler := mock.NewJobHandler()
+			defer handler.AssertExpectations(t)
+			handler.On("Fail", expiredJob).Return(nil)
+
+			s := NewService(nil, nil, nil, nil, nil, nil, nil, jobQueue, nil, nil, workerConfig)
+			s.Handle(job.TypeReconciliation, handler)
+
+			claimed, err := s.poll()
+
+			assert.Nil(t, err)
+			assert.False(t, claimed)
+		})
+		t.Run("should not claim when queue is empty", func(t *testing.T) {
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			var noJob *protocol.QueuedJob
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(noJob, protocol.ErrJobQueueEmpty)
+
+			s := NewService(nil, nil, nil, nil, nil, nil, nil, jobQueue, nil, nil, workerConfig)
+
+			claimed, err := s.poll()
+
+			assert.Nil(t, err)
+			assert.False(t, claimed)
+		})
+		t.Run("should mark profile failed when job lease expired after max attempts", func(t *testing.T) {
+			expiredJob := &protocol.QueuedJob{
+				ID:         2,
+				JobID:      "profile-2",
+				JobType:    job.TypeProfile,
+				Attempts:   3,
+				LeaseOwner: "replica-2",
+			}
+			profile := &job.Profile{
+				ID:      "profile-2",
+				Status:  job.StateInProgress,
+				Message: "profile in progress",
+				URN:     "a.b.c",
+			}
+			failedProfile := &job.Profile{
+				ID:      "profile-2",
+				Status:  job.StateFailed,
+				Message: "profile failed because job lease expired after 3 attempts",
+				URN:     "a.b.c",
+			}
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			var noJob *protocol.QueuedJob
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob{expiredJob}, nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(noJob, protocol.ErrJobQueueEmpty)
+
+			profileStore.On("Get", "profile-2").Return(profile, nil)
+			profileStore.On("Update", failedProfile).Return(nil)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			statsClient := mock.NewDummyStats()
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			s := NewService(profileStore, nil, nil, nil, nil, nil, statsClientBuilder, jobQueue, nil, nil, workerConfig)
+
+			claimed, err := s.poll()
+
+			assert.Nil(t, err)
+			assert.False(t, claimed)
+			assert.Equal(t, failedProfile, profile)
+		})
+		t.Run("should return error when claim failed", func(t *testing.T) {
+			someError := errors.New("DB error")
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			var noJob *protocol.QueuedJob
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(noJob, someError)
+
+			s := NewService(nil, nil, nil, nil, nil, nil, nil, jobQueue, nil, nil, workerConfig)
+
+			claimed, err := s.poll()
+
+			assert.Equal(t, someError, err)
+			assert.False(t, claimed)
+		})
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		t.Run("should cancel profile and its running queries", func(t *testing.T) {
+			ID := "profile-1"
 			profile := &job.Profile{
+				ID:      ID,
+				Status:  job.StateInProgress,
+				Message: "profile in progress",
+				URN:     "a.b.c",
+			}
+			cancelledProfile := &job.Profile{
+				ID:      ID,
+				Status:  job.StateCancelled,
+				Message: "profile cancelled",
+				URN:     "a.b.c",
+			}
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			queryCanceller := mock.NewQueryCanceller()
+			defer queryCanceller.AssertExpectations(t)
+
+			runningProfile := &job.Profile{
+				ID:      ID,
+				Status:  job.StateInProgress,
+				Message: "profile in progress",
+				URN:     "a.b.c",
+			}
+
+			profileStore.On("Get", ID).Return(profile, nil)
+			jobQueue.On("Cancel", ID, job.TypeProfile).Return(nil)
+			queryCanceller.On("Cancel", runningProfile).Return(nil)
+			profileStore.On("Update", cancelledProfile).Return(nil)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			statsClient := mock.NewDummyStats()
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			s := NewService(profileStore, nil, nil, nil, nil, nil, statsClientBuilder, jobQueue, queryCanceller, nil, workerConfig)
+
+			runningCtx, cancelRunning := context.WithCancel(context.Background())
+			defer cancelRunning()
+			untrack := s.trackRunning(ID, cancelRunning)
+			defer untrack()
+
+			result, err := s.Cancel(ID)
+
+			assert.Nil(t, err)
+			assert.Equal(t, cancelledProfile, result)
+			assert.Equal(t, context.Canceled, runningCtx.Err())
+		})
+		t.Run("should keep profile state when cancel running queries failed", func(t *testing.T) {
+			ID := "profile-1"
+			profile := &job.Profile{
+				ID:      ID,
+				Status:  job.StateInProgress,
+				Message: "profile in progress",
+				URN:     "a.b.c",
+			}
+			someError := errors.New("API error")
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			queryCanceller := mock.NewQueryCanceller()
+			defer queryCanceller.AssertExpectations(t)
+
+			profileStore.On("Get", ID).Return(profile, nil)
+			jobQueue.On("Cancel", ID, job.TypeProfile).Return(nil)
+			queryCanceller.On("Cancel", profile).Return(someError)
+
+			s := NewService(profileStore, nil, nil, nil, nil, nil, nil, jobQueue, queryCanceller, nil, workerConfig)
+
+			result, err := s.Cancel(ID)
+
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, someError)
+			assert.Equal(t, job.StateInProgress, profile.Status)
+			profileStore.AssertNotCalled(t, "Update", testifyMock.Anything)
+		})
+		t.Run("should return error when profile is already finished", func(t *testing.T) {
+			ID := "profile-1"
+			profile := &job.Profile{
+				ID:      ID,
+				Status:  job.StateCompleted,
+				Message: "profile completed",
+				URN:     "a.b.c",
+			}
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+
+			jobQueue := mock.NewJobQueue()
+			defer jobQueue.AssertExpectations(t)
+
+			profileStore.On("Get", ID).Return(profile, nil)
+
+			s := NewService(profileStore, nil, nil, nil, nil, nil, nil, jobQueue, nil, nil, workerConfig)
+
+			result, err := s.Cancel(ID)
+
+			assert.Nil(t, result)
+			assert.Equal(t, protocol.ErrProfileFinished, err)
+		})
+		t.Run("should stop running profile without overriding the cancelled state", func(t *testing.T) {
+			profile := &job.Profile{
+				ID:      "profile-1",
 				Status:  job.StateCreated,
 				Message: "profile started",
 				URN:     "a.b.c",
 			}
 
+			inProgressProfile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateInProgress,
+				Message: "profile in progress",
+				URN:     "a.b.c",
+			}
+
+			estimatedProfile := &job.Profile{
+				ID:      "profile-1",
+				Status:  job.StateInProgress,
+				Message: "estimated bytes to be processed: 100",
+				URN:     "a.b.c",
+			}
+
+			var metrics []*metric.Metric
+
 			profileStore := mock.NewProfileStore()
 			defer profileStore.AssertExpectations(t)
 
 			metricGenerator := mock.NewMetricGenerator()
 			defer metricGenerator.AssertExpectations(t)
 
-			publisher := mock.NewPublisher()
-			defer publisher.AssertExpectations(t)
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
 
-			profileStore.On("Create", profile).Return(&job.Profile{}, someError)
+			profileStore.On("Update", inProgressProfile).Return(nil)
+			costEstimator.On("Estimate", inProgressProfile).Return(&protocol.CostEstimate{TotalBytes: 100}, nil)
+			profileStore.On("Update", estimatedProfile).Return(nil)
 
-			statsClientBuilder := mock.NewStatBuilder()
-			defer statsClientBuilder.AssertExpectations(t)
+			metricGenerator.On("Generate", testifyMock.Anything, estimatedProfile).Return(metrics, context.Canceled)
 
-			s := NewService(profileStore, metricGenerator, publisher, nil, nil, statsClientBuilder)
+			s := NewService(profileStore, nil, metricGenerator, nil, nil, nil, nil, nil, nil, costEstimator, workerConfig)
 
-			_, err := s.CreateProfile(profile)
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
 
-			_ = s.WaitAll(context.Background())
+			stopped := s.runProfile(protocol.NewEntryWithContext(ctx), profile, mock.NewDummyStats())
 
-			assert.Error(t, err)
+			assert.True(t, stopped)
+			assert.Equal(t, estimatedProfile, profile)
+		})
+	})
+	t.Run("WaitAll", func(t *testing.T) {
+		t.Run("should stop workers", func(t *testing.T) {
+			jobQueue := mock.NewJobQueue()
+
+			var noJob *protocol.QueuedJob
+			jobQueue.On("FailExpired", 3).Return([]*protocol.QueuedJob(nil), nil)
+			jobQueue.On("Claim", "replica-1", time.Minute, 3).Return(noJob, protocol.ErrJobQueueEmpty)
+
+			s := NewService(nil, nil, nil, nil, nil, nil, nil, jobQueue, nil, nil, workerConfig)
+			s.Start()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			err := s.WaitAll(ctx)
+
+			assert.Nil(t, err)
 		})
 	})
 	t.Run("Get", func(t *testing.T) {
@@ -265,12 +894,15 @@ func TestProfileService(t *testing.T) {
 			metricGenerator := mock.NewMetricGenerator()
 			defer metricGenerator.AssertExpectations(t)
 
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
+
 			publisher := mock.NewPublisher()
 			defer publisher.AssertExpectations(t)
 
 			profileStore.On("Get", ID).Return(profile, nil)
 
-			s := NewService(profileStore, metricGenerator, publisher, nil, nil, nil)
+			s := NewService(profileStore, nil, metricGenerator, publisher, nil, nil, nil, nil, nil, nil, nil)
 
 			result, _ := s.Get(ID)
 
@@ -287,12 +919,15 @@ func TestProfileService(t *testing.T) {
 			metricGenerator := mock.NewMetricGenerator()
 			defer metricGenerator.AssertExpectations(t)
 
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
+
 			publisher := mock.NewPublisher()
 			defer publisher.AssertExpectations(t)
 
 			profileStore.On("Get", ID).Return(profile, someError)
 
-			s := NewService(profileStore, metricGenerator, publisher, nil, nil, nil)
+			s := NewService(profileStore, nil, metricGenerator, publisher, nil, nil, nil, nil, nil, nil, nil)
 
 			result, err := s.Get(ID)
 
@@ -328,7 +963,7 @@ func TestProfileService(t *testing.T) {
 			statusStore.On("GetStatusLogByIDandType", profileID, jobType).Return(statusList, nil)
 			defer statusStore.AssertExpectations(t)
 
-			service := NewService(nil, nil, nil, nil, statusStore, nil)
+			service := NewService(nil, nil, nil, nil, nil, statusStore, nil, nil, nil, nil, nil)
 			result, err := service.GetLog(profileID)
 
 			assert.Nil(t, err)
@@ -2,6 +2,7 @@ package profile
 
 import (
 	"errors"
+	"fmt"
 	"github.com/odpf/predator/util"
 	"time"
 
@@ -16,6 +17,7 @@ type profileRecord struct {
 	GroupName      string
 	Filter         string
 	Mode           string
+	Kind           string
 	TotalRecords   int64
 	AuditTime      time.Time
 	EventTimestamp time.Time `gorm:"not null"`
@@ -28,6 +30,7 @@ func newProfileRecord(prof *job.Profile) *profileRecord {
 		GroupName:      prof.GroupName,
 		Filter:         prof.Filter,
 		Mode:           prof.Mode.String(),
+		Kind:           prof.Kind.OrDefault().String(),
 		TotalRecords:   prof.TotalRecords,
 		AuditTime:      prof.AuditTimestamp,
 		EventTimestamp: prof.EventTimestamp,
@@ -44,6 +47,7 @@ func (p *profileRecord) toProfile(status *protocol.Status) *job.Profile {
 		GroupName:        p.GroupName,
 		Filter:           p.Filter,
 		Mode:             job.Mode(p.Mode),
+		Kind:             job.Kind(p.Kind).OrDefault(),
 		TotalRecords:     p.TotalRecords,
 		AuditTimestamp:   p.AuditTime,
 		UpdatedTimestamp: status.EventTimestamp,
@@ -89,7 +93,16 @@ func (s *Store) Create(profile *job.Profile) (*job.Profile, error) {
 }
 
 //Update only insert new status of profile, all field in profile is immutable
+//cancelled profile is not updated anymore, so status written by the job that is still stopping never override the cancellation
 func (s *Store) Update(profile *job.Profile) error {
+	latestStatus, err := s.statusStore.GetLatestStatusByIDandType(profile.ID, job.TypeProfile)
+	if err != nil && err != protocol.ErrStatusNotFound {
+		return err
+	}
+	if latestStatus != nil && latestStatus.Status == job.StateCancelled.String() {
+		return protocol.ErrProfileCancelled
+	}
+
 	status := &protocol.Status{
 		JobID:   profile.ID,
 		JobType: job.TypeProfile,
@@ -138,3 +151,35 @@ func (s *Store) Get(ID string) (*job.Profile, error) {
 
 	return p.toProfile(status), nil
 }
+
+//GetLastCompleted get the latest completed standard profile of the same urn, group and filter created before the profile
+func (s *Store) GetLastCompleted(profile *job.Profile) (*job.Profile, error) {
+	completedProfiles := fmt.Sprintf("SELECT job_id FROM %s WHERE job_type = ? AND status = ?", statusTableName)
+
+	var records []*profileRecord
+	handle := s.db.Where("urn = ? AND group_name = ? AND filter = ? AND kind = ? AND id <> ? AND event_timestamp < ?",
+		profile.URN, profile.GroupName, profile.Filter, job.KindStandard.String(), profile.ID, profile.EventTimestamp).
+		Where(fmt.Sprintf("CAST(id AS TEXT) IN (%s)", completedProfiles), job.TypeProfile.String(), job.StateCompleted.String()).
+		Order("event_timestamp DESC").
+		Limit(1).
+		Find(&records)
+
+	if err := handle.Error; err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, protocol.ErrProfileNotFound
+	}
+
+	p := records[0]
+	status, err := s.statusStore.GetLatestStatusByIDandType(p.ID, job.TypeProfile)
+	if err != nil {
+		if err == protocol.ErrStatusNotFound {
+			return nil, protocol.ErrProfileInvalid
+		}
+		return nil, err
+	}
+
+	return p.toProfile(status), nil
+}
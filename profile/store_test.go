@@ -13,6 +13,7 @@ import (
 )
 
 func TestProfileStore(t *testing.T) {
+	standard := job.KindStandard.String()
 	t.Run("Create", func(t *testing.T) {
 		t.Run("should create profile", func(t *testing.T) {
 			db, clearDb := pmock.NewDatabase(new(profileRecord))
@@ -30,6 +31,7 @@ func TestProfileStore(t *testing.T) {
 				GroupName:      groupName,
 				Mode:           job.ModeComplete,
 				Filter:         "field_status = 'sample_status'",
+				Kind:           job.KindStandard,
 			}
 
 			status := &protocol.Status{
@@ -153,6 +155,7 @@ func TestProfileStore(t *testing.T) {
 			sStore := pmock.NewStatusStore()
 			defer sStore.AssertExpectations(t)
 			sStore.On("Store", status).Return(nil)
+			sStore.On("GetLatestStatusByIDandType", "1", job.TypeProfile).Return(status, nil)
 			sStore.On("Store", updatedStatis).Return(nil)
 
 			store := NewStore(db, "profile_records", sStore)
@@ -181,8 +184,10 @@ func TestProfileStore(t *testing.T) {
 				TotalRecords:   20,
 			}
 
+			var noStatus *protocol.Status
 			sStore := pmock.NewStatusStore()
 			defer sStore.AssertExpectations(t)
+			sStore.On("GetLatestStatusByIDandType", "1", job.TypeProfile).Return(noStatus, protocol.ErrStatusNotFound)
 
 			store := NewStore(db, "profile_records", sStore)
 			err := store.Update(updatedProf)
@@ -212,6 +217,7 @@ func TestProfileStore(t *testing.T) {
 
 			sStore := pmock.NewStatusStore()
 			defer sStore.AssertExpectations(t)
+			sStore.On("GetLatestStatusByIDandType", "1", job.TypeProfile).Return(status, nil)
 			sStore.On("Store", status).Return(someError)
 
 			store := NewStore(db, "profile_records", sStore)
@@ -219,6 +225,32 @@ func TestProfileStore(t *testing.T) {
 
 			assert.Error(t, err)
 		})
+		t.Run("should return error when profile is cancelled", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(profileRecord))
+			defer clearDb()
+
+			prof := &job.Profile{
+				ID:      "1",
+				Status:  job.StateInProgress,
+				Message: "in progress",
+			}
+
+			cancelledStatus := &protocol.Status{
+				JobID:   "1",
+				JobType: job.TypeProfile,
+				Status:  job.StateCancelled.String(),
+				Message: "profile cancelled",
+			}
+
+			sStore := pmock.NewStatusStore()
+			defer sStore.AssertExpectations(t)
+			sStore.On("GetLatestStatusByIDandType", "1", job.TypeProfile).Return(cancelledStatus, nil)
+
+			store := NewStore(db, "profile_records", sStore)
+			err := store.Update(prof)
+
+			assert.Equal(t, protocol.ErrProfileCancelled, err)
+		})
 	})
 	t.Run("Get", func(t *testing.T) {
 		t.Run("should return Profile", func(t *testing.T) {
@@ -230,6 +262,7 @@ func TestProfileStore(t *testing.T) {
 				ID:             ID,
 				EventTimestamp: currentTime,
 				URN:            tableURN,
+				Kind:           job.KindExploratory,
 				Status:         job.StateCreated,
 			}
 			status := &protocol.Status{
@@ -278,4 +311,71 @@ func TestProfileStore(t *testing.T) {
 			assert.Equal(t, protocol.ErrProfileNotFound, err)
 		})
 	})
+	t.Run("GetLastCompleted", func(t *testing.T) {
+		t.Run("should return latest completed standard profile of the same urn, group and filter", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(profileRecord))
+			defer clearDb()
+
+			db.Table(profileTableName).CreateTable(&profileRecord{})
+			db.Exec("CREATE TABLE status (id INTEGER PRIMARY KEY, job_id TEXT, job_type TEXT, status TEXT, message TEXT, created_at DATETIME)")
+			defer db.DropTableIfExists(profileTableName, statusTableName)
+
+			urn := "project.dataset.table"
+			groupName := "__PARTITION__"
+			now := time.Now().In(time.UTC)
+
+			profiles := []*profileRecord{
+				{ID: "profile-1", URN: urn, GroupName: groupName, Kind: standard, EventTimestamp: now.Add(-48 * time.Hour)},
+				{ID: "profile-2", URN: urn, GroupName: groupName, Kind: standard, EventTimestamp: now.Add(-24 * time.Hour)},
+				{ID: "profile-failed", URN: urn, GroupName: groupName, Kind: standard, EventTimestamp: now.Add(-12 * time.Hour)},
+				{ID: "profile-other-group", URN: urn, GroupName: "field_status", Kind: standard, EventTimestamp: now.Add(-12 * time.Hour)},
+				{ID: "profile-other-filter", URN: urn, GroupName: groupName, Filter: "field_status = 'active'", Kind: standard, EventTimestamp: now.Add(-12 * time.Hour)},
+				{ID: "profile-reconciliation", URN: urn, GroupName: groupName, Kind: job.KindReconciliation.String(), EventTimestamp: now.Add(-6 * time.Hour)},
+				{ID: "profile-current", URN: urn, GroupName: groupName, Kind: standard, EventTimestamp: now},
+			}
+			for _, p := range profiles {
+				db.Table(profileTableName).Create(p)
+				if p.ID != "profile-failed" && p.ID != "profile-current" {
+					db.Exec("INSERT INTO status (job_id, job_type, status) VALUES (?, ?, ?)", p.ID, job.TypeProfile, job.StateCompleted)
+				}
+			}
+
+			status := &protocol.Status{
+				JobID:   "profile-2",
+				JobType: job.TypeProfile,
+				Status:  job.StateCompleted.String(),
+			}
+			sStore := pmock.NewStatusStore()
+			defer sStore.AssertExpectations(t)
+			sStore.On("GetLatestStatusByIDandType", "profile-2", job.TypeProfile).Return(status, nil)
+
+			store := NewStore(db, profileTableName, sStore)
+			current := &job.Profile{
+				ID:             "profile-current",
+				URN:            urn,
+				GroupName:      groupName,
+				EventTimestamp: now,
+			}
+
+			result, err := store.GetLastCompleted(current)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "profile-2", result.ID)
+			assert.Equal(t, job.StateCompleted, result.Status)
+		})
+		t.Run("should return ErrProfileNotFound when no completed profile exist", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(profileRecord))
+			defer clearDb()
+
+			db.Table(profileTableName).CreateTable(&profileRecord{})
+			db.Exec("CREATE TABLE status (id INTEGER PRIMARY KEY, job_id TEXT, job_type TEXT, status TEXT, message TEXT, created_at DATETIME)")
+			defer db.DropTableIfExists(profileTableName, statusTableName)
+
+			store := NewStore(db, profileTableName, pmock.NewStatusStore())
+			result, err := store.GetLastCompleted(&job.Profile{ID: "profile-current", URN: "project.dataset.table", EventTimestamp: time.Now()})
+
+			assert.Equal(t, protocol.ErrProfileNotFound, err)
+			assert.Nil(t, result)
+		})
+	})
 }
@@ -23,10 +23,36 @@ type AuditReport struct {
 	Condition      string
 	Metadata       map[string]interface{}
 	ToleranceRules []ToleranceRule
+	ExpectedBand   *ExpectedBand
+	GroupOverride  string
+	//NoBaseline is true when pct_change_vs_previous rule is skipped because there is no previous profile
+	NoBaseline     bool
 	PassFlag       bool
+	Severity       Severity
 	EventTimestamp time.Time
 }
 
+//FailsAudit whether the report is failed with error severity
+func (a *AuditReport) FailsAudit() bool {
+	return !a.PassFlag && a.Severity.OrDefault() == SeverityError
+}
+
+//ExpectedBand is range of metric value expected by anomaly rule, calculated from the metric history
+type ExpectedBand struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	//BaselineSize is number of historical values used to calculate the band
+	BaselineSize int `json:"baseline_size"`
+}
+
+//MinAnomalyBaselineSize is minimum number of historical values needed to calculate the expected band
+const MinAnomalyBaselineSize = 2
+
+//IsAvailable whether the band is calculated, band is not calculated when the history is too short
+func (e *ExpectedBand) IsAvailable() bool {
+	return e.BaselineSize >= MinAnomalyBaselineSize
+}
+
 //AuditGroup is a type to do group by operation on AuditReport
 type AuditGroup []*AuditReport
 
@@ -76,8 +102,7 @@ func formPartitionInfo(partition string) string {
 func formToleranceInfo(toleranceRules []ToleranceRule) string {
 	var toleranceRulesInfo []string
 	for _, toleranceRule := range toleranceRules {
-		toleranceRuleInfo := fmt.Sprintf("%s %.2f", strings.ToUpper(string(toleranceRule.Comparator)), toleranceRule.Value)
-		toleranceRulesInfo = append(toleranceRulesInfo, toleranceRuleInfo)
+		toleranceRulesInfo = append(toleranceRulesInfo, toleranceRule.String())
 	}
 	return strings.Join(toleranceRulesInfo, ", ")
 }
@@ -88,14 +113,83 @@ func formIssueMessage(element *AuditReport) string {
 	toleranceInfo := formToleranceInfo(element.ToleranceRules)
 	metricValue := util.RoundMetricValue(element.MetricValue)
 	conditionInfo := formConditionInfo(element.MetricName, element.Condition)
+	ruleInfo := FormRuleInfo(element.MetricName, element.Metadata)
 
-	return fmt.Sprintf("%s %sIS NOT PASSED THE TOLERANCE %s%s\nTolerance: %s\nACTUAL VALUE: %s", strings.ToUpper(element.MetricName.String()), fieldInfo, partitionInfo, conditionInfo, toleranceInfo, metricValue)
+	bandInfo := formExpectedBandInfo(element.ExpectedBand)
+	if element.NoBaseline {
+		bandInfo += "\nPREVIOUS VALUE: NOT AVAILABLE"
+	}
+
+	return fmt.Sprintf("%s%s %sIS NOT PASSED THE TOLERANCE %s%s%s\nTolerance: %s%s\nACTUAL VALUE: %s", FormSeverityInfo(element.Severity), strings.ToUpper(element.MetricName.String()), fieldInfo, partitionInfo, conditionInfo, ruleInfo, toleranceInfo, bandInfo, metricValue)
+}
+
+//FormSeverityInfo prefix issue of failure that does not fail the audit with its severity
+func FormSeverityInfo(severity Severity) string {
+	if severity.OrDefault() == SeverityError {
+		return ""
+	}
+	return fmt.Sprintf("[%s] ", strings.ToUpper(string(severity)))
+}
+
+//FormRuleInfo describe rule parameters of conformity and orphan metric, values and pattern are kept as configured
+func FormRuleInfo(metricName metric.Type, metadata map[string]interface{}) string {
+	switch metricName {
+	case metric.AcceptedValuesPct:
+		if acceptedValues, ok := metric.GetAcceptedValues(metadata); ok {
+			return fmt.Sprintf("\nACCEPTED VALUES: %s", strings.Join(acceptedValues, ", "))
+		}
+	case metric.PatternMismatchPct:
+		if pattern, ok := metric.GetPattern(metadata); ok {
+			return fmt.Sprintf("\nPATTERN: %s", pattern)
+		}
+	case metric.LengthOutOfRangePct:
+		minLength, hasMin := metric.GetLength(metadata, metric.MinLength)
+		maxLength, hasMax := metric.GetLength(metadata, metric.MaxLength)
+		switch {
+		case hasMin && hasMax:
+			return fmt.Sprintf("\nLENGTH RANGE: %d - %d", minLength, maxLength)
+		case hasMin:
+			return fmt.Sprintf("\nLENGTH RANGE: AT LEAST %d", minLength)
+		case hasMax:
+			return fmt.Sprintf("\nLENGTH RANGE: AT MOST %d", maxLength)
+		}
+	case metric.OrphanPct:
+		if reference, ok := metric.GetReference(metadata); ok {
+			var joinConditions []string
+			for i, field := range reference.Fields {
+				joinConditions = append(joinConditions, fmt.Sprintf("%s = %s", field, reference.ReferenceFields[i]))
+			}
+			return fmt.Sprintf("\nREFERENCE: %s (%s)", reference.URN, strings.Join(joinConditions, ", "))
+		}
+	case metric.ReconciliationDiffPct:
+		if reconciled, ok := metric.GetReconciled(metadata); ok {
+			return fmt.Sprintf("\nRECONCILED METRIC: %s\nSOURCE: %s\nSOURCE VALUE: %s, TARGET VALUE: %s", strings.ToUpper(reconciled.Type.String()),
+				reconciled.SourceURN, util.RoundMetricValue(reconciled.SourceValue), util.RoundMetricValue(reconciled.TargetValue))
+		}
+	case metric.CustomSQL:
+		if custom, ok := metric.GetCustom(metadata); ok {
+			return fmt.Sprintf("\nCUSTOM METRIC: %s", custom.Name)
+		}
+	}
+	return ""
+}
+
+func formExpectedBandInfo(band *ExpectedBand) string {
+	if band == nil {
+		return ""
+	}
+	if !band.IsAvailable() {
+		return fmt.Sprintf("\nEXPECTED RANGE: NOT AVAILABLE (BASELINE SIZE %d)", band.BaselineSize)
+	}
+	return fmt.Sprintf("\nEXPECTED RANGE: %.2f - %.2f (BASELINE SIZE %d)", band.Lower, band.Upper, band.BaselineSize)
 }
 
 func formConditionInfo(metricName metric.Type, condition string) string {
 	var conditionInfo string
 	if metricName == metric.InvalidPct {
 		conditionInfo = fmt.Sprintf("\nCONDITION: %s", strings.ToUpper(condition))
+	} else if metricName == metric.SchemaDrift && condition != "" {
+		conditionInfo = fmt.Sprintf("\nCHANGE: %s", strings.ToUpper(condition))
 	}
 	return conditionInfo
 }
@@ -116,13 +210,36 @@ func FormIssueSummary(auditResults []*AuditReport) string {
 type ValidatedMetric struct {
 	Metric         *metric.Metric
 	ToleranceRules []ToleranceRule
+	ExpectedBand   *ExpectedBand
+	GroupOverride  string
+	NoBaseline     bool
 	PassFlag       bool
+	Severity       Severity
 }
 
 //AuditService is service of auditor
 type AuditService interface {
 	//RunAudit start audit service
 	RunAudit(profileID string) (*AuditResult, error)
+	//GetAudit get audit and its reports
+	GetAudit(ID string) (*AuditResult, error)
+	//GetAudits get audits matching the filter with their reports and profile
+	GetAudits(filter *AuditFilter) ([]*AuditResult, error)
+}
+
+//AuditFilter is filter of audit history, zero value fields are not filtered
+type AuditFilter struct {
+	URN       string
+	ProfileID string
+	//From and To are range of audit event timestamp, From is inclusive and To is exclusive
+	From time.Time
+	To   time.Time
+	//Pass filter completed audits without failed report of error severity when true and audits with such report when false
+	Pass *bool
+	//FieldID filter audits that have report of the field, reports of other fields are excluded
+	FieldID string
+	Limit   int
+	Offset  int
 }
 
 //Auditor to compare quality result with tolerances
@@ -134,23 +251,35 @@ type Auditor interface {
 type AuditResult struct {
 	Audit        *job.Audit
 	AuditReports []*AuditReport
+	//Profile is the audited profile, only set on audits listed by GetAudits
+	Profile *job.Profile
 }
 
 //AuditSummary is summary of audit
 type AuditSummary struct {
 	IsPass  bool
 	Message string
+	//Severity is the highest severity of failed reports, empty when no report failed
+	Severity Severity
 }
 
 //AuditStore is store for audit entity
 type AuditStore interface {
 	CreateAudit(audit *job.Audit) (*job.Audit, error)
 	UpdateAudit(audit *job.Audit) error
+	//GetAudit get audit with its latest state, return ErrAuditNotFound when audit is not found
+	GetAudit(ID string) (*job.Audit, error)
+	//GetAudits get audits matching the filter with their profile ordered by the latest event timestamp, reports are not set
+	GetAudits(filter *AuditFilter) ([]*AuditResult, error)
 }
 
 //AuditResultStore to store the auditing result
 type AuditResultStore interface {
 	StoreResults(results []*AuditReport) error
+	//GetResultsByAuditID get reports of the audit, reports of other fields are excluded when fieldID is not empty
+	GetResultsByAuditID(auditID string, fieldID string) ([]*AuditReport, error)
+	//GetResultsByAuditIDs get reports of the audits ordered by audit, reports of other fields are excluded when fieldID is not empty
+	GetResultsByAuditIDs(auditIDs []string, fieldID string) ([]*AuditReport, error)
 }
 
 //AuditPublisher for publisher for audit
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/odpf/predator/protocol/metric"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -143,5 +144,216 @@ func TestIssueSummary(t *testing.T) {
 
 			assert.Equal(t, expected, issueSum)
 		})
+
+		t.Run("should return schema drift issue summary with the change", func(t *testing.T) {
+			auditRes := []*AuditReport{
+				{
+					AuditID:     "audit-abcd",
+					TableURN:    "project.dataset.table",
+					FieldID:     "tags",
+					MetricName:  metric.SchemaDrift,
+					MetricValue: 1.0,
+					Condition:   "mode changed from NULLABLE to REPEATED",
+					ToleranceRules: []ToleranceRule{
+						{
+							Comparator: ComparatorLessThanEq,
+							Value:      0.0,
+						},
+					},
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "SCHEMA_DRIFT OF TAGS IS NOT PASSED THE TOLERANCE \nCHANGE: MODE CHANGED FROM NULLABLE TO REPEATED\nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 1.000"
+
+			assert.Equal(t, expected, issueSum)
+		})
+
+		t.Run("should return length out of range issue summary with length range", func(t *testing.T) {
+			auditRes := []*AuditReport{
+				{
+					AuditID:     "audit-abcd",
+					TableURN:    "project.dataset.table",
+					FieldID:     "code",
+					Partition:   "2019-01-02",
+					MetricName:  metric.LengthOutOfRangePct,
+					MetricValue: 1.0,
+					Metadata: map[string]interface{}{
+						metric.MinLength: 2,
+						metric.MaxLength: 8,
+					},
+					ToleranceRules: []ToleranceRule{
+						{
+							Comparator: ComparatorLessThanEq,
+							Value:      0.0,
+						},
+					},
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "LENGTH_OUT_OF_RANGE_PCT OF CODE IS NOT PASSED THE TOLERANCE IN PARTITION 2019-01-02\nLENGTH RANGE: 2 - 8\nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 1.000"
+
+			assert.Equal(t, expected, issueSum)
+		})
+		t.Run("should return orphan issue summary with reference", func(t *testing.T) {
+			auditRes := []*AuditReport{
+				{
+					AuditID:     "audit-abcd",
+					TableURN:    "project.dataset.orders",
+					Partition:   "2019-01-02",
+					MetricName:  metric.OrphanPct,
+					MetricValue: 2.5,
+					Metadata: map[string]interface{}{
+						metric.ReferenceURN:    "project.dataset.customers",
+						metric.JoinFields:      []interface{}{"customer_id", "region"},
+						metric.ReferenceFields: []interface{}{"id", "region"},
+					},
+					ToleranceRules: []ToleranceRule{
+						{
+							Comparator: ComparatorLessThanEq,
+							Value:      0.0,
+						},
+					},
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "ORPHAN_PCT IS NOT PASSED THE TOLERANCE IN PARTITION 2019-01-02\nREFERENCE: project.dataset.customers (customer_id = id, region = region)\nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 2.500"
+
+			assert.Equal(t, expected, issueSum)
+		})
+		t.Run("should return reconciliation issue summary with source and target value", func(t *testing.T) {
+			auditRes := []*AuditReport{
+				{
+					AuditID:     "audit-abcd",
+					TableURN:    "project-b.dataset.orders",
+					FieldID:     "amount",
+					MetricName:  metric.ReconciliationDiffPct,
+					MetricValue: 10,
+					Metadata: map[string]interface{}{
+						metric.ReconciledMetric: "sum",
+						metric.SourceURN:        "project-a.dataset.orders",
+						metric.SourceValue:      100.0,
+						metric.TargetValue:      90.0,
+					},
+					ToleranceRules: []ToleranceRule{
+						{
+							Comparator: ComparatorLessThanEq,
+							Value:      0.0,
+						},
+					},
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "RECONCILIATION_DIFF_PCT OF AMOUNT IS NOT PASSED THE TOLERANCE \nRECONCILED METRIC: SUM\nSOURCE: project-a.dataset.orders\nSOURCE VALUE: 100.000, TARGET VALUE: 90.000\nTolerance: LESS_THAN_EQ 0.00\nACTUAL VALUE: 10.000"
+
+			assert.Equal(t, expected, issueSum)
+		})
+		t.Run("should return issue summary prefixed with severity below error", func(t *testing.T) {
+			auditRes := []*AuditReport{
+				{
+					AuditID:        "audit-abcd",
+					TableURN:       "project.dataset.orders",
+					MetricName:     metric.RowCount,
+					MetricValue:    0,
+					ToleranceRules: []ToleranceRule{{Comparator: ComparatorMoreThan, Value: 0}},
+					PassFlag:       false,
+					Severity:       SeverityWarn,
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "[WARN] ROW_COUNT IS NOT PASSED THE TOLERANCE \nTolerance: MORE_THAN 0.00\nACTUAL VALUE: 0.000"
+
+			assert.Equal(t, expected, issueSum)
+			assert.False(t, auditRes[0].FailsAudit())
+		})
+		t.Run("should return issue summary with range and any of rules", func(t *testing.T) {
+			auditRes := []*AuditReport{
+				{
+					AuditID:     "audit-abcd",
+					TableURN:    "project.dataset.orders",
+					MetricName:  metric.RowCount,
+					MetricValue: 50,
+					ToleranceRules: []ToleranceRule{
+						{Comparator: ComparatorBetween, Value: 100, Upper: 1000},
+						{
+							Comparator: ComparatorAnyOf,
+							Groups: [][]ToleranceRule{
+								{{Comparator: ComparatorEqual, Value: 0}},
+								{{Comparator: ComparatorPctChangeVsPrevious, Value: 20}, {Comparator: ComparatorOutside, Value: 10, Upper: 20}},
+							},
+						},
+					},
+					PassFlag: false,
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "ROW_COUNT IS NOT PASSED THE TOLERANCE \nTolerance: BETWEEN 100.00 AND 1000.00, ANY_OF (EQUAL 0.00 | PCT_CHANGE_VS_PREVIOUS 20.00, OUTSIDE 10.00 AND 20.00)\nACTUAL VALUE: 50.000"
+
+			assert.Equal(t, expected, issueSum)
+		})
+		t.Run("should return custom sql issue summary with metric name", func(t *testing.T) {
+			auditRes := []*AuditReport{
+				{
+					AuditID:     "audit-abcd",
+					TableURN:    "project.dataset.orders",
+					Partition:   "2019-01-02",
+					MetricName:  metric.CustomSQL,
+					MetricValue: 12,
+					Metadata: map[string]interface{}{
+						metric.CustomName:       "late_orders",
+						metric.CustomExpression: "COUNTIF(delivered_at > promised_at)",
+					},
+					ToleranceRules: []ToleranceRule{
+						{
+							Comparator: ComparatorLessThanEq,
+							Value:      10.0,
+						},
+					},
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "CUSTOM_SQL IS NOT PASSED THE TOLERANCE IN PARTITION 2019-01-02\nCUSTOM METRIC: late_orders\nTolerance: LESS_THAN_EQ 10.00\nACTUAL VALUE: 12.000"
+
+			assert.Equal(t, expected, issueSum)
+		})
+		t.Run("should return anomaly issue summary with expected range", func(t *testing.T) {
+			auditRes := []*AuditReport{
+				{
+					AuditID:     "audit-abcd",
+					TableURN:    "project.dataset.table",
+					Partition:   "2019-01-02",
+					MetricName:  "row_count",
+					MetricValue: 10.0,
+					ExpectedBand: &ExpectedBand{
+						Lower:        900.0,
+						Upper:        1100.0,
+						BaselineSize: 28,
+					},
+					PassFlag:       false,
+					EventTimestamp: time.Now().In(time.UTC),
+				},
+			}
+
+			issueSum := FormIssueSummary(auditRes)
+			expected := "ROW_COUNT IS NOT PASSED THE TOLERANCE IN PARTITION 2019-01-02\nTolerance: \nEXPECTED RANGE: 900.00 - 1100.00 (BASELINE SIZE 28)\nACTUAL VALUE: 10.000"
+
+			assert.Equal(t, expected, issueSum)
+		})
 	})
 }
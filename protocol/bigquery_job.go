@@ -10,10 +10,14 @@ type BigqueryJob struct {
 	//BqID is bigquery job ID provided by query execution
 	BqID string
 
+	//Location is bigquery location where the job runs, required to find the job again
+	Location string
+
 	CreatedAt time.Time
 }
 
 //BigqueryJobStore to store Bigquery job created by profile job
 type BigqueryJobStore interface {
 	Store(bigqueryJob *BigqueryJob) error
+	GetByProfileID(profileID string) ([]*BigqueryJob, error)
 }
@@ -0,0 +1,52 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/odpf/predator/protocol/job"
+)
+
+var (
+	//ErrBytesLimitExceeded when estimated bytes processed by a profile exceed the configured max bytes billed
+	ErrBytesLimitExceeded = errors.New("estimated bytes processed exceed max bytes billed")
+)
+
+//QueryCost is estimated bytes processed by a query
+type QueryCost struct {
+	Query          *job.Query
+	EstimatedBytes int64
+}
+
+//CostEstimate is estimated bytes processed by all queries of a profile
+type CostEstimate struct {
+	Queries    []*QueryCost
+	TotalBytes int64
+	//MaxBytesBilled is max bytes billed configured for the profiled table, zero means unlimited
+	MaxBytesBilled int64
+}
+
+//IsExceeded true when total estimated bytes is more than max bytes billed
+func (c *CostEstimate) IsExceeded() bool {
+	return c.MaxBytesBilled > 0 && c.TotalBytes > c.MaxBytesBilled
+}
+
+//Limit apply max bytes billed to every query run with the entry, ErrBytesLimitExceeded is returned when the estimate exceed the limit
+func (c *CostEstimate) Limit(entry Entry) (Entry, error) {
+	if c.IsExceeded() {
+		return entry, fmt.Errorf("%w, estimated %d bytes while the limit is %d bytes", ErrBytesLimitExceeded, c.TotalBytes, c.MaxBytesBilled)
+	}
+	return entry.WithMaxBytesBilled(c.MaxBytesBilled), nil
+}
+
+//CostEstimator estimate bytes processed by a profile without running the queries
+type CostEstimator interface {
+	Estimate(entry Entry, profile *job.Profile) (*CostEstimate, error)
+	//EstimateQueries estimate bytes processed by queries planned for the profile by other than the metric generator
+	EstimateQueries(entry Entry, profile *job.Profile, plannedQueries []*job.Query) (*CostEstimate, error)
+}
+
+//BytesLimitResolver resolve max bytes billed of a profiled table, zero means unlimited
+type BytesLimitResolver interface {
+	Resolve(urn string) (int64, error)
+}
@@ -0,0 +1,164 @@
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//maxCronLookahead is how far Next search the next activation time before giving up
+const maxCronLookahead = 5 * 366 * 24 * time.Hour
+
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+//Cron is parsed standard five fields cron expression, minute hour day-of-month month day-of-week
+type Cron struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+	//restricted day fields are matched with OR as in standard cron when both of them are restricted
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+//ParseCron parse five fields cron expression, each field support *, value, range, list and step
+func ParseCron(expression string) (*Cron, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression %q, expected %d fields but got %d", expression, len(cronFields), len(fields))
+	}
+
+	var bits [5]uint64
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q, %w", expression, err)
+		}
+		bits[i] = b
+	}
+
+	//sunday can be written as 0 or 7
+	dayOfWeek := bits[4]
+	if dayOfWeek&(1<<7) != 0 {
+		dayOfWeek |= 1
+	}
+
+	return &Cron{
+		minute:               bits[0],
+		hour:                 bits[1],
+		dayOfMonth:           bits[2],
+		month:                bits[3],
+		dayOfWeek:            dayOfWeek,
+		dayOfMonthRestricted: !strings.HasPrefix(fields[2], "*"),
+		dayOfWeekRestricted:  !strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(expression string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expression, ",") {
+		rangeExpression, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpression = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q of %s field", part[i+1:], field.name)
+			}
+		}
+
+		start, end := field.min, field.max
+		switch {
+		case rangeExpression == "*":
+		case strings.Contains(rangeExpression, "-"):
+			bounds := strings.SplitN(rangeExpression, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range %q of %s field", rangeExpression, field.name)
+			}
+		default:
+			var err error
+			if start, err = parseCronValue(rangeExpression, field); err != nil {
+				return 0, err
+			}
+			//single value without step only match the value itself
+			if !strings.Contains(part, "/") {
+				end = start
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < field.min || v > field.max {
+		return 0, fmt.Errorf("invalid value %q of %s field, should be between %d and %d", value, field.name, field.min, field.max)
+	}
+	return v, nil
+}
+
+//Next return the first activation time after t in UTC, zero time when there is no activation in the next five years
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.In(time.UTC).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronLookahead)
+
+	for !t.After(limit) {
+		if !matchBit(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !matchBit(c.hour, t.Hour()) {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !matchBit(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *Cron) matchDay(t time.Time) bool {
+	dayOfMonth := matchBit(c.dayOfMonth, t.Day())
+	dayOfWeek := matchBit(c.dayOfWeek, int(t.Weekday()))
+	if c.dayOfMonthRestricted && c.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+func matchBit(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
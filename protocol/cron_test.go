@@ -0,0 +1,92 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCron(t *testing.T) {
+	t.Run("ParseCron", func(t *testing.T) {
+		t.Run("should return error when expression is invalid", func(t *testing.T) {
+			expressions := []string{
+				"* * * *",
+				"60 * * * *",
+				"* 24 * * *",
+				"* * 0 * *",
+				"* * * 13 *",
+				"* * * * 8",
+				"*/0 * * * *",
+				"5-1 * * * *",
+				"a * * * *",
+			}
+			for _, expression := range expressions {
+				_, err := ParseCron(expression)
+				assert.Error(t, err, expression)
+			}
+		})
+	})
+	t.Run("Next", func(t *testing.T) {
+		from := time.Date(2021, 1, 1, 10, 30, 15, 0, time.UTC) //friday
+		suites := []struct {
+			Description string
+			Expression  string
+			Expected    time.Time
+		}{
+			{
+				Description: "should return next minute for every minute expression",
+				Expression:  "* * * * *",
+				Expected:    time.Date(2021, 1, 1, 10, 31, 0, 0, time.UTC),
+			},
+			{
+				Description: "should return next day when the time already passed today",
+				Expression:  "0 2 * * *",
+				Expected:    time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC),
+			},
+			{
+				Description: "should return next step",
+				Expression:  "*/15 * * * *",
+				Expected:    time.Date(2021, 1, 1, 10, 45, 0, 0, time.UTC),
+			},
+			{
+				Description: "should return next value of list and range",
+				Expression:  "0 9-11,20 * * *",
+				Expected:    time.Date(2021, 1, 1, 11, 0, 0, 0, time.UTC),
+			},
+			{
+				Description: "should return next day of week with sunday as 7",
+				Expression:  "0 0 * * 7",
+				Expected:    time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				Description: "should match either day of month or day of week when both are restricted",
+				Expression:  "0 0 15 * 1",
+				Expected:    time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				Description: "should return next month",
+				Expression:  "0 0 1 3 *",
+				Expected:    time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				Description: "should skip month without the day",
+				Expression:  "0 0 31 * *",
+				Expected:    time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				Description: "should return zero time when there is no activation",
+				Expression:  "0 0 30 2 *",
+				Expected:    time.Time{},
+			},
+		}
+		for _, test := range suites {
+			t.Run(test.Description, func(t *testing.T) {
+				cron, err := ParseCron(test.Expression)
+
+				assert.Nil(t, err)
+				assert.Equal(t, test.Expected, cron.Next(from))
+			})
+		}
+	})
+}
@@ -12,8 +12,10 @@ type Entity struct {
 	Environment   string
 	GitURL        string
 	GcpProjectIDs []string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	//MaxBytesBilled is limit of bytes processed by a profile of the entity tables, zero means the default limit is used
+	MaxBytesBilled int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 var ErrEntityNotFound = errors.New("entity not found")
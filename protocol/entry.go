@@ -15,6 +15,8 @@ const (
 	jobTypeKey   entryKey = "job_type"
 	statusKey    entryKey = "status"
 	groupKey     entryKey = "group"
+
+	maxBytesBilledKey entryKey = "max_bytes_billed"
 )
 
 //Entry as an entry struct for logging
@@ -29,6 +31,18 @@ func NewEntry() Entry {
 	}
 }
 
+//NewEntryWithContext to construct Entry of a job that is cancelled along with the context
+func NewEntryWithContext(ctx context.Context) Entry {
+	return Entry{
+		ctx: ctx,
+	}
+}
+
+//Context to get context of the entry, it is done when the job is cancelled
+func (e Entry) Context() context.Context {
+	return e.ctx
+}
+
 //WithJobID to set Profile or AuditReport Partition
 func (e Entry) WithJobID(jobID string) Entry {
 	return Entry{
@@ -71,6 +85,13 @@ func (e Entry) WithGroup(group string) Entry {
 	}
 }
 
+//WithMaxBytesBilled to set max bytes billed of each query run by the job
+func (e Entry) WithMaxBytesBilled(maxBytesBilled int64) Entry {
+	return Entry{
+		ctx: context.WithValue(e.ctx, maxBytesBilledKey, maxBytesBilled),
+	}
+}
+
 //Status to get status
 func (e Entry) Status() string {
 	v := e.ctx.Value(statusKey)
@@ -160,3 +181,18 @@ func (e Entry) Group() string {
 
 	return out
 }
+
+//MaxBytesBilled to get max bytes billed, zero means unlimited
+func (e Entry) MaxBytesBilled() int64 {
+	v := e.ctx.Value(maxBytesBilledKey)
+	if v == nil {
+		return 0
+	}
+
+	out, ok := v.(int64)
+	if !ok {
+		return 0
+	}
+
+	return out
+}
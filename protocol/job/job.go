@@ -131,6 +131,30 @@ var (
 	ModeComplete Mode = "complete"
 )
 
+//Kind is purpose of the profile
+type Kind string
+
+func (k Kind) String() string {
+	return string(k)
+}
+
+//OrDefault return standard kind when kind is not set
+func (k Kind) OrDefault() Kind {
+	if k == "" {
+		return KindStandard
+	}
+	return k
+}
+
+var (
+	//KindStandard is profile of the table data quality, metrics of standard profiles are the history of the table
+	KindStandard Kind = "standard"
+	//KindReconciliation is profile run by reconciliation of a table with its source table
+	KindReconciliation Kind = "reconciliation"
+	//KindExploratory is profile run to suggest tolerance spec
+	KindExploratory Kind = "exploratory"
+)
+
 //Profile is profile task
 type Profile struct {
 	ID string
@@ -150,6 +174,9 @@ type Profile struct {
 	Mode      Mode
 	URN       string
 
+	//Kind is purpose of the profile, only metrics of standard profile are used as history of the table
+	Kind Kind
+
 	//TotalRecords is number of row profiled stat
 	TotalRecords int64
 
@@ -197,6 +224,30 @@ type Audit struct {
 	EventTimestamp time.Time
 }
 
+//Reconciliation is an entity of one reconciliation task, source and target table are profiled with identical metric specs
+//and the relative difference of each metric is audited
+type Reconciliation struct {
+	ID        string
+	SourceURN string
+	TargetURN string
+	GroupName string
+	Filter    string
+	//SumFields is numeric fields which sum are compared
+	SumFields []string
+	//KeyFields is fields which distinct combination count are compared, distinct key is not compared when it is empty
+	KeyFields []string
+	//MaxDiffPct is maximum relative difference percentage of a metric that still passed
+	MaxDiffPct float64
+
+	SourceProfileID string
+	TargetProfileID string
+	AuditID         string
+
+	State          State
+	Message        string
+	EventTimestamp time.Time
+}
+
 //State is state of a Job
 type State string
 
@@ -213,8 +264,15 @@ var (
 	StateCompleted State = "completed"
 	//StateFailed is
 	StateFailed State = "failed"
+	//StateCancelled is state of job stopped by user request
+	StateCancelled State = "cancelled"
 )
 
+//IsFinished whether job with the state will not progress anymore
+func (s State) IsFinished() bool {
+	return s == StateCompleted || s == StateFailed || s == StateCancelled
+}
+
 //Type is type of job
 type Type string
 
@@ -223,6 +281,8 @@ var (
 	TypeProfile Type = "profile"
 	//TypeAudit for audit
 	TypeAudit Type = "audit"
+	//TypeReconciliation for reconciliation of source and target table
+	TypeReconciliation Type = "reconciliation"
 	//TypeUnknown when unable to get correct job type
 	TypeUnknown Type = "unknown"
 )
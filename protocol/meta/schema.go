@@ -0,0 +1,138 @@
+package meta
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//ColumnSchema is flattened column of a table schema, nested column name is fully qualified by its parent
+type ColumnSchema struct {
+	Name string    `json:"name"`
+	Type FieldType `json:"type"`
+	Mode Mode      `json:"mode"`
+}
+
+//SchemaOf flatten fields of table spec into list of column schema
+func SchemaOf(t *TableSpec) []*ColumnSchema {
+	var columns []*ColumnSchema
+	for _, field := range t.FieldsFlatten() {
+		columns = append(columns, &ColumnSchema{
+			Name: field.ID(),
+			Type: field.FieldType,
+			Mode: field.Mode,
+		})
+	}
+	return columns
+}
+
+//FieldsOf build field specs from flattened columns, nested column should be listed after its parent
+//column without mode is nullable
+func FieldsOf(columns []*ColumnSchema) ([]*FieldSpec, error) {
+	var fields []*FieldSpec
+	fieldsByID := make(map[string]*FieldSpec)
+	for _, column := range columns {
+		field := &FieldSpec{
+			Name:      column.Name,
+			FieldType: column.Type,
+			Mode:      column.Mode,
+			Level:     RootLevel,
+		}
+		if field.Mode == "" {
+			field.Mode = ModeNullable
+		}
+
+		if i := strings.LastIndex(column.Name, "."); i >= 0 {
+			parent, ok := fieldsByID[column.Name[:i]]
+			if !ok {
+				return nil, fmt.Errorf("parent of column %s should be listed before the column", column.Name)
+			}
+			field.Name = column.Name[i+1:]
+			field.Parent = parent
+			field.Level = parent.Level + 1
+			parent.Fields = append(parent.Fields, field)
+		} else {
+			fields = append(fields, field)
+		}
+		fieldsByID[column.Name] = field
+	}
+	return fields, nil
+}
+
+//SchemaChangeKind kind of schema change
+type SchemaChangeKind string
+
+const (
+	//ColumnAdded column exist only on current schema
+	ColumnAdded SchemaChangeKind = "added"
+	//ColumnRemoved column exist only on previous schema
+	ColumnRemoved SchemaChangeKind = "removed"
+	//ColumnTypeChanged column type is changed
+	ColumnTypeChanged SchemaChangeKind = "type_changed"
+	//ColumnModeChanged column mode is changed, such as NULLABLE to REPEATED
+	ColumnModeChanged SchemaChangeKind = "mode_changed"
+)
+
+//SchemaChange is change of a column between two schemas
+type SchemaChange struct {
+	Column   string
+	Kind     SchemaChangeKind
+	Previous *ColumnSchema
+	Current  *ColumnSchema
+}
+
+//Description is human readable description of the change
+func (s *SchemaChange) Description() string {
+	switch s.Kind {
+	case ColumnAdded:
+		return fmt.Sprintf("column added with type %s and mode %s", s.Current.Type, s.Current.Mode)
+	case ColumnRemoved:
+		return fmt.Sprintf("column removed, previous type %s and mode %s", s.Previous.Type, s.Previous.Mode)
+	case ColumnTypeChanged:
+		return fmt.Sprintf("type changed from %s to %s", s.Previous.Type, s.Current.Type)
+	case ColumnModeChanged:
+		return fmt.Sprintf("mode changed from %s to %s", s.Previous.Mode, s.Current.Mode)
+	}
+	return string(s.Kind)
+}
+
+//DiffSchema compare current schema against previous schema, changes are sorted by column name
+//a column with both type and mode changed produce one change for each
+func DiffSchema(previous []*ColumnSchema, current []*ColumnSchema) []*SchemaChange {
+	previousColumns := make(map[string]*ColumnSchema)
+	for _, column := range previous {
+		previousColumns[column.Name] = column
+	}
+	currentColumns := make(map[string]*ColumnSchema)
+	for _, column := range current {
+		currentColumns[column.Name] = column
+	}
+
+	var changes []*SchemaChange
+	for name, currentColumn := range currentColumns {
+		previousColumn, ok := previousColumns[name]
+		if !ok {
+			changes = append(changes, &SchemaChange{Column: name, Kind: ColumnAdded, Current: currentColumn})
+			continue
+		}
+		if previousColumn.Type != currentColumn.Type {
+			changes = append(changes, &SchemaChange{Column: name, Kind: ColumnTypeChanged, Previous: previousColumn, Current: currentColumn})
+		}
+		if previousColumn.Mode != currentColumn.Mode {
+			changes = append(changes, &SchemaChange{Column: name, Kind: ColumnModeChanged, Previous: previousColumn, Current: currentColumn})
+		}
+	}
+	for name, previousColumn := range previousColumns {
+		if _, ok := currentColumns[name]; !ok {
+			changes = append(changes, &SchemaChange{Column: name, Kind: ColumnRemoved, Previous: previousColumn})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Column == changes[j].Column {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Column < changes[j].Column
+	})
+	return changes
+}
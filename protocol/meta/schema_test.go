@@ -0,0 +1,89 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchema(t *testing.T) {
+	t.Run("SchemaOf", func(t *testing.T) {
+		t.Run("should return flattened columns with fully qualified name", func(t *testing.T) {
+			parent := &FieldSpec{Name: "address", FieldType: FieldTypeRecord, Mode: ModeNullable, Level: RootLevel}
+			parent.Fields = []*FieldSpec{
+				{Name: "city", FieldType: FieldTypeString, Mode: ModeNullable, Level: 2, Parent: parent},
+			}
+			tableSpec := &TableSpec{
+				Fields: []*FieldSpec{
+					{Name: "id", FieldType: FieldTypeInteger, Mode: ModeRequired, Level: RootLevel},
+					parent,
+				},
+			}
+
+			expected := []*ColumnSchema{
+				{Name: "id", Type: FieldTypeInteger, Mode: ModeRequired},
+				{Name: "address", Type: FieldTypeRecord, Mode: ModeNullable},
+				{Name: "address.city", Type: FieldTypeString, Mode: ModeNullable},
+			}
+
+			assert.Equal(t, expected, SchemaOf(tableSpec))
+		})
+	})
+	t.Run("FieldsOf", func(t *testing.T) {
+		t.Run("should return fields with nested fields of flattened columns", func(t *testing.T) {
+			columns := []*ColumnSchema{
+				{Name: "id", Type: FieldTypeInteger, Mode: ModeRequired},
+				{Name: "address", Type: FieldTypeRecord},
+				{Name: "address.city", Type: FieldTypeString, Mode: ModeNullable},
+			}
+
+			fields, err := FieldsOf(columns)
+
+			assert.Nil(t, err)
+			assert.Equal(t, columns[0].Name, fields[0].ID())
+			assert.Equal(t, ModeNullable, fields[1].Mode)
+			assert.Equal(t, "address.city", fields[1].Fields[0].ID())
+			assert.Equal(t, 2, fields[1].Fields[0].Level)
+			assert.Equal(t, []*ColumnSchema{columns[0], {Name: "address", Type: FieldTypeRecord, Mode: ModeNullable}, columns[2]}, SchemaOf(&TableSpec{Fields: fields}))
+		})
+		t.Run("should return error when parent column is not listed before nested column", func(t *testing.T) {
+			_, err := FieldsOf([]*ColumnSchema{{Name: "address.city", Type: FieldTypeString}})
+
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("DiffSchema", func(t *testing.T) {
+		t.Run("should return added, removed, type and mode changes sorted by column", func(t *testing.T) {
+			previous := []*ColumnSchema{
+				{Name: "id", Type: FieldTypeInteger, Mode: ModeRequired},
+				{Name: "name", Type: FieldTypeString, Mode: ModeNullable},
+				{Name: "tags", Type: FieldTypeString, Mode: ModeNullable},
+				{Name: "amount", Type: FieldTypeInteger, Mode: ModeNullable},
+			}
+			current := []*ColumnSchema{
+				{Name: "id", Type: FieldTypeInteger, Mode: ModeRequired},
+				{Name: "tags", Type: FieldTypeString, Mode: ModeRepeated},
+				{Name: "amount", Type: FieldTypeFloat, Mode: ModeRequired},
+				{Name: "email", Type: FieldTypeString, Mode: ModeNullable},
+			}
+
+			changes := DiffSchema(previous, current)
+
+			expected := []*SchemaChange{
+				{Column: "amount", Kind: ColumnModeChanged, Previous: previous[3], Current: current[2]},
+				{Column: "amount", Kind: ColumnTypeChanged, Previous: previous[3], Current: current[2]},
+				{Column: "email", Kind: ColumnAdded, Current: current[3]},
+				{Column: "name", Kind: ColumnRemoved, Previous: previous[1]},
+				{Column: "tags", Kind: ColumnModeChanged, Previous: previous[2], Current: current[1]},
+			}
+			assert.Equal(t, expected, changes)
+			assert.Equal(t, "mode changed from NULLABLE to REPEATED", changes[4].Description())
+			assert.Equal(t, "type changed from INTEGER to FLOAT", changes[1].Description())
+		})
+		t.Run("should return no change when schemas are equal", func(t *testing.T) {
+			schema := []*ColumnSchema{{Name: "id", Type: FieldTypeInteger, Mode: ModeRequired}}
+
+			assert.Empty(t, DiffSchema(schema, schema))
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 )
 
 // Mode type represent Mode of a Field
@@ -151,6 +152,8 @@ var IngestionTimeField = &FieldSpec{
 
 //TableSpec struct to represent Table information detail
 type TableSpec struct {
+	//Warehouse where the table stored, empty value is bigquery
+	Warehouse   Warehouse
 	ProjectName string
 	DatasetName string
 	TableName   string
@@ -165,6 +168,10 @@ type TableSpec struct {
 	Labels map[string]string
 	Fields []*FieldSpec
 
+	//LastModifiedTime is the last time the table is modified
+	//zero value when the warehouse does not provide it
+	LastModifiedTime time.Time
+
 	//dictionary with fieldID as key and FieldSpec as value
 	//to be able to effieciently search FieldSpec information
 	m         sync.Mutex
@@ -176,9 +183,9 @@ func (t *TableSpec) IsPartitioned() bool {
 	return t.PartitionField != ""
 }
 
-//TableID is fully qualified table name
+//TableID is fully qualified table name, prefixed with the warehouse scheme for table outside bigquery
 func (t *TableSpec) TableID() string {
-	return fmt.Sprintf("%s.%s.%s", t.ProjectName, t.DatasetName, t.TableName)
+	return WithWarehouseScheme(t.Warehouse, fmt.Sprintf("%s.%s.%s", t.ProjectName, t.DatasetName, t.TableName))
 }
 
 //FieldsFlatten return fields flattened in array
@@ -0,0 +1,57 @@
+package meta
+
+import (
+	"fmt"
+	"strings"
+)
+
+//Warehouse is data warehouse where a table is stored
+type Warehouse string
+
+func (w Warehouse) String() string {
+	return string(w)
+}
+
+const (
+	//WarehouseBigQuery google bigquery, URN format is ${project}.${dataset}.${table}
+	WarehouseBigQuery Warehouse = "bigquery"
+	//WarehousePostgres postgresql, URN format is postgres:${database}.${schema}.${table}
+	WarehousePostgres Warehouse = "postgres"
+)
+
+const urnSchemeSeparator = ":"
+
+//ParseWarehouse get warehouse of a table from the scheme of the URN, URN without scheme is a bigquery table
+func ParseWarehouse(urn string) Warehouse {
+	idx := strings.Index(urn, urnSchemeSeparator)
+	if idx < 0 {
+		return WarehouseBigQuery
+	}
+	return Warehouse(urn[:idx])
+}
+
+//TrimWarehouseScheme remove warehouse scheme from the URN
+func TrimWarehouseScheme(urn string) string {
+	idx := strings.Index(urn, urnSchemeSeparator)
+	if idx < 0 {
+		return urn
+	}
+	return urn[idx+len(urnSchemeSeparator):]
+}
+
+//ParsePostgresTableID get database, schema and table name of postgres URN with format postgres:${database}.${schema}.${table}
+func ParsePostgresTableID(urn string) (database string, schema string, table string, err error) {
+	segments := strings.Split(TrimWarehouseScheme(urn), ".")
+	if ParseWarehouse(urn) != WarehousePostgres || len(segments) != 3 {
+		return "", "", "", fmt.Errorf("wrong format of urn %s. expected postgres:${database}.${schema}.${table_name}", urn)
+	}
+	return segments[0], segments[1], segments[2], nil
+}
+
+//WithWarehouseScheme create URN of the table ID stored on the warehouse
+func WithWarehouseScheme(warehouse Warehouse, tableID string) string {
+	if warehouse == "" || warehouse == WarehouseBigQuery {
+		return tableID
+	}
+	return warehouse.String() + urnSchemeSeparator + tableID
+}
@@ -0,0 +1,48 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarehouse(t *testing.T) {
+	t.Run("ParseWarehouse", func(t *testing.T) {
+		t.Run("should return bigquery when urn has no scheme", func(t *testing.T) {
+			assert.Equal(t, WarehouseBigQuery, ParseWarehouse("project.dataset.table"))
+		})
+		t.Run("should return warehouse of the urn scheme", func(t *testing.T) {
+			assert.Equal(t, WarehousePostgres, ParseWarehouse("postgres:mart.public.orders"))
+		})
+	})
+	t.Run("TrimWarehouseScheme", func(t *testing.T) {
+		assert.Equal(t, "mart.public.orders", TrimWarehouseScheme("postgres:mart.public.orders"))
+		assert.Equal(t, "project.dataset.table", TrimWarehouseScheme("project.dataset.table"))
+	})
+	t.Run("ParsePostgresTableID", func(t *testing.T) {
+		t.Run("should return database, schema and table of the urn", func(t *testing.T) {
+			database, schema, table, err := ParsePostgresTableID("postgres:mart.public.orders")
+
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"mart", "public", "orders"}, []string{database, schema, table})
+		})
+		t.Run("should return error when urn is not postgres table", func(t *testing.T) {
+			for _, urn := range []string{"project.dataset.table", "postgres:public.orders"} {
+				_, _, _, err := ParsePostgresTableID(urn)
+
+				assert.Error(t, err)
+			}
+		})
+	})
+	t.Run("TableID", func(t *testing.T) {
+		t.Run("should return urn with warehouse scheme of postgres table", func(t *testing.T) {
+			tableSpec := &TableSpec{
+				Warehouse:   WarehousePostgres,
+				ProjectName: "mart",
+				DatasetName: "public",
+				TableName:   "orders",
+			}
+			assert.Equal(t, "postgres:mart.public.orders", tableSpec.TableID())
+		})
+	})
+}
@@ -1,8 +1,12 @@
 package metric
 
 import (
+	"math"
 	"sort"
+	"strings"
 	"time"
+
+	"github.com/odpf/predator/protocol/meta"
 )
 
 //Type is type of metric
@@ -23,6 +27,22 @@ const (
 	RowCount Type = "row_count"
 	//InvalidPct is invalid percentage
 	InvalidPct Type = "invalid_pct"
+	//AcceptedValuesPct is percentage of values that are not in the accepted values
+	AcceptedValuesPct Type = "accepted_values_pct"
+	//PatternMismatchPct is percentage of values that do not match the pattern
+	PatternMismatchPct Type = "pattern_mismatch_pct"
+	//LengthOutOfRangePct is percentage of values which length is out of the length range
+	LengthOutOfRangePct Type = "length_out_of_range_pct"
+	//OrphanPct is percentage of rows which join key is not found on the reference table
+	OrphanPct Type = "orphan_pct"
+	//FreshnessLagSeconds is number of seconds between the latest data and the audit time, it is not a percentage
+	FreshnessLagSeconds Type = "freshness_lag_seconds"
+	//ReconciliationDiffPct is relative difference percentage of a metric of target table from its source table
+	ReconciliationDiffPct Type = "reconciliation_diff_pct"
+	//SchemaDrift is schema change of the table from its previous snapshot or pinned schema, value is 1 for each change
+	SchemaDrift Type = "schema_drift"
+	//CustomSQL is numeric value of each group returned by sql expression or query configured on the spec
+	CustomSQL Type = "custom_sql"
 )
 
 const (
@@ -37,11 +57,44 @@ const (
 	Sum Type = "sum"
 	//InvalidCount is invalid count metric
 	InvalidCount Type = "invalidcount"
+
+	//Min is minimum value metric
+	Min Type = "min"
+	//Max is maximum value metric
+	Max Type = "max"
+	//Avg is average value metric
+	Avg Type = "avg"
+	//StdDev is sample standard deviation metric
+	StdDev Type = "stddev"
+	//Quantile is approximate quantile metric
+	Quantile Type = "quantile"
+
+	//UnacceptedCount is count of values that are not in the accepted values
+	UnacceptedCount Type = "unacceptedcount"
+	//PatternMismatchCount is count of values that do not match the pattern
+	PatternMismatchCount Type = "patternmismatchcount"
+	//LengthOutOfRangeCount is count of values which length is out of the length range
+	LengthOutOfRangeCount Type = "lengthoutofrangecount"
+	//OrphanCount is count of rows which join key is not found on the reference table
+	OrphanCount Type = "orphancount"
+	//FreshnessLag is number of seconds between the latest timestamp and the audit time
+	FreshnessLag Type = "freshnesslag"
 )
 
 var (
 	//TypesBasicMetric metric in basic metric category
-	TypesBasicMetric = []Type{NullCount, Count, UniqueCount, Sum, InvalidCount}
+	TypesBasicMetric = []Type{NullCount, Count, UniqueCount, Sum, InvalidCount, Min, Max, Avg, StdDev, Quantile,
+		UnacceptedCount, PatternMismatchCount, LengthOutOfRangeCount, OrphanCount, FreshnessLag}
+
+	//TypesStatistical metric of numeric field value distribution
+	TypesStatistical = []Type{Min, Max, Avg, StdDev, Quantile}
+
+	//conformityCountTypes is basic count metric of each conformity metric of string field
+	conformityCountTypes = map[Type]Type{
+		AcceptedValuesPct:   UnacceptedCount,
+		PatternMismatchPct:  PatternMismatchCount,
+		LengthOutOfRangePct: LengthOutOfRangeCount,
+	}
 
 	typeCategoryMap = map[Type]Category{
 		NullCount:             Basic,
@@ -49,14 +102,33 @@ var (
 		Count:                 Basic,
 		InvalidCount:          Basic,
 		Sum:                   Quality,
+		Min:                   Quality,
+		Max:                   Quality,
+		Avg:                   Quality,
+		StdDev:                Quality,
+		Quantile:              Quality,
 		NullnessPct:           Quality,
 		DuplicationPct:        Quality,
 		TrendInconsistencyPct: Quality,
 		RowCount:              Quality,
 		InvalidPct:            Quality,
+		AcceptedValuesPct:     Quality,
+		PatternMismatchPct:    Quality,
+		LengthOutOfRangePct:   Quality,
+		UnacceptedCount:       Basic,
+		PatternMismatchCount:  Basic,
+		LengthOutOfRangeCount: Basic,
+		OrphanPct:             Quality,
+		OrphanCount:           Basic,
+		FreshnessLagSeconds:   Quality,
+		FreshnessLag:          Basic,
+		ReconciliationDiffPct: Quality,
+		SchemaDrift:           Quality,
+		CustomSQL:             Quality,
 	}
 	//TypesDataQuality is metric types in data quality category
-	TypesDataQuality = []Type{NullnessPct, DuplicationPct, TrendInconsistencyPct, RowCount, InvalidPct}
+	TypesDataQuality = []Type{NullnessPct, DuplicationPct, TrendInconsistencyPct, RowCount, InvalidPct,
+		AcceptedValuesPct, PatternMismatchPct, LengthOutOfRangePct, OrphanPct, FreshnessLagSeconds, SchemaDrift, CustomSQL}
 
 	//TypeAll is all of metric types
 	TypeAll = append(TypesDataQuality, TypesBasicMetric...)
@@ -110,8 +182,296 @@ type Metric struct {
 const (
 	//UniqueFields is metadata needed to form unique count metric
 	UniqueFields = "uniquefields"
+	//TrendLookback is metadata of number of previous profiles used as baseline of trend inconsistency metric
+	TrendLookback = "lookback"
+	//TrendThresholdPct is metadata of maximum deviation percentage of a group count from its baseline
+	TrendThresholdPct = "threshold_pct"
+	//QuantileFraction is metadata of quantile metric, such as 0.99 for 99th percentile
+	QuantileFraction = "quantile"
+	//AcceptedValues is metadata of accepted values metric, list of allowed values of the field
+	AcceptedValues = "accepted_values"
+	//Pattern is metadata of pattern mismatch metric, regular expression that the values should contain
+	Pattern = "pattern"
+	//MinLength is metadata of length out of range metric, minimum allowed length of the values
+	MinLength = "min_length"
+	//MaxLength is metadata of length out of range metric, maximum allowed length of the values
+	MaxLength = "max_length"
+	//ReferenceURN is metadata of orphan metric, URN of the referenced table
+	ReferenceURN = "reference_urn"
+	//JoinFields is metadata of orphan metric, fields of the table that refer to the reference fields
+	JoinFields = "join_fields"
+	//ReferenceFields is metadata of orphan metric, fields of the referenced table in the same order as the join fields
+	ReferenceFields = "reference_fields"
+	//TimestampField is metadata of freshness metric, timestamp field of the latest data, table last modified time is used when it is not configured
+	TimestampField = "timestamp_field"
+	//ReconciledMetric is metadata of reconciliation metric, type of the compared basic metric
+	ReconciledMetric = "reconciled_metric"
+	//SourceURN is metadata of reconciliation metric, URN of the source table
+	SourceURN = "source_urn"
+	//SourceValue is metadata of reconciliation metric, value of the compared metric on the source table
+	SourceValue = "source_value"
+	//TargetValue is metadata of reconciliation metric, value of the compared metric on the target table
+	TargetValue = "target_value"
+	//PinnedSchema is metadata of schema drift metric, list of expected columns used instead of the previous snapshot
+	PinnedSchema = "schema"
+	//SchemaChange is metadata of schema drift metric, kind of the change
+	SchemaChange = "schema_change"
+	//PreviousType is metadata of schema drift metric, type of the column on the baseline schema
+	PreviousType = "previous_type"
+	//PreviousMode is metadata of schema drift metric, mode of the column on the baseline schema
+	PreviousMode = "previous_mode"
+	//CurrentType is metadata of schema drift metric, type of the column on the current schema
+	CurrentType = "current_type"
+	//CurrentMode is metadata of schema drift metric, mode of the column on the current schema
+	CurrentMode = "current_mode"
+	//CustomName is metadata of custom sql metric, name that identify the metric on the spec
+	CustomName = "name"
+	//CustomExpression is metadata of custom sql metric, aggregate select expression calculated on every group of the table
+	CustomExpression = "expression"
+	//CustomQuery is metadata of custom sql metric, full query that return value column and optional group_value column
+	CustomQuery = "query"
+)
+
+const (
+	//DefaultTrendLookback is default number of previous profiles used as trend baseline
+	DefaultTrendLookback = 7
+	//DefaultTrendThresholdPct is default maximum deviation percentage before a group breaks the trend
+	DefaultTrendThresholdPct = 50.0
 )
 
+//IsStatistical check whether metric type is statistical metric of numeric field
+func IsStatistical(metricType Type) bool {
+	for _, t := range TypesStatistical {
+		if t == metricType {
+			return true
+		}
+	}
+	return false
+}
+
+//GetQuantile get quantile fraction from metadata of quantile metric
+func GetQuantile(metadata map[string]interface{}) (float64, bool) {
+	return GetNumber(metadata, QuantileFraction)
+}
+
+//GetTrendLookback get number of previous profiles used as baseline of trend inconsistency metric, default is used when not configured
+func GetTrendLookback(metadata map[string]interface{}) int {
+	if lookback, ok := GetNumber(metadata, TrendLookback); ok {
+		return int(lookback)
+	}
+	return DefaultTrendLookback
+}
+
+//GetTrendThresholdPct get maximum deviation percentage of trend inconsistency metric, default is used when not configured
+func GetTrendThresholdPct(metadata map[string]interface{}) float64 {
+	if thresholdPct, ok := GetNumber(metadata, TrendThresholdPct); ok {
+		return thresholdPct
+	}
+	return DefaultTrendThresholdPct
+}
+
+//GetNumber get numeric metadata value of the key, false when the value is not found or not a number
+func GetNumber(metadata map[string]interface{}, key string) (float64, bool) {
+	switch v := metadata[key].(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+//IsConformity check whether metric type is conformity metric of string field values
+func IsConformity(metricType Type) bool {
+	_, ok := conformityCountTypes[metricType]
+	return ok
+}
+
+//GetConformityCountType get basic count metric needed to calculate the conformity metric
+func GetConformityCountType(metricType Type) (Type, bool) {
+	countType, ok := conformityCountTypes[metricType]
+	return countType, ok
+}
+
+//GetAcceptedValues get accepted values from metadata of accepted values metric, every value should be a string
+func GetAcceptedValues(metadata map[string]interface{}) ([]string, bool) {
+	return getStringList(metadata, AcceptedValues)
+}
+
+//GetUniqueFields get fields of unique count and duplication metric, every field should be a string
+func GetUniqueFields(metadata map[string]interface{}) ([]string, bool) {
+	return getStringList(metadata, UniqueFields)
+}
+
+func getStringList(metadata map[string]interface{}, key string) ([]string, bool) {
+	switch v := metadata[key].(type) {
+	case []string:
+		return v, true
+	case []interface{}:
+		var values []string
+		for _, raw := range v {
+			value, ok := raw.(string)
+			if !ok {
+				return nil, false
+			}
+			values = append(values, value)
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+//GetPattern get regular expression from metadata of pattern mismatch metric
+func GetPattern(metadata map[string]interface{}) (string, bool) {
+	pattern, ok := metadata[Pattern].(string)
+	return pattern, ok
+}
+
+//GetLength get length boundary from metadata of length out of range metric, key is either MinLength or MaxLength
+func GetLength(metadata map[string]interface{}, key string) (int, bool) {
+	switch v := metadata[key].(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+//Reference is referenced table and join keys of orphan metric
+type Reference struct {
+	URN             string
+	Fields          []string
+	ReferenceFields []string
+}
+
+//Equal whether both references refer to the same table with the same join keys
+func (r *Reference) Equal(other *Reference) bool {
+	if r.URN != other.URN || len(r.Fields) != len(other.Fields) || len(r.ReferenceFields) != len(other.ReferenceFields) {
+		return false
+	}
+	for i := range r.Fields {
+		if r.Fields[i] != other.Fields[i] {
+			return false
+		}
+	}
+	for i := range r.ReferenceFields {
+		if r.ReferenceFields[i] != other.ReferenceFields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//GetReference get reference from metadata of orphan metric
+func GetReference(metadata map[string]interface{}) (*Reference, bool) {
+	urn, ok := metadata[ReferenceURN].(string)
+	if !ok {
+		return nil, false
+	}
+	fields, ok := getStringList(metadata, JoinFields)
+	if !ok {
+		return nil, false
+	}
+	referenceFields, ok := getStringList(metadata, ReferenceFields)
+	if !ok {
+		return nil, false
+	}
+	return &Reference{URN: urn, Fields: fields, ReferenceFields: referenceFields}, true
+}
+
+//Reconciled is the compared metric of reconciliation metric
+type Reconciled struct {
+	Type        Type
+	SourceURN   string
+	SourceValue float64
+	TargetValue float64
+}
+
+//GetReconciled get the compared metric from metadata of reconciliation metric
+func GetReconciled(metadata map[string]interface{}) (*Reconciled, bool) {
+	metricType, ok := metadata[ReconciledMetric].(string)
+	if !ok {
+		return nil, false
+	}
+	sourceURN, ok := metadata[SourceURN].(string)
+	if !ok {
+		return nil, false
+	}
+	sourceValue, ok := metadata[SourceValue].(float64)
+	if !ok {
+		return nil, false
+	}
+	targetValue, ok := metadata[TargetValue].(float64)
+	if !ok {
+		return nil, false
+	}
+	return &Reconciled{Type: Type(metricType), SourceURN: sourceURN, SourceValue: sourceValue, TargetValue: targetValue}, true
+}
+
+//GetPinnedSchema get pinned columns from metadata of schema drift metric, mode of a column is NULLABLE when it is not configured
+//return false when the schema is not configured or malformed
+func GetPinnedSchema(metadata map[string]interface{}) ([]*meta.ColumnSchema, bool) {
+	rawColumns, ok := metadata[PinnedSchema].([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	var columns []*meta.ColumnSchema
+	for _, rawColumn := range rawColumns {
+		column, ok := rawColumn.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, _ := column["name"].(string)
+		fieldType, _ := column["type"].(string)
+		mode, _ := column["mode"].(string)
+		if mode == "" {
+			mode = string(meta.ModeNullable)
+		}
+		columns = append(columns, &meta.ColumnSchema{
+			Name: name,
+			Type: meta.FieldType(strings.ToUpper(fieldType)),
+			Mode: meta.Mode(strings.ToUpper(mode)),
+		})
+	}
+	return columns, true
+}
+
+//Custom is sql of custom sql metric, either the expression or the query is configured
+type Custom struct {
+	Name       string
+	Expression string
+	Query      string
+}
+
+//GetCustom get name and sql of custom sql metric from metadata
+func GetCustom(metadata map[string]interface{}) (*Custom, bool) {
+	name, ok := metadata[CustomName].(string)
+	if !ok {
+		return nil, false
+	}
+	expression, _ := metadata[CustomExpression].(string)
+	query, _ := metadata[CustomQuery].(string)
+	return &Custom{Name: name, Expression: expression, Query: query}, true
+}
+
+//GetTimestampField get timestamp field from metadata of freshness metric, empty string when table last modified time is used
+func GetTimestampField(metadata map[string]interface{}) string {
+	field, _ := metadata[TimestampField].(string)
+	return field
+}
+
 //New create Metric
 func New(fieldID string,
 	_type Type,
@@ -240,6 +600,67 @@ func (f *Finder) WithPartition(partition string) *Finder {
 	return f
 }
 
+type quantileMatcher struct {
+	Quantile float64
+}
+
+func (q quantileMatcher) match(metric *Metric) bool {
+	quantile, ok := GetQuantile(metric.Metadata)
+	return ok && quantile == q.Quantile
+}
+
+type referenceMatcher struct {
+	Reference *Reference
+}
+
+func (r referenceMatcher) match(metric *Metric) bool {
+	reference, ok := GetReference(metric.Metadata)
+	return ok && reference.Equal(r.Reference)
+}
+
+//WithReference find orphan metric of the reference
+func (f *Finder) WithReference(reference *Reference) *Finder {
+	f.matchers = append(f.matchers, referenceMatcher{Reference: reference})
+	return f
+}
+
+type timestampFieldMatcher struct {
+	TimestampField string
+}
+
+func (t timestampFieldMatcher) match(metric *Metric) bool {
+	return GetTimestampField(metric.Metadata) == t.TimestampField
+}
+
+type customNameMatcher struct {
+	Name string
+}
+
+func (c customNameMatcher) match(metric *Metric) bool {
+	custom, ok := GetCustom(metric.Metadata)
+	return ok && custom.Name == c.Name
+}
+
+//WithCustomName find custom sql metric based on its name
+func (f *Finder) WithCustomName(name string) *Finder {
+	f.matchers = append(f.matchers, customNameMatcher{Name: name})
+	return f
+}
+
+//WithTimestampField find freshness metric based on the timestamp field
+func (f *Finder) WithTimestampField(timestampField string) *Finder {
+	f.matchers = append(f.matchers, timestampFieldMatcher{TimestampField: timestampField})
+	return f
+}
+
+func (f *Finder) WithQuantile(quantile float64) *Finder {
+	q := quantileMatcher{
+		Quantile: quantile,
+	}
+	f.matchers = append(f.matchers, q)
+	return f
+}
+
 type conditionMatcher struct {
 	Condition string
 }
@@ -41,12 +41,20 @@ func (pg ProfileGroup) ByPartitionDate() map[string][]*ProfileMetric {
 type MetricStore interface {
 	Store(profile *job.Profile, metrics []*metric.Metric) error
 	GetMetricsByProfileID(ID string) ([]*metric.Metric, error)
+	//DeleteByProfileID delete metrics stored by the profile, so a retried profile job does not store them twice
+	DeleteByProfileID(ID string) error
+	//GetPreviousMetrics get metrics of at most limit latest completed profiles of the same urn and group created before the profile
+	GetPreviousMetrics(profile *job.Profile, limit int) ([]*metric.Metric, error)
+	//GetMetrics get metrics of completed profiles matching the query ordered by profile event timestamp, only the latest metrics within the query limit are returned
+	GetMetrics(query *MetricQuery) ([]*ProfileMetric, error)
 }
 
 //MetricsGenerator generate metric
 type MetricGenerator interface {
 	//Generate metrics
 	Generate(entry Entry, config *job.Profile) ([]*metric.Metric, error)
+	//Plan queries that will be run to generate metrics, without running them
+	Plan(profile *job.Profile) ([]*job.Query, error)
 }
 
 //ProfileService is service of profiler
@@ -54,6 +62,13 @@ type ProfileService interface {
 	//CreateProfile create profile job
 	CreateProfile(detail *job.Profile) (*job.Profile, error)
 	Get(ID string) (*job.Profile, error)
+	//Cancel cancel profile job that is not finished yet
+	Cancel(ID string) (*job.Profile, error)
+	//Estimate estimate bytes processed by the profile without running it
+	Estimate(profile *job.Profile) (*CostEstimate, error)
+	//Start start workers that run created profile jobs
+	Start()
+	//WaitAll stop workers and wait until running jobs finished
 	WaitAll(ctx context.Context) error
 	GetLog(ID string) ([]*Status, error)
 }
@@ -61,9 +76,13 @@ type ProfileService interface {
 //MetricProfiler collect metrics, actually do metric calculation to obtain the value of metric
 type MetricProfiler interface {
 	Profile(entry Entry, profile *job.Profile, metricSpecs []*metric.Spec) ([]*metric.Metric, error)
+	//Plan queries that will be run to profile the metric specs, without running them
+	Plan(profile *job.Profile, metricSpecs []*metric.Spec) ([]*job.Query, error)
 }
 
 //ProfileStatisticGenerator generate profile statistic
 type ProfileStatisticGenerator interface {
-	Generate(profile *job.Profile) error
+	Generate(entry Entry, profile *job.Profile) error
+	//Plan queries that will be run to generate profile statistic, without running them
+	Plan(profile *job.Profile) ([]*job.Query, error)
 }
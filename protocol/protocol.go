@@ -1,7 +1,9 @@
 package protocol
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/odpf/predator/protocol/meta"
@@ -16,6 +18,9 @@ var (
 
 	//ErrTableMetadataNotFound should be thrown when a table is not exist
 	ErrTableMetadataNotFound = errors.New("table metadata not found")
+
+	//ErrWarehouseNotConfigured is an error when the warehouse of a table is not configured on this deployment
+	ErrWarehouseNotConfigured = errors.New("warehouse is not configured")
 )
 
 //ConstraintStore interface of store that
@@ -55,6 +60,10 @@ var (
 	//ErrProfileInvalid when a profile doesnt have any status in status Log
 	//normally profile at least has one status in status Log
 	ErrProfileInvalid = errors.New("profile invalid")
+	//ErrProfileCancelled when updating a cancelled profile
+	ErrProfileCancelled = errors.New("profile is cancelled")
+	//ErrProfileFinished when cancelling a profile that is already finished
+	ErrProfileFinished = errors.New("profile is already finished")
 )
 
 //ProfileStore to store profile
@@ -62,6 +71,49 @@ type ProfileStore interface {
 	Create(profile *job.Profile) (*job.Profile, error)
 	Update(profile *job.Profile) error
 	Get(ID string) (*job.Profile, error)
+	//GetLastCompleted get the latest completed standard profile of the same urn, group and filter created before the profile
+	GetLastCompleted(profile *job.Profile) (*job.Profile, error)
+}
+
+var (
+	//ErrJobQueueEmpty when there is no job available to be claimed
+	ErrJobQueueEmpty = errors.New("no job available in queue")
+	//ErrJobLeaseLost when lease of a claimed job is expired and taken by another worker
+	ErrJobLeaseLost = errors.New("job lease lost")
+)
+
+//QueuedJob is a profile or reconciliation job claimed from the job queue
+type QueuedJob struct {
+	ID             int
+	JobID          string
+	JobType        job.Type
+	Attempts       int
+	LeaseOwner     string
+	LeaseExpiresAt time.Time
+}
+
+//JobQueue is durable queue of profile and reconciliation jobs shared by all predator replicas
+type JobQueue interface {
+	//Enqueue add job of the type to the queue
+	Enqueue(jobID string, jobType job.Type) error
+	//Claim lease the oldest available job to the owner, job with expired lease is available until it reach max attempts
+	Claim(owner string, lease time.Duration, maxAttempts int) (*QueuedJob, error)
+	//Extend renew lease of a claimed job
+	Extend(job *QueuedJob, lease time.Duration) error
+	//Cancel mark job that is not finished yet as cancelled, running job lose its lease
+	Cancel(jobID string, jobType job.Type) error
+	//Complete mark claimed job as done
+	Complete(job *QueuedJob) error
+	//FailExpired mark jobs with expired lease that already reach max attempts as failed
+	FailExpired(maxAttempts int) ([]*QueuedJob, error)
+}
+
+//JobHandler run claimed jobs of a job type other than profile on the profile workers
+type JobHandler interface {
+	//Run run the job, ctx is cancelled when the job lease is lost
+	Run(ctx context.Context, queuedJob *QueuedJob) error
+	//Fail mark the job as failed when its lease keep expiring until max attempts
+	Fail(queuedJob *QueuedJob) error
 }
 
 //ProfileBQLogger to log profile id and bq job id mapping
@@ -84,6 +136,24 @@ type PartitionScanner interface {
 	GetAffectedPartition(tableURN string, lastModifiedTimestamp time.Time) ([]string, error)
 }
 
+//CheckPostgresDatabase check that the postgres URN is on the connected database, every postgres query run on a single database
+func CheckPostgresDatabase(urn string, connectedDatabase string) error {
+	database, _, _, err := meta.ParsePostgresTableID(urn)
+	if err != nil {
+		return err
+	}
+	if database != connectedDatabase {
+		return fmt.Errorf("database %s of %s is not the connected postgres database %s ,%w", database, urn, connectedDatabase, ErrWarehouseNotConfigured)
+	}
+	return nil
+}
+
+//TableLister to get tables of a dataset
+type TableLister interface {
+	//ListTables get URN of every table in the dataset of the project
+	ListTables(project string, dataset string) ([]string, error)
+}
+
 //ProfileConfig as an identifier to do profiling
 type ProfileConfig struct {
 	ProfileID   string
@@ -121,9 +191,20 @@ var (
 )
 
 //MetricQuery is field selector to query metrics
+//empty fields are not used to filter metrics
 type MetricQuery struct {
 	ProfileID   string
 	Partition   string
 	MetricTypes []metric.Type
 	URN         string
+	FieldID     string
+	//From inclusive lower bound of profile event timestamp
+	From time.Time
+	//To exclusive upper bound of profile event timestamp
+	To time.Time
+	//Limit is maximum number of the latest metrics returned, zero means MaxMetricQueryLimit
+	Limit int
 }
+
+//MaxMetricQueryLimit is maximum number of metrics returned by a metric query
+const MaxMetricQueryLimit = 10000
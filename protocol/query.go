@@ -7,5 +7,15 @@ type Row map[string]interface{}
 
 //QueryExecutor that execute bigquery SQL query script return list of Row as result
 type QueryExecutor interface {
-	Run(profile *job.Profile, query string, queryType job.QueryType) ([]Row, error)
+	Run(entry Entry, profile *job.Profile, query string, queryType job.QueryType) ([]Row, error)
+}
+
+//QueryCanceller cancel queries of a profile that may still be running on the warehouse
+type QueryCanceller interface {
+	Cancel(profile *job.Profile) error
+}
+
+//QueryEstimator estimate bytes processed by a query without running it
+type QueryEstimator interface {
+	Estimate(entry Entry, profile *job.Profile, query string) (int64, error)
 }
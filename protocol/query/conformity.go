@@ -0,0 +1,46 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+//conformityDialect is dialect specific part of conformity predicate
+type conformityDialect interface {
+	stringLiteral(value string) string
+	regexpContains(column string, pattern string) string
+}
+
+//buildConformityPredicate build predicate of non null values that do not conform the rule of conformity metric
+//return false when the metric expression is not a conformity metric
+func buildConformityPredicate(m *MetricExpression, dialect conformityDialect) (string, bool) {
+	var violation string
+	switch m.MetricType {
+	case MetricTypeUnacceptedCount:
+		if len(m.AcceptedValues) == 0 {
+			return fmt.Sprintf("%s is not null", m.Arg), true
+		}
+		var literals []string
+		for _, value := range m.AcceptedValues {
+			literals = append(literals, dialect.stringLiteral(value))
+		}
+		violation = fmt.Sprintf("%s not in (%s)", m.Arg, strings.Join(literals, ", "))
+	case MetricTypePatternMismatchCount:
+		violation = fmt.Sprintf("not %s", dialect.regexpContains(m.Arg, m.Pattern))
+	case MetricTypeLengthOutOfRangeCount:
+		var outOfRange []string
+		if m.MinLength > 0 {
+			outOfRange = append(outOfRange, fmt.Sprintf("length(%s) < %d", m.Arg, m.MinLength))
+		}
+		if m.MaxLength > 0 {
+			outOfRange = append(outOfRange, fmt.Sprintf("length(%s) > %d", m.Arg, m.MaxLength))
+		}
+		if len(outOfRange) == 0 {
+			return "false", true
+		}
+		violation = fmt.Sprintf("(%s)", strings.Join(outOfRange, " or "))
+	default:
+		return "", false
+	}
+	return fmt.Sprintf("%s is not null and %s", m.Arg, violation), true
+}
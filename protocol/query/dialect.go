@@ -0,0 +1,128 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/odpf/predator/protocol/meta"
+)
+
+//Dialect is SQL dialect of a data warehouse
+type Dialect interface {
+	//TableIdentifier build quoted identifier of the table used on from clause
+	TableIdentifier(tableID string) string
+	//BuildMetric build select expression of the metric
+	BuildMetric(m *MetricExpression) (string, error)
+}
+
+var (
+	//BigQuery is dialect of google bigquery standard SQL
+	BigQuery Dialect = &bigqueryDialect{}
+	//Postgres is dialect of postgresql
+	Postgres Dialect = &postgresDialect{}
+)
+
+//DialectOf get SQL dialect of the warehouse where the table is stored
+func DialectOf(urn string) Dialect {
+	if meta.ParseWarehouse(urn) == meta.WarehousePostgres {
+		return Postgres
+	}
+	return BigQuery
+}
+
+type bigqueryDialect struct {
+}
+
+//stringLiteral bigquery quoted string literal, backslash and single quote are escaped
+func (b *bigqueryDialect) stringLiteral(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return fmt.Sprintf("'%s'", escaped)
+}
+
+func (b *bigqueryDialect) regexpContains(column string, pattern string) string {
+	return fmt.Sprintf("regexp_contains(%s, %s)", column, b.stringLiteral(pattern))
+}
+
+func (b *bigqueryDialect) TableIdentifier(tableID string) string {
+	return fmt.Sprintf("`%s`", tableID)
+}
+
+func (b *bigqueryDialect) BuildMetric(m *MetricExpression) (string, error) {
+	if predicate, ok := buildConformityPredicate(m, b); ok {
+		return fmt.Sprintf(metricTypeExpressionTemplate[MetricTypeInvalidCount], predicate, m.Alias), nil
+	}
+	metricTemplate, ok := metricTypeExpressionTemplate[m.MetricType]
+	if !ok {
+		return "", ErrorMetricTypeNotFound
+	}
+	if m.MetricType == MetricTypeQuantile {
+		offset := int(math.Round(m.Quantile * quantileBuckets))
+		return fmt.Sprintf(metricTemplate, m.Arg, quantileBuckets, offset, m.Alias), nil
+	}
+	if m.MetricType == MetricTypeFreshnessLag {
+		return fmt.Sprintf(metricTemplate, m.AuditTime.UTC().Format(auditTimeLayout), m.Arg, m.Alias), nil
+	}
+	return fmt.Sprintf(metricTemplate, m.Arg, m.Alias), nil
+}
+
+var postgresMetricTypeExpressionTemplate = map[MetricType]string{
+	MetricTypeCount:                "count(%s) as %s",
+	MetricTypeNullCount:            "count(*) filter (where %s is null) as %s",
+	MetricTypeSum:                  "sum(cast(%s as double precision)) as %s",
+	MetricTypeCountForRepeated:     "count(*) filter (where cardinality(%s)>0) as %s",
+	MetricTypeNullCountForRepeated: "count(*) filter (where coalesce(cardinality(%s),0)=0) as %s",
+	MetricTypeUniqueCount:          "count(distinct %s) as %s",
+	MetricTypeInvalidCount:         "count(*) filter (where %s) as %s",
+	MetricTypeMin:                  "min(cast(%s as double precision)) as %s",
+	MetricTypeMax:                  "max(cast(%s as double precision)) as %s",
+	MetricTypeAvg:                  "avg(cast(%s as double precision)) as %s",
+	MetricTypeStdDev:               "stddev_samp(cast(%s as double precision)) as %s",
+	MetricTypeQuantile:             "percentile_cont(%g) within group (order by cast(%s as double precision)) as %s",
+	MetricTypeOrphanCount:          "count(*) filter (where %s) as %s",
+	MetricTypeFreshnessLag:         "cast(extract(epoch from (timestamp with time zone '%s' - max(%s))) as bigint) as %s",
+}
+
+type postgresDialect struct {
+}
+
+//stringLiteral postgres string literal with standard conforming strings, only single quote is escaped
+func (p *postgresDialect) stringLiteral(value string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+}
+
+func (p *postgresDialect) regexpContains(column string, pattern string) string {
+	return fmt.Sprintf("%s ~ %s", column, p.stringLiteral(pattern))
+}
+
+//TableIdentifier postgres query run on a connection to the database, only schema and table name are used
+//executor reject table of other database than the connected database
+func (p *postgresDialect) TableIdentifier(tableID string) string {
+	segments := strings.Split(meta.TrimWarehouseScheme(tableID), ".")
+	if len(segments) > 2 {
+		segments = segments[len(segments)-2:]
+	}
+
+	var quoted []string
+	for _, segment := range segments {
+		quoted = append(quoted, fmt.Sprintf(`"%s"`, segment))
+	}
+	return strings.Join(quoted, ".")
+}
+
+func (p *postgresDialect) BuildMetric(m *MetricExpression) (string, error) {
+	if predicate, ok := buildConformityPredicate(m, p); ok {
+		return fmt.Sprintf(postgresMetricTypeExpressionTemplate[MetricTypeInvalidCount], predicate, m.Alias), nil
+	}
+	metricTemplate, ok := postgresMetricTypeExpressionTemplate[m.MetricType]
+	if !ok {
+		return "", ErrorMetricTypeNotFound
+	}
+	if m.MetricType == MetricTypeQuantile {
+		return fmt.Sprintf(metricTemplate, m.Quantile, m.Arg, m.Alias), nil
+	}
+	if m.MetricType == MetricTypeFreshnessLag {
+		return fmt.Sprintf(metricTemplate, m.AuditTime.UTC().Format(auditTimeLayout), m.Arg, m.Alias), nil
+	}
+	return fmt.Sprintf(metricTemplate, m.Arg, m.Alias), nil
+}
@@ -0,0 +1,132 @@
+package query_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odpf/predator/protocol/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialect(t *testing.T) {
+	t.Run("DialectOf", func(t *testing.T) {
+		t.Run("should return bigquery dialect when urn has no scheme", func(t *testing.T) {
+			assert.Equal(t, query.BigQuery, query.DialectOf("project.dataset.table"))
+		})
+		t.Run("should return postgres dialect when urn has postgres scheme", func(t *testing.T) {
+			assert.Equal(t, query.Postgres, query.DialectOf("postgres:mart.public.orders"))
+		})
+	})
+	t.Run("Postgres", func(t *testing.T) {
+		t.Run("TableIdentifier", func(t *testing.T) {
+			t.Run("should quote schema and table name", func(t *testing.T) {
+				assert.Equal(t, `"public"."orders"`, query.Postgres.TableIdentifier("postgres:mart.public.orders"))
+			})
+		})
+		t.Run("BuildMetric", func(t *testing.T) {
+			testCases := []struct {
+				description string
+				metric      *query.MetricExpression
+				expected    string
+			}{
+				{
+					description: "should return null count metric expression",
+					metric:      query.NewMetricExpression("status", "nullcount_status", query.MetricTypeNullCount),
+					expected:    "count(*) filter (where status is null) as nullcount_status",
+				},
+				{
+					description: "should return invalid count metric expression",
+					metric:      query.NewMetricExpression("amount < 0", "invalidcount", query.MetricTypeInvalidCount),
+					expected:    "count(*) filter (where amount < 0) as invalidcount",
+				},
+				{
+					description: "should return count metric expression for repeated fields",
+					metric:      query.NewMetricExpression("tags", "count_tags", query.MetricTypeCountForRepeated),
+					expected:    "count(*) filter (where cardinality(tags)>0) as count_tags",
+				},
+				{
+					description: "should return sum metric expression",
+					metric:      query.NewMetricExpression("amount", "sum_amount", query.MetricTypeSum),
+					expected:    "sum(cast(amount as double precision)) as sum_amount",
+				},
+				{
+					description: "should return quantile metric expression",
+					metric: &query.MetricExpression{
+						Arg:        "amount",
+						Alias:      "quantile_amount",
+						MetricType: query.MetricTypeQuantile,
+						Quantile:   0.99,
+					},
+					expected: "percentile_cont(0.99) within group (order by cast(amount as double precision)) as quantile_amount",
+				},
+				{
+					description: "should return unaccepted count metric expression",
+					metric: &query.MetricExpression{
+						Arg:            "status",
+						Alias:          "unacceptedcount_status",
+						MetricType:     query.MetricTypeUnacceptedCount,
+						AcceptedValues: []string{"ACTIVE", "driver's"},
+					},
+					expected: "count(*) filter (where status is not null and status not in ('ACTIVE', 'driver''s')) as unacceptedcount_status",
+				},
+				{
+					description: "should return pattern mismatch count metric expression",
+					metric: &query.MetricExpression{
+						Arg:        "code",
+						Alias:      "patternmismatchcount_code",
+						MetricType: query.MetricTypePatternMismatchCount,
+						Pattern:    `^[A-Z]{2}\d+$`,
+					},
+					expected: `count(*) filter (where code is not null and not code ~ '^[A-Z]{2}\d+$') as patternmismatchcount_code`,
+				},
+				{
+					description: "should return freshness lag metric expression",
+					metric: &query.MetricExpression{
+						Arg:        "created_at",
+						Alias:      "freshnesslag_0",
+						MetricType: query.MetricTypeFreshnessLag,
+						AuditTime:  time.Date(2021, 3, 4, 10, 30, 0, 0, time.UTC),
+					},
+					expected: "cast(extract(epoch from (timestamp with time zone '2021-03-04 10:30:00 UTC' - max(created_at))) as bigint) as freshnesslag_0",
+				},
+			}
+			for _, test := range testCases {
+				t.Run(test.description, func(t *testing.T) {
+					result, err := query.Postgres.BuildMetric(test.metric)
+
+					assert.Nil(t, err)
+					assert.Equal(t, test.expected, result)
+				})
+			}
+			t.Run("should return ErrorMetricTypeNotFound when given unsupported metric type", func(t *testing.T) {
+				_, err := query.Postgres.BuildMetric(query.NewMetricExpression("status", "average_status", "average"))
+				assert.Equal(t, query.ErrorMetricTypeNotFound, err)
+			})
+		})
+		t.Run("should generate sql with postgres dialect", func(t *testing.T) {
+			q := &query.Query{
+				Expressions: []*query.SelectExpression{
+					{
+						Expression: "created_date",
+						Alias:      "__group_value",
+					},
+				},
+				Metrics: []*query.MetricExpression{
+					query.NewMetricExpression("1", "count", query.MetricTypeCount),
+					query.NewMetricExpression("status", "nullcount_status", query.MetricTypeNullCount),
+				},
+				From: &query.FromClause{
+					TableID: "postgres:mart.public.orders",
+				},
+				Where:   &query.NoFilter{},
+				GroupBy: &query.GroupByExpression{Expression: "created_date"},
+				Dialect: query.Postgres,
+			}
+
+			expected := "SELECT created_date AS __group_value , " +
+				"count(1) as count , count(*) filter (where status is null) as nullcount_status " +
+				`FROM "public"."orders" WHERE TRUE GROUP BY created_date`
+			assert.Equal(t, expected, q.String())
+		})
+	})
+}
@@ -21,15 +21,71 @@ func (u *Unnest) Equal(other *Unnest) bool {
 	return *u == *other
 }
 
+const (
+	//referenceAlias is alias of the distinct join keys of the referenced table
+	referenceAlias = "__reference"
+	//referenceKeyAlias is alias of a join key of the referenced table
+	referenceKeyAlias = "__reference_key"
+)
+
+//ReferenceJoin is left join to distinct join keys of a referenced table, rows without matching keys are orphan
+//the keys are aliased so that columns of the table are not ambiguous on other clauses
+type ReferenceJoin struct {
+	ReferenceTableID string
+	Fields           []string
+	ReferenceFields  []string
+}
+
+//BuildWith build left join sql string using the dialect
+func (r *ReferenceJoin) BuildWith(dialect Dialect) string {
+	var keys []string
+	var conditions []string
+	for i, field := range r.Fields {
+		alias := fmt.Sprintf("%s_%d", referenceKeyAlias, i)
+		keys = append(keys, fmt.Sprintf("%s AS %s", r.ReferenceFields[i], alias))
+		conditions = append(conditions, fmt.Sprintf("%s = %s.%s", field, referenceAlias, alias))
+	}
+	return fmt.Sprintf("LEFT JOIN (SELECT DISTINCT %s FROM %s) AS %s ON %s",
+		strings.Join(keys, defaultExpressionSeparator), dialect.TableIdentifier(r.ReferenceTableID),
+		referenceAlias, strings.Join(conditions, " AND "))
+}
+
+//OrphanCondition condition of rows which join keys are not null and not found on the referenced table
+func (r *ReferenceJoin) OrphanCondition() string {
+	var conditions []string
+	for _, field := range r.Fields {
+		conditions = append(conditions, fmt.Sprintf("%s is not null", field))
+	}
+	conditions = append(conditions, fmt.Sprintf("%s.%s_0 is null", referenceAlias, referenceKeyAlias))
+	return strings.Join(conditions, " and ")
+}
+
+//Equal comparable
+func (r *ReferenceJoin) Equal(other *ReferenceJoin) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	return r.ReferenceTableID == other.ReferenceTableID &&
+		strings.Join(r.Fields, ",") == strings.Join(other.Fields, ",") &&
+		strings.Join(r.ReferenceFields, ",") == strings.Join(other.ReferenceFields, ",")
+}
+
 //FromClause is a definition of from clause in sql
 type FromClause struct {
 	TableID       string
 	UnnestClauses []*Unnest
+	//Join is optional
+	Join *ReferenceJoin
 }
 
 //Build is a method to build from clause sql string
 func (fc *FromClause) Build() string {
-	table := fmt.Sprintf("`%s`", fc.TableID)
+	return fc.BuildWith(BigQuery)
+}
+
+//BuildWith build from clause sql string using the dialect
+func (fc *FromClause) BuildWith(dialect Dialect) string {
+	table := dialect.TableIdentifier(fc.TableID)
 
 	var unnestClauses []string
 	if len(fc.UnnestClauses) > 0 {
@@ -39,7 +95,11 @@ func (fc *FromClause) Build() string {
 		}
 	}
 
-	return strings.Join(append([]string{table}, unnestClauses...), defaultExpressionSeparator)
+	from := strings.Join(append([]string{table}, unnestClauses...), defaultExpressionSeparator)
+	if fc.Join != nil {
+		from = fmt.Sprintf("%s %s", from, fc.Join.BuildWith(dialect))
+	}
+	return from
 }
 
 //Equal is comparison
@@ -55,5 +115,5 @@ func (fc *FromClause) Equal(other *FromClause) bool {
 		}
 	}
 
-	return fc.TableID == other.TableID
+	return fc.TableID == other.TableID && fc.Join.Equal(other.Join)
 }
@@ -53,6 +53,54 @@ func TestFromClause(t *testing.T) {
 				clauses := fromClause.Build()
 				assert.Equal(t, expected, clauses)
 			})
+
+			t.Run("should return from clause with left join to distinct keys of the referenced table", func(t *testing.T) {
+				fromClause := query.FromClause{
+					TableID: "project.dataset.orders",
+					Join: &query.ReferenceJoin{
+						ReferenceTableID: "project.dataset.customers",
+						Fields:           []string{"customer_id", "region"},
+						ReferenceFields:  []string{"id", "region"},
+					},
+				}
+
+				expected := "`project.dataset.orders` LEFT JOIN (SELECT DISTINCT id AS __reference_key_0 , region AS __reference_key_1 FROM `project.dataset.customers`) AS __reference " +
+					"ON customer_id = __reference.__reference_key_0 AND region = __reference.__reference_key_1"
+
+				clauses := fromClause.Build()
+				assert.Equal(t, expected, clauses)
+			})
+		})
+		t.Run("Equal", func(t *testing.T) {
+			t.Run("should return false when the join is different", func(t *testing.T) {
+				fromClause := &query.FromClause{TableID: "project.dataset.orders"}
+				other := &query.FromClause{
+					TableID: "project.dataset.orders",
+					Join: &query.ReferenceJoin{
+						ReferenceTableID: "project.dataset.customers",
+						Fields:           []string{"customer_id"},
+						ReferenceFields:  []string{"id"},
+					},
+				}
+
+				assert.False(t, fromClause.Equal(other))
+				assert.True(t, other.Equal(other))
+			})
+		})
+	})
+	t.Run("ReferenceJoin", func(t *testing.T) {
+		t.Run("OrphanCondition", func(t *testing.T) {
+			t.Run("should return condition of not null join keys without matching reference key", func(t *testing.T) {
+				join := &query.ReferenceJoin{
+					ReferenceTableID: "project.dataset.customers",
+					Fields:           []string{"customer_id", "region"},
+					ReferenceFields:  []string{"id", "region"},
+				}
+
+				expected := "customer_id is not null and region is not null and __reference.__reference_key_0 is null"
+
+				assert.Equal(t, expected, join.OrphanCondition())
+			})
 		})
 	})
 }
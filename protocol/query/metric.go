@@ -2,10 +2,10 @@ package query
 
 import (
 	"errors"
-	"fmt"
 	"github.com/odpf/predator/protocol/meta"
 	"github.com/odpf/predator/protocol/metric"
 	"strings"
+	"time"
 )
 
 //MetricType is type of metric
@@ -26,8 +26,34 @@ const (
 	MetricTypeUniqueCount = "UNIQUECOUNT"
 	//MetricTypeInvalidCount is metric type of invalid count
 	MetricTypeInvalidCount = "INVALIDCOUNT"
+	//MetricTypeMin is metric type of min
+	MetricTypeMin = "MIN"
+	//MetricTypeMax is metric type of max
+	MetricTypeMax = "MAX"
+	//MetricTypeAvg is metric type of avg
+	MetricTypeAvg = "AVG"
+	//MetricTypeStdDev is metric type of sample standard deviation
+	MetricTypeStdDev = "STDDEV"
+	//MetricTypeQuantile is metric type of approximate quantile
+	MetricTypeQuantile = "QUANTILE"
+	//MetricTypeUnacceptedCount is metric type of count of values that are not accepted
+	MetricTypeUnacceptedCount = "UNACCEPTEDCOUNT"
+	//MetricTypePatternMismatchCount is metric type of count of values that do not match the pattern
+	MetricTypePatternMismatchCount = "PATTERNMISMATCHCOUNT"
+	//MetricTypeLengthOutOfRangeCount is metric type of count of values which length is out of range
+	MetricTypeLengthOutOfRangeCount = "LENGTHOUTOFRANGECOUNT"
+	//MetricTypeOrphanCount is metric type of count of rows which join key is not found on the reference table
+	MetricTypeOrphanCount = "ORPHANCOUNT"
+	//MetricTypeFreshnessLag is metric type of seconds between the latest timestamp and the audit time
+	MetricTypeFreshnessLag = "FRESHNESSLAG"
 )
 
+//auditTimeLayout is layout of audit time timestamp literal, lag is measured in seconds
+const auditTimeLayout = "2006-01-02 15:04:05 UTC"
+
+//quantileBuckets is number of buckets of approximate quantile, quantile is rounded to 1/quantileBuckets precision
+const quantileBuckets = 1000
+
 //ParseMetricType to parse metric name to metric type
 func ParseMetricType(_type metric.Type, mode meta.Mode) MetricType {
 	var metricType MetricType
@@ -45,6 +71,26 @@ func ParseMetricType(_type metric.Type, mode meta.Mode) MetricType {
 		metricType = MetricTypeUniqueCount
 	} else if _type == metric.InvalidCount {
 		metricType = MetricTypeInvalidCount
+	} else if _type == metric.Min {
+		metricType = MetricTypeMin
+	} else if _type == metric.Max {
+		metricType = MetricTypeMax
+	} else if _type == metric.Avg {
+		metricType = MetricTypeAvg
+	} else if _type == metric.StdDev {
+		metricType = MetricTypeStdDev
+	} else if _type == metric.Quantile {
+		metricType = MetricTypeQuantile
+	} else if _type == metric.UnacceptedCount {
+		metricType = MetricTypeUnacceptedCount
+	} else if _type == metric.PatternMismatchCount {
+		metricType = MetricTypePatternMismatchCount
+	} else if _type == metric.LengthOutOfRangeCount {
+		metricType = MetricTypeLengthOutOfRangeCount
+	} else if _type == metric.OrphanCount {
+		metricType = MetricTypeOrphanCount
+	} else if _type == metric.FreshnessLag {
+		metricType = MetricTypeFreshnessLag
 	}
 	return metricType
 }
@@ -57,6 +103,13 @@ var metricTypeExpressionTemplate = map[MetricType]string{
 	MetricTypeNullCountForRepeated: "countif(array_length(%s)=0) as %s",
 	MetricTypeUniqueCount:          "count(distinct %s) as %s",
 	MetricTypeInvalidCount:         "countif(%s) as %s",
+	MetricTypeMin:                  "min(cast(%s as float64)) as %s",
+	MetricTypeMax:                  "max(cast(%s as float64)) as %s",
+	MetricTypeAvg:                  "avg(cast(%s as float64)) as %s",
+	MetricTypeStdDev:               "stddev_samp(cast(%s as float64)) as %s",
+	MetricTypeQuantile:             "approx_quantiles(cast(%s as float64), %d)[safe_offset(%d)] as %s",
+	MetricTypeOrphanCount:          "countif(%s) as %s",
+	MetricTypeFreshnessLag:         "timestamp_diff(timestamp '%s', max(%s), second) as %s",
 }
 
 //ErrorMetricTypeNotFound is error when  metric type is undefined, or not found from the list
@@ -67,15 +120,22 @@ type MetricExpression struct {
 	Arg        string
 	Alias      string
 	MetricType MetricType
+	//Quantile is fraction of approximate quantile, only used by MetricTypeQuantile
+	Quantile float64
+	//AcceptedValues is allowed values, only used by MetricTypeUnacceptedCount
+	AcceptedValues []string
+	//Pattern is regular expression the values should contain, only used by MetricTypePatternMismatchCount
+	Pattern string
+	//MinLength and MaxLength is allowed length range, zero MaxLength is unbounded, only used by MetricTypeLengthOutOfRangeCount
+	MinLength int
+	MaxLength int
+	//AuditTime is the time the latest timestamp is compared to, only used by MetricTypeFreshnessLag
+	AuditTime time.Time
 }
 
 //Build is process of constructing script from metric definition
 func (m *MetricExpression) Build() (string, error) {
-	metricTemplate, ok := metricTypeExpressionTemplate[m.MetricType]
-	if !ok {
-		return "", ErrorMetricTypeNotFound
-	}
-	return fmt.Sprintf(metricTemplate, m.Arg, m.Alias), nil
+	return BigQuery.BuildMetric(m)
 }
 
 //NewMetricExpression is constructor for metric expression
@@ -92,9 +152,14 @@ type MetricExpressionList []*MetricExpression
 
 //Build build each metric expression and join them
 func (m MetricExpressionList) Build() (string, error) {
+	return m.BuildWith(BigQuery)
+}
+
+//BuildWith build each metric expression using the dialect and join them
+func (m MetricExpressionList) BuildWith(dialect Dialect) (string, error) {
 	var metricList []string
 	for _, me := range []*MetricExpression(m) {
-		metricExpression, err := me.Build()
+		metricExpression, err := dialect.BuildMetric(me)
 		if err != nil {
 			return "", err
 		}
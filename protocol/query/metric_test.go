@@ -4,6 +4,7 @@ import (
 	"github.com/odpf/predator/protocol/query"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestMetricExpression(t *testing.T) {
@@ -64,6 +65,73 @@ func TestMetricExpression(t *testing.T) {
 
 				expected := "countif(array_length(status)=0) as nullcount_status"
 
+				metricScript, _ := metric.Build()
+				assert.Equal(t, expected, metricScript)
+			})
+			t.Run("should return stddev metric expression", func(t *testing.T) {
+				columnName := "amount"
+				aliasName := "stddev_amount"
+				metric := query.NewMetricExpression(columnName, aliasName, query.MetricTypeStdDev)
+
+				expected := "stddev_samp(cast(amount as float64)) as stddev_amount"
+
+				metricScript, _ := metric.Build()
+				assert.Equal(t, expected, metricScript)
+			})
+			t.Run("should return approximate quantile metric expression", func(t *testing.T) {
+				columnName := "amount"
+				aliasName := "quantile_amount"
+				metric := query.NewMetricExpression(columnName, aliasName, query.MetricTypeQuantile)
+				metric.Quantile = 0.99
+
+				expected := "approx_quantiles(cast(amount as float64), 1000)[safe_offset(990)] as quantile_amount"
+
+				metricScript, _ := metric.Build()
+				assert.Equal(t, expected, metricScript)
+			})
+			t.Run("should return unaccepted count metric expression with escaped values", func(t *testing.T) {
+				metric := query.NewMetricExpression("`status`", "unacceptedcount_status", query.MetricTypeUnacceptedCount)
+				metric.AcceptedValues = []string{"ACTIVE", "driver's"}
+
+				expected := "countif(`status` is not null and `status` not in ('ACTIVE', 'driver\\'s')) as unacceptedcount_status"
+
+				metricScript, _ := metric.Build()
+				assert.Equal(t, expected, metricScript)
+			})
+			t.Run("should return pattern mismatch count metric expression", func(t *testing.T) {
+				metric := query.NewMetricExpression("`code`", "patternmismatchcount_code", query.MetricTypePatternMismatchCount)
+				metric.Pattern = `^[A-Z]{2}\d+$`
+
+				expected := "countif(`code` is not null and not regexp_contains(`code`, '^[A-Z]{2}\\\\d+$')) as patternmismatchcount_code"
+
+				metricScript, _ := metric.Build()
+				assert.Equal(t, expected, metricScript)
+			})
+			t.Run("should return length out of range count metric expression", func(t *testing.T) {
+				metric := query.NewMetricExpression("`code`", "lengthoutofrangecount_code", query.MetricTypeLengthOutOfRangeCount)
+				metric.MinLength = 2
+				metric.MaxLength = 8
+
+				expected := "countif(`code` is not null and (length(`code`) < 2 or length(`code`) > 8)) as lengthoutofrangecount_code"
+
+				metricScript, _ := metric.Build()
+				assert.Equal(t, expected, metricScript)
+			})
+			t.Run("should return length out of range count metric expression without upper bound", func(t *testing.T) {
+				metric := query.NewMetricExpression("`code`", "lengthoutofrangecount_code", query.MetricTypeLengthOutOfRangeCount)
+				metric.MinLength = 2
+
+				expected := "countif(`code` is not null and (length(`code`) < 2)) as lengthoutofrangecount_code"
+
+				metricScript, _ := metric.Build()
+				assert.Equal(t, expected, metricScript)
+			})
+			t.Run("should return freshness lag metric expression in seconds before audit time", func(t *testing.T) {
+				metric := query.NewMetricExpression("`event_timestamp`", "freshnesslag_0", query.MetricTypeFreshnessLag)
+				metric.AuditTime = time.Date(2021, 3, 4, 17, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+
+				expected := "timestamp_diff(timestamp '2021-03-04 10:30:00 UTC', max(`event_timestamp`), second) as freshnesslag_0"
+
 				metricScript, _ := metric.Build()
 				assert.Equal(t, expected, metricScript)
 			})
@@ -42,11 +42,21 @@ type Query struct {
 
 	//GroupBy is optional
 	GroupBy GroupByClause
+
+	//Dialect is optional, BigQuery dialect is used when not set
+	Dialect Dialect
+}
+
+func (q *Query) dialect() Dialect {
+	if q.Dialect == nil {
+		return BigQuery
+	}
+	return q.Dialect
 }
 
 //NewQuery is a function to build a sql to calculate metric
 func (q *Query) String() string {
-	fromExpression := q.From.Build()
+	fromExpression := q.From.BuildWith(q.dialect())
 	whereExpression := q.Where.Build()
 
 	//sequence of select expression
@@ -58,7 +68,7 @@ func (q *Query) String() string {
 	}
 
 	if len(q.Metrics) > 0 {
-		metricsExpression, _ := q.Metrics.Build()
+		metricsExpression, _ := q.Metrics.BuildWith(q.dialect())
 		expressions = append(expressions, metricsExpression)
 	}
 
@@ -95,6 +105,7 @@ func (q *Query) Merge(other *Query) (*Query, error) {
 		Metrics: combinedMetrics,
 		Where:   q.Where,
 		From:    q.From,
+		Dialect: q.Dialect,
 	}
 
 	return merged, nil
@@ -0,0 +1,37 @@
+package protocol
+
+import (
+	"errors"
+
+	"github.com/odpf/predator/protocol/job"
+)
+
+var (
+	//ErrReconciliationNotFound thrown when no reconciliation found
+	ErrReconciliationNotFound = errors.New("reconciliation not found")
+	//ErrReconciliationInvalid thrown when the compared fields are not found or not comparable
+	ErrReconciliationInvalid = errors.New("invalid reconciliation")
+)
+
+//ReconciliationResult is reconciliation job and the report of each compared metric
+type ReconciliationResult struct {
+	Reconciliation *job.Reconciliation
+	AuditReports   []*AuditReport
+}
+
+//ReconciliationStore is store of reconciliation entity
+type ReconciliationStore interface {
+	Create(reconciliation *job.Reconciliation) (*job.Reconciliation, error)
+	//Update store profile and audit of the reconciliation and its latest state
+	Update(reconciliation *job.Reconciliation) error
+	//Get get reconciliation with its latest state, return ErrReconciliationNotFound when reconciliation is not found
+	Get(ID string) (*job.Reconciliation, error)
+}
+
+//ReconciliationService is service to reconcile target table with its source table
+type ReconciliationService interface {
+	//Reconcile validate and enqueue the reconciliation, source and target table are profiled and audited by profile workers
+	Reconcile(reconciliation *job.Reconciliation) (*ReconciliationResult, error)
+	//Get get reconciliation and its reports
+	Get(ID string) (*ReconciliationResult, error)
+}
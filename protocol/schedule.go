@@ -0,0 +1,125 @@
+package protocol
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/odpf/predator/protocol/job"
+)
+
+//Schedule is configuration of profile and audit run periodically by predator scheduler
+type Schedule struct {
+	//Cron is standard five fields cron expression evaluated in UTC
+	Cron string `json:"cron" yaml:"cron"`
+	//Filter is template of profile filter, rendered with the scheduled time of the run
+	Filter string `json:"filter" yaml:"filter,omitempty"`
+	Group  string `json:"group" yaml:"group,omitempty"`
+	//Mode is profile mode, complete mode is used when it is empty
+	Mode job.Mode `json:"mode" yaml:"mode,omitempty"`
+	//Audit whether the profile is audited after it is completed
+	Audit bool `json:"audit" yaml:"audit,omitempty"`
+}
+
+//filterData is data used to render filter template of a schedule
+type filterData struct {
+	//ScheduledTime is activation time of the run
+	ScheduledTime time.Time
+	//Date is date of the scheduled time in YYYY-MM-DD format
+	Date string
+	//PreviousDate is date before the scheduled time in YYYY-MM-DD format
+	PreviousDate string
+}
+
+//RenderFilter render filter template with the scheduled time
+func (s *Schedule) RenderFilter(scheduledTime time.Time) (string, error) {
+	tmpl, err := template.New("filter").Option("missingkey=error").Parse(s.Filter)
+	if err != nil {
+		return "", fmt.Errorf("invalid filter template, %w", err)
+	}
+
+	data := &filterData{
+		ScheduledTime: scheduledTime,
+		Date:          scheduledTime.Format("2006-01-02"),
+		PreviousDate:  scheduledTime.AddDate(0, 0, -1).Format("2006-01-02"),
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("unable to render filter template, %w", err)
+	}
+	return buf.String(), nil
+}
+
+//Validate check cron expression, filter template and mode of the schedule
+func (s *Schedule) Validate() []error {
+	var errs []error
+	if _, err := ParseCron(s.Cron); err != nil {
+		errs = append(errs, fmt.Errorf("[schedule.cron] %w", err))
+	}
+	if _, err := s.RenderFilter(time.Now().In(time.UTC)); err != nil {
+		errs = append(errs, fmt.Errorf("[schedule.filter] %w", err))
+	}
+	if s.Mode != "" {
+		if err := s.Mode.IsValid(); err != nil {
+			errs = append(errs, fmt.Errorf("[schedule.mode] %w", err))
+		}
+	}
+	return errs
+}
+
+//ScheduleRunStatus is status of a scheduled run
+type ScheduleRunStatus string
+
+const (
+	//ScheduleRunCreated run is created, the profile is not created yet
+	ScheduleRunCreated ScheduleRunStatus = "created"
+	//ScheduleRunProfiling profile of the run is created and waiting to be finished
+	ScheduleRunProfiling ScheduleRunStatus = "profiling"
+	//ScheduleRunAuditing profile of the run is completed and being audited
+	ScheduleRunAuditing ScheduleRunStatus = "auditing"
+	//ScheduleRunCompleted run is completed
+	ScheduleRunCompleted ScheduleRunStatus = "completed"
+	//ScheduleRunFailed run is failed
+	ScheduleRunFailed ScheduleRunStatus = "failed"
+)
+
+//ScheduleRun is one run of a schedule
+type ScheduleRun struct {
+	ID            int
+	URN           string
+	ScheduledTime time.Time
+	Status        ScheduleRunStatus
+	Message       string
+	ProfileID     string
+	AuditID       string
+	//Audit whether the profile of the run is audited, taken from the schedule when the run is created
+	Audit     bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+var (
+	//ErrScheduleRunExists thrown when run of the urn at the scheduled time already created by other replica
+	ErrScheduleRunExists = errors.New("schedule run already exists")
+	//ErrScheduleRunNotFound thrown when no run found
+	ErrScheduleRunNotFound = errors.New("schedule run not found")
+	//ErrScheduleRunStatusChanged thrown when the run status is changed by other replica
+	ErrScheduleRunStatusChanged = errors.New("schedule run status is changed")
+)
+
+//ScheduleRunStore is store of schedule run history
+type ScheduleRunStore interface {
+	//Create run, return ErrScheduleRunExists when run of the urn at the scheduled time already exists
+	Create(run *ScheduleRun) error
+	//Update run when its status is still the previous status, return ErrScheduleRunStatusChanged otherwise
+	Update(run *ScheduleRun, previous ScheduleRunStatus) error
+	//GetLatest get run with the latest scheduled time of the urn
+	GetLatest(urn string) (*ScheduleRun, error)
+	//GetByStatus get runs with the status
+	GetByStatus(status ScheduleRunStatus) ([]*ScheduleRun, error)
+	//GetByURN get runs of the urn ordered by the latest scheduled time
+	GetByURN(urn string) ([]*ScheduleRun, error)
+}
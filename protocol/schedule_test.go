@@ -0,0 +1,37 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule(t *testing.T) {
+	t.Run("RenderFilter", func(t *testing.T) {
+		t.Run("should render filter with the scheduled time", func(t *testing.T) {
+			schedule := &Schedule{Filter: "__PARTITION__ = '{{ .PreviousDate }}'"}
+
+			filter, err := schedule.RenderFilter(time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC))
+
+			assert.Nil(t, err)
+			assert.Equal(t, "__PARTITION__ = '2021-01-01'", filter)
+		})
+	})
+	t.Run("Validate", func(t *testing.T) {
+		t.Run("should return errors of invalid schedule", func(t *testing.T) {
+			errs := (&Schedule{
+				Cron:   "0 25 * * *",
+				Filter: "__PARTITION__ = '{{ .Unknown }}'",
+				Mode:   "partial",
+			}).Validate()
+
+			assert.Len(t, errs, 3)
+		})
+		t.Run("should return no error when schedule is valid", func(t *testing.T) {
+			errs := (&Schedule{Cron: "0 2 * * *", Filter: "__PARTITION__ = '{{ .PreviousDate }}'"}).Validate()
+
+			assert.Empty(t, errs)
+		})
+	})
+}
@@ -0,0 +1,29 @@
+package protocol
+
+import (
+	"errors"
+	"time"
+
+	"github.com/odpf/predator/protocol/meta"
+)
+
+//ErrSchemaSnapshotNotFound thrown when no schema snapshot found
+var ErrSchemaSnapshotNotFound = errors.New("schema snapshot not found")
+
+//SchemaSnapshot is schema of a table taken when the table is profiled
+type SchemaSnapshot struct {
+	ID        int
+	ProfileID string
+	URN       string
+	Columns   []*meta.ColumnSchema
+	CreatedAt time.Time
+}
+
+//SchemaStore is store of table schema snapshot
+type SchemaStore interface {
+	Store(snapshot *SchemaSnapshot) error
+	//GetByProfileID get schema snapshot taken by the profile, return ErrSchemaSnapshotNotFound when not found
+	GetByProfileID(profileID string) (*SchemaSnapshot, error)
+	//GetPrevious get the latest snapshot of the same urn taken before the snapshot, return ErrSchemaSnapshotNotFound when not found
+	GetPrevious(snapshot *SchemaSnapshot) (*SchemaSnapshot, error)
+}
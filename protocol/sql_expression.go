@@ -7,4 +7,5 @@ var ErrPartitionExpressionIsNotSupported = errors.New("partition expression is n
 //SQLExpressionFactory to generate SQL expression
 type SQLExpressionFactory interface {
 	CreatePartitionExpression(urn string) (string, error)
+	CreatePartitionFilterExpression(urn string, partitionIDs []string) (string, error)
 }
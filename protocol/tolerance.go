@@ -2,9 +2,15 @@ package protocol
 
 import (
 	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
 	"github.com/odpf/predator/protocol/metric"
 )
 
@@ -24,17 +30,364 @@ const (
 	ComparatorMoreThan Comparator = "more_than"
 	//ComparatorMoreThanEq metric >= value
 	ComparatorMoreThanEq Comparator = "more_than_eq"
+	//ComparatorEqual metric == value
+	ComparatorEqual Comparator = "equal"
+	//ComparatorNotEqual metric != value
+	ComparatorNotEqual Comparator = "not_equal"
+	//ComparatorBetween value <= metric <= upper
+	ComparatorBetween Comparator = "between"
+	//ComparatorOutside metric < value or metric > upper
+	ComparatorOutside Comparator = "outside"
+	//ComparatorPctChangeVsPrevious absolute percentage change of metric from the metric of previous profile <= value
+	ComparatorPctChangeVsPrevious Comparator = "pct_change_vs_previous"
+	//ComparatorAnyOf at least one of the groups pass, all rules of a group should pass
+	ComparatorAnyOf Comparator = "any_of"
 )
 
+//Comparators is supported comparators
+var Comparators = []Comparator{
+	ComparatorLessThan, ComparatorLessThanEq, ComparatorMoreThan, ComparatorMoreThanEq,
+	ComparatorEqual, ComparatorNotEqual, ComparatorBetween, ComparatorOutside,
+	ComparatorPctChangeVsPrevious, ComparatorAnyOf,
+}
+
+//IsValid whether the comparator is supported
+func (c Comparator) IsValid() bool {
+	for _, comparator := range Comparators {
+		if c == comparator {
+			return true
+		}
+	}
+	return false
+}
+
+//IsRange whether the comparator compare metric with a range from value to upper
+func (c Comparator) IsRange() bool {
+	return c == ComparatorBetween || c == ComparatorOutside
+}
+
+//Severity is how a failed tolerance affect the audit, only failure of error severity fail the audit
+type Severity string
+
+const (
+	//SeverityInfo failure is only reported
+	SeverityInfo Severity = "info"
+	//SeverityWarn failure is reported as warning
+	SeverityWarn Severity = "warn"
+	//SeverityError failure fail the audit
+	SeverityError Severity = "error"
+)
+
+//Severities is supported severities ordered from the lowest
+var Severities = []Severity{SeverityInfo, SeverityWarn, SeverityError}
+
+//IsValid whether the severity is supported
+func (s Severity) IsValid() bool {
+	for _, severity := range Severities {
+		if s == severity {
+			return true
+		}
+	}
+	return false
+}
+
+//OrDefault return error severity when the severity is not configured
+func (s Severity) OrDefault() Severity {
+	if s == "" {
+		return SeverityError
+	}
+	return s
+}
+
+//IsHigherThan whether the severity is higher than the other, not configured severity is treated as error
+func (s Severity) IsHigherThan(other Severity) bool {
+	return s.rank() > other.rank()
+}
+
+func (s Severity) rank() int {
+	for i, severity := range Severities {
+		if s.OrDefault() == severity {
+			return i
+		}
+	}
+	return -1
+}
+
 //ToleranceRule represents tolerance comparator and its value
+//Upper is only used by range comparator, Groups is only used by any_of comparator
 type ToleranceRule struct {
-	Comparator Comparator `json:"comparator"`
-	Value      float64    `json:"value"`
+	Comparator Comparator        `json:"comparator"`
+	Value      float64           `json:"value"`
+	Upper      float64           `json:"upper,omitempty"`
+	Groups     [][]ToleranceRule `json:"groups,omitempty"`
+}
+
+func (t ToleranceRule) String() string {
+	switch {
+	case t.Comparator.IsRange():
+		return fmt.Sprintf("%s %.2f AND %.2f", strings.ToUpper(string(t.Comparator)), t.Value, t.Upper)
+	case t.Comparator == ComparatorAnyOf:
+		var groupsInfo []string
+		for _, group := range t.Groups {
+			var rulesInfo []string
+			for _, rule := range group {
+				rulesInfo = append(rulesInfo, rule.String())
+			}
+			groupsInfo = append(groupsInfo, strings.Join(rulesInfo, ", "))
+		}
+		return fmt.Sprintf("%s (%s)", strings.ToUpper(string(t.Comparator)), strings.Join(groupsInfo, " | "))
+	default:
+		return fmt.Sprintf("%s %.2f", strings.ToUpper(string(t.Comparator)), t.Value)
+	}
+}
+
+//HasComparator whether any of the rules, including rules in groups of any_of rule, use the comparator
+func HasComparator(rules []ToleranceRule, comparator Comparator) bool {
+	for _, rule := range rules {
+		if rule.Comparator == comparator {
+			return true
+		}
+		for _, group := range rule.Groups {
+			if HasComparator(group, comparator) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//AnomalyRule is tolerance rule that compare metric value with the band expected from the metric history
+type AnomalyRule struct {
+	//Lookback is number of previous profiles used as baseline
+	Lookback int `json:"lookback" yaml:"lookback"`
+	//ZScore is number of standard deviations from the baseline mean that still considered as normal
+	ZScore float64 `json:"zscore" yaml:"zscore"`
+}
+
+//GroupOverride is tolerance rules used instead of rules of the metric on groups whose value match the group pattern
+//the group pattern is either exact group value, glob such as ID-* or regular expression enclosed in slashes such as /^(ID|SG)$/
+//both the tolerance rules and the anomaly rule of the metric are replaced, a matched group without anomaly rule in the override is not checked for anomaly
+type GroupOverride struct {
+	Group          string
+	ToleranceRules []ToleranceRule
+	Anomaly        *AnomalyRule
+
+	//compiled is compiled regular expression of the group pattern, nil when the pattern is not a valid regular expression
+	compiled *regexp.Regexp
+}
+
+//NewGroupOverride create group override, regular expression of the group pattern is compiled once here
+func NewGroupOverride(group string, toleranceRules []ToleranceRule, anomaly *AnomalyRule) *GroupOverride {
+	override := &GroupOverride{Group: group, ToleranceRules: toleranceRules, Anomaly: anomaly}
+	if override.IsRegex() {
+		override.compiled, _ = regexp.Compile(override.regex())
+	}
+	return override
+}
+
+//IsRegex whether the group pattern is regular expression
+func (g *GroupOverride) IsRegex() bool {
+	return len(g.Group) > 1 && strings.HasPrefix(g.Group, "/") && strings.HasSuffix(g.Group, "/")
+}
+
+//IsGlob whether the group pattern is glob
+func (g *GroupOverride) IsGlob() bool {
+	return !g.IsRegex() && strings.ContainsAny(g.Group, globMetaCharacters)
+}
+
+//Validate return error when the group pattern is not a valid glob or regular expression
+func (g *GroupOverride) Validate() error {
+	if g.IsRegex() {
+		_, err := regexp.Compile(g.regex())
+		return err
+	}
+	if g.IsGlob() {
+		_, err := path.Match(g.Group, "")
+		return err
+	}
+	return nil
+}
+
+//Matches whether the group value match the group pattern, invalid pattern match nothing
+func (g *GroupOverride) Matches(groupValue string) bool {
+	if g.IsRegex() {
+		re := g.compiled
+		if re == nil {
+			var err error
+			if re, err = regexp.Compile(g.regex()); err != nil {
+				return false
+			}
+		}
+		return re.MatchString(groupValue)
+	}
+	if g.IsGlob() {
+		matched, err := path.Match(g.Group, groupValue)
+		return err == nil && matched
+	}
+	return g.Group == groupValue
+}
+
+func (g *GroupOverride) regex() string {
+	return g.Group[1 : len(g.Group)-1]
+}
+
+const globMetaCharacters = "*?["
+
+//specificity exact group value is the most specific followed by glob then regular expression
+//glob with more characters other than wildcards is more specific
+func (g *GroupOverride) specificity() (int, int) {
+	switch {
+	case g.IsRegex():
+		return 0, 0
+	case g.IsGlob():
+		literal := len(g.Group)
+		for _, c := range globMetaCharacters {
+			literal -= strings.Count(g.Group, string(c))
+		}
+		return 1, literal
+	default:
+		return 2, 0
+	}
+}
+
+//isMoreSpecificThan whether the group pattern is more specific than the other
+func (g *GroupOverride) isMoreSpecificThan(other *GroupOverride) bool {
+	kind, literal := g.specificity()
+	otherKind, otherLiteral := other.specificity()
+	if kind != otherKind {
+		return kind > otherKind
+	}
+	return literal > otherLiteral
+}
+
+//FieldSelector select fields of the table that a field tolerance is applied to, selected field should match every configured criteria
+//FieldID is glob of root field ID such as *_id, Nested is glob of nested field ID such as items.*, Type is type of the field
+//root fields are selected when Nested is not configured
+type FieldSelector struct {
+	FieldID string
+	Nested  string
+	Type    meta.FieldType
+}
+
+//Validate return error when no criteria is configured, both root and nested fields are selected or the glob is invalid
+func (f *FieldSelector) Validate() error {
+	if f.FieldID == "" && f.Nested == "" && f.Type == "" {
+		return errors.New("field selector should configure at least one of fieldid, nested or type")
+	}
+	if f.FieldID != "" && f.Nested != "" {
+		return errors.New("field selector should not configure both fieldid and nested")
+	}
+	for _, pattern := range []string{f.FieldID, f.Nested} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%s is not a valid glob ,%w", pattern, err)
+		}
+	}
+	return nil
+}
+
+//Select get fields of the table that match the selector, ordered as the table schema
+func (f *FieldSelector) Select(tableSpec *meta.TableSpec) ([]*meta.FieldSpec, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	candidates := tableSpec.Fields
+	pattern := f.FieldID
+	if f.Nested != "" {
+		candidates = nil
+		for _, field := range tableSpec.FieldsFlatten() {
+			if field.Parent != nil {
+				candidates = append(candidates, field)
+			}
+		}
+		pattern = f.Nested
+	}
+
+	var fields []*meta.FieldSpec
+	for _, field := range candidates {
+		if pattern != "" {
+			if matched, _ := path.Match(pattern, field.ID()); !matched {
+				continue
+			}
+		}
+		if f.Type != "" && !strings.EqualFold(f.Type.String(), field.FieldType.String()) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func (f *FieldSelector) String() string {
+	var criteria []string
+	if f.FieldID != "" {
+		criteria = append(criteria, "fieldid: "+f.FieldID)
+	}
+	if f.Nested != "" {
+		criteria = append(criteria, "nested: "+f.Nested)
+	}
+	if f.Type != "" {
+		criteria = append(criteria, "type: "+f.Type.String())
+	}
+	return "[" + strings.Join(criteria, ", ") + "]"
+}
+
+//IsFieldSelector whether the field ID is a glob that should be used as field selector
+func IsFieldSelector(fieldID string) bool {
+	return strings.ContainsAny(fieldID, globMetaCharacters)
+}
+
+//ExpandFieldSelectors replace tolerance with field selector by a tolerance of each selected field
+//tolerance of a field configured explicitly wins over selected one of the same metric and condition, and the first selector wins when a field is selected more than once
+//selector that match no field is expanded to nothing
+func ExpandFieldSelectors(tableSpec *meta.TableSpec, tolerances []*Tolerance) ([]*Tolerance, error) {
+	type toleranceKey struct {
+		fieldID    string
+		metricName metric.Type
+		condition  string
+	}
+
+	configured := make(map[toleranceKey]bool)
+	for _, t := range tolerances {
+		if t.FieldSelector == nil && t.FieldID != "" {
+			configured[toleranceKey{fieldID: t.FieldID, metricName: t.MetricName, condition: t.Condition}] = true
+		}
+	}
+
+	var expanded []*Tolerance
+	for _, t := range tolerances {
+		if t.FieldSelector == nil {
+			expanded = append(expanded, t)
+			continue
+		}
+
+		fields, err := t.FieldSelector.Select(tableSpec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select fields of %s metric on table %s ,%w", t.MetricName, t.TableURN, err)
+		}
+		for _, field := range fields {
+			key := toleranceKey{fieldID: field.ID(), metricName: t.MetricName, condition: t.Condition}
+			if configured[key] {
+				continue
+			}
+			configured[key] = true
+
+			selected := *t
+			selected.FieldID = field.ID()
+			selected.FieldSelector = nil
+			expanded = append(expanded, &selected)
+		}
+	}
+	return expanded, nil
 }
 
 type ToleranceSpec struct {
 	URN        string
 	Tolerances []*Tolerance
+	//MaxBytesBilled is limit of bytes processed by a profile of the table, zero means the entity or default limit is used
+	MaxBytesBilled int64
+	//Schedule is configuration of periodic profile and audit of the table, nil when the table is not scheduled
+	Schedule *Schedule
 }
 
 //Tolerance is tolerance of quality metrics
@@ -46,8 +399,50 @@ type Tolerance struct {
 	Condition      string //condition for invalid_pct metric
 	Metadata       map[string]interface{}
 	ToleranceRules []ToleranceRule
+	Anomaly        *AnomalyRule
+	GroupOverrides []*GroupOverride
+	Severity       Severity
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	//FieldSelector select fields the tolerance is applied to instead of FieldID, nil when the tolerance is applied to FieldID
+	FieldSelector *FieldSelector
+}
+
+//GetGroupOverride get the most specific group override that match the group value, the first one is taken when equally specific
+//nil is returned when no override match
+func (t *Tolerance) GetGroupOverride(groupValue string) *GroupOverride {
+	var selected *GroupOverride
+	for _, override := range t.GroupOverrides {
+		if !override.Matches(groupValue) {
+			continue
+		}
+		if selected == nil || override.isMoreSpecificThan(selected) {
+			selected = override
+		}
+	}
+	return selected
+}
+
+//Identity get metadata that tells apart tolerances of the same metric on the same field and condition,
+//which are name of custom sql, quantile fraction, reference of orphan metric and timestamp field of freshness metric
+func (t *Tolerance) Identity() string {
+	switch t.MetricName {
+	case metric.CustomSQL:
+		if custom, ok := metric.GetCustom(t.Metadata); ok {
+			return custom.Name
+		}
+	case metric.Quantile:
+		if quantile, ok := metric.GetQuantile(t.Metadata); ok {
+			return strconv.FormatFloat(quantile, 'g', -1, 64)
+		}
+	case metric.OrphanPct:
+		if reference, ok := metric.GetReference(t.Metadata); ok {
+			return fmt.Sprintf("%s(%s)=(%s)", reference.URN, strings.Join(reference.Fields, ","), strings.Join(reference.ReferenceFields, ","))
+		}
+	case metric.FreshnessLagSeconds:
+		return metric.GetTimestampField(t.Metadata)
+	}
+	return ""
 }
 
 var (
@@ -99,3 +494,21 @@ type SpecValidator interface {
 	//Validate content of data quality spec should return error ErrSpecInvalid when field or table not found
 	Validate(spec *ToleranceSpec) error
 }
+
+//SpecSuggestion tolerance spec suggested to a table
+type SpecSuggestion struct {
+	URN string
+	//Spec is nil until the exploratory profile is completed
+	Spec *ToleranceSpec
+	//Profile is exploratory profile run to suggest the spec, nil when the spec is suggested from metric history
+	Profile *job.Profile
+}
+
+//SpecSuggester suggest tolerance spec of a table that has no spec yet
+type SpecSuggester interface {
+	//Suggest suggest tolerance spec from metric history of the table, when there is no history an exploratory profile
+	//of data matching the filter is enqueued and the spec is suggested once the profile is completed
+	Suggest(urn string, filter string) (*SpecSuggestion, error)
+	//GetSuggestion get suggestion of the exploratory profile
+	GetSuggestion(profileID string) (*SpecSuggestion, error)
+}
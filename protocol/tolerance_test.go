@@ -0,0 +1,167 @@
+package protocol
+
+import (
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTolerance(t *testing.T) {
+	t.Run("GetGroupOverride", func(t *testing.T) {
+		exact := &GroupOverride{Group: "ID"}
+		shortGlob := &GroupOverride{Group: "I*"}
+		longGlob := &GroupOverride{Group: "IN-*"}
+		regex := &GroupOverride{Group: "/^(ID|IN-.*|SG)$/"}
+		tolerance := &Tolerance{GroupOverrides: []*GroupOverride{regex, shortGlob, longGlob, exact}}
+
+		t.Run("should return exact override over glob and regex", func(t *testing.T) {
+			assert.Equal(t, exact, tolerance.GetGroupOverride("ID"))
+		})
+		t.Run("should return glob with more literal characters", func(t *testing.T) {
+			assert.Equal(t, longGlob, tolerance.GetGroupOverride("IN-JKT"))
+		})
+		t.Run("should return regex when only regex match", func(t *testing.T) {
+			assert.Equal(t, regex, tolerance.GetGroupOverride("SG"))
+		})
+		t.Run("should return nil when no override match", func(t *testing.T) {
+			assert.Nil(t, tolerance.GetGroupOverride("MY"))
+		})
+	})
+	t.Run("GroupOverride", func(t *testing.T) {
+		t.Run("Validate", func(t *testing.T) {
+			t.Run("should return nil when pattern is valid", func(t *testing.T) {
+				for _, group := range []string{"ID", "2021-01-*", "/^ID$/"} {
+					assert.Nil(t, (&GroupOverride{Group: group}).Validate())
+				}
+			})
+			t.Run("should return error when glob or regex is invalid", func(t *testing.T) {
+				assert.NotNil(t, (&GroupOverride{Group: "[ID"}).Validate())
+				assert.NotNil(t, (&GroupOverride{Group: "/(ID/"}).Validate())
+			})
+		})
+		t.Run("Matches", func(t *testing.T) {
+			t.Run("should match with regex compiled when the override is created", func(t *testing.T) {
+				override := NewGroupOverride("/^(ID|SG)$/", nil, nil)
+
+				assert.NotNil(t, override.compiled)
+				assert.True(t, override.Matches("SG"))
+				assert.False(t, override.Matches("MY"))
+			})
+			t.Run("should match nothing when regex is invalid", func(t *testing.T) {
+				assert.False(t, NewGroupOverride("/(ID/", nil, nil).Matches("(ID"))
+			})
+		})
+	})
+	t.Run("FieldSelector", func(t *testing.T) {
+		item := &meta.FieldSpec{Name: "items", FieldType: meta.FieldTypeRecord}
+		itemID := &meta.FieldSpec{Name: "item_id", FieldType: meta.FieldTypeString, Parent: item}
+		quantity := &meta.FieldSpec{Name: "quantity", FieldType: meta.FieldTypeInteger, Parent: item}
+		item.Fields = []*meta.FieldSpec{itemID, quantity}
+		userID := &meta.FieldSpec{Name: "user_id", FieldType: meta.FieldTypeString}
+		amount := &meta.FieldSpec{Name: "amount", FieldType: meta.FieldTypeInteger}
+		createdAt := &meta.FieldSpec{Name: "created_at", FieldType: meta.FieldTypeTimestamp}
+		tableSpec := &meta.TableSpec{Fields: []*meta.FieldSpec{userID, amount, createdAt, item}}
+
+		t.Run("Select", func(t *testing.T) {
+			t.Run("should return root fields matching field ID glob", func(t *testing.T) {
+				fields, err := (&FieldSelector{FieldID: "*_id"}).Select(tableSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, []*meta.FieldSpec{userID}, fields)
+			})
+			t.Run("should return root fields of the type", func(t *testing.T) {
+				fields, err := (&FieldSelector{Type: "timestamp"}).Select(tableSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, []*meta.FieldSpec{createdAt}, fields)
+			})
+			t.Run("should return nested fields matching nested glob and type", func(t *testing.T) {
+				fields, err := (&FieldSelector{Nested: "items.*", Type: meta.FieldTypeInteger}).Select(tableSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, []*meta.FieldSpec{quantity}, fields)
+			})
+			t.Run("should return error when selector is invalid", func(t *testing.T) {
+				for _, selector := range []*FieldSelector{{}, {FieldID: "*_id", Nested: "items.*"}, {FieldID: "[_id"}} {
+					_, err := selector.Select(tableSpec)
+
+					assert.NotNil(t, err)
+				}
+			})
+		})
+		t.Run("ExpandFieldSelectors", func(t *testing.T) {
+			t.Run("should expand selector to selected fields and keep explicitly configured field", func(t *testing.T) {
+				explicit := &Tolerance{
+					FieldID:        "items.item_id",
+					MetricName:     metric.NullnessPct,
+					ToleranceRules: []ToleranceRule{{Comparator: ComparatorLessThanEq, Value: 10}},
+				}
+				tolerances := []*Tolerance{
+					{
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []ToleranceRule{{Comparator: ComparatorLessThanEq, Value: 0}},
+						FieldSelector:  &FieldSelector{Type: meta.FieldTypeString},
+					},
+					{
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []ToleranceRule{{Comparator: ComparatorLessThanEq, Value: 1}},
+						FieldSelector:  &FieldSelector{Nested: "items.*"},
+					},
+					explicit,
+				}
+
+				expected := []*Tolerance{
+					{
+						FieldID:        "user_id",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []ToleranceRule{{Comparator: ComparatorLessThanEq, Value: 0}},
+					},
+					{
+						FieldID:        "items.quantity",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []ToleranceRule{{Comparator: ComparatorLessThanEq, Value: 1}},
+					},
+					explicit,
+				}
+
+				result, err := ExpandFieldSelectors(tableSpec, tolerances)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should keep selected field when explicitly configured field has other condition", func(t *testing.T) {
+				explicit := &Tolerance{
+					FieldID:        "user_id",
+					MetricName:     metric.InvalidPct,
+					Condition:      "user_id is null",
+					ToleranceRules: []ToleranceRule{{Comparator: ComparatorLessThanEq, Value: 10}},
+				}
+				tolerances := []*Tolerance{
+					{
+						MetricName:     metric.InvalidPct,
+						Condition:      "length(user_id) > 36",
+						ToleranceRules: []ToleranceRule{{Comparator: ComparatorLessThanEq, Value: 0}},
+						FieldSelector:  &FieldSelector{FieldID: "user_id"},
+					},
+					explicit,
+				}
+
+				expected := []*Tolerance{
+					{
+						FieldID:        "user_id",
+						MetricName:     metric.InvalidPct,
+						Condition:      "length(user_id) > 36",
+						ToleranceRules: []ToleranceRule{{Comparator: ComparatorLessThanEq, Value: 0}},
+					},
+					explicit,
+				}
+
+				result, err := ExpandFieldSelectors(tableSpec, tolerances)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+		})
+	})
+}
@@ -2,12 +2,16 @@ package protocol
 
 import (
 	"errors"
+	"fmt"
 	"strings"
+
+	"github.com/odpf/predator/protocol/metric"
 )
 
 //UploadFactory creator of UploadTask
 type UploadFactory interface {
-	Create(gitRepo *GitInfo) (Task, error)
+	//Create create upload task of the git repository, the task of dry run report the changes as UploadDiff without writing
+	Create(gitRepo *GitInfo, dryRun bool) (Task, error)
 }
 
 //ErrUploadSpecValidation thrown when upload failed caused by invalid spec, contains list of invalid spec errors
@@ -34,3 +38,65 @@ func IsUploadSpecValidationError(err error) bool {
 type Task interface {
 	Run() (interface{}, error)
 }
+
+//ErrUploadRemovalLimit thrown when upload would remove more specs than the configured limit
+type ErrUploadRemovalLimit struct {
+	RemovedCount  int
+	TotalCount    int
+	MaxRemovalPct int
+}
+
+func (e *ErrUploadRemovalLimit) Error() string {
+	return fmt.Sprintf("upload is refused, %d of %d specs would be removed, more than the limit of %d%%", e.RemovedCount, e.TotalCount, e.MaxRemovalPct)
+}
+
+func IsUploadRemovalLimitError(err error) bool {
+	var e *ErrUploadRemovalLimit
+	return errors.As(err, &e)
+}
+
+//ChangeType is kind of change of a spec
+type ChangeType string
+
+const (
+	//ChangeAdded tolerance only exist on the uploaded spec
+	ChangeAdded ChangeType = "added"
+	//ChangeRemoved tolerance only exist on the stored spec
+	ChangeRemoved ChangeType = "removed"
+	//ChangeModified attribute of a tolerance or the spec is different
+	ChangeModified ChangeType = "modified"
+)
+
+//SpecChange is a semantic change of a tolerance or of a table level setting of the spec
+type SpecChange struct {
+	Type ChangeType
+	//FieldID is field id or field selector of the tolerance, empty for table level metric and table level setting
+	FieldID string
+	//MetricName is metric of the tolerance, empty for table level setting
+	MetricName metric.Type
+	//Condition is condition of the tolerance, set on invalid_pct metric
+	Condition string
+	//Identity is identifying metadata of the tolerance, such as name of custom sql metric or quantile fraction
+	Identity string
+	//Attribute is the modified attribute, such as tolerance or schedule, empty when the whole tolerance is added or removed
+	Attribute string
+	Before    interface{}
+	After     interface{}
+}
+
+//SpecDiff is changes of a table spec between the stored spec and the uploaded spec
+type SpecDiff struct {
+	URN     string
+	Changes []*SpecChange
+}
+
+//UploadDiff is per table changes of an upload, returned by upload task of dry run
+type UploadDiff struct {
+	Added   []string
+	Removed []string
+	//Changed is specs that exist on both source and destination with at least a change
+	Changed        []*SpecDiff
+	UnchangedCount int
+	//RemovalLimitExceeded whether the upload would be refused because it remove more specs than the limit
+	RemovalLimitExceeded bool
+}
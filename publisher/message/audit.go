@@ -81,21 +81,47 @@ func auditValueProto(auditJob *job.Audit, group *predator.Group, reports []*prot
 func generateAuditResultsProto(reports []*protocol.AuditReport) []*predator.Result {
 	var results []*predator.Result
 	for _, a := range reports {
-		var rules []*predator.ToleranceRule
-		for _, rule := range a.ToleranceRules {
-			rules = append(rules, &predator.ToleranceRule{
-				Name:  string(rule.Comparator),
-				Value: rule.Value,
-			})
-		}
 		results = append(results, &predator.Result{
-			Name:      a.MetricName.String(),
-			FieldId:   a.FieldID,
-			Value:     a.MetricValue,
-			Rules:     rules,
-			PassFlag:  a.PassFlag,
-			Condition: a.Condition,
+			Name:         a.MetricName.String(),
+			FieldId:      a.FieldID,
+			Value:        a.MetricValue,
+			Rules:        generateToleranceRulesProto(a.ToleranceRules),
+			PassFlag:     a.PassFlag,
+			Condition:    a.Condition,
+			Severity:     string(a.Severity),
+			ExpectedBand: generateExpectedBandProto(a.ExpectedBand),
 		})
 	}
 	return results
 }
+
+func generateExpectedBandProto(band *protocol.ExpectedBand) *predator.ExpectedBand {
+	if band == nil {
+		return nil
+	}
+	return &predator.ExpectedBand{
+		Lower:        band.Lower,
+		Upper:        band.Upper,
+		BaselineSize: int32(band.BaselineSize),
+	}
+}
+
+//generateToleranceRulesProto map tolerance rules with upper bound of range rules and groups of any_of rule
+func generateToleranceRulesProto(toleranceRules []protocol.ToleranceRule) []*predator.ToleranceRule {
+	var rules []*predator.ToleranceRule
+	for _, rule := range toleranceRules {
+		var groups []*predator.ToleranceRuleGroup
+		for _, group := range rule.Groups {
+			groups = append(groups, &predator.ToleranceRuleGroup{
+				Rules: generateToleranceRulesProto(group),
+			})
+		}
+		rules = append(rules, &predator.ToleranceRule{
+			Name:   string(rule.Comparator),
+			Value:  rule.Value,
+			Upper:  rule.Upper,
+			Groups: groups,
+		})
+	}
+	return rules
+}
@@ -29,6 +29,7 @@ var auditReports = []*protocol.AuditReport{
 			},
 		},
 		PassFlag: true,
+		Severity: protocol.SeverityError,
 	},
 	{
 		AuditID:     auditID,
@@ -42,7 +43,9 @@ var auditReports = []*protocol.AuditReport{
 				Value:      1.0,
 			},
 		},
-		PassFlag: true,
+		ExpectedBand: &protocol.ExpectedBand{Lower: 90, Upper: 110, BaselineSize: 7},
+		PassFlag:     false,
+		Severity:     protocol.SeverityWarn,
 	},
 	{
 		AuditID:     auditID,
@@ -158,6 +161,7 @@ func TestAuditValueBuilder(t *testing.T) {
 							},
 						},
 						PassFlag: true,
+						Severity: "error",
 					},
 					{
 						Name:  "row_count",
@@ -168,7 +172,9 @@ func TestAuditValueBuilder(t *testing.T) {
 								Value: 1.0,
 							},
 						},
-						PassFlag: true,
+						ExpectedBand: &predator.ExpectedBand{Lower: 90, Upper: 110, BaselineSize: 7},
+						PassFlag:     false,
+						Severity:     "warn",
 					},
 					{
 						Name:  metric.InvalidPct.String(),
@@ -193,3 +199,25 @@ func TestAuditValueBuilder(t *testing.T) {
 		})
 	})
 }
+
+func TestGenerateToleranceRulesProto(t *testing.T) {
+	t.Run("should map upper bound of range rule and groups of any_of rule", func(t *testing.T) {
+		rules := []protocol.ToleranceRule{
+			{Comparator: protocol.ComparatorBetween, Value: 5, Upper: 10},
+			{Comparator: protocol.ComparatorAnyOf, Groups: [][]protocol.ToleranceRule{
+				{{Comparator: protocol.ComparatorEqual, Value: 0}},
+				{{Comparator: protocol.ComparatorPctChangeVsPrevious, Value: 20}, {Comparator: protocol.ComparatorMoreThan, Value: 1000}},
+			}},
+		}
+
+		expected := []*predator.ToleranceRule{
+			{Name: "between", Value: 5, Upper: 10},
+			{Name: "any_of", Groups: []*predator.ToleranceRuleGroup{
+				{Rules: []*predator.ToleranceRule{{Name: "equal", Value: 0}}},
+				{Rules: []*predator.ToleranceRule{{Name: "pct_change_vs_previous", Value: 20}, {Name: "more_than", Value: 1000}}},
+			}},
+		}
+
+		assert.Equal(t, expected, generateToleranceRulesProto(rules))
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"sort"
+	"strings"
 )
 
 type ProfileKeyProtoBuilder struct {
@@ -94,12 +95,37 @@ func generateProtoTableMetrics(metrics []*metric.Metric) []*predator.Metric {
 			Name:      tableMetric.Type.String(),
 			Value:     tableMetric.Value,
 			Condition: tableMetric.Condition,
+			Metadata:  generateProtoMetadata(tableMetric.Metadata),
 		}
 		protoTableMetrics = append(protoTableMetrics, protoTableMetric)
 	}
 	return protoTableMetrics
 }
 
+//generateProtoMetadata convert metric metadata to string values, list is joined with comma
+func generateProtoMetadata(metadata map[string]interface{}) map[string]string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	protoMetadata := make(map[string]string)
+	for key, value := range metadata {
+		switch v := value.(type) {
+		case []string:
+			protoMetadata[key] = strings.Join(v, ",")
+		case []interface{}:
+			var items []string
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			protoMetadata[key] = strings.Join(items, ",")
+		default:
+			protoMetadata[key] = fmt.Sprint(v)
+		}
+	}
+	return protoMetadata
+}
+
 func generateProtoColumnMetric(fieldID string, tableSpec *meta.TableSpec) (*predator.ColumnMetric, error) {
 	fieldSpec, err := tableSpec.GetFieldSpecByID(fieldID)
 	if err != nil {
@@ -133,6 +159,7 @@ func generateProtoColumnMetricsMap(columnMetrics []*metric.Metric, tableSpec *me
 			Name:      fm.Type.String(),
 			Value:     fm.Value,
 			Condition: fm.Condition,
+			Metadata:  generateProtoMetadata(fm.Metadata),
 		}
 		protoColumnMetricsMap[fm.FieldID].Metrics = append(protoColumnMetricsMap[fm.FieldID].Metrics, metricItem)
 	}
@@ -303,3 +303,22 @@ func TestProfileValueProtoBuilder(t *testing.T) {
 		})
 	})
 }
+
+func TestGenerateProtoMetadata(t *testing.T) {
+	t.Run("should convert metadata values to string", func(t *testing.T) {
+		metadata := map[string]interface{}{
+			metric.QuantileFraction: 0.99,
+			metric.UniqueFields:     []interface{}{"field1", "field2"},
+		}
+
+		expected := map[string]string{
+			metric.QuantileFraction: "0.99",
+			metric.UniqueFields:     "field1,field2",
+		}
+
+		assert.Equal(t, expected, generateProtoMetadata(metadata))
+	})
+	t.Run("should return nil when metadata is empty", func(t *testing.T) {
+		assert.Nil(t, generateProtoMetadata(nil))
+	})
+}
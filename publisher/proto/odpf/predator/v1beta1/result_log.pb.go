@@ -182,6 +182,10 @@ type Result struct {
 	Rules     []*ToleranceRule `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
 	PassFlag  bool             `protobuf:"varint,5,opt,name=pass_flag,json=passFlag,proto3" json:"pass_flag,omitempty"`
 	Condition string           `protobuf:"bytes,6,opt,name=condition,proto3" json:"condition,omitempty"`
+	// not in odpf/proton yet
+	Severity string `protobuf:"bytes,7,opt,name=severity,proto3" json:"severity,omitempty"`
+	// range expected by anomaly rule, not in odpf/proton yet
+	ExpectedBand *ExpectedBand `protobuf:"bytes,8,opt,name=expected_band,json=expectedBand,proto3" json:"expected_band,omitempty"`
 }
 
 func (x *Result) Reset() {
@@ -258,6 +262,84 @@ func (x *Result) GetCondition() string {
 	return ""
 }
 
+func (x *Result) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Result) GetExpectedBand() *ExpectedBand {
+	if x != nil {
+		return x.ExpectedBand
+	}
+	return nil
+}
+
+// not in odpf/proton yet
+type ExpectedBand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lower        float64 `protobuf:"fixed64,1,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper        float64 `protobuf:"fixed64,2,opt,name=upper,proto3" json:"upper,omitempty"`
+	BaselineSize int32   `protobuf:"varint,3,opt,name=baseline_size,json=baselineSize,proto3" json:"baseline_size,omitempty"`
+}
+
+func (x *ExpectedBand) Reset() {
+	*x = ExpectedBand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_odpf_predator_v1beta1_result_log_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpectedBand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpectedBand) ProtoMessage() {}
+
+func (x *ExpectedBand) ProtoReflect() protoreflect.Message {
+	mi := &file_odpf_predator_v1beta1_result_log_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpectedBand.ProtoReflect.Descriptor instead.
+func (*ExpectedBand) Descriptor() ([]byte, []int) {
+	return file_odpf_predator_v1beta1_result_log_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ExpectedBand) GetLower() float64 {
+	if x != nil {
+		return x.Lower
+	}
+	return 0
+}
+
+func (x *ExpectedBand) GetUpper() float64 {
+	if x != nil {
+		return x.Upper
+	}
+	return 0
+}
+
+func (x *ExpectedBand) GetBaselineSize() int32 {
+	if x != nil {
+		return x.BaselineSize
+	}
+	return 0
+}
+
 type ToleranceRule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -265,12 +347,16 @@ type ToleranceRule struct {
 
 	Name  string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Value float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	// upper bound of between and outside rules, not in odpf/proton yet
+	Upper float64 `protobuf:"fixed64,3,opt,name=upper,proto3" json:"upper,omitempty"`
+	// rule groups of any_of rule, not in odpf/proton yet
+	Groups []*ToleranceRuleGroup `protobuf:"bytes,4,rep,name=groups,proto3" json:"groups,omitempty"`
 }
 
 func (x *ToleranceRule) Reset() {
 	*x = ToleranceRule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_odpf_predator_v1beta1_result_log_proto_msgTypes[3]
+		mi := &file_odpf_predator_v1beta1_result_log_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -283,7 +369,7 @@ func (x *ToleranceRule) String() string {
 func (*ToleranceRule) ProtoMessage() {}
 
 func (x *ToleranceRule) ProtoReflect() protoreflect.Message {
-	mi := &file_odpf_predator_v1beta1_result_log_proto_msgTypes[3]
+	mi := &file_odpf_predator_v1beta1_result_log_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,7 +382,7 @@ func (x *ToleranceRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ToleranceRule.ProtoReflect.Descriptor instead.
 func (*ToleranceRule) Descriptor() ([]byte, []int) {
-	return file_odpf_predator_v1beta1_result_log_proto_rawDescGZIP(), []int{3}
+	return file_odpf_predator_v1beta1_result_log_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ToleranceRule) GetName() string {
@@ -313,6 +399,68 @@ func (x *ToleranceRule) GetValue() float64 {
 	return 0
 }
 
+func (x *ToleranceRule) GetUpper() float64 {
+	if x != nil {
+		return x.Upper
+	}
+	return 0
+}
+
+func (x *ToleranceRule) GetGroups() []*ToleranceRuleGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// not in odpf/proton yet
+type ToleranceRuleGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*ToleranceRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *ToleranceRuleGroup) Reset() {
+	*x = ToleranceRuleGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_odpf_predator_v1beta1_result_log_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ToleranceRuleGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToleranceRuleGroup) ProtoMessage() {}
+
+func (x *ToleranceRuleGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_odpf_predator_v1beta1_result_log_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToleranceRuleGroup.ProtoReflect.Descriptor instead.
+func (*ToleranceRuleGroup) Descriptor() ([]byte, []int) {
+	return file_odpf_predator_v1beta1_result_log_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ToleranceRuleGroup) GetRules() []*ToleranceRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
 var File_odpf_predator_v1beta1_result_log_proto protoreflect.FileDescriptor
 
 var file_odpf_predator_v1beta1_result_log_proto_rawDesc = []byte{
@@ -350,7 +498,7 @@ var file_odpf_predator_v1beta1_result_log_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x63, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
 	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xc4, 0x01, 0x0a, 0x06,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xaa, 0x02, 0x0a, 0x06,
 	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x66, 0x69,
 	0x65, 0x6c, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x66, 0x69,
@@ -363,16 +511,39 @@ var file_odpf_predator_v1beta1_result_log_proto_rawDesc = []byte{
 	0x66, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73,
 	0x46, 0x6c, 0x61, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
 	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0x39, 0x0a, 0x0d, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x52,
-	0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x4c, 0x0a,
-	0x17, 0x69, 0x6f, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x2e,
-	0x70, 0x72, 0x65, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x42, 0x0e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x4c, 0x6f, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x1f, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x64, 0x70, 0x66, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x65, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x48,
+	0x0a, 0x0d, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x6e, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x70, 0x72, 0x65,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x45, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x42, 0x61, 0x6e, 0x64, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x42, 0x61, 0x6e, 0x64, 0x22, 0x5f, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x42, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x75,
+	0x70, 0x70, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x62, 0x61, 0x73,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x92, 0x01, 0x0a, 0x0d, 0x54, 0x6f,
+	0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x06, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6f, 0x64,
+	0x70, 0x66, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x62, 0x65,
+	0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x75, 0x6c,
+	0x65, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x50,
+	0x0a, 0x12, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x3a, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x54, 0x6f, 0x6c, 0x65,
+	0x72, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x42, 0x4c, 0x0a, 0x17, 0x69, 0x6f, 0x2e, 0x6f, 0x64, 0x70, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x65, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x42, 0x0e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x4c, 0x6f, 0x67, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x1f, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x64, 0x70, 0x66, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x65, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -387,27 +558,32 @@ func file_odpf_predator_v1beta1_result_log_proto_rawDescGZIP() []byte {
 	return file_odpf_predator_v1beta1_result_log_proto_rawDescData
 }
 
-var file_odpf_predator_v1beta1_result_log_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_odpf_predator_v1beta1_result_log_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_odpf_predator_v1beta1_result_log_proto_goTypes = []interface{}{
 	(*ResultLogKey)(nil),          // 0: odpf.predator.v1beta1.ResultLogKey
 	(*ResultLogMessage)(nil),      // 1: odpf.predator.v1beta1.ResultLogMessage
 	(*Result)(nil),                // 2: odpf.predator.v1beta1.Result
-	(*ToleranceRule)(nil),         // 3: odpf.predator.v1beta1.ToleranceRule
-	(*Group)(nil),                 // 4: odpf.predator.v1beta1.Group
-	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*ExpectedBand)(nil),          // 3: odpf.predator.v1beta1.ExpectedBand
+	(*ToleranceRule)(nil),         // 4: odpf.predator.v1beta1.ToleranceRule
+	(*ToleranceRuleGroup)(nil),    // 5: odpf.predator.v1beta1.ToleranceRuleGroup
+	(*Group)(nil),                 // 6: odpf.predator.v1beta1.Group
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
 }
 var file_odpf_predator_v1beta1_result_log_proto_depIdxs = []int32{
-	4, // 0: odpf.predator.v1beta1.ResultLogKey.group:type_name -> odpf.predator.v1beta1.Group
-	5, // 1: odpf.predator.v1beta1.ResultLogKey.event_timestamp:type_name -> google.protobuf.Timestamp
-	4, // 2: odpf.predator.v1beta1.ResultLogMessage.group:type_name -> odpf.predator.v1beta1.Group
+	6, // 0: odpf.predator.v1beta1.ResultLogKey.group:type_name -> odpf.predator.v1beta1.Group
+	7, // 1: odpf.predator.v1beta1.ResultLogKey.event_timestamp:type_name -> google.protobuf.Timestamp
+	6, // 2: odpf.predator.v1beta1.ResultLogMessage.group:type_name -> odpf.predator.v1beta1.Group
 	2, // 3: odpf.predator.v1beta1.ResultLogMessage.results:type_name -> odpf.predator.v1beta1.Result
-	5, // 4: odpf.predator.v1beta1.ResultLogMessage.event_timestamp:type_name -> google.protobuf.Timestamp
-	3, // 5: odpf.predator.v1beta1.Result.rules:type_name -> odpf.predator.v1beta1.ToleranceRule
-	6, // [6:6] is the sub-list for method output_type
-	6, // [6:6] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	7, // 4: odpf.predator.v1beta1.ResultLogMessage.event_timestamp:type_name -> google.protobuf.Timestamp
+	4, // 5: odpf.predator.v1beta1.Result.rules:type_name -> odpf.predator.v1beta1.ToleranceRule
+	3, // 6: odpf.predator.v1beta1.Result.expected_band:type_name -> odpf.predator.v1beta1.ExpectedBand
+	5, // 7: odpf.predator.v1beta1.ToleranceRule.groups:type_name -> odpf.predator.v1beta1.ToleranceRuleGroup
+	4, // 8: odpf.predator.v1beta1.ToleranceRuleGroup.rules:type_name -> odpf.predator.v1beta1.ToleranceRule
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_odpf_predator_v1beta1_result_log_proto_init() }
@@ -454,6 +630,18 @@ func file_odpf_predator_v1beta1_result_log_proto_init() {
 			}
 		}
 		file_odpf_predator_v1beta1_result_log_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExpectedBand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_odpf_predator_v1beta1_result_log_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ToleranceRule); i {
 			case 0:
 				return &v.state
@@ -465,6 +653,18 @@ func file_odpf_predator_v1beta1_result_log_proto_init() {
 				return nil
 			}
 		}
+		file_odpf_predator_v1beta1_result_log_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ToleranceRuleGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -472,7 +672,7 @@ func file_odpf_predator_v1beta1_result_log_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_odpf_predator_v1beta1_result_log_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -12,6 +12,7 @@ import (
 	"google.golang.org/api/iterator"
 	"log"
 	"os"
+	"strings"
 )
 
 var logger = log.New(os.Stdout, "INFO: ", log.Lshortfile|log.LstdFlags)
@@ -38,8 +39,10 @@ func NewBigqueryExecutor(client bqiface.Client,
 }
 
 //Run executes query and log the bigquery job progress and information to a storage
-func (qe *BigqueryExecutor) Run(profile *job.Profile, query string, queryType job.QueryType) ([]protocol.Row, error) {
+//the bigquery job is stopped waiting when the entry context is done, the job itself is cancelled by Cancel
+func (qe *BigqueryExecutor) Run(entry protocol.Entry, profile *job.Profile, query string, queryType job.QueryType) ([]protocol.Row, error) {
 	var jobID string
+	ctx := entry.Context()
 
 	label, err := protocol.ParseLabel(profile.URN)
 	if err != nil {
@@ -52,17 +55,7 @@ func (qe *BigqueryExecutor) Run(profile *job.Profile, query string, queryType jo
 		return nil, err
 	}
 
-	switch queryType {
-	case job.FieldLevelQuery:
-		tag := stats.KV{K: "query_type", V: "column_metric"}
-		statsClient = statsClient.WithTags(tag)
-	case job.TableLevelQuery:
-		tag := stats.KV{K: "query_type", V: "table_metric"}
-		statsClient = statsClient.WithTags(tag)
-	case job.StatisticalQuery:
-		tag := stats.KV{K: "query_type", V: "metadata"}
-		statsClient = statsClient.WithTags(tag)
-	}
+	statsClient = withQueryTypeTag(statsClient, queryType)
 
 	defer func() {
 		if err != nil {
@@ -83,9 +76,10 @@ func (qe *BigqueryExecutor) Run(profile *job.Profile, query string, queryType jo
 	q := qe.client.Query(query)
 	queryConfig := q.QueryConfig()
 	queryConfig.Priority = bigquery.BatchPriority
+	queryConfig.MaxBytesBilled = entry.MaxBytesBilled()
 	q.SetQueryConfig(queryConfig)
 
-	queryJob, err := q.Run(context.Background())
+	queryJob, err := q.Run(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -106,18 +100,19 @@ func (qe *BigqueryExecutor) Run(profile *job.Profile, query string, queryType jo
 	bigqueryJob := &protocol.BigqueryJob{
 		ProfileID: profile.ID,
 		BqID:      jobID,
+		Location:  queryJob.Location(),
 	}
 	err = qe.store.Store(bigqueryJob)
 	if err != nil {
 		return nil, err
 	}
 
-	it, err := queryJob.Read(context.Background())
+	it, err := queryJob.Read(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	jobStatus, err := queryJob.Status(context.Background())
+	jobStatus, err := queryJob.Status(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -169,3 +164,70 @@ func (qe *BigqueryExecutor) Run(profile *job.Profile, query string, queryType jo
 
 	return rows, err
 }
+
+//Estimate dry run the query to get the bytes processed by the query
+func (qe *BigqueryExecutor) Estimate(entry protocol.Entry, profile *job.Profile, query string) (int64, error) {
+	q := qe.client.Query(query)
+	queryConfig := q.QueryConfig()
+	queryConfig.DryRun = true
+	q.SetQueryConfig(queryConfig)
+
+	queryJob, err := q.Run(entry.Context())
+	if err != nil {
+		return 0, fmt.Errorf("unable to dry run query of profile %s ,%w", profile.ID, err)
+	}
+
+	jobStatus := queryJob.LastStatus()
+	if jobStatus == nil || jobStatus.Statistics == nil {
+		return 0, fmt.Errorf("dry run of profile %s query returns no statistics", profile.ID)
+	}
+	return jobStatus.Statistics.TotalBytesProcessed, nil
+}
+
+//Cancel cancel all bigquery jobs started by the profile, every job is tried even when cancelling another job failed
+func (qe *BigqueryExecutor) Cancel(profile *job.Profile) error {
+	bigqueryJobs, err := qe.store.GetByProfileID(profile.ID)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, bigqueryJob := range bigqueryJobs {
+		if err := qe.cancelJob(bigqueryJob); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", bigqueryJob.BqID, err))
+			continue
+		}
+
+		msg := xlog.Format("cancelled bigquery job", xlog.NewValue("bq_job_id", bigqueryJob.BqID), xlog.NewValue("profile_id", profile.ID))
+		logger.Println(msg)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("unable to cancel %d of %d bigquery jobs of profile %s: %s",
+			len(failures), len(bigqueryJobs), profile.ID, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (qe *BigqueryExecutor) cancelJob(bigqueryJob *protocol.BigqueryJob) error {
+	queryJob, err := qe.client.JobFromIDLocation(context.Background(), bigqueryJob.BqID, bigqueryJob.Location)
+	if err != nil {
+		return err
+	}
+	return queryJob.Cancel(context.Background())
+}
+
+func withQueryTypeTag(statsClient stats.Client, queryType job.QueryType) stats.Client {
+	switch queryType {
+	case job.FieldLevelQuery:
+		tag := stats.KV{K: "query_type", V: "column_metric"}
+		return statsClient.WithTags(tag)
+	case job.TableLevelQuery:
+		tag := stats.KV{K: "query_type", V: "table_metric"}
+		return statsClient.WithTags(tag)
+	case job.StatisticalQuery:
+		tag := stats.KV{K: "query_type", V: "metadata"}
+		return statsClient.WithTags(tag)
+	}
+	return statsClient
+}
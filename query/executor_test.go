@@ -11,6 +11,7 @@ import (
 	"github.com/odpf/predator/protocol/job"
 	"github.com/odpf/predator/stats"
 	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
 	"testing"
 )
 
@@ -18,6 +19,7 @@ func TestBigqueryExecutor(t *testing.T) {
 	t.Run("Run", func(t *testing.T) {
 		t.Run("should return result", func(t *testing.T) {
 			profileID := "job-abcd"
+			entry := protocol.NewEntry().WithMaxBytesBilled(1000)
 			bqJobID := "bq-1234"
 
 			profile := &job.Profile{
@@ -34,6 +36,7 @@ func TestBigqueryExecutor(t *testing.T) {
 			profileBQ := &protocol.BigqueryJob{
 				ProfileID: profileID,
 				BqID:      bqJobID,
+				Location:  "asia-southeast1",
 			}
 
 			queryConfig := bqiface.QueryConfig{
@@ -44,7 +47,8 @@ func TestBigqueryExecutor(t *testing.T) {
 
 			modifiedQueryConfig := bqiface.QueryConfig{
 				QueryConfig: bigquery.QueryConfig{
-					Priority: bigquery.BatchPriority,
+					Priority:       bigquery.BatchPriority,
+					MaxBytesBilled: 1000,
 				},
 			}
 
@@ -103,9 +107,10 @@ func TestBigqueryExecutor(t *testing.T) {
 
 			bqJob := &mock.JobMock{}
 			bqJob.On("ID").Return(bqJobID)
-			bqJob.On("Read", context.Background()).Return(rowIterator, nil)
+			bqJob.On("Location").Return("asia-southeast1")
+			bqJob.On("Read", entry.Context()).Return(rowIterator, nil)
 
-			bqJob.On("Status", context.Background()).Return(jobStatus, nil)
+			bqJob.On("Status", entry.Context()).Return(jobStatus, nil)
 
 			query := &mock.QueryMock{}
 			query.On("QueryConfig").Return(queryConfig)
@@ -113,7 +118,7 @@ func TestBigqueryExecutor(t *testing.T) {
 			query.On("JobIDConfig").Return(&bigquery.JobIDConfig{
 				JobID: bqJobID,
 			})
-			query.On("Run", context.Background()).Return(bqJob, nil)
+			query.On("Run", entry.Context()).Return(bqJob, nil)
 
 			client := &mock.BQClientMock{}
 
@@ -138,7 +143,7 @@ func TestBigqueryExecutor(t *testing.T) {
 			statsClientBuilder.On("Build").Return(statsClient, nil)
 
 			queryExecutor := NewBigqueryExecutor(client, bigqueryJobStore, profileStore, statsClientBuilder)
-			result, err := queryExecutor.Run(profile, queryStr, queryType)
+			result, err := queryExecutor.Run(entry, profile, queryStr, queryType)
 
 			assert.Equal(t, expected, result)
 			assert.Nil(t, err)
@@ -202,10 +207,141 @@ func TestBigqueryExecutor(t *testing.T) {
 			statsClientBuilder.On("Build").Return(statsClient, nil)
 
 			queryExecutor := NewBigqueryExecutor(client, bigqueryJobStore, profileStore, statsClientBuilder)
-			result, err := queryExecutor.Run(profile, queryStr, queryType)
+			result, err := queryExecutor.Run(protocol.NewEntry(), profile, queryStr, queryType)
 
 			assert.Error(t, err)
 			assert.Nil(t, result)
 		})
 	})
+	t.Run("Estimate", func(t *testing.T) {
+		profile := &job.Profile{
+			ID:  "job-abcd",
+			URN: "a.b.c",
+		}
+		queryStr := "SELECT count(*) FROM `a.b.c`"
+		t.Run("should dry run query and return total bytes processed", func(t *testing.T) {
+			dryRunQueryConfig := bqiface.QueryConfig{
+				QueryConfig: bigquery.QueryConfig{
+					DryRun: true,
+				},
+			}
+
+			bqJob := &mock.JobMock{}
+			defer bqJob.AssertExpectations(t)
+			bqJob.On("LastStatus").Return(&bigquery.JobStatus{
+				Statistics: &bigquery.JobStatistics{TotalBytesProcessed: 1024},
+			})
+
+			query := &mock.QueryMock{}
+			defer query.AssertExpectations(t)
+			query.On("QueryConfig").Return(bqiface.QueryConfig{})
+			query.On("SetQueryConfig", dryRunQueryConfig)
+			query.On("Run", context.Background()).Return(bqJob, nil)
+
+			client := &mock.BQClientMock{}
+			defer client.AssertExpectations(t)
+			client.On("Query", queryStr).Return(query)
+
+			queryExecutor := NewBigqueryExecutor(client, nil, nil, nil)
+			estimatedBytes, err := queryExecutor.Estimate(protocol.NewEntry(), profile, queryStr)
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(1024), estimatedBytes)
+		})
+		t.Run("should return error when dry run failed", func(t *testing.T) {
+			someError := errors.New("invalid query")
+
+			query := &mock.QueryMock{}
+			query.On("QueryConfig").Return(bqiface.QueryConfig{})
+			query.On("SetQueryConfig", testifyMock.Anything)
+			query.On("Run", context.Background()).Return((*mock.JobMock)(nil), someError)
+
+			client := &mock.BQClientMock{}
+			client.On("Query", queryStr).Return(query)
+
+			queryExecutor := NewBigqueryExecutor(client, nil, nil, nil)
+			_, err := queryExecutor.Estimate(protocol.NewEntry(), profile, queryStr)
+
+			assert.ErrorIs(t, err, someError)
+		})
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		profile := &job.Profile{
+			ID:  "job-abcd",
+			URN: "a.b.c",
+		}
+		bigqueryJobs := []*protocol.BigqueryJob{
+			{ProfileID: "job-abcd", BqID: "bq-1", Location: "US"},
+			{ProfileID: "job-abcd", BqID: "bq-2", Location: "asia-southeast1"},
+		}
+		t.Run("should cancel bigquery jobs of the profile", func(t *testing.T) {
+			firstJob := &mock.JobMock{}
+			defer firstJob.AssertExpectations(t)
+			firstJob.On("Cancel", context.Background()).Return(nil)
+
+			secondJob := &mock.JobMock{}
+			defer secondJob.AssertExpectations(t)
+			secondJob.On("Cancel", context.Background()).Return(nil)
+
+			client := &mock.BQClientMock{}
+			defer client.AssertExpectations(t)
+			client.On("JobFromIDLocation", context.Background(), "bq-1", "US").Return(firstJob, nil)
+			client.On("JobFromIDLocation", context.Background(), "bq-2", "asia-southeast1").Return(secondJob, nil)
+
+			bigqueryJobStore := mock.NewBigqueryJobStore()
+			defer bigqueryJobStore.AssertExpectations(t)
+			bigqueryJobStore.On("GetByProfileID", "job-abcd").Return(bigqueryJobs, nil)
+
+			queryExecutor := NewBigqueryExecutor(client, bigqueryJobStore, nil, nil)
+			err := queryExecutor.Cancel(profile)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should cancel remaining bigquery jobs and return error when cancel bigquery job failed", func(t *testing.T) {
+			someError := errors.New("API error")
+
+			firstJob := &mock.JobMock{}
+			defer firstJob.AssertExpectations(t)
+			firstJob.On("Cancel", context.Background()).Return(someError)
+
+			secondJob := &mock.JobMock{}
+			defer secondJob.AssertExpectations(t)
+			secondJob.On("Cancel", context.Background()).Return(nil)
+
+			client := &mock.BQClientMock{}
+			defer client.AssertExpectations(t)
+			client.On("JobFromIDLocation", context.Background(), "bq-1", "US").Return(firstJob, nil)
+			client.On("JobFromIDLocation", context.Background(), "bq-2", "asia-southeast1").Return(secondJob, nil)
+
+			bigqueryJobStore := mock.NewBigqueryJobStore()
+			defer bigqueryJobStore.AssertExpectations(t)
+			bigqueryJobStore.On("GetByProfileID", "job-abcd").Return(bigqueryJobs, nil)
+
+			queryExecutor := NewBigqueryExecutor(client, bigqueryJobStore, nil, nil)
+			err := queryExecutor.Cancel(profile)
+
+			assert.EqualError(t, err, "unable to cancel 1 of 2 bigquery jobs of profile job-abcd: bq-1: API error")
+		})
+		t.Run("should return error when bigquery job is not found", func(t *testing.T) {
+			someError := errors.New("job not found")
+
+			secondJob := &mock.JobMock{}
+			defer secondJob.AssertExpectations(t)
+			secondJob.On("Cancel", context.Background()).Return(nil)
+
+			client := &mock.BQClientMock{}
+			defer client.AssertExpectations(t)
+			client.On("JobFromIDLocation", context.Background(), "bq-1", "US").Return((*mock.JobMock)(nil), someError)
+			client.On("JobFromIDLocation", context.Background(), "bq-2", "asia-southeast1").Return(secondJob, nil)
+
+			bigqueryJobStore := mock.NewBigqueryJobStore()
+			defer bigqueryJobStore.AssertExpectations(t)
+			bigqueryJobStore.On("GetByProfileID", "job-abcd").Return(bigqueryJobs, nil)
+
+			queryExecutor := NewBigqueryExecutor(client, bigqueryJobStore, nil, nil)
+			err := queryExecutor.Cancel(profile)
+
+			assert.EqualError(t, err, "unable to cancel 1 of 2 bigquery jobs of profile job-abcd: bq-1: job not found")
+		})
+	})
 }
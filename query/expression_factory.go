@@ -5,6 +5,14 @@ import (
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/meta"
 	"github.com/odpf/predator/protocol/query"
+	"github.com/odpf/predator/util"
+	"strings"
+	"time"
+)
+
+const (
+	nullPartitionID          = "__NULL__"
+	unpartitionedPartitionID = "__UNPARTITIONED__"
 )
 
 //SQLExpressionFactory create custom SQL expression
@@ -45,7 +53,13 @@ func NewSQLExpressionFactory(metadataStore protocol.MetadataStore) *SQLExpressio
 //
 //* timepartitioning YEAR
 //  DATE_TRUNC(DATE(ts_column, "UTC"),YEAR)
+//
+//Tables outside bigquery are not supported
 func (p *SQLExpressionFactory) CreatePartitionExpression(urn string) (string, error) {
+	if meta.ParseWarehouse(urn) != meta.WarehouseBigQuery {
+		return "", protocol.ErrPartitionExpressionIsNotSupported
+	}
+
 	tableSpec, err := p.metadataStore.GetMetadata(urn)
 	if err != nil {
 		return "", err
@@ -136,3 +150,75 @@ func (p *SQLExpressionFactory) CreatePartitionExpression(urn string) (string, er
 
 	return "", nil
 }
+
+//CreatePartitionFilterExpression create sql filter expression that only match records of the given partitions
+//partition ID follows the format of bigquery INFORMATION_SCHEMA.PARTITIONS, such as 20210101 on DAY time partitioning
+//Here is the example of generated expression of DATE field partitioned by DAY
+//  (field_date IN (DATE "2021-01-01",DATE "2021-01-02") OR field_date IS NULL)
+//
+//When there is no partition ID, the generated expression does not match any record
+func (p *SQLExpressionFactory) CreatePartitionFilterExpression(urn string, partitionIDs []string) (string, error) {
+	if len(partitionIDs) == 0 {
+		return "FALSE", nil
+	}
+
+	partitionExpression, err := p.CreatePartitionExpression(urn)
+	if err != nil {
+		return "", err
+	}
+
+	tableSpec, err := p.metadataStore.GetMetadata(urn)
+	if err != nil {
+		return "", err
+	}
+
+	var literals []string
+	includeNull := false
+	for _, partitionID := range partitionIDs {
+		if partitionID == nullPartitionID || partitionID == unpartitionedPartitionID {
+			includeNull = true
+			continue
+		}
+		literal, err := createPartitionLiteral(partitionID, tableSpec.TimePartitioningType)
+		if err != nil {
+			return "", err
+		}
+		literals = append(literals, literal)
+	}
+
+	var conditions []string
+	if len(literals) > 0 {
+		conditions = append(conditions, fmt.Sprintf("%s IN (%s)", partitionExpression, strings.Join(literals, ",")))
+	}
+	if includeNull {
+		conditions = append(conditions, fmt.Sprintf("%s IS NULL", partitionExpression))
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(conditions, " OR ")), nil
+}
+
+func createPartitionLiteral(partitionID string, timePartitioning meta.TimePartitioning) (string, error) {
+	var layout string
+	switch timePartitioning {
+	case meta.HourPartitioning:
+		layout = "2006010215"
+	case meta.DayPartitioning:
+		layout = "20060102"
+	case meta.MonthPartitioning:
+		layout = "200601"
+	case meta.YearPartitioning:
+		layout = "2006"
+	default:
+		return "", fmt.Errorf("time partitioning %s is not supported", timePartitioning)
+	}
+
+	partitionTime, err := time.Parse(layout, partitionID)
+	if err != nil {
+		return "", fmt.Errorf("invalid partition ID %s of %s time partitioning, %w", partitionID, timePartitioning, err)
+	}
+
+	if timePartitioning == meta.HourPartitioning {
+		return fmt.Sprintf("TIMESTAMP %s", util.DoubleQuote(partitionTime.Format("2006-01-02 15:04:05 UTC"))), nil
+	}
+	return fmt.Sprintf("DATE %s", util.DoubleQuote(partitionTime.Format("2006-01-02"))), nil
+}
@@ -2,6 +2,7 @@ package query
 
 import (
 	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/meta"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -158,3 +159,136 @@ func TestSQLExpressionFactory_CreatePartitionExpression(t *testing.T) {
 		})
 	}
 }
+
+func TestSQLExpressionFactory_CreatePartitionExpressionOutsideBigquery(t *testing.T) {
+	t.Run("should return ErrPartitionExpressionIsNotSupported when table is not stored in bigquery", func(t *testing.T) {
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		factory := NewSQLExpressionFactory(metadataStore)
+		expression, err := factory.CreatePartitionExpression("postgres:mart.public.orders")
+
+		assert.Empty(t, expression)
+		assert.Equal(t, protocol.ErrPartitionExpressionIsNotSupported, err)
+	})
+}
+
+func TestSQLExpressionFactory_CreatePartitionFilterExpression(t *testing.T) {
+	suites := []struct {
+		Description  string
+		TableSpec    *meta.TableSpec
+		URN          string
+		PartitionIDs []string
+		Expression   string
+		ExpectError  bool
+	}{
+		{
+			Description: "should return expression with DATE literals when DAY time partitioning",
+			TableSpec: &meta.TableSpec{
+				TimePartitioningType: meta.DayPartitioning,
+				PartitionField:       "field_date",
+				Fields: []*meta.FieldSpec{
+					{
+						Name:      "field_date",
+						FieldType: meta.FieldTypeDate,
+					},
+				},
+			},
+			URN:          "project.dataset.table",
+			PartitionIDs: []string{"20210101", "20210102"},
+			Expression:   "(field_date IN (DATE \"2021-01-01\",DATE \"2021-01-02\"))",
+		},
+		{
+			Description: "should return expression with TIMESTAMP literals when HOUR time partitioning",
+			TableSpec: &meta.TableSpec{
+				TimePartitioningType: meta.HourPartitioning,
+				PartitionField:       "field_timestamp",
+				Fields: []*meta.FieldSpec{
+					{
+						Name:      "field_timestamp",
+						FieldType: meta.FieldTypeTimestamp,
+					},
+				},
+			},
+			URN:          "project.dataset.table",
+			PartitionIDs: []string{"2021010110"},
+			Expression:   "(TIMESTAMP_TRUNC(field_timestamp,HOUR,\"UTC\") IN (TIMESTAMP \"2021-01-01 10:00:00 UTC\"))",
+		},
+		{
+			Description: "should return expression with first day of month when MONTH time partitioning",
+			TableSpec: &meta.TableSpec{
+				TimePartitioningType: meta.MonthPartitioning,
+				PartitionField:       "field_timestamp",
+				Fields: []*meta.FieldSpec{
+					{
+						Name:      "field_timestamp",
+						FieldType: meta.FieldTypeTimestamp,
+					},
+				},
+			},
+			URN:          "project.dataset.table",
+			PartitionIDs: []string{"202101"},
+			Expression:   "(DATE_TRUNC(DATE(field_timestamp,\"UTC\"),MONTH) IN (DATE \"2021-01-01\"))",
+		},
+		{
+			Description: "should include null partition",
+			TableSpec: &meta.TableSpec{
+				TimePartitioningType: meta.DayPartitioning,
+				PartitionField:       "field_date",
+				Fields: []*meta.FieldSpec{
+					{
+						Name:      "field_date",
+						FieldType: meta.FieldTypeDate,
+					},
+				},
+			},
+			URN:          "project.dataset.table",
+			PartitionIDs: []string{"20210101", "__NULL__"},
+			Expression:   "(field_date IN (DATE \"2021-01-01\") OR field_date IS NULL)",
+		},
+		{
+			Description:  "should return expression that match no record when there is no partition",
+			URN:          "project.dataset.table",
+			PartitionIDs: nil,
+			Expression:   "FALSE",
+		},
+		{
+			Description: "should return error when partition ID is invalid",
+			TableSpec: &meta.TableSpec{
+				TimePartitioningType: meta.DayPartitioning,
+				PartitionField:       "field_date",
+				Fields: []*meta.FieldSpec{
+					{
+						Name:      "field_date",
+						FieldType: meta.FieldTypeDate,
+					},
+				},
+			},
+			URN:          "project.dataset.table",
+			PartitionIDs: []string{"2021010110"},
+			ExpectError:  true,
+		},
+	}
+
+	for _, test := range suites {
+		t.Run(test.Description, func(t *testing.T) {
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			if test.TableSpec != nil {
+				metadataStore.On("GetMetadata", test.URN).Return(test.TableSpec, nil)
+			}
+
+			factory := NewSQLExpressionFactory(metadataStore)
+			expression, err := factory.CreatePartitionFilterExpression(test.URN, test.PartitionIDs)
+
+			if test.ExpectError {
+				assert.Empty(t, expression)
+				assert.Error(t, err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, test.Expression, expression)
+			}
+		})
+	}
+}
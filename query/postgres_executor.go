@@ -0,0 +1,161 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/xlog"
+	"github.com/odpf/predator/stats"
+)
+
+//PostgresExecutor to execute query in postgresql database
+type PostgresExecutor struct {
+	db                 *sql.DB
+	database           string
+	profileStore       protocol.ProfileStore
+	statsClientBuilder stats.ClientBuilder
+}
+
+//NewPostgresExecutor constructor of PostgresExecutor, database is name of the database db is connected to
+func NewPostgresExecutor(db *sql.DB,
+	database string,
+	profileStore protocol.ProfileStore,
+	statsFactory stats.ClientBuilder) *PostgresExecutor {
+	return &PostgresExecutor{
+		db:                 db,
+		database:           database,
+		profileStore:       profileStore,
+		statsClientBuilder: statsFactory,
+	}
+}
+
+//Run executes query and convert the result to the same value types of bigquery result
+//the query is cancelled when the entry context is done, profile of other database than the connected database is rejected
+func (pe *PostgresExecutor) Run(entry protocol.Entry, profile *job.Profile, query string, queryType job.QueryType) ([]protocol.Row, error) {
+	if err := protocol.CheckPostgresDatabase(profile.URN, pe.database); err != nil {
+		return nil, err
+	}
+
+	label, err := protocol.ParseLabel(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+
+	clientBuilder := pe.statsClientBuilder.WithURN(label)
+	statsClient, err := clientBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+	statsClient = withQueryTypeTag(statsClient, queryType)
+
+	defer func() {
+		if err != nil {
+			msg := xlog.Format(fmt.Errorf("postgres query to fetch %s metrics failed %w", queryType.String(), err).Error(), xlog.NewValue("profile_id", profile.ID))
+
+			profile.Message = msg
+			if err = pe.profileStore.Update(profile); err != nil {
+				e := fmt.Errorf("unable to write log message %w", err)
+				logger.Println(e)
+			}
+			failedStatMetric := stats.Metric("profile.postgres.query.failed.count")
+			statsClient.Increment(failedStatMetric)
+		}
+		completedStatMetric := stats.Metric("profile.postgres.query.completed.count")
+		statsClient.Increment(completedStatMetric)
+	}()
+
+	msg := xlog.Format(fmt.Sprintf("started postgres query to fetch %s metrics", queryType.String()), xlog.NewValue("profile_id", profile.ID))
+	logger.Println(msg)
+
+	profile.Message = msg
+	if err = pe.profileStore.Update(profile); err != nil {
+		return nil, fmt.Errorf("unable to write log message %w", err)
+	}
+
+	startTime := time.Now()
+	rows, err := pe.queryRows(entry.Context(), query)
+	if err != nil {
+		return nil, err
+	}
+	statsClient.DurationUntilNow("profile.postgres.query.time", startTime)
+
+	msg = xlog.Format(fmt.Sprintf("postgres query to fetch %s metrics finished", queryType.String()), xlog.NewValue("profile_id", profile.ID))
+	logger.Println(msg)
+
+	profile.Message = msg
+	if err = pe.profileStore.Update(profile); err != nil {
+		return nil, fmt.Errorf("unable to write log message %w", err)
+	}
+
+	return rows, nil
+}
+
+func (pe *PostgresExecutor) queryRows(ctx context.Context, query string) ([]protocol.Row, error) {
+	sqlRows, err := pe.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlRows.Close()
+
+	columnTypes, err := sqlRows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []protocol.Row
+	for sqlRows.Next() {
+		values := make([]interface{}, len(columnTypes))
+		pointers := make([]interface{}, len(columnTypes))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := sqlRows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := make(protocol.Row)
+		for i, columnType := range columnTypes {
+			value, err := convertPostgresValue(columnType.DatabaseTypeName(), values[i])
+			if err != nil {
+				return nil, err
+			}
+			row[columnType.Name()] = value
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, sqlRows.Err()
+}
+
+//convertPostgresValue convert value returned by postgres driver to the type returned by bigquery client for the same data type
+func convertPostgresValue(databaseType string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case time.Time:
+		if databaseType == "DATE" {
+			return civil.DateOf(v), nil
+		}
+		return v, nil
+	case []byte:
+		switch databaseType {
+		case "BYTEA":
+			return v, nil
+		case "NUMERIC":
+			r, ok := new(big.Rat).SetString(string(v))
+			if !ok {
+				return nil, fmt.Errorf("unable to parse numeric value %s", string(v))
+			}
+			return r, nil
+		case "TIME":
+			return civil.ParseTime(string(v))
+		}
+		return string(v), nil
+	}
+	return value, nil
+}
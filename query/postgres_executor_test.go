@@ -0,0 +1,169 @@
+package query
+
+import (
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresExecutor(t *testing.T) {
+	t.Run("Run", func(t *testing.T) {
+		profile := &job.Profile{
+			ID:  "job-abcd",
+			URN: "postgres:mart.public.orders",
+		}
+		label := &protocol.Label{
+			Project: "mart",
+			Dataset: "public",
+			Table:   "orders",
+		}
+		tags := []stats.KV{{K: "query_type", V: "table_metric"}}
+
+		t.Run("should return result", func(t *testing.T) {
+			db, err := sql.Open("sqlite3", ":memory:")
+			assert.Nil(t, err)
+			defer db.Close()
+			db.SetMaxOpenConns(1)
+
+			_, err = db.Exec("CREATE TABLE orders (created_date DATE, amount FLOAT)")
+			assert.Nil(t, err)
+			_, err = db.Exec("INSERT INTO orders VALUES ('2021-01-01', 10.5), ('2021-01-01', 20.0), ('2021-01-02', 1.0)")
+			assert.Nil(t, err)
+
+			statsClient := mock.NewDummyStats()
+			defer statsClient.AssertExpectations(t)
+			statsClient.On("WithTags", tags).Return(statsClient)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			profileStore := mock.NewProfileStoreStub()
+
+			queryStr := "SELECT created_date AS dt, count(1) AS ct FROM orders GROUP BY created_date ORDER BY created_date"
+			executor := NewPostgresExecutor(db, "mart", profileStore, statsClientBuilder)
+			result, err := executor.Run(protocol.NewEntry(), profile, queryStr, job.TableLevelQuery)
+
+			expected := []protocol.Row{
+				{
+					"dt": civil.Date{Year: 2021, Month: 1, Day: 1},
+					"ct": int64(2),
+				},
+				{
+					"dt": civil.Date{Year: 2021, Month: 1, Day: 2},
+					"ct": int64(1),
+				},
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should return error when query failed", func(t *testing.T) {
+			db, err := sql.Open("sqlite3", ":memory:")
+			assert.Nil(t, err)
+			defer db.Close()
+
+			statsClient := mock.NewDummyStats()
+			defer statsClient.AssertExpectations(t)
+			statsClient.On("WithTags", tags).Return(statsClient)
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+			statsClientBuilder.On("WithURN", label).Return(statsClientBuilder)
+			statsClientBuilder.On("Build").Return(statsClient, nil)
+
+			profileStore := mock.NewProfileStoreStub()
+
+			executor := NewPostgresExecutor(db, "mart", profileStore, statsClientBuilder)
+			result, err := executor.Run(protocol.NewEntry(), profile, "SELECT count(1) FROM unknown_table", job.TableLevelQuery)
+
+			assert.Nil(t, result)
+			assert.Error(t, err)
+		})
+		t.Run("should return ErrWarehouseNotConfigured without running query when table is on other database", func(t *testing.T) {
+			db, err := sql.Open("sqlite3", ":memory:")
+			assert.Nil(t, err)
+			defer db.Close()
+
+			statsClientBuilder := mock.NewStatBuilder()
+			defer statsClientBuilder.AssertExpectations(t)
+
+			otherDatabaseProfile := &job.Profile{ID: "job-abcd", URN: "postgres:staging.public.orders"}
+
+			executor := NewPostgresExecutor(db, "mart", mock.NewProfileStoreStub(), statsClientBuilder)
+			result, err := executor.Run(protocol.NewEntry(), otherDatabaseProfile, "SELECT 1", job.TableLevelQuery)
+
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, protocol.ErrWarehouseNotConfigured)
+		})
+	})
+	t.Run("convertPostgresValue", func(t *testing.T) {
+		timestamp := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+		testCases := []struct {
+			description  string
+			databaseType string
+			value        interface{}
+			expected     interface{}
+		}{
+			{
+				description:  "should convert date to civil.Date",
+				databaseType: "DATE",
+				value:        timestamp,
+				expected:     civil.Date{Year: 2021, Month: 1, Day: 1},
+			},
+			{
+				description:  "should keep timestamp",
+				databaseType: "TIMESTAMPTZ",
+				value:        timestamp,
+				expected:     timestamp,
+			},
+			{
+				description:  "should convert numeric to big.Rat",
+				databaseType: "NUMERIC",
+				value:        []byte("10.25"),
+				expected:     big.NewRat(41, 4),
+			},
+			{
+				description:  "should convert time to civil.Time",
+				databaseType: "TIME",
+				value:        []byte("10:15:00"),
+				expected:     civil.Time{Hour: 10, Minute: 15},
+			},
+			{
+				description:  "should keep bytea",
+				databaseType: "BYTEA",
+				value:        []byte("abc"),
+				expected:     []byte("abc"),
+			},
+			{
+				description:  "should convert other bytes to string",
+				databaseType: "UUID",
+				value:        []byte("b4a7ab5c-8ee2-4f3c-9a3e-4a4ff1a1a0b1"),
+				expected:     "b4a7ab5c-8ee2-4f3c-9a3e-4a4ff1a1a0b1",
+			},
+			{
+				description:  "should keep integer",
+				databaseType: "INT8",
+				value:        int64(10),
+				expected:     int64(10),
+			},
+		}
+		for _, test := range testCases {
+			t.Run(test.description, func(t *testing.T) {
+				result, err := convertPostgresValue(test.databaseType, test.value)
+
+				assert.Nil(t, err)
+				assert.Equal(t, test.expected, result)
+			})
+		}
+	})
+}
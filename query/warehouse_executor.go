@@ -0,0 +1,58 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+)
+
+//WarehouseExecutor execute query using executor of the warehouse where the profiled table stored
+type WarehouseExecutor struct {
+	executors map[meta.Warehouse]protocol.QueryExecutor
+}
+
+//NewWarehouseExecutor create WarehouseExecutor
+func NewWarehouseExecutor(executors map[meta.Warehouse]protocol.QueryExecutor) *WarehouseExecutor {
+	return &WarehouseExecutor{executors: executors}
+}
+
+//Run executes query on the warehouse of the profile URN
+func (w *WarehouseExecutor) Run(entry protocol.Entry, profile *job.Profile, query string, queryType job.QueryType) ([]protocol.Row, error) {
+	warehouse := meta.ParseWarehouse(profile.URN)
+	executor, ok := w.executors[warehouse]
+	if !ok {
+		return nil, fmt.Errorf("unable to run query of %s on %s ,%w", profile.URN, warehouse, protocol.ErrWarehouseNotConfigured)
+	}
+	return executor.Run(entry, profile, query, queryType)
+}
+
+//Estimate estimate bytes processed by the query on the warehouse that support query estimation
+//zero is returned for warehouse that does not support it
+func (w *WarehouseExecutor) Estimate(entry protocol.Entry, profile *job.Profile, query string) (int64, error) {
+	warehouse := meta.ParseWarehouse(profile.URN)
+	executor, ok := w.executors[warehouse]
+	if !ok {
+		return 0, fmt.Errorf("unable to estimate query of %s on %s ,%w", profile.URN, warehouse, protocol.ErrWarehouseNotConfigured)
+	}
+	estimator, ok := executor.(protocol.QueryEstimator)
+	if !ok {
+		return 0, nil
+	}
+	return estimator.Estimate(entry, profile, query)
+}
+
+//Cancel cancel queries of the profile on the warehouse that support query cancellation
+func (w *WarehouseExecutor) Cancel(profile *job.Profile) error {
+	warehouse := meta.ParseWarehouse(profile.URN)
+	executor, ok := w.executors[warehouse]
+	if !ok {
+		return nil
+	}
+	canceller, ok := executor.(protocol.QueryCanceller)
+	if !ok {
+		return nil
+	}
+	return canceller.Cancel(profile)
+}
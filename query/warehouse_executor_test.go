@@ -0,0 +1,116 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarehouseExecutor(t *testing.T) {
+	t.Run("Run", func(t *testing.T) {
+		t.Run("should run query with executor of the urn warehouse", func(t *testing.T) {
+			profile := &job.Profile{ID: "job-abcd", URN: "postgres:mart.public.orders"}
+			queryStr := `SELECT count(1) AS ct FROM "public"."orders" WHERE TRUE`
+			rows := []protocol.Row{{"ct": int64(10)}}
+
+			bigqueryExecutor := mock.NewQueryExecutor()
+			defer bigqueryExecutor.AssertExpectations(t)
+
+			postgresExecutor := mock.NewQueryExecutor()
+			defer postgresExecutor.AssertExpectations(t)
+			postgresExecutor.On("Run", profile, queryStr, job.StatisticalQuery).Return(rows, nil)
+
+			executor := NewWarehouseExecutor(map[meta.Warehouse]protocol.QueryExecutor{
+				meta.WarehouseBigQuery: bigqueryExecutor,
+				meta.WarehousePostgres: postgresExecutor,
+			})
+			result, err := executor.Run(protocol.NewEntry(), profile, queryStr, job.StatisticalQuery)
+
+			assert.Nil(t, err)
+			assert.Equal(t, rows, result)
+		})
+		t.Run("should return ErrWarehouseNotConfigured when warehouse has no executor", func(t *testing.T) {
+			profile := &job.Profile{ID: "job-abcd", URN: "postgres:mart.public.orders"}
+
+			executor := NewWarehouseExecutor(map[meta.Warehouse]protocol.QueryExecutor{
+				meta.WarehouseBigQuery: mock.NewQueryExecutor(),
+			})
+			result, err := executor.Run(protocol.NewEntry(), profile, "SELECT 1", job.StatisticalQuery)
+
+			assert.Nil(t, result)
+			assert.ErrorIs(t, err, protocol.ErrWarehouseNotConfigured)
+		})
+	})
+	t.Run("Estimate", func(t *testing.T) {
+		t.Run("should estimate query with estimator of the urn warehouse", func(t *testing.T) {
+			profile := &job.Profile{ID: "job-abcd", URN: "a.b.c"}
+
+			queryEstimator := mock.NewQueryEstimator()
+			defer queryEstimator.AssertExpectations(t)
+			queryEstimator.On("Estimate", profile, "SELECT 1").Return(int64(100), nil)
+
+			executor := NewWarehouseExecutor(map[meta.Warehouse]protocol.QueryExecutor{
+				meta.WarehouseBigQuery: &estimatingExecutor{QueryExecutor: mock.NewQueryExecutor(), QueryEstimator: queryEstimator},
+			})
+			estimatedBytes, err := executor.Estimate(protocol.NewEntry(), profile, "SELECT 1")
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(100), estimatedBytes)
+		})
+		t.Run("should return zero when executor does not support estimation", func(t *testing.T) {
+			profile := &job.Profile{ID: "job-abcd", URN: "postgres:mart.public.orders"}
+
+			executor := NewWarehouseExecutor(map[meta.Warehouse]protocol.QueryExecutor{
+				meta.WarehousePostgres: mock.NewQueryExecutor(),
+			})
+			estimatedBytes, err := executor.Estimate(protocol.NewEntry(), profile, "SELECT 1")
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(0), estimatedBytes)
+		})
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		t.Run("should cancel queries with canceller of the urn warehouse", func(t *testing.T) {
+			profile := &job.Profile{ID: "job-abcd", URN: "a.b.c"}
+
+			bigqueryExecutor := &cancellableExecutor{QueryExecutor: mock.NewQueryExecutor()}
+
+			executor := NewWarehouseExecutor(map[meta.Warehouse]protocol.QueryExecutor{
+				meta.WarehouseBigQuery: bigqueryExecutor,
+			})
+			err := executor.Cancel(profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, []*job.Profile{profile}, bigqueryExecutor.cancelled)
+		})
+		t.Run("should do nothing when executor does not support cancellation", func(t *testing.T) {
+			profile := &job.Profile{ID: "job-abcd", URN: "postgres:mart.public.orders"}
+
+			executor := NewWarehouseExecutor(map[meta.Warehouse]protocol.QueryExecutor{
+				meta.WarehousePostgres: mock.NewQueryExecutor(),
+			})
+			err := executor.Cancel(profile)
+
+			assert.Nil(t, err)
+		})
+	})
+}
+
+type cancellableExecutor struct {
+	protocol.QueryExecutor
+	cancelled []*job.Profile
+}
+
+func (c *cancellableExecutor) Cancel(profile *job.Profile) error {
+	c.cancelled = append(c.cancelled, profile)
+	return nil
+}
+
+type estimatingExecutor struct {
+	protocol.QueryExecutor
+	protocol.QueryEstimator
+}
@@ -0,0 +1,100 @@
+package reconciliation
+
+import (
+	"math"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
+)
+
+const maxDiffPct = 100.0
+
+type metricKey struct {
+	metricType metric.Type
+	fieldID    string
+	groupValue string
+}
+
+func keyOf(m *metric.Metric) metricKey {
+	return metricKey{metricType: m.Type, fieldID: m.FieldID, groupValue: m.GroupValue}
+}
+
+//compare create audit report of relative difference of each metric and group, metric that only found on one of the tables is compared with zero
+func compare(audit *job.Audit, reconciliation *job.Reconciliation, sourceMetrics []*metric.Metric, targetMetrics []*metric.Metric) []*protocol.AuditReport {
+	sourceByKey := make(map[metricKey]*metric.Metric)
+	targetByKey := make(map[metricKey]*metric.Metric)
+	var keys []metricKey
+	for _, m := range sourceMetrics {
+		key := keyOf(m)
+		if _, ok := sourceByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		sourceByKey[key] = m
+	}
+	for _, m := range targetMetrics {
+		key := keyOf(m)
+		if _, ok := sourceByKey[key]; !ok {
+			if _, ok := targetByKey[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		targetByKey[key] = m
+	}
+
+	rule := protocol.ToleranceRule{
+		Comparator: protocol.ComparatorLessThanEq,
+		Value:      reconciliation.MaxDiffPct,
+	}
+
+	var reports []*protocol.AuditReport
+	for _, key := range keys {
+		var sourceValue, targetValue float64
+		metadata := make(map[string]interface{})
+		if m, ok := sourceByKey[key]; ok {
+			sourceValue = m.Value
+			copyMetadata(metadata, m.Metadata)
+		}
+		if m, ok := targetByKey[key]; ok {
+			targetValue = m.Value
+			copyMetadata(metadata, m.Metadata)
+		}
+		metadata[metric.ReconciledMetric] = key.metricType.String()
+		metadata[metric.SourceURN] = reconciliation.SourceURN
+		metadata[metric.SourceValue] = sourceValue
+		metadata[metric.TargetValue] = targetValue
+
+		diff := diffPct(sourceValue, targetValue)
+		reports = append(reports, &protocol.AuditReport{
+			AuditID:        audit.ID,
+			GroupValue:     key.groupValue,
+			TableURN:       audit.URN,
+			FieldID:        key.fieldID,
+			MetricName:     metric.ReconciliationDiffPct,
+			MetricValue:    diff,
+			Metadata:       metadata,
+			ToleranceRules: []protocol.ToleranceRule{rule},
+			PassFlag:       diff <= rule.Value,
+			Severity:       protocol.SeverityError,
+			EventTimestamp: audit.EventTimestamp,
+		})
+	}
+	return reports
+}
+
+func copyMetadata(destination map[string]interface{}, source map[string]interface{}) {
+	for key, value := range source {
+		destination[key] = value
+	}
+}
+
+//diffPct relative difference of target value from source value, any value differ 100 percent from zero source value
+func diffPct(sourceValue float64, targetValue float64) float64 {
+	if sourceValue == targetValue {
+		return 0
+	}
+	if sourceValue == 0 {
+		return maxDiffPct
+	}
+	return math.Abs(targetValue-sourceValue) / math.Abs(sourceValue) * 100
+}
@@ -0,0 +1,118 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	audit := &job.Audit{
+		ID:             "audit-1",
+		URN:            "project-b.dataset.orders",
+		EventTimestamp: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	reconciliation := &job.Reconciliation{
+		SourceURN:  "project-a.dataset.orders",
+		TargetURN:  "project-b.dataset.orders",
+		MaxDiffPct: 1,
+	}
+	rules := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 1}}
+
+	t.Run("should report relative difference of each metric and group", func(t *testing.T) {
+		sourceMetrics := []*metric.Metric{
+			{Type: metric.Count, Owner: metric.Table, GroupValue: "2021-01-01", Value: 100},
+			{Type: metric.Sum, Owner: metric.Field, FieldID: "amount", GroupValue: "2021-01-01", Value: 2000},
+		}
+		targetMetrics := []*metric.Metric{
+			{Type: metric.Count, Owner: metric.Table, GroupValue: "2021-01-01", Value: 100},
+			{Type: metric.Sum, Owner: metric.Field, FieldID: "amount", GroupValue: "2021-01-01", Value: 1900},
+		}
+
+		reports := compare(audit, reconciliation, sourceMetrics, targetMetrics)
+
+		expected := []*protocol.AuditReport{
+			{
+				AuditID:     "audit-1",
+				GroupValue:  "2021-01-01",
+				TableURN:    "project-b.dataset.orders",
+				MetricName:  metric.ReconciliationDiffPct,
+				MetricValue: 0,
+				Metadata: map[string]interface{}{
+					metric.ReconciledMetric: "count",
+					metric.SourceURN:        "project-a.dataset.orders",
+					metric.SourceValue:      100.0,
+					metric.TargetValue:      100.0,
+				},
+				ToleranceRules: rules,
+				PassFlag:       true,
+				Severity:       protocol.SeverityError,
+				EventTimestamp: audit.EventTimestamp,
+			},
+			{
+				AuditID:     "audit-1",
+				GroupValue:  "2021-01-01",
+				TableURN:    "project-b.dataset.orders",
+				FieldID:     "amount",
+				MetricName:  metric.ReconciliationDiffPct,
+				MetricValue: 5,
+				Metadata: map[string]interface{}{
+					metric.ReconciledMetric: "sum",
+					metric.SourceURN:        "project-a.dataset.orders",
+					metric.SourceValue:      2000.0,
+					metric.TargetValue:      1900.0,
+				},
+				ToleranceRules: rules,
+				PassFlag:       false,
+				Severity:       protocol.SeverityError,
+				EventTimestamp: audit.EventTimestamp,
+			},
+		}
+		assert.Equal(t, expected, reports)
+	})
+	t.Run("should compare group that only found on one table with zero", func(t *testing.T) {
+		sourceMetrics := []*metric.Metric{
+			{Type: metric.Count, Owner: metric.Table, GroupValue: "2021-01-01", Value: 100},
+		}
+		targetMetrics := []*metric.Metric{
+			{Type: metric.Count, Owner: metric.Table, GroupValue: "2021-01-02", Value: 50},
+		}
+
+		reports := compare(audit, reconciliation, sourceMetrics, targetMetrics)
+
+		assert.Len(t, reports, 2)
+		assert.Equal(t, "2021-01-01", reports[0].GroupValue)
+		assert.Equal(t, 100.0, reports[0].MetricValue)
+		assert.Equal(t, 0.0, reports[0].Metadata[metric.TargetValue])
+		assert.Equal(t, "2021-01-02", reports[1].GroupValue)
+		assert.Equal(t, 100.0, reports[1].MetricValue)
+		assert.Equal(t, 0.0, reports[1].Metadata[metric.SourceValue])
+	})
+	t.Run("should keep unique fields of distinct key metric", func(t *testing.T) {
+		uniqueFields := map[string]interface{}{metric.UniqueFields: []string{"order_id"}}
+		sourceMetrics := []*metric.Metric{
+			{Type: metric.UniqueCount, Owner: metric.Table, Metadata: uniqueFields, Value: 10},
+		}
+		targetMetrics := []*metric.Metric{
+			{Type: metric.UniqueCount, Owner: metric.Table, Metadata: uniqueFields, Value: 10},
+		}
+
+		reports := compare(audit, reconciliation, sourceMetrics, targetMetrics)
+
+		assert.Len(t, reports, 1)
+		assert.Equal(t, []string{"order_id"}, reports[0].Metadata[metric.UniqueFields])
+		assert.True(t, reports[0].PassFlag)
+	})
+}
+
+func TestDiffPct(t *testing.T) {
+	assert.Equal(t, 0.0, diffPct(0, 0))
+	assert.Equal(t, 100.0, diffPct(0, 5))
+	assert.Equal(t, 100.0, diffPct(5, 0))
+	assert.Equal(t, 50.0, diffPct(-10, -5))
+	assert.Equal(t, 10.0, diffPct(100, 110))
+}
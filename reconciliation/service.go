@@ -0,0 +1,392 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+)
+
+//Service reconcile target table with its source table by profiling both tables with identical metric specs
+//and auditing relative difference of each metric, the result is stored and published as audit result
+//reconciliations are enqueued to the job queue and run by the profile workers
+type Service struct {
+	reconciliationStore    protocol.ReconciliationStore
+	jobQueue               protocol.JobQueue
+	metadataStore          protocol.MetadataStore
+	profileStore           protocol.ProfileStore
+	metricStore            protocol.MetricStore
+	metricProfiler         protocol.MetricProfiler
+	costEstimator          protocol.CostEstimator
+	auditStore             protocol.AuditStore
+	resultStore            protocol.AuditResultStore
+	publisher              protocol.Publisher
+	messageProviderFactory protocol.MessageProviderFactory
+}
+
+//NewService create reconciliation Service
+func NewService(reconciliationStore protocol.ReconciliationStore,
+	jobQueue protocol.JobQueue,
+	metadataStore protocol.MetadataStore,
+	profileStore protocol.ProfileStore,
+	metricStore protocol.MetricStore,
+	metricProfiler protocol.MetricProfiler,
+	costEstimator protocol.CostEstimator,
+	auditStore protocol.AuditStore,
+	resultStore protocol.AuditResultStore,
+	publisher protocol.Publisher,
+	messageProviderFactory protocol.MessageProviderFactory) *Service {
+	return &Service{
+		reconciliationStore:    reconciliationStore,
+		jobQueue:               jobQueue,
+		metadataStore:          metadataStore,
+		profileStore:           profileStore,
+		metricStore:            metricStore,
+		metricProfiler:         metricProfiler,
+		costEstimator:          costEstimator,
+		auditStore:             auditStore,
+		resultStore:            resultStore,
+		publisher:              publisher,
+		messageProviderFactory: messageProviderFactory,
+	}
+}
+
+//Reconcile validate and enqueue the reconciliation, the created reconciliation is returned without waiting for the result
+func (s *Service) Reconcile(reconciliation *job.Reconciliation) (*protocol.ReconciliationResult, error) {
+	for _, urn := range []string{reconciliation.SourceURN, reconciliation.TargetURN} {
+		tableSpec, err := s.metadataStore.GetMetadata(urn)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateFields(reconciliation, tableSpec); err != nil {
+			return nil, err
+		}
+	}
+
+	reconciliation.State = job.StateCreated
+	reconciliation.Message = fmt.Sprintf("reconcile table %s with source table %s", reconciliation.TargetURN, reconciliation.SourceURN)
+	reconciliation.EventTimestamp = time.Now().In(time.UTC)
+
+	created, err := s.reconciliationStore.Create(reconciliation)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.jobQueue.Enqueue(created.ID, job.TypeReconciliation); err != nil {
+		return nil, err
+	}
+
+	return &protocol.ReconciliationResult{Reconciliation: created}, nil
+}
+
+//Run run claimed reconciliation job on the profile workers, the job is stopped when ctx is cancelled
+func (s *Service) Run(ctx context.Context, queuedJob *protocol.QueuedJob) error {
+	reconciliation, err := s.reconciliationStore.Get(queuedJob.JobID)
+	if err != nil {
+		return err
+	}
+
+	if reconciliation.State.IsFinished() {
+		return nil
+	}
+	return s.execute(ctx, reconciliation)
+}
+
+//Fail mark reconciliation failed when its job lease keep expiring until max attempts
+func (s *Service) Fail(queuedJob *protocol.QueuedJob) error {
+	reconciliation, err := s.reconciliationStore.Get(queuedJob.JobID)
+	if err != nil {
+		return err
+	}
+
+	reconciliation.State = job.StateFailed
+	reconciliation.Message = fmt.Sprintf("reconciliation failed because job lease expired after %d attempts", queuedJob.Attempts)
+	return s.reconciliationStore.Update(reconciliation)
+}
+
+//execute profile source and target table and audit the difference of their metrics, the final state is stored on the reconciliation
+//state is not stored when the job is stopped because its lease is lost, the job is run again by the worker that take it over
+func (s *Service) execute(ctx context.Context, reconciliation *job.Reconciliation) error {
+	_, err := s.run(ctx, reconciliation)
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil {
+		reconciliation.State = job.StateFailed
+		reconciliation.Message = fmt.Sprintf("reconciliation of table %s failed - %v", reconciliation.TargetURN, err)
+		return s.reconciliationStore.Update(reconciliation)
+	}
+
+	reconciliation.State = job.StateCompleted
+	reconciliation.Message = fmt.Sprintf("table %s has been reconciled with source table %s", reconciliation.TargetURN, reconciliation.SourceURN)
+	return s.reconciliationStore.Update(reconciliation)
+}
+
+func (s *Service) run(ctx context.Context, reconciliation *job.Reconciliation) ([]*protocol.AuditReport, error) {
+	sourceProfile, targetProfile, err := s.getProfiles(reconciliation)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation.SourceProfileID = sourceProfile.ID
+	reconciliation.TargetProfileID = targetProfile.ID
+	reconciliation.State = job.StateInProgress
+	reconciliation.Message = "profiling source and target table"
+	if err := s.reconciliationStore.Update(reconciliation); err != nil {
+		return nil, err
+	}
+
+	sourceMetrics, err := s.profile(ctx, reconciliation, sourceProfile)
+	if err != nil {
+		return nil, err
+	}
+	targetMetrics, err := s.profile(ctx, reconciliation, targetProfile)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := s.auditStore.CreateAudit(&job.Audit{
+		ProfileID:      targetProfile.ID,
+		URN:            targetProfile.URN,
+		TotalRecords:   targetProfile.TotalRecords,
+		EventTimestamp: time.Now().In(time.UTC),
+		State:          job.StateCreated,
+		Message:        fmt.Sprintf("Start reconciliation AuditReport on Table %s", targetProfile.URN),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation.AuditID = audit.ID
+	reconciliation.Message = "auditing difference of source and target metrics"
+	if err := s.reconciliationStore.Update(reconciliation); err != nil {
+		return nil, err
+	}
+
+	reports := compare(audit, reconciliation, sourceMetrics, targetMetrics)
+	if err := s.audit(audit, reports); err != nil {
+		audit.State = job.StateFailed
+		audit.Message = fmt.Sprintf("AuditReport Table %s failed - %v", audit.URN, err)
+		if updateErr := s.auditStore.UpdateAudit(audit); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, err
+	}
+
+	audit.State = job.StateCompleted
+	audit.Message = fmt.Sprintf("Table %s has all audited", audit.URN)
+	if err := s.auditStore.UpdateAudit(audit); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+//getProfiles create source and target profile of the reconciliation, profiles created by the previous attempt of a retried job
+//are reused and their metrics are removed, so the retried job does not leave profiles behind or store metrics twice
+func (s *Service) getProfiles(reconciliation *job.Reconciliation) (*job.Profile, *job.Profile, error) {
+	if reconciliation.SourceProfileID == "" || reconciliation.TargetProfileID == "" {
+		sourceProfile, err := s.createProfile(reconciliation, reconciliation.SourceURN)
+		if err != nil {
+			return nil, nil, err
+		}
+		targetProfile, err := s.createProfile(reconciliation, reconciliation.TargetURN)
+		if err != nil {
+			return nil, nil, err
+		}
+		return sourceProfile, targetProfile, nil
+	}
+
+	var profiles []*job.Profile
+	for _, profileID := range []string{reconciliation.SourceProfileID, reconciliation.TargetProfileID} {
+		profile, err := s.profileStore.Get(profileID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := s.metricStore.DeleteByProfileID(profileID); err != nil {
+			return nil, nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles[0], profiles[1], nil
+}
+
+func (s *Service) createProfile(reconciliation *job.Reconciliation, urn string) (*job.Profile, error) {
+	return s.profileStore.Create(&job.Profile{
+		URN:            urn,
+		GroupName:      reconciliation.GroupName,
+		Filter:         reconciliation.Filter,
+		Mode:           job.ModeComplete,
+		Kind:           job.KindReconciliation,
+		Status:         job.StateCreated,
+		Message:        fmt.Sprintf("profile to reconcile table %s with source table %s", reconciliation.TargetURN, reconciliation.SourceURN),
+		EventTimestamp: reconciliation.EventTimestamp,
+		AuditTimestamp: reconciliation.EventTimestamp,
+	})
+}
+
+//profile calculate and store metrics of the profile, total records of the profile is taken from the row count of all groups
+func (s *Service) profile(ctx context.Context, reconciliation *job.Reconciliation, profile *job.Profile) ([]*metric.Metric, error) {
+	metrics, err := s.calculate(ctx, reconciliation, profile)
+	if err != nil {
+		profile.Status = job.StateFailed
+		profile.Message = fmt.Sprintf("reconciliation profile failed because %s", err.Error())
+		if updateErr := s.profileStore.Update(profile); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, err
+	}
+
+	profile.Status = job.StateCompleted
+	profile.Message = "reconciliation profile completed"
+	if err := s.profileStore.Update(profile); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func (s *Service) calculate(ctx context.Context, reconciliation *job.Reconciliation, profile *job.Profile) ([]*metric.Metric, error) {
+	entry := protocol.NewEntryWithContext(ctx).
+		WithJobID(profile.ID).
+		WithJobType(job.TypeProfile).
+		WithTableURN(profile.URN)
+
+	profile.Status = job.StateInProgress
+	profile.Message = "reconciliation profile in progress"
+	if err := s.profileStore.Update(profile); err != nil {
+		return nil, err
+	}
+
+	metricSpecs := reconciliationMetricSpecs(reconciliation, profile.URN)
+	entry, err := s.applyCostLimit(entry, profile, metricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := s.metricProfiler.Profile(entry, profile, metricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.metricStore.Store(profile, metrics); err != nil {
+		return nil, err
+	}
+
+	var totalRecords int64
+	for _, m := range metrics {
+		if m.Type == metric.Count && m.Owner == metric.Table {
+			totalRecords += int64(m.Value)
+		}
+	}
+	profile.TotalRecords = totalRecords
+	return metrics, nil
+}
+
+//applyCostLimit reject the profile when the estimated bytes processed by its planned queries exceed the limit
+//otherwise the limit is applied as max bytes billed of every query run by the profile, the same way as other profiles
+func (s *Service) applyCostLimit(entry protocol.Entry, profile *job.Profile, metricSpecs []*metric.Spec) (protocol.Entry, error) {
+	plannedQueries, err := s.metricProfiler.Plan(profile, metricSpecs)
+	if err != nil {
+		return entry, err
+	}
+
+	estimate, err := s.costEstimator.EstimateQueries(entry, profile, plannedQueries)
+	if err != nil {
+		return entry, err
+	}
+
+	return estimate.Limit(entry)
+}
+
+func (s *Service) audit(audit *job.Audit, reports []*protocol.AuditReport) error {
+	if err := s.resultStore.StoreResults(reports); err != nil {
+		return err
+	}
+
+	messageProviders := s.messageProviderFactory.CreateAuditMessage(audit, reports)
+	for _, messageProvider := range messageProviders {
+		if err := s.publisher.Publish(messageProvider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//Get get reconciliation and reports of its audit
+func (s *Service) Get(ID string) (*protocol.ReconciliationResult, error) {
+	reconciliation, err := s.reconciliationStore.Get(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &protocol.ReconciliationResult{Reconciliation: reconciliation}
+	if reconciliation.AuditID == "" {
+		return result, nil
+	}
+
+	reports, err := s.resultStore.GetResultsByAuditID(reconciliation.AuditID, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		report.TableURN = reconciliation.TargetURN
+	}
+	result.AuditReports = reports
+	return result, nil
+}
+
+//validateFields make sure sum fields are numeric and key fields are exist on the table
+func validateFields(reconciliation *job.Reconciliation, tableSpec *meta.TableSpec) error {
+	urn := tableSpec.TableID()
+	for _, fieldID := range reconciliation.SumFields {
+		fieldSpec, err := tableSpec.GetFieldSpecByID(fieldID)
+		if err != nil {
+			return fmt.Errorf("%w: sum field %s is not found on table %s", protocol.ErrReconciliationInvalid, fieldID, urn)
+		}
+		if !fieldSpec.FieldType.IsNumeric() {
+			return fmt.Errorf("%w: sum field %s of table %s is not numeric", protocol.ErrReconciliationInvalid, fieldID, urn)
+		}
+	}
+	for _, fieldID := range reconciliation.KeyFields {
+		if _, err := tableSpec.GetFieldSpecByID(fieldID); err != nil {
+			return fmt.Errorf("%w: key field %s is not found on table %s", protocol.ErrReconciliationInvalid, fieldID, urn)
+		}
+	}
+	return nil
+}
+
+//reconciliationMetricSpecs metric specs profiled on both source and target table
+func reconciliationMetricSpecs(reconciliation *job.Reconciliation, urn string) []*metric.Spec {
+	specs := []*metric.Spec{
+		{
+			Name:    metric.Count,
+			TableID: urn,
+			Owner:   metric.Table,
+		},
+	}
+
+	for _, fieldID := range reconciliation.SumFields {
+		specs = append(specs, &metric.Spec{
+			Name:    metric.Sum,
+			TableID: urn,
+			FieldID: fieldID,
+			Owner:   metric.Field,
+		})
+	}
+
+	if len(reconciliation.KeyFields) > 0 {
+		specs = append(specs, &metric.Spec{
+			Name:    metric.UniqueCount,
+			TableID: urn,
+			Owner:   metric.Table,
+			Metadata: map[string]interface{}{
+				metric.UniqueFields: reconciliation.KeyFields,
+			},
+		})
+	}
+
+	return specs
+}
@@ -0,0 +1,338 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+	testifyMock "github.com/stretchr/testify/mock"
+)
+
+func TestService(t *testing.T) {
+	sourceURN := "project-a.dataset.orders"
+	targetURN := "project-b.dataset.orders"
+	groupName := "__PARTITION__"
+	filter := "__PARTITION__ = '2021-03-01'"
+
+	tableSpec := func(project string) *meta.TableSpec {
+		return &meta.TableSpec{
+			ProjectName: project,
+			DatasetName: "dataset",
+			TableName:   "orders",
+			Fields: []*meta.FieldSpec{
+				{Name: "order_id", FieldType: meta.FieldTypeString, Mode: meta.ModeRequired, Level: meta.RootLevel},
+				{Name: "amount", FieldType: meta.FieldTypeFloat, Mode: meta.ModeNullable, Level: meta.RootLevel},
+			},
+		}
+	}
+	request := func() *job.Reconciliation {
+		return &job.Reconciliation{
+			SourceURN:  sourceURN,
+			TargetURN:  targetURN,
+			GroupName:  groupName,
+			Filter:     filter,
+			SumFields:  []string{"amount"},
+			KeyFields:  []string{"order_id"},
+			MaxDiffPct: 1,
+		}
+	}
+	specs := func(urn string) []*metric.Spec {
+		return []*metric.Spec{
+			{Name: metric.Count, TableID: urn, Owner: metric.Table},
+			{Name: metric.Sum, TableID: urn, FieldID: "amount", Owner: metric.Field},
+			{Name: metric.UniqueCount, TableID: urn, Owner: metric.Table, Metadata: map[string]interface{}{metric.UniqueFields: []string{"order_id"}}},
+		}
+	}
+	createdMessage := fmt.Sprintf("reconcile table %s with source table %s", targetURN, sourceURN)
+	profileMessage := fmt.Sprintf("profile to reconcile table %s with source table %s", targetURN, sourceURN)
+
+	t.Run("Reconcile", func(t *testing.T) {
+		t.Run("should validate and enqueue reconciliation", func(t *testing.T) {
+			metadataStore := mock.NewMetadataStore()
+			metadataStore.On("GetMetadata", sourceURN).Return(tableSpec("project-a"), nil)
+			metadataStore.On("GetMetadata", targetURN).Return(tableSpec("project-b"), nil)
+
+			state := request()
+			state.State = job.StateCreated
+			state.Message = createdMessage
+			created := *state
+			created.ID = "reconciliation-1"
+			reconciliationStore := mock.NewReconciliationStore()
+			defer reconciliationStore.AssertExpectations(t)
+			reconciliationStore.On("Create", state).Return(&created, nil)
+
+			queue := mock.NewJobQueue()
+			defer queue.AssertExpectations(t)
+			queue.On("Enqueue", "reconciliation-1", job.TypeReconciliation).Return(nil)
+
+			service := NewService(reconciliationStore, queue, metadataStore, mock.NewProfileStore(), mock.NewMetricStore(), mock.NewProfiler(), mock.NewCostEstimator(),
+				mock.NewAuditStore(), mock.NewAuditResultStore(), mock.NewPublisher(), mock.NewMessageProviderFactory())
+			result, err := service.Reconcile(request())
+
+			assert.Nil(t, err)
+			assert.Equal(t, &created, result.Reconciliation)
+			assert.Empty(t, result.AuditReports)
+		})
+		t.Run("should return ErrReconciliationInvalid when sum field is not numeric", func(t *testing.T) {
+			metadataStore := mock.NewMetadataStore()
+			metadataStore.On("GetMetadata", sourceURN).Return(tableSpec("project-a"), nil)
+
+			reconciliation := request()
+			reconciliation.SumFields = []string{"order_id"}
+
+			service := NewService(mock.NewReconciliationStore(), mock.NewJobQueue(), metadataStore, mock.NewProfileStore(), mock.NewMetricStore(), mock.NewProfiler(), mock.NewCostEstimator(),
+				mock.NewAuditStore(), mock.NewAuditResultStore(), mock.NewPublisher(), mock.NewMessageProviderFactory())
+			result, err := service.Reconcile(reconciliation)
+
+			assert.Nil(t, result)
+			assert.True(t, errors.Is(err, protocol.ErrReconciliationInvalid))
+		})
+	})
+	t.Run("Run", func(t *testing.T) {
+		t.Run("should profile both tables and audit difference of their metrics", func(t *testing.T) {
+			metadataStore := mock.NewMetadataStore()
+
+			reconciliationStore := mock.NewReconciliationStore()
+			defer reconciliationStore.AssertExpectations(t)
+			state := request()
+			state.State = job.StateCreated
+			state.Message = createdMessage
+			claimed := *state
+			claimed.ID = "reconciliation-1"
+			reconciliationStore.On("Get", "reconciliation-1").Return(&claimed, nil)
+
+			profiling := *state
+			profiling.ID = "reconciliation-1"
+			profiling.SourceProfileID = "profile-1"
+			profiling.TargetProfileID = "profile-2"
+			profiling.State = job.StateInProgress
+			profiling.Message = "profiling source and target table"
+			auditing := profiling
+			auditing.AuditID = "audit-1"
+			auditing.Message = "auditing difference of source and target metrics"
+			completed := auditing
+			completed.State = job.StateCompleted
+			completed.Message = fmt.Sprintf("table %s has been reconciled with source table %s", targetURN, sourceURN)
+			reconciliationStore.On("Update", &profiling).Return(nil)
+			reconciliationStore.On("Update", &auditing).Return(nil)
+			reconciliationStore.On("Update", &completed).Return(nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			for i, urn := range []string{sourceURN, targetURN} {
+				id := fmt.Sprintf("profile-%d", i+1)
+				profileStore.On("Create", &job.Profile{URN: urn, GroupName: groupName, Filter: filter, Kind: job.KindReconciliation, Status: job.StateCreated, Message: profileMessage}).
+					Return(&job.Profile{ID: id, URN: urn, GroupName: groupName, Filter: filter, Status: job.StateCreated, Message: profileMessage}, nil)
+				profileStore.On("Update", &job.Profile{ID: id, URN: urn, GroupName: groupName, Filter: filter, Status: job.StateInProgress, Message: "reconciliation profile in progress"}).Return(nil)
+				profileStore.On("Update", &job.Profile{ID: id, URN: urn, GroupName: groupName, Filter: filter, Status: job.StateCompleted, Message: "reconciliation profile completed", TotalRecords: 100}).Return(nil)
+			}
+
+			sourceMetrics := []*metric.Metric{
+				{Type: metric.Count, Owner: metric.Table, GroupValue: "2021-03-01", Value: 100},
+				{Type: metric.Sum, Owner: metric.Field, FieldID: "amount", GroupValue: "2021-03-01", Value: 2000},
+			}
+			targetMetrics := []*metric.Metric{
+				{Type: metric.Count, Owner: metric.Table, GroupValue: "2021-03-01", Value: 100},
+				{Type: metric.Sum, Owner: metric.Field, FieldID: "amount", GroupValue: "2021-03-01", Value: 1900},
+			}
+			profiler := mock.NewProfiler()
+			defer profiler.AssertExpectations(t)
+			profiler.On("Profile", testifyMock.Anything, testifyMock.Anything, specs(sourceURN)).Return(sourceMetrics, nil)
+			profiler.On("Profile", testifyMock.Anything, testifyMock.Anything, specs(targetURN)).Return(targetMetrics, nil)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("Store", testifyMock.Anything, sourceMetrics).Return(nil)
+			metricStore.On("Store", testifyMock.Anything, targetMetrics).Return(nil)
+
+			profiler.On("Plan", testifyMock.Anything, testifyMock.Anything).Return([]*job.Query{}, nil)
+			costEstimator := mock.NewCostEstimator()
+			costEstimator.On("EstimateQueries", testifyMock.Anything, testifyMock.Anything).Return(&protocol.CostEstimate{TotalBytes: 100}, nil)
+
+			auditMessage := fmt.Sprintf("Start reconciliation AuditReport on Table %s", targetURN)
+			createdAudit := &job.Audit{ID: "audit-1", ProfileID: "profile-2", URN: targetURN, TotalRecords: 100, State: job.StateCreated, Message: auditMessage}
+			completedAudit := *createdAudit
+			completedAudit.State = job.StateCompleted
+			completedAudit.Message = fmt.Sprintf("Table %s has all audited", targetURN)
+			auditStore := mock.NewAuditStore()
+			defer auditStore.AssertExpectations(t)
+			auditStore.On("CreateAudit", &job.Audit{ProfileID: "profile-2", URN: targetURN, State: job.StateCreated, Message: auditMessage}).Return(createdAudit, nil)
+			auditStore.On("UpdateAudit", &completedAudit).Return(nil)
+
+			var reports []*protocol.AuditReport
+			resultStore := mock.NewAuditResultStore()
+			defer resultStore.AssertExpectations(t)
+			resultStore.On("StoreResults", testifyMock.Anything).Return(nil).Run(func(args testifyMock.Arguments) {
+				reports = args.Get(0).([]*protocol.AuditReport)
+			})
+
+			messageProvider := mock.NewMessageBuilder()
+			messageProviderFactory := mock.NewMessageProviderFactory()
+			messageProviderFactory.On("CreateAuditMessage", testifyMock.Anything, testifyMock.Anything).Return([]protocol.MessageProvider{messageProvider})
+			publisher := mock.NewPublisher()
+			defer publisher.AssertExpectations(t)
+			publisher.On("Publish", messageProvider).Return(nil)
+
+			queued := &protocol.QueuedJob{ID: 1, JobID: "reconciliation-1", JobType: job.TypeReconciliation, Attempts: 1, LeaseOwner: "replica-1"}
+
+			service := NewService(reconciliationStore, mock.NewJobQueue(), metadataStore, profileStore, metricStore, profiler, costEstimator,
+				auditStore, resultStore, publisher, messageProviderFactory)
+			err := service.Run(context.Background(), queued)
+
+			assert.Nil(t, err)
+			assert.Len(t, reports, 2)
+			assert.True(t, reports[0].PassFlag)
+			assert.False(t, reports[1].PassFlag)
+			assert.Equal(t, 5.0, reports[1].MetricValue)
+			assert.Equal(t, "audit-1", reports[1].AuditID)
+		})
+		t.Run("should mark reconciliation failed when profiling failed", func(t *testing.T) {
+			reconciliationStore := mock.NewReconciliationStore()
+			defer reconciliationStore.AssertExpectations(t)
+			reconciliationStore.On("Get", "reconciliation-1").Return(&job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, State: job.StateCreated}, nil)
+			reconciliationStore.On("Update", &job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, SourceProfileID: "profile-1",
+				TargetProfileID: "profile-2", State: job.StateInProgress, Message: "profiling source and target table"}).Return(nil)
+			reconciliationStore.On("Update", &job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, SourceProfileID: "profile-1",
+				TargetProfileID: "profile-2", State: job.StateFailed, Message: fmt.Sprintf("reconciliation of table %s failed - query failed", targetURN)}).Return(nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			profileStore.On("Create", testifyMock.Anything).Return(&job.Profile{ID: "profile-1", URN: sourceURN}, nil).Once()
+			profileStore.On("Create", testifyMock.Anything).Return(&job.Profile{ID: "profile-2", URN: targetURN}, nil).Once()
+			profileStore.On("Update", &job.Profile{ID: "profile-1", URN: sourceURN, Status: job.StateInProgress, Message: "reconciliation profile in progress"}).Return(nil)
+			profileStore.On("Update", &job.Profile{ID: "profile-1", URN: sourceURN, Status: job.StateFailed, Message: "reconciliation profile failed because query failed"}).Return(nil)
+
+			profiler := mock.NewProfiler()
+			profiler.On("Profile", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything).Return([]*metric.Metric{}, errors.New("query failed"))
+
+			profiler.On("Plan", testifyMock.Anything, testifyMock.Anything).Return([]*job.Query{}, nil)
+			costEstimator := mock.NewCostEstimator()
+			costEstimator.On("EstimateQueries", testifyMock.Anything, testifyMock.Anything).Return(&protocol.CostEstimate{TotalBytes: 100}, nil)
+
+			queued := &protocol.QueuedJob{ID: 1, JobID: "reconciliation-1", JobType: job.TypeReconciliation, Attempts: 1, LeaseOwner: "replica-1"}
+
+			service := NewService(reconciliationStore, mock.NewJobQueue(), mock.NewMetadataStore(), profileStore, mock.NewMetricStore(), profiler, costEstimator,
+				mock.NewAuditStore(), mock.NewAuditResultStore(), mock.NewPublisher(), mock.NewMessageProviderFactory())
+			err := service.Run(context.Background(), queued)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should mark reconciliation failed when estimated bytes exceed the limit", func(t *testing.T) {
+			reconciliationStore := mock.NewReconciliationStore()
+			defer reconciliationStore.AssertExpectations(t)
+			reconciliationStore.On("Get", "reconciliation-1").Return(&job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, State: job.StateCreated}, nil)
+			reconciliationStore.On("Update", &job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, SourceProfileID: "profile-1",
+				TargetProfileID: "profile-2", State: job.StateInProgress, Message: "profiling source and target table"}).Return(nil)
+			reconciliationStore.On("Update", testifyMock.MatchedBy(func(reconciliation *job.Reconciliation) bool {
+				return reconciliation.State == job.StateFailed && strings.Contains(reconciliation.Message, protocol.ErrBytesLimitExceeded.Error())
+			})).Return(nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			profileStore.On("Create", testifyMock.Anything).Return(&job.Profile{ID: "profile-1", URN: sourceURN}, nil).Once()
+			profileStore.On("Create", testifyMock.Anything).Return(&job.Profile{ID: "profile-2", URN: targetURN}, nil).Once()
+			profileStore.On("Update", testifyMock.Anything).Return(nil)
+
+			plannedQueries := []*job.Query{{URN: sourceURN, Content: "SELECT count(*)"}}
+			profiler := mock.NewProfiler()
+			defer profiler.AssertExpectations(t)
+			profiler.On("Plan", testifyMock.Anything, testifyMock.Anything).Return(plannedQueries, nil)
+
+			costEstimator := mock.NewCostEstimator()
+			defer costEstimator.AssertExpectations(t)
+			costEstimator.On("EstimateQueries", testifyMock.Anything, plannedQueries).Return(&protocol.CostEstimate{TotalBytes: 2000, MaxBytesBilled: 1000}, nil)
+
+			queued := &protocol.QueuedJob{ID: 1, JobID: "reconciliation-1", JobType: job.TypeReconciliation, Attempts: 1, LeaseOwner: "replica-1"}
+
+			service := NewService(reconciliationStore, mock.NewJobQueue(), mock.NewMetadataStore(), profileStore, mock.NewMetricStore(), profiler, costEstimator,
+				mock.NewAuditStore(), mock.NewAuditResultStore(), mock.NewPublisher(), mock.NewMessageProviderFactory())
+			err := service.Run(context.Background(), queued)
+
+			assert.Nil(t, err)
+			profiler.AssertNotCalled(t, "Profile", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything)
+		})
+		t.Run("should reuse profiles of the previous attempt and delete their metrics", func(t *testing.T) {
+			previous := &job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, SourceProfileID: "profile-1",
+				TargetProfileID: "profile-2", State: job.StateInProgress, Message: "profiling source and target table"}
+			reconciliationStore := mock.NewReconciliationStore()
+			defer reconciliationStore.AssertExpectations(t)
+			reconciliationStore.On("Get", "reconciliation-1").Return(previous, nil)
+			reconciliationStore.On("Update", &job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, SourceProfileID: "profile-1",
+				TargetProfileID: "profile-2", State: job.StateInProgress, Message: "profiling source and target table"}).Return(nil)
+			reconciliationStore.On("Update", &job.Reconciliation{ID: "reconciliation-1", SourceURN: sourceURN, TargetURN: targetURN, SourceProfileID: "profile-1",
+				TargetProfileID: "profile-2", State: job.StateFailed, Message: fmt.Sprintf("reconciliation of table %s failed - query failed", targetURN)}).Return(nil)
+
+			profileStore := mock.NewProfileStore()
+			defer profileStore.AssertExpectations(t)
+			profileStore.On("Get", "profile-1").Return(&job.Profile{ID: "profile-1", URN: sourceURN, Status: job.StateInProgress}, nil)
+			profileStore.On("Get", "profile-2").Return(&job.Profile{ID: "profile-2", URN: targetURN, Status: job.StateInProgress}, nil)
+			profileStore.On("Update", &job.Profile{ID: "profile-1", URN: sourceURN, Status: job.StateInProgress, Message: "reconciliation profile in progress"}).Return(nil)
+			profileStore.On("Update", &job.Profile{ID: "profile-1", URN: sourceURN, Status: job.StateFailed, Message: "reconciliation profile failed because query failed"}).Return(nil)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("DeleteByProfileID", "profile-1").Return(nil)
+			metricStore.On("DeleteByProfileID", "profile-2").Return(nil)
+
+			profiler := mock.NewProfiler()
+			profiler.On("Profile", testifyMock.Anything, testifyMock.Anything, testifyMock.Anything).Return([]*metric.Metric{}, errors.New("query failed"))
+
+			profiler.On("Plan", testifyMock.Anything, testifyMock.Anything).Return([]*job.Query{}, nil)
+			costEstimator := mock.NewCostEstimator()
+			costEstimator.On("EstimateQueries", testifyMock.Anything, testifyMock.Anything).Return(&protocol.CostEstimate{TotalBytes: 100}, nil)
+
+			queued := &protocol.QueuedJob{ID: 1, JobID: "reconciliation-1", JobType: job.TypeReconciliation, Attempts: 2, LeaseOwner: "replica-1"}
+
+			service := NewService(reconciliationStore, mock.NewJobQueue(), mock.NewMetadataStore(), profileStore, metricStore, profiler, costEstimator,
+				mock.NewAuditStore(), mock.NewAuditResultStore(), mock.NewPublisher(), mock.NewMessageProviderFactory())
+			err := service.Run(context.Background(), queued)
+
+			assert.Nil(t, err)
+			profileStore.AssertNotCalled(t, "Create", testifyMock.Anything)
+		})
+	})
+	t.Run("Fail", func(t *testing.T) {
+		t.Run("should mark reconciliation failed when job lease expired after max attempts", func(t *testing.T) {
+			reconciliationStore := mock.NewReconciliationStore()
+			defer reconciliationStore.AssertExpectations(t)
+			reconciliationStore.On("Get", "reconciliation-1").Return(&job.Reconciliation{ID: "reconciliation-1", State: job.StateInProgress}, nil)
+			reconciliationStore.On("Update", &job.Reconciliation{ID: "reconciliation-1", State: job.StateFailed,
+				Message: "reconciliation failed because job lease expired after 3 attempts"}).Return(nil)
+
+			queued := &protocol.QueuedJob{ID: 1, JobID: "reconciliation-1", JobType: job.TypeReconciliation, Attempts: 3}
+
+			service := NewService(reconciliationStore, mock.NewJobQueue(), mock.NewMetadataStore(), mock.NewProfileStore(), mock.NewMetricStore(), mock.NewProfiler(), mock.NewCostEstimator(),
+				mock.NewAuditStore(), mock.NewAuditResultStore(), mock.NewPublisher(), mock.NewMessageProviderFactory())
+			err := service.Fail(queued)
+
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("Get", func(t *testing.T) {
+		t.Run("should return reconciliation with reports of its audit", func(t *testing.T) {
+			reconciliation := &job.Reconciliation{ID: "reconciliation-1", TargetURN: targetURN, AuditID: "audit-1", State: job.StateCompleted}
+			reconciliationStore := mock.NewReconciliationStore()
+			reconciliationStore.On("Get", "reconciliation-1").Return(reconciliation, nil)
+
+			reports := []*protocol.AuditReport{{AuditID: "audit-1", MetricName: metric.ReconciliationDiffPct, PassFlag: true}}
+			resultStore := mock.NewAuditResultStore()
+			resultStore.On("GetResultsByAuditID", "audit-1", "").Return(reports, nil)
+
+			service := NewService(reconciliationStore, mock.NewJobQueue(), mock.NewMetadataStore(), mock.NewProfileStore(), mock.NewMetricStore(), mock.NewProfiler(), mock.NewCostEstimator(),
+				mock.NewAuditStore(), resultStore, mock.NewPublisher(), mock.NewMessageProviderFactory())
+			result, err := service.Get("reconciliation-1")
+
+			assert.Nil(t, err)
+			assert.Equal(t, reconciliation, result.Reconciliation)
+			assert.Equal(t, targetURN, result.AuditReports[0].TableURN)
+		})
+	})
+}
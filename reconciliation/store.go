@@ -0,0 +1,148 @@
+package reconciliation
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+)
+
+const fieldSeparator = ","
+
+type reconciliationRecord struct {
+	ID              string
+	SourceURN       string
+	TargetURN       string
+	GroupName       string
+	Filter          string
+	SumFields       string
+	KeyFields       string
+	MaxDiffPct      float64
+	SourceProfileID string
+	TargetProfileID string
+	AuditID         string
+	EventTimestamp  time.Time
+}
+
+func newReconciliationRecord(reconciliation *job.Reconciliation) *reconciliationRecord {
+	return &reconciliationRecord{
+		ID:              reconciliation.ID,
+		SourceURN:       reconciliation.SourceURN,
+		TargetURN:       reconciliation.TargetURN,
+		GroupName:       reconciliation.GroupName,
+		Filter:          reconciliation.Filter,
+		SumFields:       strings.Join(reconciliation.SumFields, fieldSeparator),
+		KeyFields:       strings.Join(reconciliation.KeyFields, fieldSeparator),
+		MaxDiffPct:      reconciliation.MaxDiffPct,
+		SourceProfileID: reconciliation.SourceProfileID,
+		TargetProfileID: reconciliation.TargetProfileID,
+		AuditID:         reconciliation.AuditID,
+		EventTimestamp:  reconciliation.EventTimestamp,
+	}
+}
+
+func (r *reconciliationRecord) toReconciliation(status *protocol.Status) *job.Reconciliation {
+	return &job.Reconciliation{
+		ID:              r.ID,
+		SourceURN:       r.SourceURN,
+		TargetURN:       r.TargetURN,
+		GroupName:       r.GroupName,
+		Filter:          r.Filter,
+		SumFields:       splitFields(r.SumFields),
+		KeyFields:       splitFields(r.KeyFields),
+		MaxDiffPct:      r.MaxDiffPct,
+		SourceProfileID: r.SourceProfileID,
+		TargetProfileID: r.TargetProfileID,
+		AuditID:         r.AuditID,
+		State:           job.State(status.Status),
+		Message:         status.Message,
+		EventTimestamp:  r.EventTimestamp.In(time.UTC),
+	}
+}
+
+func splitFields(fields string) []string {
+	if fields == "" {
+		return nil
+	}
+	return strings.Split(fields, fieldSeparator)
+}
+
+//Store is postgres backed store of reconciliation, state of the reconciliation is stored on status store
+type Store struct {
+	db          *gorm.DB
+	statusStore protocol.StatusStore
+}
+
+//NewStore create reconciliation store
+func NewStore(db *gorm.DB, tableName string, statusStore protocol.StatusStore) *Store {
+	return &Store{
+		db:          db.Table(tableName),
+		statusStore: statusStore,
+	}
+}
+
+//Create store reconciliation and its created state
+func (s *Store) Create(reconciliation *job.Reconciliation) (*job.Reconciliation, error) {
+	record := newReconciliationRecord(reconciliation)
+	if err := s.db.Create(record).Error; err != nil {
+		return nil, err
+	}
+
+	status := &protocol.Status{
+		JobID:          record.ID,
+		JobType:        job.TypeReconciliation,
+		Message:        reconciliation.Message,
+		Status:         job.StateCreated.String(),
+		EventTimestamp: time.Now().In(time.UTC),
+	}
+	if err := s.statusStore.Store(status); err != nil {
+		return nil, err
+	}
+
+	return record.toReconciliation(status), nil
+}
+
+//Update store profile and audit of the reconciliation and insert its latest state
+func (s *Store) Update(reconciliation *job.Reconciliation) error {
+	handle := s.db.Model(&reconciliationRecord{}).
+		Where("id = ?", reconciliation.ID).
+		Updates(map[string]interface{}{
+			"source_profile_id": reconciliation.SourceProfileID,
+			"target_profile_id": reconciliation.TargetProfileID,
+			"audit_id":          reconciliation.AuditID,
+		})
+	if err := handle.Error; err != nil {
+		return err
+	}
+
+	status := &protocol.Status{
+		JobID:          reconciliation.ID,
+		JobType:        job.TypeReconciliation,
+		Message:        reconciliation.Message,
+		Status:         reconciliation.State.String(),
+		EventTimestamp: time.Now().In(time.UTC),
+	}
+	return s.statusStore.Store(status)
+}
+
+//Get get reconciliation with its latest state
+func (s *Store) Get(ID string) (*job.Reconciliation, error) {
+	var records []*reconciliationRecord
+	if err := s.db.Where("id = ?", ID).Limit(1).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, protocol.ErrReconciliationNotFound
+	}
+
+	status, err := s.statusStore.GetLatestStatusByIDandType(ID, job.TypeReconciliation)
+	if err != nil {
+		if err != protocol.ErrStatusNotFound {
+			return nil, err
+		}
+		status = &protocol.Status{}
+	}
+	return records[0].toReconciliation(status), nil
+}
@@ -0,0 +1,88 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	pmock "github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	tableName := "reconciliation_records"
+	eventTimestamp := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	newReconciliation := func() *job.Reconciliation {
+		return &job.Reconciliation{
+			ID:             "reconciliation-1",
+			SourceURN:      "project-a.dataset.orders",
+			TargetURN:      "project-b.dataset.orders",
+			GroupName:      "__PARTITION__",
+			Filter:         "__PARTITION__ = '2021-03-01'",
+			SumFields:      []string{"amount", "fee"},
+			KeyFields:      []string{"order_id"},
+			MaxDiffPct:     0.5,
+			State:          job.StateCreated,
+			Message:        "reconcile",
+			EventTimestamp: eventTimestamp,
+		}
+	}
+	t.Run("Create", func(t *testing.T) {
+		t.Run("should create reconciliation and its created status", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(reconciliationRecord))
+			defer clearDb()
+
+			statusStore := pmock.NewStatusStore()
+			defer statusStore.AssertExpectations(t)
+			statusStore.On("Store", &protocol.Status{JobID: "reconciliation-1", JobType: job.TypeReconciliation, Status: "created", Message: "reconcile"}).Return(nil)
+
+			store := NewStore(db, tableName, statusStore)
+			created, err := store.Create(newReconciliation())
+
+			assert.Nil(t, err)
+			assert.Equal(t, newReconciliation(), created)
+		})
+	})
+	t.Run("Update", func(t *testing.T) {
+		t.Run("should store profiles, audit and status of reconciliation", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(reconciliationRecord))
+			defer clearDb()
+
+			completedStatus := &protocol.Status{JobID: "reconciliation-1", JobType: job.TypeReconciliation, Status: "completed", Message: "done"}
+			statusStore := pmock.NewStatusStore()
+			defer statusStore.AssertExpectations(t)
+			statusStore.On("Store", &protocol.Status{JobID: "reconciliation-1", JobType: job.TypeReconciliation, Status: "created", Message: "reconcile"}).Return(nil)
+			statusStore.On("Store", completedStatus).Return(nil)
+			statusStore.On("GetLatestStatusByIDandType", "reconciliation-1", job.TypeReconciliation).Return(completedStatus, nil)
+
+			store := NewStore(db, tableName, statusStore)
+			reconciliation, err := store.Create(newReconciliation())
+			assert.Nil(t, err)
+
+			reconciliation.SourceProfileID = "profile-1"
+			reconciliation.TargetProfileID = "profile-2"
+			reconciliation.AuditID = "audit-1"
+			reconciliation.State = job.StateCompleted
+			reconciliation.Message = "done"
+			err = store.Update(reconciliation)
+			result, getErr := store.Get("reconciliation-1")
+
+			assert.Nil(t, err)
+			assert.Nil(t, getErr)
+			assert.Equal(t, reconciliation, result)
+		})
+	})
+	t.Run("Get", func(t *testing.T) {
+		t.Run("should return ErrReconciliationNotFound when reconciliation is not found", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(reconciliationRecord))
+			defer clearDb()
+
+			store := NewStore(db, tableName, pmock.NewStatusStore())
+			result, err := store.Get("reconciliation-1")
+
+			assert.Nil(t, result)
+			assert.Equal(t, protocol.ErrReconciliationNotFound, err)
+		})
+	})
+}
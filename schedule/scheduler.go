@@ -0,0 +1,331 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/macros"
+)
+
+var logger = log.New(os.Stdout, "INFO: ", log.Lshortfile|log.LstdFlags)
+
+//Scheduler create profile and audit of tables with schedule in their tolerance spec
+//every replica run the scheduler, the schedule run store make sure a run is only created once
+type Scheduler struct {
+	toleranceStore       protocol.ToleranceStore
+	runStore             protocol.ScheduleRunStore
+	profileService       protocol.ProfileService
+	auditService         protocol.AuditService
+	sqlExpressionFactory protocol.SQLExpressionFactory
+	interval             time.Duration
+	specRefreshInterval  time.Duration
+	runTimeout           time.Duration
+	startedAt            time.Time
+
+	//specs is tolerance specs read at specsReadAt, reused until the spec refresh interval passed
+	specs       []*protocol.ToleranceSpec
+	specsReadAt time.Time
+
+	wg       sync.WaitGroup
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+//New create scheduler that check the schedules every interval
+//tolerance specs are read again after specRefreshInterval, run that stay created or auditing longer than runTimeout is failed
+func New(toleranceStore protocol.ToleranceStore,
+	runStore protocol.ScheduleRunStore,
+	profileService protocol.ProfileService,
+	auditService protocol.AuditService,
+	sqlExpressionFactory protocol.SQLExpressionFactory,
+	interval time.Duration,
+	specRefreshInterval time.Duration,
+	runTimeout time.Duration) *Scheduler {
+	return &Scheduler{
+		toleranceStore:       toleranceStore,
+		runStore:             runStore,
+		profileService:       profileService,
+		auditService:         auditService,
+		sqlExpressionFactory: sqlExpressionFactory,
+		interval:             interval,
+		specRefreshInterval:  specRefreshInterval,
+		runTimeout:           runTimeout,
+		startedAt:            time.Now().In(time.UTC),
+		stop:                 make(chan struct{}),
+	}
+}
+
+//Start check the schedules periodically until stopped
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case now := <-ticker.C:
+				if err := s.tick(now.In(time.UTC)); err != nil {
+					logger.Printf("failed to run schedules: %v\n", err)
+				}
+			}
+		}
+	}()
+}
+
+//Stop stop checking the schedules and wait until the running check finished
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	waitChan := make(chan bool)
+	go func() {
+		s.wg.Wait()
+		close(waitChan)
+	}()
+
+	select {
+	case <-waitChan:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//tick create runs of schedules that are due, progress the runs waiting for their profile and fail the stuck runs
+func (s *Scheduler) tick(now time.Time) error {
+	specs, err := s.getSpecs(now)
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range specs {
+		if spec.Schedule == nil {
+			continue
+		}
+		if err := s.schedule(spec, now); err != nil {
+			logger.Printf("failed to schedule %s: %v\n", spec.URN, err)
+		}
+	}
+
+	runs, err := s.runStore.GetByStatus(protocol.ScheduleRunProfiling)
+	if err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		if err := s.progress(run); err != nil {
+			logger.Printf("failed to progress schedule run %d of %s: %v\n", run.ID, run.URN, err)
+		}
+	}
+
+	for _, status := range []protocol.ScheduleRunStatus{protocol.ScheduleRunCreated, protocol.ScheduleRunAuditing} {
+		if err := s.failStuckRuns(status, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//getSpecs get tolerance specs, the specs are only read again from the store after the spec refresh interval
+func (s *Scheduler) getSpecs(now time.Time) ([]*protocol.ToleranceSpec, error) {
+	if !s.specsReadAt.IsZero() && now.Sub(s.specsReadAt) < s.specRefreshInterval {
+		return s.specs, nil
+	}
+
+	specs, err := s.toleranceStore.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	s.specs = specs
+	s.specsReadAt = now
+	return specs, nil
+}
+
+//failStuckRuns fail runs with the status that are not updated longer than the run timeout
+//such as run of a replica that stopped while creating the profile or auditing
+func (s *Scheduler) failStuckRuns(status protocol.ScheduleRunStatus, now time.Time) error {
+	runs, err := s.runStore.GetByStatus(status)
+	if err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		if now.Sub(run.UpdatedAt) < s.runTimeout {
+			continue
+		}
+		run.Status = protocol.ScheduleRunFailed
+		run.Message = fmt.Sprintf("schedule run is %s for more than %s", status, s.runTimeout)
+		if err := ignoreStatusChanged(s.runStore.Update(run, status)); err != nil {
+			logger.Printf("failed to fail stuck schedule run %d of %s: %v\n", run.ID, run.URN, err)
+		}
+	}
+	return nil
+}
+
+//schedule create run and its profile when the schedule is due
+//only the latest missed activation is run, activations before the scheduler started are not run for table without run history
+func (s *Scheduler) schedule(spec *protocol.ToleranceSpec, now time.Time) error {
+	cron, err := protocol.ParseCron(spec.Schedule.Cron)
+	if err != nil {
+		return err
+	}
+
+	from := s.startedAt
+	latest, err := s.runStore.GetLatest(spec.URN)
+	if err == nil {
+		from = latest.ScheduledTime
+	} else if err != protocol.ErrScheduleRunNotFound {
+		return err
+	}
+
+	scheduledTime := latestActivation(cron, from, now)
+	if scheduledTime.IsZero() {
+		return nil
+	}
+
+	run := &protocol.ScheduleRun{
+		URN:           spec.URN,
+		ScheduledTime: scheduledTime,
+		Status:        protocol.ScheduleRunCreated,
+		Message:       "schedule run created",
+		Audit:         spec.Schedule.Audit,
+	}
+	if err := s.runStore.Create(run); err != nil {
+		if err == protocol.ErrScheduleRunExists {
+			return nil
+		}
+		return err
+	}
+
+	profile, err := s.createProfile(spec.URN, spec.Schedule, scheduledTime)
+	if err != nil {
+		run.Status = protocol.ScheduleRunFailed
+		run.Message = fmt.Sprintf("unable to create profile because %s", err.Error())
+		if updateErr := s.runStore.Update(run, protocol.ScheduleRunCreated); updateErr != nil {
+			return updateErr
+		}
+		return err
+	}
+
+	run.Status = protocol.ScheduleRunProfiling
+	run.Message = "profile created"
+	run.ProfileID = profile.ID
+	return s.runStore.Update(run, protocol.ScheduleRunCreated)
+}
+
+//latestActivation return the latest activation after from that is not after now, zero time when there is none
+func latestActivation(cron *protocol.Cron, from time.Time, now time.Time) time.Time {
+	var activation time.Time
+	for next := cron.Next(from); !next.IsZero() && !next.After(now); next = cron.Next(next) {
+		activation = next
+	}
+	return activation
+}
+
+func (s *Scheduler) createProfile(urn string, schedule *protocol.Schedule, scheduledTime time.Time) (*job.Profile, error) {
+	filter, err := schedule.RenderFilter(scheduledTime)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := schedule.Mode
+	if mode == "" {
+		mode = job.ModeComplete
+	}
+
+	profile := &job.Profile{
+		URN:            urn,
+		Mode:           mode,
+		Filter:         filter,
+		GroupName:      schedule.Group,
+		Status:         job.StateCreated,
+		Message:        "profile created",
+		EventTimestamp: time.Now().In(time.UTC),
+		AuditTimestamp: scheduledTime,
+	}
+
+	if profile.GroupName, err = s.renderPartitionMacros(profile.GroupName, urn); err != nil {
+		return nil, err
+	}
+	if profile.Filter, err = s.renderPartitionMacros(profile.Filter, urn); err != nil {
+		return nil, err
+	}
+
+	return s.profileService.CreateProfile(profile)
+}
+
+func (s *Scheduler) renderPartitionMacros(expression string, urn string) (string, error) {
+	if !macros.IsUsingMacros(expression, macros.Partition) {
+		return expression, nil
+	}
+
+	partitionExpression, err := s.sqlExpressionFactory.CreatePartitionExpression(urn)
+	if err != nil {
+		return "", err
+	}
+	return macros.ReplaceMacros(expression, partitionExpression, macros.Partition)
+}
+
+//progress finish the run when its profile is finished, the profile is audited first when audit is enabled
+func (s *Scheduler) progress(run *protocol.ScheduleRun) error {
+	profile, err := s.profileService.Get(run.ProfileID)
+	if err != nil {
+		return err
+	}
+
+	if !profile.Status.IsFinished() {
+		return nil
+	}
+
+	if profile.Status != job.StateCompleted {
+		run.Status = protocol.ScheduleRunFailed
+		run.Message = fmt.Sprintf("profile %s, %s", profile.Status, profile.Message)
+		return ignoreStatusChanged(s.runStore.Update(run, protocol.ScheduleRunProfiling))
+	}
+
+	if !run.Audit {
+		run.Status = protocol.ScheduleRunCompleted
+		run.Message = "profile completed"
+		return ignoreStatusChanged(s.runStore.Update(run, protocol.ScheduleRunProfiling))
+	}
+
+	run.Status = protocol.ScheduleRunAuditing
+	run.Message = "profile completed, audit in progress"
+	if err := s.runStore.Update(run, protocol.ScheduleRunProfiling); err != nil {
+		return ignoreStatusChanged(err)
+	}
+
+	result, err := s.auditService.RunAudit(profile.ID)
+	if err != nil {
+		run.Status = protocol.ScheduleRunFailed
+		run.Message = fmt.Sprintf("audit failed because %s", err.Error())
+		if updateErr := s.runStore.Update(run, protocol.ScheduleRunAuditing); updateErr != nil {
+			return updateErr
+		}
+		return err
+	}
+
+	run.Status = protocol.ScheduleRunCompleted
+	run.Message = "audit completed"
+	run.AuditID = result.Audit.ID
+	return s.runStore.Update(run, protocol.ScheduleRunAuditing)
+}
+
+//ignoreStatusChanged ignore error of run that is already progressed by other replica
+func ignoreStatusChanged(err error) error {
+	if errors.Is(err, protocol.ErrScheduleRunStatusChanged) {
+		return nil
+	}
+	return err
+}
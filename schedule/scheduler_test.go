@@ -0,0 +1,355 @@
+package schedule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	urn := "project.dataset.table"
+	now := time.Date(2021, 1, 2, 10, 0, 0, 0, time.UTC)
+	scheduledTime := time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC)
+	spec := &protocol.ToleranceSpec{
+		URN: urn,
+		Schedule: &protocol.Schedule{
+			Cron:   "0 2 * * *",
+			Filter: "__PARTITION__ = '{{ .PreviousDate }}'",
+			Group:  "__PARTITION__",
+			Audit:  true,
+		},
+	}
+	var noRuns []*protocol.ScheduleRun
+	t.Run("tick", func(t *testing.T) {
+		t.Run("should create run and profile when schedule is due", func(t *testing.T) {
+			latestRun := &protocol.ScheduleRun{
+				ID:            1,
+				URN:           urn,
+				ScheduledTime: scheduledTime.AddDate(0, 0, -1),
+				Status:        protocol.ScheduleRunCompleted,
+			}
+			run := &protocol.ScheduleRun{
+				URN:           urn,
+				ScheduledTime: scheduledTime,
+				Status:        protocol.ScheduleRunCreated,
+				Message:       "schedule run created",
+				Audit:         true,
+			}
+			profile := &job.Profile{
+				URN:            urn,
+				Mode:           job.ModeComplete,
+				Filter:         "DATE(event_timestamp) = '2021-01-01'",
+				GroupName:      "DATE(event_timestamp)",
+				Status:         job.StateCreated,
+				Message:        "profile created",
+				AuditTimestamp: scheduledTime,
+			}
+			createdProfile := &job.Profile{ID: "profile-1", URN: urn}
+			profilingRun := &protocol.ScheduleRun{
+				URN:           urn,
+				ScheduledTime: scheduledTime,
+				Status:        protocol.ScheduleRunProfiling,
+				Message:       "profile created",
+				ProfileID:     "profile-1",
+				Audit:         true,
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			defer toleranceStore.AssertExpectations(t)
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{spec, {URN: "project.dataset.other"}}, nil)
+
+			runStore := mock.NewScheduleRunStore()
+			defer runStore.AssertExpectations(t)
+			runStore.On("GetLatest", urn).Return(latestRun, nil)
+			runStore.On("Create", run).Return(nil)
+			runStore.On("Update", profilingRun, protocol.ScheduleRunCreated).Return(nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return(noRuns, nil)
+
+			sqlExpressionFactory := mock.NewSQLExpressionFactory()
+			defer sqlExpressionFactory.AssertExpectations(t)
+			sqlExpressionFactory.On("CreatePartitionExpression", urn).Return("DATE(event_timestamp)", nil)
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+			profileService.On("CreateProfile", profile).Return(createdProfile, nil)
+
+			scheduler := New(toleranceStore, runStore, profileService, mock.NewAuditService(), sqlExpressionFactory, time.Minute, time.Minute, time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should not create run when schedule is not due", func(t *testing.T) {
+			latestRun := &protocol.ScheduleRun{
+				ID:            1,
+				URN:           urn,
+				ScheduledTime: scheduledTime,
+				Status:        protocol.ScheduleRunCompleted,
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{spec}, nil)
+
+			runStore := mock.NewScheduleRunStore()
+			defer runStore.AssertExpectations(t)
+			runStore.On("GetLatest", urn).Return(latestRun, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return(noRuns, nil)
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+
+			scheduler := New(toleranceStore, runStore, profileService, mock.NewAuditService(), mock.NewSQLExpressionFactory(), time.Minute, time.Minute, time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should not run activation before scheduler started when table has no run history", func(t *testing.T) {
+			var latestRun *protocol.ScheduleRun
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{spec}, nil)
+
+			runStore := mock.NewScheduleRunStore()
+			defer runStore.AssertExpectations(t)
+			runStore.On("GetLatest", urn).Return(latestRun, protocol.ErrScheduleRunNotFound)
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return(noRuns, nil)
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+
+			scheduler := New(toleranceStore, runStore, profileService, mock.NewAuditService(), mock.NewSQLExpressionFactory(), time.Minute, time.Minute, time.Hour)
+			scheduler.startedAt = scheduledTime.Add(time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should not create profile when run is already created by other replica", func(t *testing.T) {
+			var latestRun *protocol.ScheduleRun
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{spec}, nil)
+
+			runStore := mock.NewScheduleRunStore()
+			defer runStore.AssertExpectations(t)
+			runStore.On("GetLatest", urn).Return(latestRun, protocol.ErrScheduleRunNotFound)
+			runStore.On("Create", &protocol.ScheduleRun{
+				URN:           urn,
+				ScheduledTime: scheduledTime,
+				Status:        protocol.ScheduleRunCreated,
+				Message:       "schedule run created",
+				Audit:         true,
+			}).Return(protocol.ErrScheduleRunExists)
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return(noRuns, nil)
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+
+			scheduler := New(toleranceStore, runStore, profileService, mock.NewAuditService(), mock.NewSQLExpressionFactory(), time.Minute, time.Minute, time.Hour)
+			scheduler.startedAt = scheduledTime.Add(-time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should audit completed profile of the run", func(t *testing.T) {
+			run := &protocol.ScheduleRun{
+				ID:            1,
+				URN:           urn,
+				ScheduledTime: scheduledTime,
+				Status:        protocol.ScheduleRunProfiling,
+				ProfileID:     "profile-1",
+				Audit:         true,
+			}
+			auditingRun := &protocol.ScheduleRun{
+				ID:            1,
+				URN:           urn,
+				ScheduledTime: scheduledTime,
+				Status:        protocol.ScheduleRunAuditing,
+				Message:       "profile completed, audit in progress",
+				ProfileID:     "profile-1",
+				Audit:         true,
+			}
+			completedRun := &protocol.ScheduleRun{
+				ID:            1,
+				URN:           urn,
+				ScheduledTime: scheduledTime,
+				Status:        protocol.ScheduleRunCompleted,
+				Message:       "audit completed",
+				ProfileID:     "profile-1",
+				AuditID:       "audit-1",
+				Audit:         true,
+			}
+			profile := &job.Profile{ID: "profile-1", URN: urn, Status: job.StateCompleted}
+			auditResult := &protocol.AuditResult{Audit: &job.Audit{ID: "audit-1"}}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{}, nil)
+
+			runStore := mock.NewScheduleRunStore()
+			defer runStore.AssertExpectations(t)
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return([]*protocol.ScheduleRun{run}, nil)
+			runStore.On("Update", auditingRun, protocol.ScheduleRunProfiling).Return(nil).Once()
+			runStore.On("Update", completedRun, protocol.ScheduleRunAuditing).Return(nil).Once()
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+			profileService.On("Get", "profile-1").Return(profile, nil)
+
+			auditService := mock.NewAuditService()
+			defer auditService.AssertExpectations(t)
+			auditService.On("RunAudit", "profile-1").Return(auditResult, nil)
+
+			scheduler := New(toleranceStore, runStore, profileService, auditService, mock.NewSQLExpressionFactory(), time.Minute, time.Minute, time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should not audit when run is progressed by other replica", func(t *testing.T) {
+			run := &protocol.ScheduleRun{
+				ID:        1,
+				URN:       urn,
+				Status:    protocol.ScheduleRunProfiling,
+				ProfileID: "profile-1",
+				Audit:     true,
+			}
+			profile := &job.Profile{ID: "profile-1", URN: urn, Status: job.StateCompleted}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{}, nil)
+
+			runStore := mock.NewScheduleRunStore()
+			defer runStore.AssertExpectations(t)
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return([]*protocol.ScheduleRun{run}, nil)
+			runStore.On("Update", run, protocol.ScheduleRunProfiling).Return(protocol.ErrScheduleRunStatusChanged)
+
+			profileService := mock.NewProfileService()
+			profileService.On("Get", "profile-1").Return(profile, nil)
+
+			auditService := mock.NewAuditService()
+			defer auditService.AssertExpectations(t)
+
+			scheduler := New(toleranceStore, runStore, profileService, auditService, mock.NewSQLExpressionFactory(), time.Minute, time.Minute, time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should fail run when profile failed", func(t *testing.T) {
+			run := &protocol.ScheduleRun{
+				ID:        1,
+				URN:       urn,
+				Status:    protocol.ScheduleRunProfiling,
+				ProfileID: "profile-1",
+				Audit:     true,
+			}
+			failedRun := &protocol.ScheduleRun{
+				ID:        1,
+				URN:       urn,
+				Status:    protocol.ScheduleRunFailed,
+				Message:   "profile failed, profile failed because API error",
+				ProfileID: "profile-1",
+				Audit:     true,
+			}
+			profile := &job.Profile{ID: "profile-1", URN: urn, Status: job.StateFailed, Message: "profile failed because API error"}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{}, nil)
+
+			runStore := mock.NewScheduleRunStore()
+			defer runStore.AssertExpectations(t)
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return([]*protocol.ScheduleRun{run}, nil)
+			runStore.On("Update", failedRun, protocol.ScheduleRunProfiling).Return(nil)
+
+			profileService := mock.NewProfileService()
+			profileService.On("Get", "profile-1").Return(profile, nil)
+
+			scheduler := New(toleranceStore, runStore, profileService, mock.NewAuditService(), mock.NewSQLExpressionFactory(), time.Minute, time.Minute, time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Nil(t, err)
+		})
+		t.Run("should read specs again only after the spec refresh interval", func(t *testing.T) {
+			toleranceStore := mock.NewToleranceStore()
+			defer toleranceStore.AssertExpectations(t)
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{}, nil).Twice()
+
+			runStore := mock.NewScheduleRunStore()
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return(noRuns, nil)
+
+			scheduler := New(toleranceStore, runStore, mock.NewProfileService(), mock.NewAuditService(), mock.NewSQLExpressionFactory(), time.Minute, 5*time.Minute, time.Hour)
+
+			assert.Nil(t, scheduler.tick(now))
+			assert.Nil(t, scheduler.tick(now.Add(time.Minute)))
+			assert.Nil(t, scheduler.tick(now.Add(5*time.Minute)))
+		})
+		t.Run("should fail run that stay created or auditing longer than the run timeout", func(t *testing.T) {
+			stuckRun := &protocol.ScheduleRun{
+				ID:        1,
+				URN:       urn,
+				Status:    protocol.ScheduleRunAuditing,
+				ProfileID: "profile-1",
+				Audit:     true,
+				UpdatedAt: now.Add(-2 * time.Hour),
+			}
+			recentRun := &protocol.ScheduleRun{
+				ID:        2,
+				URN:       "project.dataset.other",
+				Status:    protocol.ScheduleRunCreated,
+				UpdatedAt: now.Add(-time.Minute),
+			}
+			failedRun := &protocol.ScheduleRun{
+				ID:        1,
+				URN:       urn,
+				Status:    protocol.ScheduleRunFailed,
+				Message:   "schedule run is auditing for more than 1h0m0s",
+				ProfileID: "profile-1",
+				Audit:     true,
+				UpdatedAt: now.Add(-2 * time.Hour),
+			}
+
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetAll").Return([]*protocol.ToleranceSpec{}, nil)
+
+			runStore := mock.NewScheduleRunStore()
+			defer runStore.AssertExpectations(t)
+			runStore.On("GetByStatus", protocol.ScheduleRunProfiling).Return(noRuns, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunCreated).Return([]*protocol.ScheduleRun{recentRun}, nil)
+			runStore.On("GetByStatus", protocol.ScheduleRunAuditing).Return([]*protocol.ScheduleRun{stuckRun}, nil)
+			runStore.On("Update", failedRun, protocol.ScheduleRunAuditing).Return(nil).Once()
+
+			scheduler := New(toleranceStore, runStore, mock.NewProfileService(), mock.NewAuditService(), mock.NewSQLExpressionFactory(), time.Minute, time.Minute, time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Nil(t, err)
+			assert.Equal(t, protocol.ScheduleRunCreated, recentRun.Status)
+		})
+		t.Run("should return error when get specs failed", func(t *testing.T) {
+			var specs []*protocol.ToleranceSpec
+			toleranceStore := mock.NewToleranceStore()
+			toleranceStore.On("GetAll").Return(specs, errors.New("API error"))
+
+			scheduler := New(toleranceStore, mock.NewScheduleRunStore(), mock.NewProfileService(), mock.NewAuditService(), mock.NewSQLExpressionFactory(), time.Minute, time.Minute, time.Hour)
+			err := scheduler.tick(now)
+
+			assert.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,143 @@
+package schedule
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/odpf/predator/protocol"
+)
+
+type scheduleRunRecord struct {
+	ID            int       `gorm:"primary_key"`
+	URN           string    `gorm:"not null;unique_index:sr_urn_scheduled_time_idx"`
+	ScheduledTime time.Time `gorm:"not null;unique_index:sr_urn_scheduled_time_idx"`
+	Status        string    `gorm:"not null"`
+	Message       string
+	ProfileID     string
+	AuditID       string
+	Audit         bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func newScheduleRunRecord(run *protocol.ScheduleRun) *scheduleRunRecord {
+	return &scheduleRunRecord{
+		ID:            run.ID,
+		URN:           run.URN,
+		ScheduledTime: run.ScheduledTime,
+		Status:        string(run.Status),
+		Message:       run.Message,
+		ProfileID:     run.ProfileID,
+		AuditID:       run.AuditID,
+		Audit:         run.Audit,
+	}
+}
+
+func (r *scheduleRunRecord) toScheduleRun() *protocol.ScheduleRun {
+	return &protocol.ScheduleRun{
+		ID:            r.ID,
+		URN:           r.URN,
+		ScheduledTime: r.ScheduledTime.In(time.UTC),
+		Status:        protocol.ScheduleRunStatus(r.Status),
+		Message:       r.Message,
+		ProfileID:     r.ProfileID,
+		AuditID:       r.AuditID,
+		Audit:         r.Audit,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+//Store is postgres backed store of schedule run history
+type Store struct {
+	db *gorm.DB
+}
+
+//NewStore create schedule run store
+func NewStore(db *gorm.DB, tableName string) protocol.ScheduleRunStore {
+	return &Store{db: db.Table(tableName)}
+}
+
+//Create run, the unique index of urn and scheduled time make sure only one replica create the run
+func (s *Store) Create(run *protocol.ScheduleRun) error {
+	record := newScheduleRunRecord(run)
+	handle := s.db.Set("gorm:insert_option", "ON CONFLICT DO NOTHING").Create(record)
+
+	//postgres return no row on conflict because the id is returned by the insert statement
+	if handle.Error == sql.ErrNoRows || (handle.Error == nil && handle.RowsAffected == 0) {
+		return protocol.ErrScheduleRunExists
+	}
+	if err := handle.Error; err != nil {
+		return err
+	}
+
+	run.ID = record.ID
+	run.CreatedAt = record.CreatedAt
+	run.UpdatedAt = record.UpdatedAt
+	return nil
+}
+
+//Update run when its status is still the previous status
+func (s *Store) Update(run *protocol.ScheduleRun, previous protocol.ScheduleRunStatus) error {
+	updatedAt := time.Now().In(time.UTC)
+	handle := s.db.Model(&scheduleRunRecord{}).
+		Where("id = ? AND status = ?", run.ID, string(previous)).
+		Updates(map[string]interface{}{
+			"status":     string(run.Status),
+			"message":    run.Message,
+			"profile_id": run.ProfileID,
+			"audit_id":   run.AuditID,
+			"updated_at": updatedAt,
+		})
+	if err := handle.Error; err != nil {
+		return err
+	}
+	if handle.RowsAffected == 0 {
+		return protocol.ErrScheduleRunStatusChanged
+	}
+
+	run.UpdatedAt = updatedAt
+	return nil
+}
+
+//GetLatest get run with the latest scheduled time of the urn
+func (s *Store) GetLatest(urn string) (*protocol.ScheduleRun, error) {
+	var records []*scheduleRunRecord
+	handle := s.db.Where("urn = ?", urn).Order("scheduled_time DESC").Limit(1).Find(&records)
+	if err := handle.Error; err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, protocol.ErrScheduleRunNotFound
+	}
+	return records[0].toScheduleRun(), nil
+}
+
+//GetByStatus get runs with the status
+func (s *Store) GetByStatus(status protocol.ScheduleRunStatus) ([]*protocol.ScheduleRun, error) {
+	var records []*scheduleRunRecord
+	handle := s.db.Where("status = ?", string(status)).Order("id").Find(&records)
+	if err := handle.Error; err != nil {
+		return nil, err
+	}
+	return toScheduleRuns(records), nil
+}
+
+//GetByURN get runs of the urn ordered by the latest scheduled time
+func (s *Store) GetByURN(urn string) ([]*protocol.ScheduleRun, error) {
+	var records []*scheduleRunRecord
+	handle := s.db.Where("urn = ?", urn).Order("scheduled_time DESC").Find(&records)
+	if err := handle.Error; err != nil {
+		return nil, err
+	}
+	return toScheduleRuns(records), nil
+}
+
+func toScheduleRuns(records []*scheduleRunRecord) []*protocol.ScheduleRun {
+	var runs []*protocol.ScheduleRun
+	for _, record := range records {
+		runs = append(runs, record.toScheduleRun())
+	}
+	return runs
+}
@@ -0,0 +1,109 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	pmock "github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	tableName := "schedule_run_records"
+	urn := "project.dataset.table"
+	scheduledTime := time.Date(2021, 1, 2, 2, 0, 0, 0, time.UTC)
+	t.Run("Create", func(t *testing.T) {
+		t.Run("should create run", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(scheduleRunRecord))
+			defer clearDb()
+
+			store := NewStore(db, tableName)
+			run := &protocol.ScheduleRun{URN: urn, ScheduledTime: scheduledTime, Status: protocol.ScheduleRunCreated, Audit: true}
+
+			err := store.Create(run)
+			latest, getErr := store.GetLatest(urn)
+
+			assert.Nil(t, err)
+			assert.Nil(t, getErr)
+			assert.NotZero(t, run.ID)
+			assert.Equal(t, run.ID, latest.ID)
+			assert.Equal(t, scheduledTime, latest.ScheduledTime)
+			assert.True(t, latest.Audit)
+		})
+		t.Run("should return ErrScheduleRunExists when run of the urn at the scheduled time exists", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(scheduleRunRecord))
+			defer clearDb()
+
+			store := NewStore(db, tableName)
+			assert.Nil(t, store.Create(&protocol.ScheduleRun{URN: urn, ScheduledTime: scheduledTime, Status: protocol.ScheduleRunCreated}))
+
+			err := store.Create(&protocol.ScheduleRun{URN: urn, ScheduledTime: scheduledTime, Status: protocol.ScheduleRunCreated})
+
+			assert.Equal(t, protocol.ErrScheduleRunExists, err)
+		})
+	})
+	t.Run("Update", func(t *testing.T) {
+		t.Run("should update run with the previous status", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(scheduleRunRecord))
+			defer clearDb()
+
+			store := NewStore(db, tableName)
+			run := &protocol.ScheduleRun{URN: urn, ScheduledTime: scheduledTime, Status: protocol.ScheduleRunCreated}
+			assert.Nil(t, store.Create(run))
+
+			run.Status = protocol.ScheduleRunProfiling
+			run.ProfileID = "profile-1"
+			err := store.Update(run, protocol.ScheduleRunCreated)
+
+			profiling, getErr := store.GetByStatus(protocol.ScheduleRunProfiling)
+
+			assert.Nil(t, err)
+			assert.Nil(t, getErr)
+			assert.Len(t, profiling, 1)
+			assert.Equal(t, "profile-1", profiling[0].ProfileID)
+		})
+		t.Run("should return ErrScheduleRunStatusChanged when status is changed", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(scheduleRunRecord))
+			defer clearDb()
+
+			store := NewStore(db, tableName)
+			run := &protocol.ScheduleRun{URN: urn, ScheduledTime: scheduledTime, Status: protocol.ScheduleRunProfiling}
+			assert.Nil(t, store.Create(run))
+
+			run.Status = protocol.ScheduleRunAuditing
+			err := store.Update(run, protocol.ScheduleRunCreated)
+
+			assert.Equal(t, protocol.ErrScheduleRunStatusChanged, err)
+		})
+	})
+	t.Run("GetByURN", func(t *testing.T) {
+		t.Run("should return runs of the urn ordered by the latest scheduled time", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(scheduleRunRecord))
+			defer clearDb()
+
+			store := NewStore(db, tableName)
+			nextScheduledTime := scheduledTime.Add(24 * time.Hour)
+			assert.Nil(t, store.Create(&protocol.ScheduleRun{URN: urn, ScheduledTime: scheduledTime, Status: protocol.ScheduleRunCompleted}))
+			assert.Nil(t, store.Create(&protocol.ScheduleRun{URN: urn, ScheduledTime: nextScheduledTime, Status: protocol.ScheduleRunCreated}))
+			assert.Nil(t, store.Create(&protocol.ScheduleRun{URN: "project.dataset.other", ScheduledTime: scheduledTime, Status: protocol.ScheduleRunCreated}))
+
+			runs, err := store.GetByURN(urn)
+
+			assert.Nil(t, err)
+			assert.Len(t, runs, 2)
+			assert.Equal(t, nextScheduledTime, runs[0].ScheduledTime)
+			assert.Equal(t, scheduledTime, runs[1].ScheduledTime)
+		})
+		t.Run("should return ErrScheduleRunNotFound when urn has no run", func(t *testing.T) {
+			db, clearDb := pmock.NewDatabase(new(scheduleRunRecord))
+			defer clearDb()
+
+			store := NewStore(db, tableName)
+			run, err := store.GetLatest(urn)
+
+			assert.Nil(t, run)
+			assert.Equal(t, protocol.ErrScheduleRunNotFound, err)
+		})
+	})
+}
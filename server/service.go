@@ -2,14 +2,21 @@ package server
 
 import (
 	"context"
+	"database/sql"
 	"encoding/base64"
 	"fmt"
 	"github.com/odpf/predator/audit"
 	"github.com/odpf/predator/auditor"
 	"github.com/odpf/predator/bigqueryjob"
+	"github.com/odpf/predator/cost"
+	"github.com/odpf/predator/metric/custom"
 	"github.com/odpf/predator/metric/field"
 	"github.com/odpf/predator/metric/table"
 	"github.com/odpf/predator/profile"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/reconciliation"
+	"github.com/odpf/predator/schedule"
 	"github.com/odpf/predator/status"
 	"io/ioutil"
 	"log"
@@ -31,6 +38,7 @@ import (
 
 	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
 	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
 	"github.com/googleapis/google-cloud-go-testing/storage/stiface"
 	"github.com/gorilla/handlers"
@@ -50,12 +58,16 @@ import (
 
 const MetadataCacheExpirationSeconds = 180
 
+//ProfileJobPollIntervalSeconds is wait duration of idle profile worker before claiming the next job
+const ProfileJobPollIntervalSeconds = 5
+
 //HTTPService is predator as http service
 type HTTPService struct {
 	statsClient      stats.Client
 	server           *http.Server
 	auditService     protocol.AuditService
 	profileService   protocol.ProfileService
+	scheduler        *schedule.Scheduler
 	auditPublisher   protocol.Publisher
 	profilePublisher protocol.Publisher
 }
@@ -88,7 +100,11 @@ func (s *HTTPService) Shutdown() {
 		log.Fatalf("Unable to gracefully shutdown the server: %v\n", err)
 	}
 
-	err := s.profileService.WaitAll(ctx)
+	err := s.scheduler.Stop(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = s.profileService.WaitAll(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -143,7 +159,26 @@ func StartService(confFile *conf.ConfigFile, version string) {
 		return
 	}
 
-	directMetadataStore := metadata.NewStore(bqClient, uniqueConstraintStore)
+	warehouseDB, warehouseDatabase, err := newWarehouseDatabase(config.PostgresWarehouseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if warehouseDB != nil {
+		defer func() {
+			if err := warehouseDB.Close(); err != nil {
+				log.Println(err)
+			}
+		}()
+	}
+
+	metadataStores := map[meta.Warehouse]protocol.MetadataStore{
+		meta.WarehouseBigQuery: metadata.NewStore(bqClient, uniqueConstraintStore),
+	}
+	if warehouseDB != nil {
+		metadataStores[meta.WarehousePostgres] = metadata.NewPostgresStore(warehouseDB, warehouseDatabase, uniqueConstraintStore)
+	}
+	directMetadataStore := metadata.NewWarehouseStore(metadataStores)
 	metadataStore := metadata.NewCachedStore(MetadataCacheExpirationSeconds, directMetadataStore)
 
 	gcsC, err := storage.NewClient(context.Background())
@@ -154,7 +189,11 @@ func StartService(confFile *conf.ConfigFile, version string) {
 	gcsClient := stiface.AdaptClient(gcsC)
 	fileStoreFactory := tolerance.NewFileStoreFactory(gcsClient)
 	pathResolverFactory := tolerance.NewPathResolverFactory(entityStore)
-	toleranceStoreFactory := tolerance.NewFactory(pathResolverFactory, fileStoreFactory)
+	var tableLister protocol.TableLister
+	if config.DefaultsEnabled && config.DefaultsExpansionEnabled {
+		tableLister = metadata.NewTableLister(bqClient)
+	}
+	toleranceStoreFactory := tolerance.NewFactory(pathResolverFactory, fileStoreFactory, config.DefaultsEnabled, tableLister)
 	toleranceStore, err := toleranceStoreFactory.Create(config.ToleranceURL, config.MultiTenancyEnabled)
 
 	if err != nil {
@@ -193,26 +232,47 @@ func StartService(confFile *conf.ConfigFile, version string) {
 	}
 
 	gitRepositoryFactory := tolerance.NewGitRepositoryFactory(gitAuthPrivateKey)
-	uploadFactory := tolerance.NewUploadFactory(config.MultiTenancyEnabled, entityStore, toleranceStoreFactory, toleranceStore, gitRepositoryFactory, statsClientBuilder, metadataStore)
-
 	statusStore := status.NewStore(db, "status")
 
 	profileStore := profile.NewStore(db, "profile", statusStore)
 	metricStore := profile.NewMetricStore(db, "metric")
+	schemaStore := metadata.NewSchemaStore(db, "schema_snapshot")
 
 	bqJob := bigqueryjob.NewStore(db, "bigquery_job")
-	queryExecutor := query.NewBigqueryExecutor(bqClient, bqJob, profileStore, statsClientBuilder)
+	queryExecutors := map[meta.Warehouse]protocol.QueryExecutor{
+		meta.WarehouseBigQuery: query.NewBigqueryExecutor(bqClient, bqJob, profileStore, statsClientBuilder),
+	}
+	if warehouseDB != nil {
+		queryExecutors[meta.WarehousePostgres] = query.NewPostgresExecutor(warehouseDB, warehouseDatabase, profileStore, statsClientBuilder)
+	}
+	queryExecutor := query.NewWarehouseExecutor(queryExecutors)
 
 	fieldProfiler := field.New(queryExecutor, metadataStore)
 	tableProfiler := table.New(queryExecutor, metadataStore)
 	basicMetricProfiler := metric.NewBasicMetricProfiler(tableProfiler, fieldProfiler, profileStore, statsClientBuilder)
-	basicMetricGenerator := metric.NewDefaultGenerator(metricSpecGenerator, basicMetricProfiler, metricStore)
+	defaultBasicMetricGenerator := metric.NewDefaultGenerator(metricSpecGenerator, basicMetricProfiler, metricStore)
+
+	sqlExpressionFactory := query.NewSQLExpressionFactory(metadataStore)
+	partitionScanner := metadata.NewPartitionScanner(bqClient)
+	maxCarryForward := time.Duration(config.IncrementalMaxCarryForwardDays) * 24 * time.Hour
+	basicMetricGenerator := metric.NewIncrementalGenerator(defaultBasicMetricGenerator, profileStore, metricStore, partitionScanner, sqlExpressionFactory, maxCarryForward)
 
 	qualityMetricProfiler := metric.NewQualityMetricProfiler(metricStore, profileStore, statsClientBuilder)
 	qualityMetricGenerator := metric.NewDefaultGenerator(qualityMetricSpecGenerator, qualityMetricProfiler, metricStore)
 
-	profileStatisticGenerator := metric.NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore)
-	metricGenerator := metric.NewMultistageGenerator([]protocol.MetricGenerator{basicMetricGenerator, qualityMetricGenerator}, profileStatisticGenerator)
+	customSQLSpecGenerator := metric.NewCustomSQLSpecGenerator(metadataStore, toleranceStore)
+	customSQLProfiler := custom.New(queryExecutor, metadataStore)
+	customSQLMetricGenerator := metric.NewDefaultGenerator(customSQLSpecGenerator, customSQLProfiler, metricStore)
+
+	customSQLValidator := custom.NewQueryValidator(metadataStore, customSQLSpecGenerator, customSQLProfiler, queryExecutor)
+	uploadFactory := tolerance.NewUploadFactory(config.MultiTenancyEnabled, entityStore, toleranceStoreFactory, toleranceStore, gitRepositoryFactory, statsClientBuilder, metadataStore, customSQLValidator, config.SpecUploadMaxRemovalPct)
+
+	profileStatisticGenerator := metric.NewDefaultProfileStatisticGenerator(metadataStore, queryExecutor, profileStore, schemaStore)
+	standardMetricGenerator := metric.NewMultistageGenerator([]protocol.MetricGenerator{basicMetricGenerator, qualityMetricGenerator, customSQLMetricGenerator}, profileStatisticGenerator)
+	exploratoryMetricGenerator := tolerance.NewExploratoryGenerator(metadataStore, basicMetricProfiler, metricStore)
+	metricGenerator := metric.NewKindGenerator(standardMetricGenerator, map[job.Kind]protocol.MetricGenerator{
+		job.KindExploratory: exploratoryMetricGenerator,
+	})
 
 	messageProviderFactory := message.NewProviderFactory(profileStore, metadataStore)
 
@@ -228,12 +288,23 @@ func StartService(confFile *conf.ConfigFile, version string) {
 	profileKafkaSink := sinkFactory.Create(profileSinkConfig)
 	profilePublisher := publisher.NewPublisher(profileKafkaSink)
 
-	profileService := profile.NewService(profileStore, metricGenerator, profilePublisher, messageProviderFactory, statusStore, statsClientBuilder)
+	jobQueue := profile.NewQueue(db, "job_queue")
+	workerConfig := &profile.WorkerConfig{
+		Owner:         fmt.Sprintf("%s-%s", config.PodName, uuid.New().String()),
+		Count:         config.ProfileWorker.Count,
+		LeaseDuration: time.Duration(config.ProfileWorker.LeaseSeconds) * time.Second,
+		MaxAttempts:   config.ProfileWorker.MaxAttempts,
+		PollInterval:  ProfileJobPollIntervalSeconds * time.Second,
+	}
+	limitResolver := cost.NewLimitResolver(config.MaxBytesBilled, entityStore, toleranceStore)
+	costEstimator := cost.NewEstimator(metricGenerator, queryExecutor, limitResolver)
+
+	profileService := profile.NewService(profileStore, metricStore, metricGenerator, profilePublisher, messageProviderFactory, statusStore, statsClientBuilder, jobQueue, queryExecutor, costEstimator, workerConfig)
 
-	auditStore := audit.NewStore(db, "audit", statusStore)
+	auditStore := audit.NewStore(db, "audit", "audit_result", statusStore)
 	auditResultStore := audit.NewResultStore(db, "audit_result")
 	ruleValidator := auditor.NewDefaultRuleValidator()
-	metricAuditor := auditor.New(toleranceStore, ruleValidator, metadataStore, metricStore)
+	metricAuditor := auditor.New(toleranceStore, ruleValidator, profileStore, metadataStore, metricStore, schemaStore)
 
 	auditSinkConfig := &protocol.SinkConfig{
 		Type:   protocol.Kafka,
@@ -247,10 +318,24 @@ func StartService(confFile *conf.ConfigFile, version string) {
 	auditPublisher := publisher.NewPublisher(auditKafkaSink)
 	auditService := audit.NewService(profileStore, auditStore, auditResultStore, metricAuditor, auditPublisher, messageProviderFactory, metadataStore, statsClientBuilder)
 
-	sqlExpressionFactory := query.NewSQLExpressionFactory(metadataStore)
 	auditSummaryFactory := audit.NewAuditSummaryFactory(toleranceStore)
 
-	v1beta1Routes := router.NewV1Beta1RouteGroup(profileService, auditService, toleranceStore, entityStore, uploadFactory, auditSummaryFactory, sqlExpressionFactory, metricStore)
+	scheduleRunStore := schedule.NewStore(db, "schedule_run")
+	schedulerInterval := time.Duration(config.SchedulerIntervalSeconds) * time.Second
+	schedulerSpecRefreshInterval := time.Duration(config.SchedulerSpecRefreshSeconds) * time.Second
+	schedulerRunTimeout := time.Duration(config.SchedulerRunTimeoutSeconds) * time.Second
+	scheduler := schedule.New(toleranceStore, scheduleRunStore, profileService, auditService, sqlExpressionFactory, schedulerInterval, schedulerSpecRefreshInterval, schedulerRunTimeout)
+	scheduler.Start()
+
+	specSuggester := tolerance.NewSuggester(metadataStore, metricStore, profileService)
+
+	reconciliationStore := reconciliation.NewStore(db, "reconciliation", statusStore)
+	reconciliationService := reconciliation.NewService(reconciliationStore, jobQueue, metadataStore, profileStore, metricStore, basicMetricProfiler, costEstimator,
+		auditStore, auditResultStore, auditPublisher, messageProviderFactory)
+	profileService.Handle(job.TypeReconciliation, reconciliationService)
+	profileService.Start()
+
+	v1beta1Routes := router.NewV1Beta1RouteGroup(profileService, auditService, toleranceStore, entityStore, uploadFactory, auditSummaryFactory, sqlExpressionFactory, metricStore, scheduleRunStore, specSuggester, reconciliationService)
 
 	apiRouter := router.New(v1beta1Routes)
 
@@ -267,6 +352,7 @@ func StartService(confFile *conf.ConfigFile, version string) {
 		profilePublisher: profilePublisher,
 		auditService:     auditService,
 		profileService:   profileService,
+		scheduler:        scheduler,
 	}
 	<-service.Start()
 	service.Shutdown()
@@ -315,6 +401,29 @@ func newDatabase(dbConf *conf.Database) (*gorm.DB, error) {
 	return db, nil
 }
 
+//newWarehouseDatabase connect to postgresql warehouse and get name of the connected database, nil when the URL is not configured
+func newWarehouseDatabase(URL string) (*sql.DB, string, error) {
+	if URL == "" {
+		return nil, "", nil
+	}
+
+	db, err := sql.Open("postgres", URL)
+	if err != nil {
+		return nil, "", fmt.Errorf("error connection to postgres warehouse %v", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, "", fmt.Errorf("error connection to postgres warehouse %v", err)
+	}
+
+	var database string
+	if err := db.QueryRow("SELECT current_database()").Scan(&database); err != nil {
+		return nil, "", fmt.Errorf("error getting database of postgres warehouse %v", err)
+	}
+
+	return db, database, nil
+}
+
 func newBigqueryClient(ctx context.Context, projectID string, acc string) (bqiface.Client, error) {
 	var options []option.ClientOption
 	if acc != "" {
@@ -4,13 +4,24 @@ import (
 	"errors"
 	"fmt"
 	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+	"path"
+	"strings"
 )
 
+//defaultsFileName is name of default spec file inherited by every table spec in the same project or dataset
+//with directory layout it is placed on the project and dataset directory, such as project/dataset/_defaults.yaml
+//with default layout it replace the table name or both dataset and table name, such as project.dataset._defaults.yaml
+const defaultsFileName = "_defaults.yaml"
+
 //FileBasedStore is storage of tolerance spec that use file as storage
 type FileBasedStore struct {
-	fileStore    protocol.FileStore
-	pathResolver protocol.PathResolver
-	parser       Parser
+	fileStore       protocol.FileStore
+	pathResolver    protocol.PathResolver
+	parser          Parser
+	defaultsEnabled bool
+	tableLister     protocol.TableLister
 }
 
 //NewFileBasedStore is constructor
@@ -22,6 +33,14 @@ func NewFileBasedStore(toleranceRepo protocol.FileStore, pathResolver protocol.P
 	}
 }
 
+//WithDefaults enable inheritance of project and dataset level default specs, the table spec wins over dataset defaults and dataset defaults win over project defaults
+//when tableLister is not nil, dataset defaults are expanded to every table of the dataset that has no spec file
+func (f *FileBasedStore) WithDefaults(tableLister protocol.TableLister) *FileBasedStore {
+	f.defaultsEnabled = true
+	f.tableLister = tableLister
+	return f
+}
+
 //GetByTableID to get tolerances of a table using table ID
 func (f *FileBasedStore) GetByTableID(tableID string) (*protocol.ToleranceSpec, error) {
 	relativePath, err := f.pathResolver.GetPath(tableID)
@@ -29,6 +48,31 @@ func (f *FileBasedStore) GetByTableID(tableID string) (*protocol.ToleranceSpec,
 		return nil, err
 	}
 
+	toleranceSpec, err := f.getSpec(relativePath)
+	if !f.defaultsEnabled || (err != nil && !errors.Is(err, protocol.ErrToleranceNotFound)) {
+		return toleranceSpec, err
+	}
+
+	specFound := err == nil
+	if !specFound && f.tableLister == nil {
+		return nil, err
+	}
+
+	defaultSpecs, datasetDefaultsFound, defaultsErr := f.getDefaults(relativePath)
+	if defaultsErr != nil {
+		return nil, defaultsErr
+	}
+	if !specFound {
+		if !datasetDefaultsFound {
+			return nil, err
+		}
+		toleranceSpec = &protocol.ToleranceSpec{URN: tableID}
+	}
+
+	return mergeSpecs(tableID, append(defaultSpecs, toleranceSpec)...), nil
+}
+
+func (f *FileBasedStore) getSpec(relativePath string) (*protocol.ToleranceSpec, error) {
 	file, err := f.fileStore.Get(relativePath)
 	if err != nil {
 		if errors.Is(err, protocol.ErrFileNotFound) {
@@ -39,12 +83,128 @@ func (f *FileBasedStore) GetByTableID(tableID string) (*protocol.ToleranceSpec,
 
 	toleranceSpec, err := f.parser.Parse(file.Content)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file %s :\n%w", relativePath, err)
+		return nil, &specParseError{path: relativePath, err: err}
 	}
 
 	return toleranceSpec, nil
 }
 
+//specParseError is error of a spec file that can not be parsed
+type specParseError struct {
+	path string
+	err  error
+}
+
+func (e *specParseError) Error() string {
+	return fmt.Sprintf("failed to read file %s :\n%s", e.path, e.err)
+}
+
+func (e *specParseError) Unwrap() error {
+	return e.err
+}
+
+//getDefaults get existing default specs of the table path ordered from project level, and whether dataset level defaults exist
+func (f *FileBasedStore) getDefaults(tablePath string) ([]*protocol.ToleranceSpec, bool, error) {
+	var specs []*protocol.ToleranceSpec
+	var datasetDefaultsFound bool
+	for i, defaultsPath := range getDefaultsPaths(tablePath) {
+		spec, err := f.getSpec(defaultsPath)
+		if errors.Is(err, protocol.ErrToleranceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		specs = append(specs, spec)
+		datasetDefaultsFound = i == 1
+	}
+	return specs, datasetDefaultsFound, nil
+}
+
+//withDefaults merge the spec with its default specs when inheritance is enabled
+func (f *FileBasedStore) withDefaults(urn string, tablePath string, spec *protocol.ToleranceSpec) (*protocol.ToleranceSpec, error) {
+	if !f.defaultsEnabled {
+		return spec, nil
+	}
+	defaultSpecs, _, err := f.getDefaults(tablePath)
+	if err != nil {
+		return nil, err
+	}
+	return mergeSpecs(urn, append(defaultSpecs, spec)...), nil
+}
+
+//getDefaultsPaths get path of project and dataset level default specs of the table path, ordered from project level
+func getDefaultsPaths(tablePath string) []string {
+	if strings.Contains(tablePath, "/") {
+		datasetDir := path.Dir(tablePath)
+		return []string{path.Join(path.Dir(datasetDir), defaultsFileName), path.Join(datasetDir, defaultsFileName)}
+	}
+
+	segments := strings.Split(tablePath, ".")
+	if len(segments) < 4 {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("%s.%s", segments[0], defaultsFileName),
+		fmt.Sprintf("%s.%s.%s", segments[0], segments[1], defaultsFileName),
+	}
+}
+
+func isDefaultsPath(filePath string) bool {
+	return path.Base(filePath) == defaultsFileName || strings.HasSuffix(filePath, "."+defaultsFileName)
+}
+
+//toleranceKey identify tolerance of a spec, a spec has at most one tolerance of a metric on a field or field selector
+//with the same condition and identifying metadata, see protocol.Tolerance Identity
+type toleranceKey struct {
+	fieldID    string
+	selector   string
+	metricName metric.Type
+	condition  string
+	identity   string
+}
+
+func toleranceKeyOf(t *protocol.Tolerance) toleranceKey {
+	key := toleranceKey{fieldID: t.FieldID, metricName: t.MetricName, condition: t.Condition, identity: t.Identity()}
+	if t.FieldSelector != nil {
+		key.selector = t.FieldSelector.String()
+	}
+	return key
+}
+
+//mergeSpecs merge specs ordered from the least specific, tolerance with the same key is taken from the more specific spec
+//max bytes billed and schedule are taken from the most specific spec that configure them
+func mergeSpecs(urn string, specs ...*protocol.ToleranceSpec) *protocol.ToleranceSpec {
+	merged := &protocol.ToleranceSpec{URN: urn}
+	for _, spec := range specs {
+		overridden := make(map[toleranceKey]bool)
+		for _, t := range spec.Tolerances {
+			overridden[toleranceKeyOf(t)] = true
+		}
+
+		var tolerances []*protocol.Tolerance
+		for _, t := range merged.Tolerances {
+			if !overridden[toleranceKeyOf(t)] {
+				tolerances = append(tolerances, t)
+			}
+		}
+		for _, t := range spec.Tolerances {
+			inherited := *t
+			inherited.TableURN = urn
+			tolerances = append(tolerances, &inherited)
+		}
+		merged.Tolerances = tolerances
+
+		if spec.MaxBytesBilled != 0 {
+			merged.MaxBytesBilled = spec.MaxBytesBilled
+		}
+		if spec.Schedule != nil {
+			merged.Schedule = spec.Schedule
+		}
+	}
+	return merged
+}
+
 func (f *FileBasedStore) Create(spec *protocol.ToleranceSpec) error {
 	filePath, err := f.pathResolver.GetPath(spec.URN)
 	if err != nil {
@@ -86,6 +246,10 @@ func (f *FileBasedStore) GetAll() ([]*protocol.ToleranceSpec, error) {
 
 	var specs []*protocol.ToleranceSpec
 	for _, file := range files {
+		if f.defaultsEnabled && isDefaultsPath(file.Path) {
+			continue
+		}
+
 		urn, err := f.pathResolver.GetURN(file.Path)
 		if err != nil {
 			return nil, err
@@ -100,6 +264,11 @@ func (f *FileBasedStore) GetAll() ([]*protocol.ToleranceSpec, error) {
 			return nil, fmt.Errorf("invalid tableID %s", file.Path)
 		}
 
+		spec, err = f.withDefaults(urn, file.Path, spec)
+		if err != nil {
+			return nil, err
+		}
+
 		specs = append(specs, spec)
 	}
 
@@ -114,6 +283,10 @@ func (f *FileBasedStore) GetByProjectID(projectID string) ([]*protocol.Tolerance
 
 	var specs []*protocol.ToleranceSpec
 	for _, path := range paths {
+		if f.defaultsEnabled && isDefaultsPath(path) {
+			continue
+		}
+
 		urn, err := f.pathResolver.GetURN(path)
 		if err != nil {
 			return nil, err
@@ -135,6 +308,11 @@ func (f *FileBasedStore) GetByProjectID(projectID string) ([]*protocol.Tolerance
 				return nil, err
 			}
 
+			spec, err = f.withDefaults(urn, path, spec)
+			if err != nil {
+				return nil, err
+			}
+
 			specs = append(specs, spec)
 		}
 	}
@@ -149,7 +327,13 @@ func (f *FileBasedStore) GetResourceNames() ([]string, error) {
 	}
 
 	var urns []string
+	var defaultsPaths []string
 	for _, path := range paths {
+		if f.defaultsEnabled && isDefaultsPath(path) {
+			defaultsPaths = append(defaultsPaths, path)
+			continue
+		}
+
 		urn, err := f.pathResolver.GetURN(path)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get urn from path %s :\n%w", path, err)
@@ -158,17 +342,69 @@ func (f *FileBasedStore) GetResourceNames() ([]string, error) {
 		urns = append(urns, urn)
 	}
 
+	if f.tableLister == nil {
+		return urns, nil
+	}
+
+	return f.expandDefaults(urns, defaultsPaths)
+}
+
+//expandDefaults add tables without spec file of every bigquery dataset that has dataset level defaults, table lister only list bigquery tables
+//dataset level defaults path is resolved as the path of a table named _defaults, project level defaults path is not resolved to any table
+func (f *FileBasedStore) expandDefaults(urns []string, defaultsPaths []string) ([]string, error) {
+	listed := make(map[string]bool)
+	for _, urn := range urns {
+		listed[urn] = true
+	}
+
+	for _, defaultsPath := range defaultsPaths {
+		defaultsURN, err := f.pathResolver.GetURN(defaultsPath)
+		if err != nil || meta.ParseWarehouse(defaultsURN) != meta.WarehouseBigQuery {
+			continue
+		}
+		label, err := protocol.ParseLabel(defaultsURN)
+		if err != nil {
+			return nil, err
+		}
+
+		tableURNs, err := f.tableLister.ListTables(label.Project, label.Dataset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tables of dataset %s.%s :\n%w", label.Project, label.Dataset, err)
+		}
+		for _, urn := range tableURNs {
+			if !listed[urn] {
+				listed[urn] = true
+				urns = append(urns, urn)
+			}
+		}
+	}
 	return urns, nil
 }
 
 type Factory struct {
 	resolverFactory  *PathResolverFactory
 	fileStoreFactory protocol.FileStoreFactory
+	defaultsEnabled  bool
+	tableLister      protocol.TableLister
 }
 
-//NewFactory create Factory of protocol.ToleranceStore
-func NewFactory(resolverFactory *PathResolverFactory, fileStoreFactory protocol.FileStoreFactory) *Factory {
-	return &Factory{resolverFactory: resolverFactory, fileStoreFactory: fileStoreFactory}
+//NewFactory create Factory of protocol.ToleranceStore, created stores inherit default specs when defaultsEnabled is true
+//dataset defaults are expanded to every table of the dataset when tableLister is not nil
+func NewFactory(resolverFactory *PathResolverFactory, fileStoreFactory protocol.FileStoreFactory, defaultsEnabled bool, tableLister protocol.TableLister) *Factory {
+	return &Factory{
+		resolverFactory:  resolverFactory,
+		fileStoreFactory: fileStoreFactory,
+		defaultsEnabled:  defaultsEnabled,
+		tableLister:      tableLister,
+	}
+}
+
+func (t *Factory) newStore(fileStore protocol.FileStore, resolver protocol.PathResolver) *FileBasedStore {
+	store := NewFileBasedStore(fileStore, resolver, NewSmartParser())
+	if !t.defaultsEnabled {
+		return store
+	}
+	return store.WithDefaults(t.tableLister)
 }
 
 //Create multiple implementation of protocol.ToleranceStore
@@ -186,7 +422,7 @@ func (t *Factory) Create(URL string, multiTenancyEnabled bool) (protocol.Toleran
 		resolver = t.resolverFactory.CreateResolver(protocol.Default)
 	}
 
-	return NewFileBasedStore(fileStore, resolver, NewSmartParser()), nil
+	return t.newStore(fileStore, resolver), nil
 }
 
 //CreateWithOptions intended to create more customised version of protocol.ToleranceStore
@@ -204,5 +440,5 @@ func (t *Factory) CreateWithOptions(store protocol.FileStore, pathType protocol.
 	default:
 		return nil, errors.New("unsupported protocol.PathType")
 	}
-	return NewFileBasedStore(store, resolver, NewSmartParser()), nil
+	return t.newStore(store, resolver), nil
 }
@@ -804,3 +804,263 @@ var content = `- tableid: "project.dataset.table"
   metricname: "nullness_pct"
   tolerancerules:
     less_than_eq: 10.0`
+
+func TestToleranceStoreWithDefaults(t *testing.T) {
+	projectDefaults := &protocol.ToleranceSpec{
+		Tolerances: []*protocol.Tolerance{
+			{
+				MetricName:     metric.RowCount,
+				ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}},
+			},
+			{
+				MetricName:     metric.DuplicationPct,
+				ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10}},
+			},
+		},
+		MaxBytesBilled: 1000,
+	}
+	datasetDefaults := &protocol.ToleranceSpec{
+		Tolerances: []*protocol.Tolerance{
+			{
+				FieldID:        "_id",
+				MetricName:     metric.NullnessPct,
+				ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+			},
+			{
+				MetricName:     metric.DuplicationPct,
+				ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 1}},
+			},
+		},
+	}
+
+	t.Run("GetByTableID", func(t *testing.T) {
+		t.Run("should merge project and dataset defaults with table spec", func(t *testing.T) {
+			tableID := "project.dataset.table"
+			tableSpec := &protocol.ToleranceSpec{
+				URN: tableID,
+				Tolerances: []*protocol.Tolerance{
+					{
+						TableURN:       tableID,
+						MetricName:     metric.DuplicationPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 5}},
+					},
+				},
+				MaxBytesBilled: 2000,
+			}
+
+			fileStore := predatormock.NewMockFileStore()
+			defer fileStore.AssertExpectations(t)
+
+			parser := &parserMock{}
+			defer parser.AssertExpectations(t)
+
+			fileStore.On("Get", "project/dataset/table.yaml").Return(&protocol.File{Content: []byte("table")}, nil)
+			fileStore.On("Get", "project/_defaults.yaml").Return(&protocol.File{Content: []byte("project")}, nil)
+			fileStore.On("Get", "project/dataset/_defaults.yaml").Return(&protocol.File{Content: []byte("dataset")}, nil)
+			parser.On("Parse", []byte("table")).Return(tableSpec, nil)
+			parser.On("Parse", []byte("project")).Return(projectDefaults, nil)
+			parser.On("Parse", []byte("dataset")).Return(datasetDefaults, nil)
+
+			expected := &protocol.ToleranceSpec{
+				URN: tableID,
+				Tolerances: []*protocol.Tolerance{
+					{
+						TableURN:       tableID,
+						MetricName:     metric.RowCount,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}},
+					},
+					{
+						TableURN:       tableID,
+						FieldID:        "_id",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					},
+					{
+						TableURN:       tableID,
+						MetricName:     metric.DuplicationPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 5}},
+					},
+				},
+				MaxBytesBilled: 2000,
+			}
+
+			toleranceStore := NewFileBasedStore(fileStore, &GitPathResolver{}, parser).WithDefaults(nil)
+			result, err := toleranceStore.GetByTableID(tableID)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should return dataset defaults when table spec not found and expansion enabled", func(t *testing.T) {
+			tableID := "project.dataset.table"
+
+			fileStore := predatormock.NewMockFileStore()
+			defer fileStore.AssertExpectations(t)
+
+			parser := &parserMock{}
+			defer parser.AssertExpectations(t)
+
+			fileStore.On("Get", "project/dataset/table.yaml").Return((*protocol.File)(nil), protocol.ErrFileNotFound)
+			fileStore.On("Get", "project/_defaults.yaml").Return((*protocol.File)(nil), protocol.ErrFileNotFound)
+			fileStore.On("Get", "project/dataset/_defaults.yaml").Return(&protocol.File{Content: []byte("dataset")}, nil)
+			parser.On("Parse", []byte("dataset")).Return(datasetDefaults, nil)
+
+			expected := &protocol.ToleranceSpec{
+				URN: tableID,
+				Tolerances: []*protocol.Tolerance{
+					{
+						TableURN:       tableID,
+						FieldID:        "_id",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					},
+					{
+						TableURN:       tableID,
+						MetricName:     metric.DuplicationPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 1}},
+					},
+				},
+			}
+
+			toleranceStore := NewFileBasedStore(fileStore, &GitPathResolver{}, parser).WithDefaults(predatormock.NewTableLister())
+			result, err := toleranceStore.GetByTableID(tableID)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, result)
+		})
+		t.Run("should return ErrToleranceNotFound when table spec not found and expansion disabled", func(t *testing.T) {
+			fileStore := predatormock.NewMockFileStore()
+			defer fileStore.AssertExpectations(t)
+
+			fileStore.On("Get", "project/dataset/table.yaml").Return((*protocol.File)(nil), protocol.ErrFileNotFound)
+
+			toleranceStore := NewFileBasedStore(fileStore, &GitPathResolver{}, &parserMock{}).WithDefaults(nil)
+			result, err := toleranceStore.GetByTableID("project.dataset.table")
+
+			assert.Nil(t, result)
+			assert.True(t, errors.Is(err, protocol.ErrToleranceNotFound))
+		})
+		t.Run("should return ErrToleranceNotFound when table spec and dataset defaults not found", func(t *testing.T) {
+			fileStore := predatormock.NewMockFileStore()
+			defer fileStore.AssertExpectations(t)
+
+			parser := &parserMock{}
+			defer parser.AssertExpectations(t)
+
+			fileStore.On("Get", "project.dataset.table.yaml").Return((*protocol.File)(nil), protocol.ErrFileNotFound)
+			fileStore.On("Get", "project._defaults.yaml").Return(&protocol.File{Content: []byte("project")}, nil)
+			fileStore.On("Get", "project.dataset._defaults.yaml").Return((*protocol.File)(nil), protocol.ErrFileNotFound)
+			parser.On("Parse", []byte("project")).Return(projectDefaults, nil)
+
+			toleranceStore := NewFileBasedStore(fileStore, &DefaultPathResolver{}, parser).WithDefaults(predatormock.NewTableLister())
+			result, err := toleranceStore.GetByTableID("project.dataset.table")
+
+			assert.Nil(t, result)
+			assert.True(t, errors.Is(err, protocol.ErrToleranceNotFound))
+		})
+	})
+	t.Run("GetResourceNames", func(t *testing.T) {
+		t.Run("should skip defaults and expand dataset defaults to listed tables", func(t *testing.T) {
+			paths := []string{
+				"project/_defaults.yaml",
+				"project/dataset/_defaults.yaml",
+				"project/dataset/table_a.yaml",
+				"project/other/table_c.yaml",
+			}
+
+			fileStore := predatormock.NewMockFileStore()
+			defer fileStore.AssertExpectations(t)
+
+			tableLister := predatormock.NewTableLister()
+			defer tableLister.AssertExpectations(t)
+
+			fileStore.On("GetPaths").Return(paths, nil)
+			tableLister.On("ListTables", "project", "dataset").Return([]string{"project.dataset.table_a", "project.dataset.table_b"}, nil)
+
+			toleranceStore := NewFileBasedStore(fileStore, &GitPathResolver{}, &parserMock{}).WithDefaults(tableLister)
+			result, err := toleranceStore.GetResourceNames()
+
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"project.dataset.table_a", "project.other.table_c", "project.dataset.table_b"}, result)
+		})
+		t.Run("should skip defaults without expansion when table lister is not set", func(t *testing.T) {
+			paths := []string{
+				"project.dataset._defaults.yaml",
+				"project.dataset.table_a.yaml",
+			}
+
+			fileStore := predatormock.NewMockFileStore()
+			defer fileStore.AssertExpectations(t)
+
+			fileStore.On("GetPaths").Return(paths, nil)
+
+			toleranceStore := NewFileBasedStore(fileStore, &DefaultPathResolver{}, &parserMock{}).WithDefaults(nil)
+			result, err := toleranceStore.GetResourceNames()
+
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"project.dataset.table_a"}, result)
+		})
+	})
+	t.Run("getDefaultsPaths", func(t *testing.T) {
+		t.Run("should return project and dataset defaults path of directory layout", func(t *testing.T) {
+			result := getDefaultsPaths("entity/env/project/dataset/table.yaml")
+
+			assert.Equal(t, []string{"entity/env/project/_defaults.yaml", "entity/env/project/dataset/_defaults.yaml"}, result)
+		})
+		t.Run("should return project and dataset defaults path of default layout", func(t *testing.T) {
+			result := getDefaultsPaths("project.dataset.table.yaml")
+
+			assert.Equal(t, []string{"project._defaults.yaml", "project.dataset._defaults.yaml"}, result)
+		})
+	})
+}
+
+func TestMergeSpecs(t *testing.T) {
+	t.Run("should only override inherited tolerance with the same condition and identifying metadata", func(t *testing.T) {
+		tableID := "project.dataset.table"
+		lessThanEq := func(value float64) []protocol.ToleranceRule {
+			return []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: value}}
+		}
+		customSQL := func(name string, value float64) *protocol.Tolerance {
+			return &protocol.Tolerance{
+				MetricName:     metric.CustomSQL,
+				Metadata:       map[string]interface{}{metric.CustomName: name, metric.CustomExpression: "COUNT(*)"},
+				ToleranceRules: lessThanEq(value),
+			}
+		}
+		invalidPct := func(condition string, value float64) *protocol.Tolerance {
+			return &protocol.Tolerance{FieldID: "amount", MetricName: metric.InvalidPct, Condition: condition, ToleranceRules: lessThanEq(value)}
+		}
+		defaults := &protocol.ToleranceSpec{
+			Tolerances: []*protocol.Tolerance{
+				customSQL("refunds", 10),
+				customSQL("chargebacks", 10),
+				invalidPct("amount < 0", 1),
+				invalidPct("amount > 1000", 1),
+			},
+		}
+		tableSpec := &protocol.ToleranceSpec{
+			URN: tableID,
+			Tolerances: []*protocol.Tolerance{
+				customSQL("refunds", 5),
+				invalidPct("amount < 0", 0),
+			},
+		}
+
+		result := mergeSpecs(tableID, defaults, tableSpec)
+
+		withURN := func(t *protocol.Tolerance) *protocol.Tolerance {
+			t.TableURN = tableID
+			return t
+		}
+		expected := &protocol.ToleranceSpec{
+			URN: tableID,
+			Tolerances: []*protocol.Tolerance{
+				withURN(customSQL("chargebacks", 10)),
+				withURN(invalidPct("amount > 1000", 1)),
+				withURN(customSQL("refunds", 5)),
+				withURN(invalidPct("amount < 0", 0)),
+			},
+		}
+		assert.Equal(t, expected, result)
+	})
+}
@@ -0,0 +1,194 @@
+package tolerance
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/odpf/predator/protocol"
+)
+
+//LintLevel is level of lint diagnostic, only error fail the lint
+type LintLevel string
+
+const (
+	//LintLevelError problem that fail the upload of the spec
+	LintLevelError LintLevel = "error"
+	//LintLevelWarning problem that reduce the checks done by the linter
+	LintLevelWarning LintLevel = "warning"
+)
+
+//LintRule is kind of problem found by the linter
+type LintRule string
+
+const (
+	//LintRulePath path of the spec file can not be resolved into table urn
+	LintRulePath LintRule = "path"
+	//LintRuleParse content of the spec file can not be parsed
+	LintRuleParse LintRule = "parse"
+	//LintRuleTableID tableid of the spec is different from the table urn resolved from the path
+	LintRuleTableID LintRule = "tableid"
+	//LintRuleSpec spec is rejected by the spec validator
+	LintRuleSpec LintRule = "spec"
+	//LintRuleSchema schema of the table is not found on the schema file, checks that need the schema are skipped
+	LintRuleSchema LintRule = "schema"
+)
+
+//LintRules is rules checked by the linter
+var LintRules = []LintRule{LintRulePath, LintRuleParse, LintRuleTableID, LintRuleSpec, LintRuleSchema}
+
+//Description is human readable description of the rule
+func (r LintRule) Description() string {
+	switch r {
+	case LintRulePath:
+		return "path of spec file should be resolved into table urn"
+	case LintRuleParse:
+		return "spec file should be a valid data quality spec"
+	case LintRuleTableID:
+		return "tableid of spec should match the table urn resolved from the path"
+	case LintRuleSpec:
+		return "spec should pass the spec validation"
+	case LintRuleSchema:
+		return "schema of the table should be available to check fields of the spec"
+	}
+	return string(r)
+}
+
+//Diagnostic is a problem found on a spec file
+type Diagnostic struct {
+	Path    string    `json:"path"`
+	URN     string    `json:"urn,omitempty"`
+	Level   LintLevel `json:"level"`
+	Rule    LintRule  `json:"rule"`
+	Message string    `json:"message"`
+}
+
+//LintResult is diagnostics of every linted spec file, ordered by path
+type LintResult struct {
+	FileCount   int           `json:"file_count"`
+	Diagnostics []*Diagnostic `json:"diagnostics"`
+}
+
+//HasError whether any diagnostic is an error
+func (l *LintResult) HasError() bool {
+	for _, d := range l.Diagnostics {
+		if d.Level == LintLevelError {
+			return true
+		}
+	}
+	return false
+}
+
+//Linter check spec files without access to predator server, it resolve the path, parse and validate each spec file
+//the schema checks of the validation are only done when metadata store is configured, such as store of cached schema file
+type Linter struct {
+	fileStore     protocol.FileStore
+	pathResolver  protocol.PathResolver
+	parser        Parser
+	store         *FileBasedStore
+	validator     *SpecValidator
+	metadataStore protocol.MetadataStore
+}
+
+//NewLinter create Linter, metadataStore is optional, only structure of the specs is validated when it is nil
+func NewLinter(fileStore protocol.FileStore, pathResolver protocol.PathResolver, metadataStore protocol.MetadataStore) *Linter {
+	parser := NewSmartParser()
+	return &Linter{
+		fileStore:     fileStore,
+		pathResolver:  pathResolver,
+		parser:        parser,
+		store:         NewFileBasedStore(fileStore, pathResolver, parser).WithDefaults(nil),
+		validator:     NewSpecValidator(metadataStore),
+		metadataStore: metadataStore,
+	}
+}
+
+//Lint lint every spec file, error is only returned when the files or the schema can not be read
+func (l *Linter) Lint() (*LintResult, error) {
+	paths, err := l.fileStore.GetPaths()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	result := &LintResult{FileCount: len(paths), Diagnostics: []*Diagnostic{}}
+	for _, filePath := range paths {
+		diagnostics, err := l.lintFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lint %s :\n%w", filePath, err)
+		}
+		result.Diagnostics = append(result.Diagnostics, diagnostics...)
+	}
+	return result, nil
+}
+
+//lintFile lint a spec file, table spec is validated after merged with its defaults, defaults file is only parsed
+func (l *Linter) lintFile(filePath string) ([]*Diagnostic, error) {
+	newDiagnostic := func(urn string, level LintLevel, rule LintRule, message string) *Diagnostic {
+		return &Diagnostic{Path: filePath, URN: urn, Level: level, Rule: rule, Message: message}
+	}
+
+	var urn string
+	if !isDefaultsPath(filePath) {
+		var err error
+		urn, err = l.pathResolver.GetURN(filePath)
+		if err != nil {
+			return []*Diagnostic{newDiagnostic("", LintLevelError, LintRulePath, err.Error())}, nil
+		}
+	}
+
+	file, err := l.fileStore.Get(filePath)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := l.parser.Parse(file.Content)
+	if err != nil {
+		return []*Diagnostic{newDiagnostic(urn, LintLevelError, LintRuleParse, err.Error())}, nil
+	}
+	if urn == "" {
+		return nil, nil
+	}
+
+	var diagnostics []*Diagnostic
+	if spec.URN != "" && spec.URN != urn {
+		message := fmt.Sprintf("tableid %s does not match %s resolved from the path", spec.URN, urn)
+		diagnostics = append(diagnostics, newDiagnostic(urn, LintLevelError, LintRuleTableID, message))
+	}
+
+	merged, err := l.store.GetByTableID(urn)
+	var parseErr *specParseError
+	if errors.As(err, &parseErr) {
+		//defaults that can not be parsed is reported on the defaults file
+		return diagnostics, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	validateStructure := l.metadataStore == nil
+	if !validateStructure {
+		if _, err := l.metadataStore.GetMetadata(urn); err == protocol.ErrTableMetadataNotFound {
+			message := fmt.Sprintf("schema of %s is not found, checks that need the schema are skipped", urn)
+			diagnostics = append(diagnostics, newDiagnostic(urn, LintLevelWarning, LintRuleSchema, message))
+			validateStructure = true
+		}
+	}
+
+	if validateStructure {
+		err = l.validator.ValidateStructure(merged)
+	} else {
+		err = l.validator.Validate(merged)
+	}
+
+	var specInvalidErr *protocol.ErrSpecInvalid
+	if errors.As(err, &specInvalidErr) {
+		for _, e := range specInvalidErr.Errors {
+			diagnostics = append(diagnostics, newDiagnostic(urn, LintLevelError, LintRuleSpec, e.Error()))
+		}
+		return diagnostics, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return diagnostics, nil
+}
@@ -0,0 +1,145 @@
+package tolerance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+//LintFormat is output format of lint result
+type LintFormat string
+
+const (
+	//LintFormatText one line of each diagnostic followed by summary
+	LintFormatText LintFormat = "text"
+	//LintFormatJSON LintResult as json
+	LintFormatJSON LintFormat = "json"
+	//LintFormatSARIF static analysis results interchange format 2.1.0, supported by code scanning of merge requests
+	LintFormatSARIF LintFormat = "sarif"
+)
+
+//LintFormats is supported output formats
+var LintFormats = []LintFormat{LintFormatText, LintFormatJSON, LintFormatSARIF}
+
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName = "predator"
+	sarifToolURI  = "https://github.com/odpf/predator"
+)
+
+//Write write the lint result with the format
+func (l *LintResult) Write(w io.Writer, format LintFormat) error {
+	switch format {
+	case LintFormatText:
+		return l.writeText(w)
+	case LintFormatJSON:
+		return writeJSON(w, l)
+	case LintFormatSARIF:
+		return writeJSON(w, l.toSARIF())
+	}
+	return fmt.Errorf("lint format %s is not supported, should be one of %v", format, LintFormats)
+}
+
+func (l *LintResult) writeText(w io.Writer) error {
+	levelCount := make(map[LintLevel]int)
+	for _, d := range l.Diagnostics {
+		levelCount[d.Level]++
+		if _, err := fmt.Fprintf(w, "%s: %s: [%s] %s\n", d.Path, d.Level, d.Rule, d.Message); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d files linted, %d errors, %d warnings\n", l.FileCount, levelCount[LintLevelError], levelCount[LintLevelWarning])
+	return err
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+type sarifLog struct {
+	Version string      `json:"version"`
+	Schema  string      `json:"$schema"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    *sarifTool     `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver *sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription *sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	Level     string           `json:"level"`
+	Message   *sarifMessage    `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+//sarifSourceRoot is base of the spec file path, path of diagnostic is expected to be relative to root of the repository
+const sarifSourceRoot = "%SRCROOT%"
+
+//toSARIF convert lint result into a single run of predator, the location is the spec file path
+//lint level is used as the result level, both error and warning are sarif levels
+func (l *LintResult) toSARIF() *sarifLog {
+	var rules []*sarifRule
+	for _, rule := range LintRules {
+		rules = append(rules, &sarifRule{ID: string(rule), ShortDescription: &sarifMessage{Text: rule.Description()}})
+	}
+
+	results := []*sarifResult{}
+	for _, d := range l.Diagnostics {
+		results = append(results, &sarifResult{
+			RuleID:  string(d.Rule),
+			Level:   string(d.Level),
+			Message: &sarifMessage{Text: d.Message},
+			Locations: []*sarifLocation{
+				{PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: &sarifArtifactLocation{URI: d.Path, URIBaseID: sarifSourceRoot}}},
+			},
+		})
+	}
+
+	return &sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []*sarifRun{
+			{
+				Tool:    &sarifTool{Driver: &sarifDriver{Name: sarifToolName, InformationURI: sarifToolURI, Rules: rules}},
+				Results: results,
+			},
+		},
+	}
+}
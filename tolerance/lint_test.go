@@ -0,0 +1,220 @@
+package tolerance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	predatormock "github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinter(t *testing.T) {
+	files := map[string]string{
+		"orders.yaml": `tableid: "project.dataset.orders"`,
+		"project/_defaults.yaml": `tablemetrics:
+- metricname: "row_count"
+  tolerance:
+    more_than: 0`,
+		"project/dataset/orders.yaml": `tableid: "project.dataset.order"
+fields:
+- fieldid: "amount"
+  fieldmetrics:
+  - metricname: "max"
+    tolerance:
+      less_than: 100`,
+		"project/dataset/payments.yaml": `tableid: "project.dataset.payments"
+tablemetrics: row_count`,
+		"project/dataset/users.yaml": `tableid: "project.dataset.users"
+tablemetrics:
+- metricname: "max"
+  tolerance:
+    less_than: 100`,
+	}
+	paths := []string{
+		"project/dataset/users.yaml",
+		"project/dataset/payments.yaml",
+		"project/dataset/orders.yaml",
+		"project/_defaults.yaml",
+		"orders.yaml",
+	}
+
+	newFileStore := func() protocol.FileStore {
+		fileStore := predatormock.NewMockFileStore()
+		fileStore.On("GetPaths").Return(paths, nil)
+		for filePath, content := range files {
+			fileStore.On("Get", filePath).Return(&protocol.File{Path: filePath, Content: []byte(content)}, nil)
+		}
+		fileStore.On("Get", "project/dataset/_defaults.yaml").Return((*protocol.File)(nil), protocol.ErrFileNotFound)
+		return fileStore
+	}
+
+	t.Run("Lint", func(t *testing.T) {
+		t.Run("should return diagnostics of path, parse, tableid and spec structure when metadata store is not configured", func(t *testing.T) {
+			linter := NewLinter(newFileStore(), &GitPathResolver{}, nil)
+
+			result, err := linter.Lint()
+
+			assert.Nil(t, err)
+			assert.Equal(t, 5, result.FileCount)
+			assert.True(t, result.HasError())
+
+			var actual [][]string
+			for _, d := range result.Diagnostics {
+				actual = append(actual, []string{d.Path, string(d.Level), string(d.Rule)})
+			}
+			expected := [][]string{
+				{"orders.yaml", "error", "path"},
+				{"project/dataset/orders.yaml", "error", "tableid"},
+				{"project/dataset/payments.yaml", "error", "parse"},
+				{"project/dataset/users.yaml", "error", "spec"},
+			}
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, "tableid project.dataset.order does not match project.dataset.orders resolved from the path", result.Diagnostics[1].Message)
+		})
+		t.Run("should validate fields with the schema and warn tables without schema", func(t *testing.T) {
+			metadataStore := predatormock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+
+			ordersSpec := &meta.TableSpec{
+				ProjectName: "project",
+				DatasetName: "dataset",
+				TableName:   "orders",
+				Fields: []*meta.FieldSpec{
+					{Name: "amount", FieldType: meta.FieldTypeString, Mode: meta.ModeNullable},
+				},
+			}
+			metadataStore.On("GetMetadata", "project.dataset.orders").Return(ordersSpec, nil)
+			metadataStore.On("GetMetadata", "project.dataset.users").Return((*meta.TableSpec)(nil), protocol.ErrTableMetadataNotFound)
+
+			linter := NewLinter(newFileStore(), &GitPathResolver{}, metadataStore)
+
+			result, err := linter.Lint()
+
+			assert.Nil(t, err)
+
+			var actual [][]string
+			for _, d := range result.Diagnostics {
+				actual = append(actual, []string{d.Path, string(d.Level), string(d.Rule)})
+			}
+			expected := [][]string{
+				{"orders.yaml", "error", "path"},
+				{"project/dataset/orders.yaml", "error", "tableid"},
+				{"project/dataset/orders.yaml", "error", "spec"},
+				{"project/dataset/payments.yaml", "error", "parse"},
+				{"project/dataset/users.yaml", "warning", "schema"},
+				{"project/dataset/users.yaml", "error", "spec"},
+			}
+			assert.Equal(t, expected, actual)
+		})
+		t.Run("should report defaults that can not be parsed only on the defaults file", func(t *testing.T) {
+			fileStore := predatormock.NewMockFileStore()
+			fileStore.On("GetPaths").Return([]string{"project/_defaults.yaml", "project/dataset/orders.yaml"}, nil)
+			fileStore.On("Get", "project/_defaults.yaml").Return(&protocol.File{Path: "project/_defaults.yaml", Content: []byte("tablemetrics: row_count")}, nil)
+			fileStore.On("Get", "project/dataset/orders.yaml").Return(&protocol.File{Path: "project/dataset/orders.yaml", Content: []byte(files["project/dataset/orders.yaml"])}, nil)
+			fileStore.On("Get", "project/dataset/_defaults.yaml").Return((*protocol.File)(nil), protocol.ErrFileNotFound)
+
+			linter := NewLinter(fileStore, &GitPathResolver{}, nil)
+
+			result, err := linter.Lint()
+
+			assert.Nil(t, err)
+
+			var actual [][]string
+			for _, d := range result.Diagnostics {
+				actual = append(actual, []string{d.Path, string(d.Level), string(d.Rule)})
+			}
+			expected := [][]string{
+				{"project/_defaults.yaml", "error", "parse"},
+				{"project/dataset/orders.yaml", "error", "tableid"},
+			}
+			assert.Equal(t, expected, actual)
+		})
+		t.Run("should return error when defaults file can not be read", func(t *testing.T) {
+			ioErr := errors.New("io error")
+			fileStore := predatormock.NewMockFileStore()
+			fileStore.On("GetPaths").Return([]string{"project/dataset/orders.yaml"}, nil)
+			fileStore.On("Get", "project/dataset/orders.yaml").Return(&protocol.File{Path: "project/dataset/orders.yaml", Content: []byte(files["project/dataset/orders.yaml"])}, nil)
+			fileStore.On("Get", "project/_defaults.yaml").Return((*protocol.File)(nil), ioErr)
+
+			linter := NewLinter(fileStore, &GitPathResolver{}, nil)
+
+			result, err := linter.Lint()
+
+			assert.Nil(t, result)
+			assert.True(t, errors.Is(err, ioErr))
+		})
+		t.Run("should return error when spec files can not be listed", func(t *testing.T) {
+			ioErr := errors.New("io error")
+			fileStore := predatormock.NewMockFileStore()
+			fileStore.On("GetPaths").Return([]string(nil), ioErr)
+
+			linter := NewLinter(fileStore, &GitPathResolver{}, nil)
+
+			result, err := linter.Lint()
+
+			assert.Nil(t, result)
+			assert.Equal(t, ioErr, err)
+		})
+	})
+}
+
+func TestLintResult(t *testing.T) {
+	result := &LintResult{
+		FileCount: 2,
+		Diagnostics: []*Diagnostic{
+			{Path: "project/dataset/users.yaml", URN: "project.dataset.users", Level: LintLevelWarning, Rule: LintRuleSchema, Message: "schema of project.dataset.users is not found, checks that need the schema are skipped"},
+			{Path: "project/dataset/users.yaml", URN: "project.dataset.users", Level: LintLevelError, Rule: LintRuleSpec, Message: "max metric is only supported on field level"},
+		},
+	}
+	t.Run("Write", func(t *testing.T) {
+		t.Run("should write diagnostics as text", func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			err := result.Write(buf, LintFormatText)
+
+			expected := `project/dataset/users.yaml: warning: [schema] schema of project.dataset.users is not found, checks that need the schema are skipped
+project/dataset/users.yaml: error: [spec] max metric is only supported on field level
+2 files linted, 1 errors, 1 warnings
+`
+			assert.Nil(t, err)
+			assert.Equal(t, expected, buf.String())
+		})
+		t.Run("should write diagnostics as json", func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			err := result.Write(buf, LintFormatJSON)
+
+			var actual LintResult
+			assert.Nil(t, err)
+			assert.Nil(t, json.Unmarshal(buf.Bytes(), &actual))
+			assert.Equal(t, result, &actual)
+		})
+		t.Run("should write diagnostics as sarif", func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			err := result.Write(buf, LintFormatSARIF)
+
+			var actual sarifLog
+			assert.Nil(t, err)
+			assert.Nil(t, json.Unmarshal(buf.Bytes(), &actual))
+			assert.Equal(t, "2.1.0", actual.Version)
+			assert.Len(t, actual.Runs, 1)
+			assert.Equal(t, "predator", actual.Runs[0].Tool.Driver.Name)
+			assert.Len(t, actual.Runs[0].Tool.Driver.Rules, len(LintRules))
+			assert.Len(t, actual.Runs[0].Results, 2)
+			assert.Equal(t, "warning", actual.Runs[0].Results[0].Level)
+			assert.Equal(t, "spec", actual.Runs[0].Results[1].RuleID)
+			assert.Equal(t, "project/dataset/users.yaml", actual.Runs[0].Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+			assert.Equal(t, "%SRCROOT%", actual.Runs[0].Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URIBaseID)
+		})
+		t.Run("should return error when format is not supported", func(t *testing.T) {
+			err := result.Write(&bytes.Buffer{}, LintFormat("xml"))
+
+			assert.NotNil(t, err)
+		})
+	})
+}
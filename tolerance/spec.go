@@ -7,7 +7,9 @@ import (
 	"github.com/odpf/predator/protocol/meta"
 	"github.com/odpf/predator/protocol/metric"
 	"gopkg.in/yaml.v2"
+	"regexp"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -27,7 +29,7 @@ type Tolerance struct {
 	TableID        string
 	FieldID        string
 	MetricName     metric.Type
-	ToleranceRules RulesMap
+	ToleranceRules Rules
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
@@ -41,6 +43,7 @@ func (t *Tolerance) ToTolerance() *protocol.Tolerance {
 		FieldID:        t.FieldID,
 		MetricName:     t.MetricName,
 		ToleranceRules: toleranceRules,
+		Anomaly:        t.ToleranceRules.Anomaly,
 		CreatedAt:      t.CreatedAt,
 		UpdatedAt:      t.UpdatedAt,
 	}
@@ -49,16 +52,139 @@ func (t *Tolerance) ToTolerance() *protocol.Tolerance {
 //CompactSpec compact tolerance spec is structured
 // json/yaml schema with less redundant fields
 type CompactSpec struct {
-	TableID      string
-	TableMetrics []*MetricSpec
-	Fields       []*Field
+	TableID        string
+	MaxBytesBilled int64              `yaml:",omitempty"`
+	Schedule       *protocol.Schedule `yaml:",omitempty"`
+	TableMetrics   []*MetricSpec
+	Fields         []*Field
 }
 
 type MetricSpec struct {
 	MetricName metric.Type
 	Condition  string
 	Metadata   map[string]interface{}
-	Tolerance  RulesMap
+	Tolerance  Rules
+	//Severity of failure of the metric, failure fail the audit when not configured
+	Severity protocol.Severity `yaml:",omitempty"`
+	//GroupOverrides is tolerance used instead of the metric tolerance on groups matching the key, keyed by group value, glob or regular expression enclosed in slashes
+	GroupOverrides map[string]Rules `yaml:"group_overrides,omitempty"`
+}
+
+func newGroupOverrides(tol *protocol.Tolerance) map[string]Rules {
+	if len(tol.GroupOverrides) == 0 {
+		return nil
+	}
+	overrides := make(map[string]Rules)
+	for _, override := range tol.GroupOverrides {
+		overrides[override.Group] = newRules(override.ToleranceRules, override.Anomaly)
+	}
+	return overrides
+}
+
+//toGroupOverrides convert group overrides ordered by the group pattern
+func (m *MetricSpec) toGroupOverrides() []*protocol.GroupOverride {
+	var groups []string
+	for group := range m.GroupOverrides {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var overrides []*protocol.GroupOverride
+	for _, group := range groups {
+		rules := m.GroupOverrides[group]
+		overrides = append(overrides, protocol.NewGroupOverride(group, rules.ToArray(), rules.Anomaly))
+	}
+	return overrides
+}
+
+//Rules tolerance rules of a metric, comparator rules and optional anomaly rule
+//all rules should pass, any_of rule pass when all rules of one of its groups pass
+type Rules struct {
+	Comparators RulesMap              `yaml:",inline"`
+	Between     *Range                `yaml:"between,omitempty"`
+	Outside     *Range                `yaml:"outside,omitempty"`
+	AnyOf       []*RuleGroup          `yaml:"any_of,omitempty"`
+	Anomaly     *protocol.AnomalyRule `yaml:"anomaly,omitempty"`
+}
+
+func newRules(toleranceRules []protocol.ToleranceRule, anomaly *protocol.AnomalyRule) Rules {
+	rules := Rules{Anomaly: anomaly}
+	group := newRuleGroup(toleranceRules)
+	rules.Comparators = group.Comparators
+	rules.Between = group.Between
+	rules.Outside = group.Outside
+
+	for _, rule := range toleranceRules {
+		if rule.Comparator != protocol.ComparatorAnyOf {
+			continue
+		}
+		for _, groupRules := range rule.Groups {
+			rules.AnyOf = append(rules.AnyOf, newRuleGroup(groupRules))
+		}
+	}
+	return rules
+}
+
+//ToArray convert rules into tolerance rules, any_of groups are converted into a single any_of rule
+func (r Rules) ToArray() []protocol.ToleranceRule {
+	group := &RuleGroup{Comparators: r.Comparators, Between: r.Between, Outside: r.Outside}
+	toleranceRules := group.ToArray()
+
+	if len(r.AnyOf) > 0 {
+		anyOf := protocol.ToleranceRule{Comparator: protocol.ComparatorAnyOf}
+		for _, g := range r.AnyOf {
+			anyOf.Groups = append(anyOf.Groups, g.ToArray())
+		}
+		toleranceRules = append(toleranceRules, anyOf)
+	}
+	return toleranceRules
+}
+
+//RuleGroup is comparator rules of a group of any_of rule, all rules of the group should pass
+type RuleGroup struct {
+	Comparators RulesMap `yaml:",inline"`
+	Between     *Range   `yaml:"between,omitempty"`
+	Outside     *Range   `yaml:"outside,omitempty"`
+}
+
+func newRuleGroup(rules []protocol.ToleranceRule) *RuleGroup {
+	var scalarRules []protocol.ToleranceRule
+	group := &RuleGroup{}
+	for _, rule := range rules {
+		switch rule.Comparator {
+		case protocol.ComparatorBetween:
+			group.Between = &Range{Min: rule.Value, Max: rule.Upper}
+		case protocol.ComparatorOutside:
+			group.Outside = &Range{Min: rule.Value, Max: rule.Upper}
+		case protocol.ComparatorAnyOf:
+		default:
+			scalarRules = append(scalarRules, rule)
+		}
+	}
+	group.Comparators = NewRulesMap(scalarRules)
+	return group
+}
+
+//ToArray convert comparator rules into tolerance rules sorted by comparator, followed by range rules
+func (g *RuleGroup) ToArray() []protocol.ToleranceRule {
+	toleranceRules := g.Comparators.ToArray()
+	if g.Between != nil {
+		toleranceRules = append(toleranceRules, g.Between.toRule(protocol.ComparatorBetween))
+	}
+	if g.Outside != nil {
+		toleranceRules = append(toleranceRules, g.Outside.toRule(protocol.ComparatorOutside))
+	}
+	return toleranceRules
+}
+
+//Range is bounds of between and outside comparator, both bounds are inclusive for between
+type Range struct {
+	Min float64 `yaml:"min"`
+	Max float64 `yaml:"max"`
+}
+
+func (r *Range) toRule(comparator protocol.Comparator) protocol.ToleranceRule {
+	return protocol.ToleranceRule{Comparator: comparator, Value: r.Min, Upper: r.Max}
 }
 
 type Metadata struct {
@@ -66,11 +192,29 @@ type Metadata struct {
 	UniqueFields []string
 }
 
+//Field is metrics of a field, or of every field selected by fieldid glob, nested glob or type
 type Field struct {
-	FieldID      string
+	FieldID      string         `yaml:",omitempty"`
+	Nested       string         `yaml:",omitempty"`
+	Type         meta.FieldType `yaml:",omitempty"`
 	FieldMetrics []*MetricSpec
 }
 
+func newField(tol *protocol.Tolerance) *Field {
+	if tol.FieldSelector == nil {
+		return &Field{FieldID: tol.FieldID}
+	}
+	return &Field{FieldID: tol.FieldSelector.FieldID, Nested: tol.FieldSelector.Nested, Type: tol.FieldSelector.Type}
+}
+
+//fieldSelector get selector of the field, nil when the field is configured with exact field ID
+func (f *Field) fieldSelector() *protocol.FieldSelector {
+	if f.Nested == "" && f.Type == "" && !protocol.IsFieldSelector(f.FieldID) {
+		return nil
+	}
+	return &protocol.FieldSelector{FieldID: f.FieldID, Nested: f.Nested, Type: f.Type}
+}
+
 //RulesMap tolerance rules as map
 type RulesMap map[string]float64
 
@@ -120,8 +264,20 @@ type Parser interface {
 type FlatSpecParser struct {
 }
 
-func (s *FlatSpecParser) Serialise(tolerances *protocol.ToleranceSpec) (content []byte, err error) {
-	return nil, errors.New("unsupported serialization")
+func (s *FlatSpecParser) Serialise(toleranceSpec *protocol.ToleranceSpec) (content []byte, err error) {
+	var spec FlatSpec
+	for _, tol := range toleranceSpec.Tolerances {
+		spec = append(spec, &Tolerance{
+			ID:             tol.ID,
+			TableID:        tol.TableURN,
+			FieldID:        tol.FieldID,
+			MetricName:     tol.MetricName,
+			ToleranceRules: newRules(tol.ToleranceRules, tol.Anomaly),
+			CreatedAt:      tol.CreatedAt,
+			UpdatedAt:      tol.UpdatedAt,
+		})
+	}
+	return yaml.Marshal(spec)
 }
 
 func (s *FlatSpecParser) Parse(content []byte) (*protocol.ToleranceSpec, error) {
@@ -157,15 +313,17 @@ type CompactSpecParser struct {
 func (s *CompactSpecParser) Serialise(toleranceSpec *protocol.ToleranceSpec) (content []byte, err error) {
 
 	spec := &CompactSpec{
-		TableID:      toleranceSpec.URN,
-		TableMetrics: nil,
-		Fields:       nil,
+		TableID:        toleranceSpec.URN,
+		MaxBytesBilled: toleranceSpec.MaxBytesBilled,
+		Schedule:       toleranceSpec.Schedule,
+		TableMetrics:   nil,
+		Fields:         nil,
 	}
 
 	var tableMetrics []*MetricSpec
 	for _, tol := range toleranceSpec.Tolerances {
 		own := metric.Table
-		if tol.FieldID != "" {
+		if tol.FieldID != "" || tol.FieldSelector != nil {
 			own = metric.Field
 		}
 
@@ -176,31 +334,46 @@ func (s *CompactSpecParser) Serialise(toleranceSpec *protocol.ToleranceSpec) (co
 					metadata[metric.UniqueFields] = uniqueKeys
 				}
 			}
+			for key, value := range serialiseMetadata(tol) {
+				metadata[key] = value
+			}
 			ms := &MetricSpec{
-				MetricName: tol.MetricName,
-				Condition:  tol.Condition,
-				Metadata:   metadata,
-				Tolerance:  NewRulesMap(tol.ToleranceRules),
+				MetricName:     tol.MetricName,
+				Condition:      tol.Condition,
+				Metadata:       metadata,
+				Tolerance:      newRules(tol.ToleranceRules, tol.Anomaly),
+				Severity:       tol.Severity,
+				GroupOverrides: newGroupOverrides(tol),
 			}
 			tableMetrics = append(tableMetrics, ms)
 		}
 	}
 
-	fieldsMap := make(map[string][]*MetricSpec)
+	fieldsMap := make(map[string]*Field)
 	for _, tol := range toleranceSpec.Tolerances {
 		own := metric.Table
-		if tol.FieldID != "" {
+		if tol.FieldID != "" || tol.FieldSelector != nil {
 			own = metric.Field
 		}
 
 		if own == metric.Field {
 			ms := &MetricSpec{
-				MetricName: tol.MetricName,
-				Condition:  tol.Condition,
-				Tolerance:  NewRulesMap(tol.ToleranceRules),
+				MetricName:     tol.MetricName,
+				Condition:      tol.Condition,
+				Metadata:       serialiseMetadata(tol),
+				Tolerance:      newRules(tol.ToleranceRules, tol.Anomaly),
+				Severity:       tol.Severity,
+				GroupOverrides: newGroupOverrides(tol),
 			}
 
-			fieldsMap[tol.FieldID] = append(fieldsMap[tol.FieldID], ms)
+			key := tol.FieldID
+			if tol.FieldSelector != nil {
+				key = tol.FieldSelector.String()
+			}
+			if _, ok := fieldsMap[key]; !ok {
+				fieldsMap[key] = newField(tol)
+			}
+			fieldsMap[key].FieldMetrics = append(fieldsMap[key].FieldMetrics, ms)
 		}
 	}
 
@@ -211,13 +384,8 @@ func (s *CompactSpecParser) Serialise(toleranceSpec *protocol.ToleranceSpec) (co
 	sort.Strings(keys)
 
 	var fields []*Field
-	for _, fieldID := range keys {
-		specs := fieldsMap[fieldID]
-		f := &Field{
-			FieldID:      fieldID,
-			FieldMetrics: specs,
-		}
-		fields = append(fields, f)
+	for _, key := range keys {
+		fields = append(fields, fieldsMap[key])
 	}
 
 	spec.TableMetrics = tableMetrics
@@ -225,6 +393,33 @@ func (s *CompactSpecParser) Serialise(toleranceSpec *protocol.ToleranceSpec) (co
 	return yaml.Marshal(spec)
 }
 
+//configurableMetadata is metadata keys that can be configured on each metric
+var configurableMetadata = map[metric.Type][]string{
+	metric.TrendInconsistencyPct: {metric.TrendLookback, metric.TrendThresholdPct},
+	metric.Quantile:              {metric.QuantileFraction},
+	metric.AcceptedValuesPct:     {metric.AcceptedValues},
+	metric.PatternMismatchPct:    {metric.Pattern},
+	metric.LengthOutOfRangePct:   {metric.MinLength, metric.MaxLength},
+	metric.OrphanPct:             {metric.ReferenceURN, metric.JoinFields, metric.ReferenceFields},
+	metric.FreshnessLagSeconds:   {metric.TimestampField},
+	metric.SchemaDrift:           {metric.PinnedSchema},
+	metric.CustomSQL:             {metric.CustomName, metric.CustomExpression, metric.CustomQuery},
+}
+
+//serialiseMetadata return configured metadata of the metric, nil for metric without configurable metadata
+func serialiseMetadata(tol *protocol.Tolerance) map[string]interface{} {
+	var metadata map[string]interface{}
+	for _, key := range configurableMetadata[tol.MetricName] {
+		if value, ok := tol.Metadata[key]; ok {
+			if metadata == nil {
+				metadata = make(map[string]interface{})
+			}
+			metadata[key] = value
+		}
+	}
+	return metadata
+}
+
 func (s *CompactSpecParser) Parse(content []byte) (*protocol.ToleranceSpec, error) {
 	var storedSpec *CompactSpec
 	err := yaml.UnmarshalStrict(content, &storedSpec)
@@ -239,8 +434,10 @@ func (s *CompactSpecParser) Parse(content []byte) (*protocol.ToleranceSpec, erro
 	tolerances = append(tolerances, fieldLevelTolerances...)
 
 	return &protocol.ToleranceSpec{
-		URN:        storedSpec.TableID,
-		Tolerances: tolerances,
+		URN:            storedSpec.TableID,
+		Tolerances:     tolerances,
+		MaxBytesBilled: storedSpec.MaxBytesBilled,
+		Schedule:       storedSpec.Schedule,
 	}, nil
 }
 
@@ -259,6 +456,9 @@ func prepareTableLevelTolerances(tableURN string, gcsTableMetrics []*MetricSpec)
 				}
 			}
 		}
+		if _, ok := configurableMetadata[tableMetric.MetricName]; ok {
+			metadata = prepareMetadata(tableMetric.MetricName, tableMetric.Metadata)
+		}
 
 		toleranceRules := tableMetric.Tolerance.ToArray()
 		tolerance := &protocol.Tolerance{
@@ -267,6 +467,9 @@ func prepareTableLevelTolerances(tableURN string, gcsTableMetrics []*MetricSpec)
 			Condition:      tableMetric.Condition,
 			Metadata:       metadata,
 			ToleranceRules: toleranceRules,
+			Anomaly:        tableMetric.Tolerance.Anomaly,
+			GroupOverrides: tableMetric.toGroupOverrides(),
+			Severity:       tableMetric.Severity,
 		}
 		tolerances = append(tolerances, tolerance)
 	}
@@ -276,21 +479,65 @@ func prepareTableLevelTolerances(tableURN string, gcsTableMetrics []*MetricSpec)
 func prepareFieldLevelTolerances(tableURN string, fields []*Field) []*protocol.Tolerance {
 	var tolerances []*protocol.Tolerance
 	for _, field := range fields {
+		fieldID := field.FieldID
+		selector := field.fieldSelector()
+		if selector != nil {
+			fieldID = ""
+		}
 		for _, fieldMetric := range field.FieldMetrics {
 			toleranceRules := fieldMetric.Tolerance.ToArray()
 			tolerance := &protocol.Tolerance{
 				TableURN:       tableURN,
-				FieldID:        field.FieldID,
+				FieldID:        fieldID,
+				FieldSelector:  selector,
 				MetricName:     fieldMetric.MetricName,
 				Condition:      fieldMetric.Condition,
 				ToleranceRules: toleranceRules,
+				Anomaly:        fieldMetric.Tolerance.Anomaly,
+				GroupOverrides: fieldMetric.toGroupOverrides(),
+				Severity:       fieldMetric.Severity,
 			}
+			tolerance.Metadata = prepareMetadata(fieldMetric.MetricName, fieldMetric.Metadata)
 			tolerances = append(tolerances, tolerance)
 		}
 	}
 	return tolerances
 }
 
+//prepareMetadata take configurable metadata of the metric, the values are checked by SpecValidator
+func prepareMetadata(metricName metric.Type, rawMetadata map[string]interface{}) map[string]interface{} {
+	var metadata map[string]interface{}
+	for _, key := range configurableMetadata[metricName] {
+		if value, ok := rawMetadata[key]; ok {
+			if metadata == nil {
+				metadata = make(map[string]interface{})
+			}
+			metadata[key] = normaliseValue(value)
+		}
+	}
+	return metadata
+}
+
+//normaliseValue convert nested yaml maps into map with string keys, so the metadata can be stored as json
+func normaliseValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		normalised := make(map[string]interface{})
+		for key, nested := range v {
+			normalised[fmt.Sprint(key)] = normaliseValue(nested)
+		}
+		return normalised
+	case []interface{}:
+		normalised := make([]interface{}, len(v))
+		for i, nested := range v {
+			normalised[i] = normaliseValue(nested)
+		}
+		return normalised
+	default:
+		return value
+	}
+}
+
 //SmartParser parser that automatically Parse yaml that using either CompactSpec or FlatSpec
 type SmartParser struct {
 }
@@ -342,9 +589,33 @@ func (d *SpecValidator) Validate(spec *protocol.ToleranceSpec) error {
 		return err
 	}
 
+	return d.validate(spec, tableSpec)
+}
+
+//ValidateStructure validate the spec without table schema, checks that need the schema such as field existence and field type are skipped
+//metadata store is not used, so the spec can be validated without warehouse access
+func (d *SpecValidator) ValidateStructure(spec *protocol.ToleranceSpec) error {
+	return d.validate(spec, nil)
+}
+
+//validate validate the spec against the table schema, nil table spec skip checks that need the schema
+func (d *SpecValidator) validate(spec *protocol.ToleranceSpec, tableSpec *meta.TableSpec) error {
+	var err error
 	var fieldErrors []error
-	for _, tolerance := range spec.Tolerances {
-		if tolerance.FieldID != "" {
+	customNames := make(map[string]bool)
+	if spec.MaxBytesBilled < 0 {
+		fieldErrors = append(fieldErrors, errors.New("[maxbytesbilled] should not be a negative number"))
+	}
+
+	if spec.Schedule != nil {
+		fieldErrors = append(fieldErrors, spec.Schedule.Validate()...)
+	}
+
+	tolerances, selectorErrors := expandFieldSelectors(spec.URN, tableSpec, spec.Tolerances)
+	fieldErrors = append(fieldErrors, selectorErrors...)
+
+	for _, tolerance := range tolerances {
+		if tolerance.FieldID != "" && tableSpec != nil {
 			_, err = tableSpec.GetFieldSpecByID(tolerance.FieldID)
 			if err != nil {
 				if err == meta.ErrFieldSpecNotFound {
@@ -356,16 +627,69 @@ func (d *SpecValidator) Validate(spec *protocol.ToleranceSpec) error {
 			}
 		}
 
-		if metric.GetCategory(tolerance.MetricName) != metric.Quality {
+		if metric.GetCategory(tolerance.MetricName) != metric.Quality || tolerance.MetricName == metric.ReconciliationDiffPct {
 			err = fmt.Errorf("metric : %s is not supported", tolerance.MetricName)
 			fieldErrors = append(fieldErrors, err)
 		}
 
 		if tolerance.MetricName == metric.TrendInconsistencyPct {
-			err = fmt.Errorf("metric : %s is not supported", metric.TrendInconsistencyPct.String())
+			for _, key := range []string{metric.TrendLookback, metric.TrendThresholdPct} {
+				if _, ok := tolerance.Metadata[key]; !ok {
+					continue
+				}
+				if value, ok := metric.GetNumber(tolerance.Metadata, key); !ok || value <= 0 {
+					err = fmt.Errorf("[%s] of %s metric should be a positive number", key, tolerance.MetricName)
+					fieldErrors = append(fieldErrors, err)
+				}
+			}
+		}
+
+		if tolerance.Severity != "" && !tolerance.Severity.IsValid() {
+			err = fmt.Errorf("[severity] of %s metric should be one of %v, got %s", tolerance.MetricName, protocol.Severities, tolerance.Severity)
 			fieldErrors = append(fieldErrors, err)
 		}
 
+		fieldErrors = append(fieldErrors, validateToleranceRules(tolerance.MetricName, tolerance.ToleranceRules)...)
+
+		fieldErrors = append(fieldErrors, validateAnomalyRule(tolerance.MetricName, tolerance.Anomaly)...)
+
+		for _, override := range tolerance.GroupOverrides {
+			if err := override.Validate(); err != nil {
+				err = fmt.Errorf("group override %s of %s metric is not a valid glob or regular expression ,%w", override.Group, tolerance.MetricName, err)
+				fieldErrors = append(fieldErrors, err)
+			}
+			fieldErrors = append(fieldErrors, validateToleranceRules(tolerance.MetricName, override.ToleranceRules)...)
+			fieldErrors = append(fieldErrors, validateAnomalyRule(tolerance.MetricName, override.Anomaly)...)
+		}
+
+		if metric.IsStatistical(tolerance.MetricName) {
+			fieldErrors = append(fieldErrors, validateStatisticalMetric(tableSpec, tolerance)...)
+		}
+
+		if metric.IsConformity(tolerance.MetricName) {
+			fieldErrors = append(fieldErrors, validateConformityMetric(tableSpec, tolerance)...)
+		}
+
+		if tolerance.MetricName == metric.OrphanPct {
+			orphanErrors, err := d.validateOrphanMetric(spec.URN, tableSpec, tolerance)
+			if err != nil {
+				return err
+			}
+			fieldErrors = append(fieldErrors, orphanErrors...)
+		}
+
+		if tolerance.MetricName == metric.FreshnessLagSeconds {
+			fieldErrors = append(fieldErrors, validateFreshnessMetric(spec.URN, tableSpec, tolerance)...)
+		}
+
+		if tolerance.MetricName == metric.SchemaDrift {
+			fieldErrors = append(fieldErrors, validateSchemaDriftMetric(tolerance)...)
+		}
+
+		if tolerance.MetricName == metric.CustomSQL {
+			fieldErrors = append(fieldErrors, validateCustomSQLMetric(tolerance, customNames)...)
+		}
+
 		if tolerance.MetricName != metric.InvalidPct {
 			if len(tolerance.Condition) > 0 {
 				err = fmt.Errorf("[condition] is not supported for %s metric in %s fieldid", tolerance.MetricName, tolerance.FieldID)
@@ -383,3 +707,344 @@ func (d *SpecValidator) Validate(spec *protocol.ToleranceSpec) error {
 
 	return nil
 }
+
+//expandFieldSelectors expand field selectors of the tolerances against the table schema
+//invalid selector and selector that match no field are reported as error and not expanded
+//without table schema, valid selector is kept as a single field tolerance identified by the selector
+func expandFieldSelectors(urn string, tableSpec *meta.TableSpec, tolerances []*protocol.Tolerance) ([]*protocol.Tolerance, []error) {
+	var errs []error
+	var selectable []*protocol.Tolerance
+	for _, tolerance := range tolerances {
+		if tolerance.FieldSelector != nil && tableSpec == nil {
+			if err := tolerance.FieldSelector.Validate(); err != nil {
+				errs = append(errs, fmt.Errorf("field selector %s of %s metric is invalid ,%w", tolerance.FieldSelector, tolerance.MetricName, err))
+				continue
+			}
+			selected := *tolerance
+			selected.FieldID = tolerance.FieldSelector.String()
+			selected.FieldSelector = nil
+			selectable = append(selectable, &selected)
+			continue
+		}
+		if tolerance.FieldSelector != nil {
+			fields, err := tolerance.FieldSelector.Select(tableSpec)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("field selector %s of %s metric is invalid ,%w", tolerance.FieldSelector, tolerance.MetricName, err))
+				continue
+			}
+			if len(fields) == 0 {
+				errs = append(errs, fmt.Errorf("field selector %s of %s metric does not match any field on table : %s", tolerance.FieldSelector, tolerance.MetricName, urn))
+				continue
+			}
+		}
+		selectable = append(selectable, tolerance)
+	}
+
+	expanded, err := protocol.ExpandFieldSelectors(tableSpec, selectable)
+	if err != nil {
+		return nil, append(errs, err)
+	}
+	return expanded, errs
+}
+
+//validateAnomalyRule check lookback and zscore of the anomaly rule are positive, nil anomaly rule is valid
+func validateAnomalyRule(metricName metric.Type, anomaly *protocol.AnomalyRule) []error {
+	if anomaly == nil {
+		return nil
+	}
+
+	var errs []error
+	if anomaly.Lookback <= 0 {
+		errs = append(errs, fmt.Errorf("[lookback] of anomaly rule of %s metric should be a positive number", metricName))
+	}
+	if anomaly.ZScore <= 0 {
+		errs = append(errs, fmt.Errorf("[zscore] of anomaly rule of %s metric should be a positive number", metricName))
+	}
+	return errs
+}
+
+//validateToleranceRules check comparators are supported, range bounds are ordered, any_of groups are not empty and percentage change is not negative
+func validateToleranceRules(metricName metric.Type, rules []protocol.ToleranceRule) []error {
+	var errs []error
+	for _, rule := range rules {
+		switch {
+		case !rule.Comparator.IsValid():
+			errs = append(errs, fmt.Errorf("comparator %s of %s metric is not supported, should be one of %v", rule.Comparator, metricName, protocol.Comparators))
+		case rule.Comparator.IsRange() && rule.Value > rule.Upper:
+			errs = append(errs, fmt.Errorf("[%s] of %s metric should have min not greater than max", rule.Comparator, metricName))
+		case rule.Comparator == protocol.ComparatorPctChangeVsPrevious && rule.Value < 0:
+			errs = append(errs, fmt.Errorf("[%s] of %s metric should not be a negative number", rule.Comparator, metricName))
+		case rule.Comparator == protocol.ComparatorAnyOf:
+			for _, group := range rule.Groups {
+				if len(group) == 0 {
+					errs = append(errs, fmt.Errorf("[%s] of %s metric should not have empty group", rule.Comparator, metricName))
+					continue
+				}
+				errs = append(errs, validateToleranceRules(metricName, group)...)
+			}
+		}
+	}
+	return errs
+}
+
+//validateStatisticalMetric check statistical metric is configured on numeric field and quantile is between 0 and 1
+func validateStatisticalMetric(tableSpec *meta.TableSpec, tolerance *protocol.Tolerance) []error {
+	var errs []error
+	if tolerance.FieldID == "" {
+		errs = append(errs, fmt.Errorf("%s metric is only supported on field level", tolerance.MetricName))
+	} else if tableSpec != nil {
+		if fieldSpec, err := tableSpec.GetFieldSpecByID(tolerance.FieldID); err == nil && !fieldSpec.FieldType.IsNumeric() {
+			errs = append(errs, fmt.Errorf("%s metric is only supported on numeric field, %s is %s", tolerance.MetricName, tolerance.FieldID, fieldSpec.FieldType))
+		}
+	}
+
+	if tolerance.MetricName == metric.Quantile {
+		quantile, ok := metric.GetQuantile(tolerance.Metadata)
+		if !ok || quantile < 0 || quantile > 1 {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric in %s fieldid should be a number between 0 and 1", metric.QuantileFraction, tolerance.MetricName, tolerance.FieldID))
+		}
+	}
+	return errs
+}
+
+//validateOrphanMetric check the referenced table is stored on the same database, and both join fields and reference fields exist with the same type
+//error is returned when failed to get metadata of the referenced table for other reason than it is not found
+func (d *SpecValidator) validateOrphanMetric(urn string, tableSpec *meta.TableSpec, tolerance *protocol.Tolerance) ([]error, error) {
+	var errs []error
+	if tolerance.FieldID != "" {
+		errs = append(errs, fmt.Errorf("%s metric is only supported on table level", tolerance.MetricName))
+	}
+
+	reference, ok := metric.GetReference(tolerance.Metadata)
+	if !ok || reference.URN == "" || len(reference.Fields) == 0 || len(reference.Fields) != len(reference.ReferenceFields) {
+		errs = append(errs, fmt.Errorf("[%s] of %s metric should be configured with non empty [%s] and [%s] of the same length",
+			metric.ReferenceURN, tolerance.MetricName, metric.JoinFields, metric.ReferenceFields))
+		return errs, nil
+	}
+
+	if !isSameDatabase(urn, reference.URN) {
+		errs = append(errs, fmt.Errorf("reference table %s of %s metric should be stored on the same database as %s", reference.URN, tolerance.MetricName, urn))
+		return errs, nil
+	}
+
+	if tableSpec == nil {
+		return errs, nil
+	}
+
+	referenceSpec, err := d.metadataStore.GetMetadata(reference.URN)
+	if err != nil {
+		if err == protocol.ErrTableMetadataNotFound {
+			errs = append(errs, fmt.Errorf("reference table %s of %s metric is not found ,%w", reference.URN, tolerance.MetricName, err))
+			return errs, nil
+		}
+		return nil, err
+	}
+
+	for i, fieldID := range reference.Fields {
+		referenceFieldID := reference.ReferenceFields[i]
+		fieldSpec, err := tableSpec.GetFieldSpecByID(fieldID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("join field ID: %s of %s metric is not found on table : %s ,%w", fieldID, tolerance.MetricName, urn, err))
+		}
+		referenceFieldSpec, referenceErr := referenceSpec.GetFieldSpecByID(referenceFieldID)
+		if referenceErr != nil {
+			errs = append(errs, fmt.Errorf("reference field ID: %s of %s metric is not found on table : %s ,%w", referenceFieldID, tolerance.MetricName, reference.URN, referenceErr))
+		}
+		if err == nil && referenceErr == nil && fieldSpec.FieldType != referenceFieldSpec.FieldType {
+			errs = append(errs, fmt.Errorf("join field %s is %s but reference field %s is %s", fieldID, fieldSpec.FieldType, referenceFieldID, referenceFieldSpec.FieldType))
+		}
+	}
+	return errs, nil
+}
+
+//validateFreshnessMetric check timestamp field is a timestamp, postgres table should configure it because last modified time is not available
+//postgres timestamp without time zone is allowed and interpreted in the time zone of the connection
+func validateFreshnessMetric(urn string, tableSpec *meta.TableSpec, tolerance *protocol.Tolerance) []error {
+	var errs []error
+	if tolerance.FieldID != "" {
+		errs = append(errs, fmt.Errorf("%s metric is only supported on table level", tolerance.MetricName))
+	}
+
+	warehouse := meta.ParseWarehouse(urn)
+	rawTimestampField, configured := tolerance.Metadata[metric.TimestampField]
+	timestampField := metric.GetTimestampField(tolerance.Metadata)
+	if configured && timestampField == "" {
+		errs = append(errs, fmt.Errorf("[%s] of %s metric should be a non empty string, got %v", metric.TimestampField, tolerance.MetricName, rawTimestampField))
+		return errs
+	}
+
+	if timestampField == "" {
+		if warehouse == meta.WarehousePostgres {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric should be configured, last modified time of %s table is not available", metric.TimestampField, tolerance.MetricName, warehouse))
+		}
+		return errs
+	}
+
+	if tableSpec == nil {
+		return errs
+	}
+
+	fieldSpec, err := tableSpec.GetFieldSpecByID(timestampField)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("timestamp field ID: %s of %s metric is not found on table : %s ,%w", timestampField, tolerance.MetricName, urn, err))
+		return errs
+	}
+	isTimestamp := fieldSpec.FieldType == meta.FieldTypeTimestamp ||
+		(warehouse == meta.WarehousePostgres && fieldSpec.FieldType == meta.FieldTypeDateTime)
+	if !isTimestamp {
+		errs = append(errs, fmt.Errorf("%s metric is only supported on timestamp field, %s is %s", tolerance.MetricName, timestampField, fieldSpec.FieldType))
+	}
+	return errs
+}
+
+var (
+	pinnedFieldTypes = []meta.FieldType{meta.FieldTypeString, meta.FieldTypeBytes, meta.FieldTypeInteger, meta.FieldTypeFloat,
+		meta.FieldTypeBoolean, meta.FieldTypeTimestamp, meta.FieldTypeRecord, meta.FieldTypeDate, meta.FieldTypeTime,
+		meta.FieldTypeDateTime, meta.FieldTypeNumeric, meta.FieldTypeGeography, meta.FieldTypeUnknown}
+	pinnedModes = []meta.Mode{meta.ModeNullable, meta.ModeRequired, meta.ModeRepeated}
+)
+
+//validateSchemaDriftMetric check schema drift is configured on table level and every pinned column has name, known type and mode
+func validateSchemaDriftMetric(tolerance *protocol.Tolerance) []error {
+	var errs []error
+	if tolerance.FieldID != "" {
+		errs = append(errs, fmt.Errorf("%s metric is only supported on table level", tolerance.MetricName))
+	}
+
+	if _, configured := tolerance.Metadata[metric.PinnedSchema]; !configured {
+		return errs
+	}
+	columns, ok := metric.GetPinnedSchema(tolerance.Metadata)
+	if !ok || len(columns) == 0 {
+		return append(errs, fmt.Errorf("[%s] of %s metric should be a non empty list of columns with name, type and mode", metric.PinnedSchema, tolerance.MetricName))
+	}
+
+	names := make(map[string]bool)
+	for i, column := range columns {
+		if column.Name == "" {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric, column %d should have a name", metric.PinnedSchema, tolerance.MetricName, i+1))
+			continue
+		}
+		if names[column.Name] {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric, column %s is configured more than once", metric.PinnedSchema, tolerance.MetricName, column.Name))
+		}
+		names[column.Name] = true
+		if !isPinnedFieldType(column.Type) {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric, type of column %s is not supported, got %s", metric.PinnedSchema, tolerance.MetricName, column.Name, column.Type))
+		}
+		if !isPinnedMode(column.Mode) {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric, mode of column %s should be one of %v, got %s", metric.PinnedSchema, tolerance.MetricName, column.Name, pinnedModes, column.Mode))
+		}
+	}
+	return errs
+}
+
+//validateCustomSQLMetric check custom sql metric is configured on table level with unique name and either expression or query
+//the sql itself is checked by dry run when the spec is uploaded
+func validateCustomSQLMetric(tolerance *protocol.Tolerance, names map[string]bool) []error {
+	var errs []error
+	if tolerance.FieldID != "" {
+		errs = append(errs, fmt.Errorf("%s metric is only supported on table level", tolerance.MetricName))
+	}
+
+	custom, ok := metric.GetCustom(tolerance.Metadata)
+	if !ok || strings.TrimSpace(custom.Name) == "" {
+		return append(errs, fmt.Errorf("[%s] of %s metric should be a non empty string", metric.CustomName, tolerance.MetricName))
+	}
+	if names[custom.Name] {
+		errs = append(errs, fmt.Errorf("%s metric %s is configured more than once", tolerance.MetricName, custom.Name))
+	}
+	names[custom.Name] = true
+
+	hasExpression := strings.TrimSpace(custom.Expression) != ""
+	hasQuery := strings.TrimSpace(custom.Query) != ""
+	if hasExpression == hasQuery {
+		errs = append(errs, fmt.Errorf("%s metric %s should be configured with either [%s] or [%s]", tolerance.MetricName, custom.Name, metric.CustomExpression, metric.CustomQuery))
+	}
+	return errs
+}
+
+func isPinnedFieldType(fieldType meta.FieldType) bool {
+	for _, t := range pinnedFieldTypes {
+		if t == fieldType {
+			return true
+		}
+	}
+	return false
+}
+
+func isPinnedMode(mode meta.Mode) bool {
+	for _, m := range pinnedModes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
+//isSameDatabase whether both tables can be queried together, postgres query is run on a connection to a single database
+func isSameDatabase(urn string, otherURN string) bool {
+	warehouse := meta.ParseWarehouse(urn)
+	if warehouse != meta.ParseWarehouse(otherURN) {
+		return false
+	}
+	if warehouse != meta.WarehousePostgres {
+		return true
+	}
+	database := strings.Split(meta.TrimWarehouseScheme(urn), ".")[0]
+	otherDatabase := strings.Split(meta.TrimWarehouseScheme(otherURN), ".")[0]
+	return database == otherDatabase
+}
+
+//validateConformityMetric check conformity metric is configured on string field with valid rule parameters
+func validateConformityMetric(tableSpec *meta.TableSpec, tolerance *protocol.Tolerance) []error {
+	var errs []error
+	if tolerance.FieldID == "" {
+		errs = append(errs, fmt.Errorf("%s metric is only supported on field level", tolerance.MetricName))
+	} else if tableSpec != nil {
+		if fieldSpec, err := tableSpec.GetFieldSpecByID(tolerance.FieldID); err == nil && fieldSpec.FieldType != meta.FieldTypeString {
+			errs = append(errs, fmt.Errorf("%s metric is only supported on string field, %s is %s", tolerance.MetricName, tolerance.FieldID, fieldSpec.FieldType))
+		}
+	}
+
+	switch tolerance.MetricName {
+	case metric.AcceptedValuesPct:
+		acceptedValues, ok := metric.GetAcceptedValues(tolerance.Metadata)
+		if !ok || len(acceptedValues) == 0 {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric in %s fieldid should be a non empty list of strings", metric.AcceptedValues, tolerance.MetricName, tolerance.FieldID))
+		}
+	case metric.PatternMismatchPct:
+		pattern, ok := metric.GetPattern(tolerance.Metadata)
+		if !ok || pattern == "" {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric in %s fieldid should be a non empty string", metric.Pattern, tolerance.MetricName, tolerance.FieldID))
+		} else if _, err := regexp.Compile(pattern); err != nil {
+			errs = append(errs, fmt.Errorf("[%s] of %s metric in %s fieldid is not a valid regular expression ,%w", metric.Pattern, tolerance.MetricName, tolerance.FieldID, err))
+		}
+	case metric.LengthOutOfRangePct:
+		errs = append(errs, validateLengthRange(tolerance)...)
+	}
+	return errs
+}
+
+//validateLengthRange check at least one of min and max length is configured, max length should be positive and not less than min length
+func validateLengthRange(tolerance *protocol.Tolerance) []error {
+	var errs []error
+	_, hasMinRaw := tolerance.Metadata[metric.MinLength]
+	_, hasMaxRaw := tolerance.Metadata[metric.MaxLength]
+	if !hasMinRaw && !hasMaxRaw {
+		return append(errs, fmt.Errorf("[%s] or [%s] of %s metric in %s fieldid should be configured", metric.MinLength, metric.MaxLength, tolerance.MetricName, tolerance.FieldID))
+	}
+
+	minLength, hasMin := metric.GetLength(tolerance.Metadata, metric.MinLength)
+	if hasMinRaw && (!hasMin || minLength < 0) {
+		errs = append(errs, fmt.Errorf("[%s] of %s metric in %s fieldid should be a non negative integer", metric.MinLength, tolerance.MetricName, tolerance.FieldID))
+	}
+	maxLength, hasMax := metric.GetLength(tolerance.Metadata, metric.MaxLength)
+	if hasMaxRaw && (!hasMax || maxLength <= 0) {
+		errs = append(errs, fmt.Errorf("[%s] of %s metric in %s fieldid should be a positive integer", metric.MaxLength, tolerance.MetricName, tolerance.FieldID))
+	}
+	if hasMin && hasMax && maxLength > 0 && minLength > maxLength {
+		errs = append(errs, fmt.Errorf("[%s] of %s metric in %s fieldid should not be more than [%s]", metric.MinLength, tolerance.MetricName, tolerance.FieldID, metric.MaxLength))
+	}
+	return errs
+}
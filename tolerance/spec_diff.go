@@ -0,0 +1,139 @@
+package tolerance
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/odpf/predator/protocol"
+)
+
+//DiffSpec semantic changes from the stored spec to the uploaded spec
+//tolerances are matched by metric, field or field selector, condition and identifying metadata, order of tolerance rules and group overrides is ignored
+//and severity that is not configured is the same as error severity
+func DiffSpec(stored *protocol.ToleranceSpec, uploaded *protocol.ToleranceSpec) []*protocol.SpecChange {
+	var changes []*protocol.SpecChange
+	if stored.MaxBytesBilled != uploaded.MaxBytesBilled {
+		changes = append(changes, &protocol.SpecChange{
+			Type:      protocol.ChangeModified,
+			Attribute: "max_bytes_billed",
+			Before:    stored.MaxBytesBilled,
+			After:     uploaded.MaxBytesBilled,
+		})
+	}
+	if !reflect.DeepEqual(stored.Schedule, uploaded.Schedule) {
+		changes = append(changes, &protocol.SpecChange{
+			Type:      protocol.ChangeModified,
+			Attribute: "schedule",
+			Before:    stored.Schedule,
+			After:     uploaded.Schedule,
+		})
+	}
+
+	storedTolerances := make(map[toleranceKey]*protocol.Tolerance)
+	for _, t := range stored.Tolerances {
+		storedTolerances[toleranceKeyOf(t)] = t
+	}
+
+	uploadedKeys := make(map[toleranceKey]bool)
+	for _, t := range uploaded.Tolerances {
+		key := toleranceKeyOf(t)
+		uploadedKeys[key] = true
+
+		before, ok := storedTolerances[key]
+		if !ok {
+			changes = append(changes, newToleranceChange(protocol.ChangeAdded, t))
+			continue
+		}
+		changes = append(changes, diffTolerance(before, t)...)
+	}
+
+	for _, t := range stored.Tolerances {
+		if !uploadedKeys[toleranceKeyOf(t)] {
+			changes = append(changes, newToleranceChange(protocol.ChangeRemoved, t))
+		}
+	}
+	return changes
+}
+
+func newToleranceChange(changeType protocol.ChangeType, t *protocol.Tolerance) *protocol.SpecChange {
+	change := &protocol.SpecChange{
+		Type:       changeType,
+		FieldID:    t.FieldID,
+		MetricName: t.MetricName,
+		Condition:  t.Condition,
+		Identity:   t.Identity(),
+	}
+	if t.FieldSelector != nil {
+		change.FieldID = t.FieldSelector.String()
+	}
+	return change
+}
+
+func diffTolerance(before *protocol.Tolerance, after *protocol.Tolerance) []*protocol.SpecChange {
+	attributes := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+	}{
+		{"tolerance", sortedRules(before.ToleranceRules), sortedRules(after.ToleranceRules)},
+		{"anomaly", before.Anomaly, after.Anomaly},
+		{"condition", before.Condition, after.Condition},
+		{"metadata", emptyAsNil(before.Metadata), emptyAsNil(after.Metadata)},
+		{"severity", before.Severity.OrDefault(), after.Severity.OrDefault()},
+		{"group_overrides", sortedGroupOverrides(before.GroupOverrides), sortedGroupOverrides(after.GroupOverrides)},
+	}
+
+	var changes []*protocol.SpecChange
+	for _, attribute := range attributes {
+		if reflect.DeepEqual(attribute.before, attribute.after) {
+			continue
+		}
+		change := newToleranceChange(protocol.ChangeModified, after)
+		change.Attribute = attribute.name
+		change.Before = attribute.before
+		change.After = attribute.after
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func sortedRules(rules []protocol.ToleranceRule) []protocol.ToleranceRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	keyOf := func(rule protocol.ToleranceRule) string {
+		content, _ := json.Marshal(rule)
+		return string(content)
+	}
+	sorted := append([]protocol.ToleranceRule{}, rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return keyOf(sorted[i]) < keyOf(sorted[j])
+	})
+	return sorted
+}
+
+func sortedGroupOverrides(overrides []*protocol.GroupOverride) []*protocol.GroupOverride {
+	if len(overrides) == 0 {
+		return nil
+	}
+	sorted := make([]*protocol.GroupOverride, len(overrides))
+	for i, override := range overrides {
+		sorted[i] = &protocol.GroupOverride{
+			Group:          override.Group,
+			ToleranceRules: sortedRules(override.ToleranceRules),
+			Anomaly:        override.Anomaly,
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Group < sorted[j].Group
+	})
+	return sorted
+}
+
+func emptyAsNil(metadata map[string]interface{}) map[string]interface{} {
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
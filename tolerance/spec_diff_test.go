@@ -0,0 +1,135 @@
+package tolerance
+
+import (
+	"testing"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSpec(t *testing.T) {
+	urn := "project.dataset.table"
+	lessThan := func(value float64) protocol.ToleranceRule {
+		return protocol.ToleranceRule{Comparator: protocol.ComparatorLessThan, Value: value}
+	}
+	moreThan := func(value float64) protocol.ToleranceRule {
+		return protocol.ToleranceRule{Comparator: protocol.ComparatorMoreThan, Value: value}
+	}
+
+	t.Run("should return no change when specs are only different in order and default severity", func(t *testing.T) {
+		stored := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName:     metric.RowCount,
+					ToleranceRules: []protocol.ToleranceRule{moreThan(0), lessThan(100)},
+					GroupOverrides: []*protocol.GroupOverride{
+						{Group: "ID", ToleranceRules: []protocol.ToleranceRule{moreThan(10)}},
+						{Group: "SG", ToleranceRules: []protocol.ToleranceRule{moreThan(5)}},
+					},
+				},
+				{FieldID: "id", MetricName: metric.NullnessPct, ToleranceRules: []protocol.ToleranceRule{lessThan(1)}},
+			},
+		}
+		uploaded := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{FieldID: "id", MetricName: metric.NullnessPct, ToleranceRules: []protocol.ToleranceRule{lessThan(1)}, Severity: protocol.SeverityError, Metadata: map[string]interface{}{}},
+				{
+					MetricName:     metric.RowCount,
+					ToleranceRules: []protocol.ToleranceRule{lessThan(100), moreThan(0)},
+					GroupOverrides: []*protocol.GroupOverride{
+						{Group: "SG", ToleranceRules: []protocol.ToleranceRule{moreThan(5)}},
+						{Group: "ID", ToleranceRules: []protocol.ToleranceRule{moreThan(10)}},
+					},
+				},
+			},
+		}
+
+		changes := DiffSpec(stored, uploaded)
+
+		assert.Empty(t, changes)
+	})
+	t.Run("should return added, removed and modified tolerances and table level settings", func(t *testing.T) {
+		schedule := &protocol.Schedule{Cron: "0 1 * * *"}
+		selector := &protocol.FieldSelector{FieldID: "*_id", Type: meta.FieldTypeString}
+		stored := &protocol.ToleranceSpec{
+			URN:            urn,
+			MaxBytesBilled: 1000,
+			Tolerances: []*protocol.Tolerance{
+				{MetricName: metric.RowCount, ToleranceRules: []protocol.ToleranceRule{moreThan(0)}},
+				{FieldID: "id", MetricName: metric.NullnessPct, ToleranceRules: []protocol.ToleranceRule{lessThan(1)}},
+			},
+		}
+		uploaded := &protocol.ToleranceSpec{
+			URN:            urn,
+			MaxBytesBilled: 1000,
+			Schedule:       schedule,
+			Tolerances: []*protocol.Tolerance{
+				{MetricName: metric.RowCount, ToleranceRules: []protocol.ToleranceRule{moreThan(0)}, Severity: protocol.SeverityWarn},
+				{MetricName: metric.DuplicationPct, FieldSelector: selector, ToleranceRules: []protocol.ToleranceRule{lessThan(1)}},
+			},
+		}
+
+		changes := DiffSpec(stored, uploaded)
+
+		expected := []*protocol.SpecChange{
+			{Type: protocol.ChangeModified, Attribute: "schedule", Before: (*protocol.Schedule)(nil), After: schedule},
+			{Type: protocol.ChangeModified, MetricName: metric.RowCount, Attribute: "severity", Before: protocol.SeverityError, After: protocol.SeverityWarn},
+			{Type: protocol.ChangeAdded, FieldID: "[fieldid: *_id, type: STRING]", MetricName: metric.DuplicationPct},
+			{Type: protocol.ChangeRemoved, FieldID: "id", MetricName: metric.NullnessPct},
+		}
+		assert.Equal(t, expected, changes)
+	})
+	t.Run("should match tolerances of the same metric by condition and identifying metadata", func(t *testing.T) {
+		customSQL := func(name string, value float64) *protocol.Tolerance {
+			return &protocol.Tolerance{
+				MetricName:     metric.CustomSQL,
+				Metadata:       map[string]interface{}{metric.CustomName: name, metric.CustomExpression: "SUM(amount)"},
+				ToleranceRules: []protocol.ToleranceRule{lessThan(value)},
+			}
+		}
+		invalidPct := func(condition string) *protocol.Tolerance {
+			return &protocol.Tolerance{
+				FieldID:        "amount",
+				MetricName:     metric.InvalidPct,
+				Condition:      condition,
+				ToleranceRules: []protocol.ToleranceRule{lessThan(1)},
+			}
+		}
+		stored := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				customSQL("total_amount", 10),
+				customSQL("total_fee", 10),
+				invalidPct("amount < 0"),
+				invalidPct("amount > 1000"),
+			},
+		}
+		uploaded := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				customSQL("total_fee", 10),
+				customSQL("total_amount", 20),
+				invalidPct("amount > 1000"),
+			},
+		}
+
+		changes := DiffSpec(stored, uploaded)
+
+		expected := []*protocol.SpecChange{
+			{
+				Type:       protocol.ChangeModified,
+				MetricName: metric.CustomSQL,
+				Identity:   "total_amount",
+				Attribute:  "tolerance",
+				Before:     []protocol.ToleranceRule{lessThan(10)},
+				After:      []protocol.ToleranceRule{lessThan(20)},
+			},
+			{Type: protocol.ChangeRemoved, FieldID: "amount", MetricName: metric.InvalidPct, Condition: "amount < 0"},
+		}
+		assert.Equal(t, expected, changes)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/odpf/predator/mock"
 	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
 	"github.com/odpf/predator/protocol/meta"
 	"github.com/odpf/predator/protocol/metric"
 	"github.com/stretchr/testify/assert"
@@ -87,12 +88,96 @@ func TestParser(t *testing.T) {
 
 				assert.Equal(t, expected, result)
 			})
+			t.Run("should return tolerances with range and any of rules", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `- tableid: "project.dataset.table"
+  metricname: "row_count"
+  tolerancerules:
+    pct_change_vs_previous: 20
+    between:
+      min: 100
+      max: 1000
+    any_of:
+    - equal: 0
+    - not_equal: 50
+      outside:
+        min: 10
+        max: 20`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							MetricName: metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{
+								{Comparator: protocol.ComparatorPctChangeVsPrevious, Value: 20},
+								{Comparator: protocol.ComparatorBetween, Value: 100, Upper: 1000},
+								{
+									Comparator: protocol.ComparatorAnyOf,
+									Groups: [][]protocol.ToleranceRule{
+										{{Comparator: protocol.ComparatorEqual, Value: 0}},
+										{{Comparator: protocol.ComparatorNotEqual, Value: 50}, {Comparator: protocol.ComparatorOutside, Value: 10, Upper: 20}},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				parser := &FlatSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
 			t.Run("should error when Parse failed", func(t *testing.T) {
 				parser := &FlatSpecParser{}
 				_, err := parser.Parse([]byte("abcdef/)"))
 				assert.NotNil(t, err)
 			})
 		})
+		t.Run("Serialise", func(t *testing.T) {
+			t.Run("should return yaml that can be parsed back", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				toleranceSpec := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							FieldID:    "field1",
+							MetricName: metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{
+								{Comparator: protocol.ComparatorLessThanEq, Value: 10},
+								{Comparator: protocol.ComparatorBetween, Value: 1, Upper: 5},
+							},
+						},
+					},
+				}
+
+				expected := `- id: ""
+  tableid: project.dataset.table
+  fieldid: field1
+  metricname: nullness_pct
+  tolerancerules:
+    between:
+      min: 1
+      max: 5
+    less_than_eq: 10
+  createdat: 0001-01-01T00:00:00Z
+  updatedat: 0001-01-01T00:00:00Z
+`
+
+				parser := &FlatSpecParser{}
+				result, err := parser.Serialise(toleranceSpec)
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(result))
+
+				parsed, err := parser.Parse(result)
+				assert.Nil(t, err)
+				assert.Equal(t, toleranceSpec, parsed)
+			})
+		})
 	})
 	t.Run("CompactSpecParser", func(t *testing.T) {
 		t.Run("Parse", func(t *testing.T) {
@@ -157,152 +242,1560 @@ func TestParser(t *testing.T) {
 					},
 				}
 
-				parser := &CompactSpecParser{}
-				result, _ := parser.Parse([]byte(compactSpecYamlFileContent))
+				parser := &CompactSpecParser{}
+				result, _ := parser.Parse([]byte(compactSpecYamlFileContent))
+
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return trend inconsistency tolerances with metadata", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+tablemetrics:
+- metricname: "trend_inconsistency_pct"
+  metadata:
+    lookback: 14
+    threshold_pct: 30.5
+  tolerance:
+    less_than_eq: 10.0
+fields:
+- fieldid: "field1"
+  fieldmetrics:
+  - metricname: "trend_inconsistency_pct"
+    tolerance:
+      less_than_eq: 0.0`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							MetricName: metric.TrendInconsistencyPct,
+							ToleranceRules: []protocol.ToleranceRule{
+								{
+									Comparator: protocol.ComparatorLessThanEq,
+									Value:      10.0,
+								},
+							},
+							Metadata: map[string]interface{}{
+								metric.TrendLookback:     14,
+								metric.TrendThresholdPct: 30.5,
+							},
+						},
+						{
+							TableURN:   tableID,
+							FieldID:    "field1",
+							MetricName: metric.TrendInconsistencyPct,
+							ToleranceRules: []protocol.ToleranceRule{
+								{
+									Comparator: protocol.ComparatorLessThanEq,
+									Value:      0.0,
+								},
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return quantile tolerances with metadata", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+fields:
+- fieldid: "amount"
+  fieldmetrics:
+  - metricname: "quantile"
+    metadata:
+      quantile: 0.99
+    tolerance:
+      less_than_eq: 1000.0
+  - metricname: "max"
+    tolerance:
+      less_than_eq: 5000.0`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							FieldID:    "amount",
+							MetricName: metric.Quantile,
+							ToleranceRules: []protocol.ToleranceRule{
+								{
+									Comparator: protocol.ComparatorLessThanEq,
+									Value:      1000.0,
+								},
+							},
+							Metadata: map[string]interface{}{
+								metric.QuantileFraction: 0.99,
+							},
+						},
+						{
+							TableURN:   tableID,
+							FieldID:    "amount",
+							MetricName: metric.Max,
+							ToleranceRules: []protocol.ToleranceRule{
+								{
+									Comparator: protocol.ComparatorLessThanEq,
+									Value:      5000.0,
+								},
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return conformity tolerances with rule metadata", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+fields:
+- fieldid: "status"
+  fieldmetrics:
+  - metricname: "accepted_values_pct"
+    metadata:
+      accepted_values:
+      - ACTIVE
+      - INACTIVE
+    tolerance:
+      less_than_eq: 0.0
+  - metricname: "pattern_mismatch_pct"
+    metadata:
+      pattern: "^[A-Z]+$"
+    tolerance:
+      less_than_eq: 1.0
+  - metricname: "length_out_of_range_pct"
+    metadata:
+      min_length: 1
+      max_length: 8
+    tolerance:
+      less_than_eq: 0.0`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							FieldID:        "status",
+							MetricName:     metric.AcceptedValuesPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+							Metadata: map[string]interface{}{
+								metric.AcceptedValues: []interface{}{"ACTIVE", "INACTIVE"},
+							},
+						},
+						{
+							TableURN:       tableID,
+							FieldID:        "status",
+							MetricName:     metric.PatternMismatchPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 1}},
+							Metadata: map[string]interface{}{
+								metric.Pattern: "^[A-Z]+$",
+							},
+						},
+						{
+							TableURN:       tableID,
+							FieldID:        "status",
+							MetricName:     metric.LengthOutOfRangePct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+							Metadata: map[string]interface{}{
+								metric.MinLength: 1,
+								metric.MaxLength: 8,
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return orphan tolerance with reference metadata", func(t *testing.T) {
+				tableID := "project.dataset.orders"
+				content := `tableid: "project.dataset.orders"
+tablemetrics:
+- metricname: "orphan_pct"
+  metadata:
+    reference_urn: "project.dataset.customers"
+    join_fields:
+    - customer_id
+    reference_fields:
+    - id
+  tolerance:
+    less_than_eq: 0.0`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							MetricName:     metric.OrphanPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+							Metadata: map[string]interface{}{
+								metric.ReferenceURN:    "project.dataset.customers",
+								metric.JoinFields:      []interface{}{"customer_id"},
+								metric.ReferenceFields: []interface{}{"id"},
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return schema drift tolerance with pinned schema", func(t *testing.T) {
+				tableID := "project.dataset.orders"
+				content := `tableid: "project.dataset.orders"
+tablemetrics:
+- metricname: "schema_drift"
+  metadata:
+    schema:
+    - name: id
+      type: INTEGER
+      mode: REQUIRED
+    - name: tags
+      type: STRING
+  tolerance:
+    less_than_eq: 0`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							MetricName:     metric.SchemaDrift,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+							Metadata: map[string]interface{}{
+								metric.PinnedSchema: []interface{}{
+									map[string]interface{}{"name": "id", "type": "INTEGER", "mode": "REQUIRED"},
+									map[string]interface{}{"name": "tags", "type": "STRING"},
+								},
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return custom sql tolerances with expression and query", func(t *testing.T) {
+				tableID := "project.dataset.orders"
+				content := `tableid: "project.dataset.orders"
+tablemetrics:
+- metricname: "custom_sql"
+  metadata:
+    name: late_orders
+    expression: COUNTIF(delivered_at > promised_at)
+  tolerance:
+    less_than_eq: 10
+- metricname: "custom_sql"
+  metadata:
+    name: refund_ratio
+    query: SELECT SUM(refund) / SUM(amount) AS value FROM project.dataset.orders
+  tolerance:
+    less_than: 0.1`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							MetricName:     metric.CustomSQL,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10}},
+							Metadata: map[string]interface{}{
+								metric.CustomName:       "late_orders",
+								metric.CustomExpression: "COUNTIF(delivered_at > promised_at)",
+							},
+						},
+						{
+							TableURN:       tableID,
+							MetricName:     metric.CustomSQL,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThan, Value: 0.1}},
+							Metadata: map[string]interface{}{
+								metric.CustomName:  "refund_ratio",
+								metric.CustomQuery: "SELECT SUM(refund) / SUM(amount) AS value FROM project.dataset.orders",
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return tolerances with anomaly rule", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+tablemetrics:
+- metricname: "row_count"
+  tolerance:
+    more_than: 0
+    anomaly:
+      lookback: 28
+      zscore: 3
+fields:
+- fieldid: "amount"
+  fieldmetrics:
+  - metricname: "nullness_pct"
+    tolerance:
+      anomaly:
+        lookback: 7
+        zscore: 2.5`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							MetricName: metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{
+								{
+									Comparator: protocol.ComparatorMoreThan,
+									Value:      0.0,
+								},
+							},
+							Anomaly: &protocol.AnomalyRule{Lookback: 28, ZScore: 3},
+						},
+						{
+							TableURN:   tableID,
+							FieldID:    "amount",
+							MetricName: metric.NullnessPct,
+							Anomaly:    &protocol.AnomalyRule{Lookback: 7, ZScore: 2.5},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return tolerances with range and any of rules", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+tablemetrics:
+- metricname: "row_count"
+  tolerance:
+    pct_change_vs_previous: 20
+    between:
+      min: 100
+      max: 1000
+    any_of:
+    - equal: 0
+    - not_equal: 50
+      outside:
+        min: 10
+        max: 20`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							MetricName: metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{
+								{Comparator: protocol.ComparatorPctChangeVsPrevious, Value: 20},
+								{Comparator: protocol.ComparatorBetween, Value: 100, Upper: 1000},
+								{
+									Comparator: protocol.ComparatorAnyOf,
+									Groups: [][]protocol.ToleranceRule{
+										{{Comparator: protocol.ComparatorEqual, Value: 0}},
+										{{Comparator: protocol.ComparatorNotEqual, Value: 50}, {Comparator: protocol.ComparatorOutside, Value: 10, Upper: 20}},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return tolerances with group overrides ordered by group", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+fields:
+- fieldid: "phone"
+  fieldmetrics:
+  - metricname: "nullness_pct"
+    tolerance:
+      less_than_eq: 10
+    group_overrides:
+      TH-*:
+        less_than_eq: 20
+      ID:
+        anomaly:
+          lookback: 7
+          zscore: 3
+      /^(SG|MY)$/:
+        less_than_eq: 30`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							FieldID:        "phone",
+							MetricName:     metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10}},
+							GroupOverrides: []*protocol.GroupOverride{
+								protocol.NewGroupOverride("/^(SG|MY)$/", []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 30}}, nil),
+								{
+									Group:   "ID",
+									Anomaly: &protocol.AnomalyRule{Lookback: 7, ZScore: 3},
+								},
+								{
+									Group:          "TH-*",
+									ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 20}},
+								},
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return tolerances with severity", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+tablemetrics:
+- metricname: "row_count"
+  severity: warn
+  tolerance:
+    more_than: 0
+fields:
+- fieldid: "amount"
+  fieldmetrics:
+  - metricname: "nullness_pct"
+    severity: info
+    tolerance:
+      less_than_eq: 10`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							MetricName:     metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}},
+							Severity:       protocol.SeverityWarn,
+						},
+						{
+							TableURN:       tableID,
+							FieldID:        "amount",
+							MetricName:     metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10}},
+							Severity:       protocol.SeverityInfo,
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return tolerances with field selectors", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+fields:
+- fieldid: "*_id"
+  fieldmetrics:
+  - metricname: "nullness_pct"
+    tolerance:
+      less_than_eq: 0
+- type: TIMESTAMP
+  fieldmetrics:
+  - metricname: "nullness_pct"
+    tolerance:
+      less_than_eq: 1
+- nested: "items.*"
+  type: INTEGER
+  fieldmetrics:
+  - metricname: "sum"
+    tolerance:
+      more_than_eq: 0
+- fieldid: "amount"
+  fieldmetrics:
+  - metricname: "nullness_pct"
+    tolerance:
+      less_than_eq: 10`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							MetricName:     metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+							FieldSelector:  &protocol.FieldSelector{FieldID: "*_id"},
+						},
+						{
+							TableURN:       tableID,
+							MetricName:     metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 1}},
+							FieldSelector:  &protocol.FieldSelector{Type: meta.FieldTypeTimestamp},
+						},
+						{
+							TableURN:       tableID,
+							MetricName:     metric.Sum,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThanEq, Value: 0}},
+							FieldSelector:  &protocol.FieldSelector{Nested: "items.*", Type: meta.FieldTypeInteger},
+						},
+						{
+							TableURN:       tableID,
+							FieldID:        "amount",
+							MetricName:     metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10}},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return spec with max bytes billed", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+maxbytesbilled: 1099511627776
+tablemetrics:
+- metricname: "row_count"
+  tolerance:
+    more_than: 0`
+
+				expected := &protocol.ToleranceSpec{
+					URN:            tableID,
+					MaxBytesBilled: 1099511627776,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							MetricName: metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{
+								{
+									Comparator: protocol.ComparatorMoreThan,
+									Value:      0.0,
+								},
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+			})
+			t.Run("should return spec with schedule", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				content := `tableid: "project.dataset.table"
+schedule:
+  cron: "0 2 * * *"
+  filter: "__PARTITION__ = '{{ .PreviousDate }}'"
+  group: "__PARTITION__"
+  mode: complete
+  audit: true
+tablemetrics:
+- metricname: "row_count"
+  tolerance:
+    more_than: 0`
+
+				expected := &protocol.ToleranceSpec{
+					URN: tableID,
+					Schedule: &protocol.Schedule{
+						Cron:   "0 2 * * *",
+						Filter: "__PARTITION__ = '{{ .PreviousDate }}'",
+						Group:  "__PARTITION__",
+						Mode:   job.ModeComplete,
+						Audit:  true,
+					},
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							MetricName: metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{
+								{
+									Comparator: protocol.ComparatorMoreThan,
+									Value:      0.0,
+								},
+							},
+						},
+					},
+				}
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Parse([]byte(content))
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, result)
+
+				serialised, err := parser.Serialise(result)
+				assert.Nil(t, err)
+
+				reparsed, err := parser.Parse(serialised)
+				assert.Nil(t, err)
+				assert.Equal(t, expected, reparsed)
+			})
+			t.Run("should error when Parse failed", func(t *testing.T) {
+				parser := &CompactSpecParser{}
+				_, err := parser.Parse([]byte(content))
+				assert.NotNil(t, err)
+			})
+		})
+		t.Run("Serialize", func(t *testing.T) {
+			t.Run("should return yaml", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				uniqueFields := []string{"unique_field"}
+				tolerances := []*protocol.Tolerance{
+					{
+						TableURN:   tableID,
+						MetricName: metric.DuplicationPct,
+						ToleranceRules: []protocol.ToleranceRule{
+							{
+								Comparator: protocol.ComparatorLessThanEq,
+								Value:      0.0,
+							},
+						},
+						Metadata: map[string]interface{}{
+							metric.UniqueFields: uniqueFields,
+						},
+					},
+					{
+						TableURN:   tableID,
+						MetricName: metric.InvalidPct,
+						Condition:  "field1 - field2 - field3 != 0",
+						ToleranceRules: []protocol.ToleranceRule{
+							{
+								Comparator: protocol.ComparatorLessThanEq,
+								Value:      0.0,
+							},
+						},
+					},
+					{
+						TableURN:   tableID,
+						FieldID:    "field1",
+						MetricName: metric.InvalidPct,
+						Condition:  "field1 is null and status='SUCCESS'",
+						ToleranceRules: []protocol.ToleranceRule{
+							{
+								Comparator: protocol.ComparatorLessThanEq,
+								Value:      0.0,
+							},
+						},
+					},
+					{
+						TableURN:   tableID,
+						FieldID:    "field2",
+						MetricName: metric.InvalidPct,
+						Condition:  "field4 <= 0",
+						ToleranceRules: []protocol.ToleranceRule{
+							{
+								Comparator: protocol.ComparatorLessThanEq,
+								Value:      0.0,
+							},
+						},
+					},
+					{
+						TableURN:   tableID,
+						FieldID:    "field3",
+						MetricName: metric.NullnessPct,
+						ToleranceRules: []protocol.ToleranceRule{
+							{
+								Comparator: protocol.ComparatorLessThanEq,
+								Value:      10.0,
+							},
+							{
+								Comparator: protocol.ComparatorMoreThan,
+								Value:      5.0,
+							},
+						},
+					},
+				}
+
+				toleranceSpec := &protocol.ToleranceSpec{
+					URN:        tableID,
+					Tolerances: tolerances,
+				}
+
+				expected := `tableid: project.dataset.table
+tablemetrics:
+- metricname: duplication_pct
+  condition: ""
+  metadata:
+    uniquefields:
+    - unique_field
+  tolerance:
+    less_than_eq: 0
+- metricname: invalid_pct
+  condition: field1 - field2 - field3 != 0
+  metadata: {}
+  tolerance:
+    less_than_eq: 0
+fields:
+- fieldid: field1
+  fieldmetrics:
+  - metricname: invalid_pct
+    condition: field1 is null and status='SUCCESS'
+    metadata: {}
+    tolerance:
+      less_than_eq: 0
+- fieldid: field2
+  fieldmetrics:
+  - metricname: invalid_pct
+    condition: field4 <= 0
+    metadata: {}
+    tolerance:
+      less_than_eq: 0
+- fieldid: field3
+  fieldmetrics:
+  - metricname: nullness_pct
+    condition: ""
+    metadata: {}
+    tolerance:
+      less_than_eq: 10
+      more_than: 5
+`
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Serialise(toleranceSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(result))
+			})
+			t.Run("should return yaml with anomaly rule", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				toleranceSpec := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							MetricName: metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{
+								{
+									Comparator: protocol.ComparatorMoreThan,
+									Value:      0.0,
+								},
+							},
+							Anomaly: &protocol.AnomalyRule{Lookback: 28, ZScore: 3},
+						},
+					},
+				}
+
+				expected := `tableid: project.dataset.table
+tablemetrics:
+- metricname: row_count
+  condition: ""
+  metadata: {}
+  tolerance:
+    anomaly:
+      lookback: 28
+      zscore: 3
+    more_than: 0
+fields: []
+`
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Serialise(toleranceSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(result))
+			})
+			t.Run("should return yaml with range and any of rules", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				toleranceSpec := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:   tableID,
+							MetricName: metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{
+								{Comparator: protocol.ComparatorPctChangeVsPrevious, Value: 20},
+								{Comparator: protocol.ComparatorBetween, Value: 100, Upper: 1000},
+								{
+									Comparator: protocol.ComparatorAnyOf,
+									Groups: [][]protocol.ToleranceRule{
+										{{Comparator: protocol.ComparatorEqual, Value: 0}},
+										{{Comparator: protocol.ComparatorNotEqual, Value: 50}, {Comparator: protocol.ComparatorOutside, Value: 10, Upper: 20}},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				expected := `tableid: project.dataset.table
+tablemetrics:
+- metricname: row_count
+  condition: ""
+  metadata: {}
+  tolerance:
+    between:
+      min: 100
+      max: 1000
+    any_of:
+    - equal: 0
+    - outside:
+        min: 10
+        max: 20
+      not_equal: 50
+    pct_change_vs_previous: 20
+fields: []
+`
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Serialise(toleranceSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(result))
+			})
+			t.Run("should return yaml with group overrides", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				toleranceSpec := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							FieldID:        "phone",
+							MetricName:     metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10}},
+							GroupOverrides: []*protocol.GroupOverride{
+								{
+									Group:          "/^(SG|MY)$/",
+									ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 30}},
+								},
+								{
+									Group:   "ID",
+									Anomaly: &protocol.AnomalyRule{Lookback: 7, ZScore: 3},
+								},
+								{
+									Group:          "TH-*",
+									ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 20}},
+								},
+							},
+						},
+					},
+				}
+
+				expected := `tableid: project.dataset.table
+tablemetrics: []
+fields:
+- fieldid: phone
+  fieldmetrics:
+  - metricname: nullness_pct
+    condition: ""
+    metadata: {}
+    tolerance:
+      less_than_eq: 10
+    group_overrides:
+      /^(SG|MY)$/:
+        less_than_eq: 30
+      ID:
+        anomaly:
+          lookback: 7
+          zscore: 3
+      TH-*:
+        less_than_eq: 20
+`
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Serialise(toleranceSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(result))
+			})
+			t.Run("should return yaml with severity", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				toleranceSpec := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							MetricName:     metric.RowCount,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}},
+							Severity:       protocol.SeverityWarn,
+						},
+					},
+				}
+
+				expected := `tableid: project.dataset.table
+tablemetrics:
+- metricname: row_count
+  condition: ""
+  metadata: {}
+  tolerance:
+    more_than: 0
+  severity: warn
+fields: []
+`
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Serialise(toleranceSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(result))
+			})
+			t.Run("should return yaml with field selectors", func(t *testing.T) {
+				tableID := "project.dataset.table"
+				toleranceSpec := &protocol.ToleranceSpec{
+					URN: tableID,
+					Tolerances: []*protocol.Tolerance{
+						{
+							TableURN:       tableID,
+							FieldID:        "amount",
+							MetricName:     metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10}},
+						},
+						{
+							TableURN:       tableID,
+							MetricName:     metric.NullnessPct,
+							ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+							FieldSelector:  &protocol.FieldSelector{FieldID: "*_id", Type: meta.FieldTypeString},
+						},
+					},
+				}
+
+				expected := `tableid: project.dataset.table
+tablemetrics: []
+fields:
+- fieldid: '*_id'
+  type: STRING
+  fieldmetrics:
+  - metricname: nullness_pct
+    condition: ""
+    metadata: {}
+    tolerance:
+      less_than_eq: 0
+- fieldid: amount
+  fieldmetrics:
+  - metricname: nullness_pct
+    condition: ""
+    metadata: {}
+    tolerance:
+      less_than_eq: 10
+`
+
+				parser := &CompactSpecParser{}
+				result, err := parser.Serialise(toleranceSpec)
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, string(result))
+
+				parsed, err := parser.Parse(result)
+
+				assert.Nil(t, err)
+				assert.Equal(t, toleranceSpec.Tolerances[1].FieldSelector, parsed.Tolerances[0].FieldSelector)
+			})
+		})
+	})
+}
+
+func TestSpecValidator(t *testing.T) {
+	t.Run("should return nil, when spec is valid", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{
+			TimePartitioningType: meta.DayPartitioning,
+			PartitionField:       "field_date",
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "field_date",
+					FieldType: meta.FieldTypeDate,
+				},
+			},
+		}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					FieldID:    "field_date",
+					MetricName: metric.NullnessPct,
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.Nil(t, err)
+	})
+	t.Run("should return error, when table not found", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		var tableSpec *meta.TableSpec
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					FieldID: "field_date",
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, protocol.ErrTableMetadataNotFound)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+	})
+	t.Run("should return spec invalid error and collect all error messages when field not found", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					FieldID:    "field_date",
+					MetricName: metric.NullnessPct,
+				},
+				{
+					FieldID:    "field_unknown",
+					MetricName: metric.NullnessPct,
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+		assert.Len(t, specInvalidErr.Errors, 2)
+	})
+	t.Run("should return spec invalid error unsupported metric is configured", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{
+			TimePartitioningType: meta.DayPartitioning,
+			PartitionField:       "field_date",
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "field_date",
+					FieldType: meta.FieldTypeDate,
+				},
+			},
+		}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					FieldID:    "field_date",
+					MetricName: metric.TrendInconsistencyPct,
+				},
+				{
+					FieldID:    "field_date",
+					MetricName: metric.Type("new_metric"),
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 1)
+		assert.Equal(t, specInvalidErr.Errors[0].Error(), "metric : new_metric is not supported")
+	})
+	t.Run("should return spec invalid error when reconciliation metric is configured", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{
+			TimePartitioningType: meta.DayPartitioning,
+			PartitionField:       "field_date",
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "field_date",
+					FieldType: meta.FieldTypeDate,
+				},
+			},
+		}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName: metric.ReconciliationDiffPct,
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 1)
+		assert.Equal(t, specInvalidErr.Errors[0].Error(), "metric : reconciliation_diff_pct is not supported")
+	})
+	t.Run("should return spec invalid error when trend inconsistency metadata is not a positive number", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "field_date",
+					FieldType: meta.FieldTypeDate,
+				},
+			},
+		}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName: metric.TrendInconsistencyPct,
+					Metadata: map[string]interface{}{
+						metric.TrendLookback:     0,
+						metric.TrendThresholdPct: 20.5,
+					},
+				},
+				{
+					FieldID:    "field_date",
+					MetricName: metric.TrendInconsistencyPct,
+					Metadata: map[string]interface{}{
+						metric.TrendThresholdPct: "high",
+					},
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 2)
+		assert.Equal(t, "[lookback] of trend_inconsistency_pct metric should be a positive number", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "[threshold_pct] of trend_inconsistency_pct metric should be a positive number", specInvalidErr.Errors[1].Error())
+	})
+	t.Run("should return spec invalid error when anomaly rule is not positive", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName: metric.RowCount,
+					Anomaly:    &protocol.AnomalyRule{Lookback: 0, ZScore: -1},
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 2)
+		assert.Equal(t, "[lookback] of anomaly rule of row_count metric should be a positive number", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "[zscore] of anomaly rule of row_count metric should be a positive number", specInvalidErr.Errors[1].Error())
+	})
+	t.Run("should return spec invalid error when tolerance rules are misconfigured", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName: metric.RowCount,
+					ToleranceRules: []protocol.ToleranceRule{
+						{Comparator: "less_then", Value: 10},
+						{Comparator: protocol.ComparatorBetween, Value: 10, Upper: 1},
+						{Comparator: protocol.ComparatorPctChangeVsPrevious, Value: -5},
+						{
+							Comparator: protocol.ComparatorAnyOf,
+							Groups: [][]protocol.ToleranceRule{
+								{},
+								{{Comparator: protocol.ComparatorOutside, Value: 5, Upper: 0}},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 5)
+		assert.Equal(t, "comparator less_then of row_count metric is not supported, should be one of [less_than less_than_eq more_than more_than_eq equal not_equal between outside pct_change_vs_previous any_of]", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "[between] of row_count metric should have min not greater than max", specInvalidErr.Errors[1].Error())
+		assert.Equal(t, "[pct_change_vs_previous] of row_count metric should not be a negative number", specInvalidErr.Errors[2].Error())
+		assert.Equal(t, "[any_of] of row_count metric should not have empty group", specInvalidErr.Errors[3].Error())
+		assert.Equal(t, "[outside] of row_count metric should have min not greater than max", specInvalidErr.Errors[4].Error())
+	})
+	t.Run("should return spec invalid error when group override is misconfigured", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName:     metric.RowCount,
+					ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}},
+					GroupOverrides: []*protocol.GroupOverride{
+						{Group: "/(ID/", ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 10}}},
+						{Group: "SG", ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorBetween, Value: 10, Upper: 0}}},
+						{Group: "MY", Anomaly: &protocol.AnomalyRule{Lookback: 0, ZScore: 3}},
+					},
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 3)
+		assert.Equal(t, "group override /(ID/ of row_count metric is not a valid glob or regular expression ,error parsing regexp: missing closing ): `(ID`", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "[between] of row_count metric should have min not greater than max", specInvalidErr.Errors[1].Error())
+		assert.Equal(t, "[lookback] of anomaly rule of row_count metric should be a positive number", specInvalidErr.Errors[2].Error())
+	})
+	t.Run("should return spec invalid error when field selector is invalid or match no field", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{
+			Fields: []*meta.FieldSpec{
+				{Name: "user_id", FieldType: meta.FieldTypeString},
+				{Name: "name", FieldType: meta.FieldTypeString},
+			},
+		}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName:     metric.NullnessPct,
+					ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					FieldSelector:  &protocol.FieldSelector{FieldID: "*_id"},
+				},
+				{
+					MetricName:     metric.NullnessPct,
+					ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					FieldSelector:  &protocol.FieldSelector{FieldID: "[_id"},
+				},
+				{
+					MetricName:     metric.NullnessPct,
+					ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					FieldSelector:  &protocol.FieldSelector{Type: meta.FieldTypeTimestamp},
+				},
+				{
+					MetricName:     metric.Max,
+					ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}},
+					FieldSelector:  &protocol.FieldSelector{FieldID: "user_*"},
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 3)
+		assert.Equal(t, "field selector [fieldid: [_id] of nullness_pct metric is invalid ,[_id is not a valid glob ,syntax error in pattern", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "field selector [type: TIMESTAMP] of nullness_pct metric does not match any field on table : project-1.dataset_a.table_x", specInvalidErr.Errors[1].Error())
+		assert.Equal(t, "max metric is only supported on numeric field, user_id is STRING", specInvalidErr.Errors[2].Error())
+	})
+	t.Run("should return spec invalid error when severity is not supported", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName:     metric.RowCount,
+					ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}},
+					Severity:       "critical",
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 1)
+		assert.Equal(t, "[severity] of row_count metric should be one of [info warn error], got critical", specInvalidErr.Errors[0].Error())
+	})
+	t.Run("should return spec invalid error when statistical metric is misconfigured", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "amount",
+					FieldType: meta.FieldTypeFloat,
+				},
+				{
+					Name:      "status",
+					FieldType: meta.FieldTypeString,
+				},
+			},
+		}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					MetricName: metric.Avg,
+				},
+				{
+					FieldID:    "status",
+					MetricName: metric.Max,
+				},
+				{
+					FieldID:    "amount",
+					MetricName: metric.Quantile,
+					Metadata: map[string]interface{}{
+						metric.QuantileFraction: 99,
+					},
+				},
+				{
+					FieldID:    "amount",
+					MetricName: metric.Quantile,
+					Metadata: map[string]interface{}{
+						metric.QuantileFraction: 0.99,
+					},
+				},
+			},
+		}
+
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
+
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
+
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
 
-				assert.Equal(t, expected, result)
-			})
-			t.Run("should error when Parse failed", func(t *testing.T) {
-				parser := &CompactSpecParser{}
-				_, err := parser.Parse([]byte(content))
-				assert.NotNil(t, err)
-			})
-		})
-		t.Run("Serialize", func(t *testing.T) {
-			t.Run("should return yaml", func(t *testing.T) {
-				tableID := "project.dataset.table"
-				uniqueFields := []string{"unique_field"}
-				tolerances := []*protocol.Tolerance{
-					{
-						TableURN:   tableID,
-						MetricName: metric.DuplicationPct,
-						ToleranceRules: []protocol.ToleranceRule{
-							{
-								Comparator: protocol.ComparatorLessThanEq,
-								Value:      0.0,
-							},
-						},
-						Metadata: map[string]interface{}{
-							metric.UniqueFields: uniqueFields,
-						},
+		assert.Len(t, specInvalidErr.Errors, 3)
+		assert.Equal(t, "avg metric is only supported on field level", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "max metric is only supported on numeric field, status is STRING", specInvalidErr.Errors[1].Error())
+		assert.Equal(t, "[quantile] of quantile metric in amount fieldid should be a number between 0 and 1", specInvalidErr.Errors[2].Error())
+	})
+	t.Run("should return spec invalid error when conformity metric is misconfigured", func(t *testing.T) {
+		urn := "project-1.dataset_a.table_x"
+
+		tableSpec := &meta.TableSpec{
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "amount",
+					FieldType: meta.FieldTypeFloat,
+				},
+				{
+					Name:      "status",
+					FieldType: meta.FieldTypeString,
+				},
+			},
+		}
+
+		toleranceSpec := &protocol.ToleranceSpec{
+			URN: urn,
+			Tolerances: []*protocol.Tolerance{
+				{
+					FieldID:    "amount",
+					MetricName: metric.AcceptedValuesPct,
+					Metadata: map[string]interface{}{
+						metric.AcceptedValues: []interface{}{"A"},
 					},
-					{
-						TableURN:   tableID,
-						MetricName: metric.InvalidPct,
-						Condition:  "field1 - field2 - field3 != 0",
-						ToleranceRules: []protocol.ToleranceRule{
-							{
-								Comparator: protocol.ComparatorLessThanEq,
-								Value:      0.0,
-							},
-						},
+				},
+				{
+					FieldID:    "status",
+					MetricName: metric.AcceptedValuesPct,
+					Metadata: map[string]interface{}{
+						metric.AcceptedValues: []interface{}{1, 2},
 					},
-					{
-						TableURN:   tableID,
-						FieldID:    "field1",
-						MetricName: metric.InvalidPct,
-						Condition:  "field1 is null and status='SUCCESS'",
-						ToleranceRules: []protocol.ToleranceRule{
-							{
-								Comparator: protocol.ComparatorLessThanEq,
-								Value:      0.0,
-							},
-						},
+				},
+				{
+					FieldID:    "status",
+					MetricName: metric.PatternMismatchPct,
+					Metadata: map[string]interface{}{
+						metric.Pattern: "^[A-Z",
 					},
-					{
-						TableURN:   tableID,
-						FieldID:    "field2",
-						MetricName: metric.InvalidPct,
-						Condition:  "field4 <= 0",
-						ToleranceRules: []protocol.ToleranceRule{
-							{
-								Comparator: protocol.ComparatorLessThanEq,
-								Value:      0.0,
-							},
-						},
+				},
+				{
+					FieldID:    "status",
+					MetricName: metric.LengthOutOfRangePct,
+				},
+				{
+					FieldID:    "status",
+					MetricName: metric.LengthOutOfRangePct,
+					Metadata: map[string]interface{}{
+						metric.MinLength: 5,
+						metric.MaxLength: 2,
 					},
-					{
-						TableURN:   tableID,
-						FieldID:    "field3",
-						MetricName: metric.NullnessPct,
-						ToleranceRules: []protocol.ToleranceRule{
-							{
-								Comparator: protocol.ComparatorLessThanEq,
-								Value:      10.0,
-							},
-							{
-								Comparator: protocol.ComparatorMoreThan,
-								Value:      5.0,
-							},
-						},
+				},
+				{
+					FieldID:    "status",
+					MetricName: metric.LengthOutOfRangePct,
+					Metadata: map[string]interface{}{
+						metric.MaxLength: 8,
 					},
-				}
+				},
+			},
+		}
 
-				toleranceSpec := &protocol.ToleranceSpec{
-					URN:        tableID,
-					Tolerances: tolerances,
-				}
+		metadataStore := mock.NewMetadataStore()
+		defer metadataStore.AssertExpectations(t)
 
-				expected := `tableid: project.dataset.table
-tablemetrics:
-- metricname: duplication_pct
-  condition: ""
-  metadata:
-    uniquefields:
-    - unique_field
-  tolerance:
-    less_than_eq: 0
-- metricname: invalid_pct
-  condition: field1 - field2 - field3 != 0
-  metadata: {}
-  tolerance:
-    less_than_eq: 0
-fields:
-- fieldid: field1
-  fieldmetrics:
-  - metricname: invalid_pct
-    condition: field1 is null and status='SUCCESS'
-    metadata: {}
-    tolerance:
-      less_than_eq: 0
-- fieldid: field2
-  fieldmetrics:
-  - metricname: invalid_pct
-    condition: field4 <= 0
-    metadata: {}
-    tolerance:
-      less_than_eq: 0
-- fieldid: field3
-  fieldmetrics:
-  - metricname: nullness_pct
-    condition: ""
-    metadata: {}
-    tolerance:
-      less_than_eq: 10
-      more_than: 5
-`
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
 
-				parser := &CompactSpecParser{}
-				result, err := parser.Serialise(toleranceSpec)
+		specValidator := NewSpecValidator(metadataStore)
+		err := specValidator.Validate(toleranceSpec)
 
-				assert.Nil(t, err)
-				assert.Equal(t, expected, string(result))
-			})
-		})
-	})
-}
+		assert.True(t, protocol.IsSpecInvalidError(err))
 
-func TestSpecValidator(t *testing.T) {
-	t.Run("should return nil, when spec is valid", func(t *testing.T) {
-		urn := "project-1.dataset_a.table_x"
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 5)
+		assert.Equal(t, "accepted_values_pct metric is only supported on string field, amount is FLOAT", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "[accepted_values] of accepted_values_pct metric in status fieldid should be a non empty list of strings", specInvalidErr.Errors[1].Error())
+		assert.Contains(t, specInvalidErr.Errors[2].Error(), "[pattern] of pattern_mismatch_pct metric in status fieldid is not a valid regular expression")
+		assert.Equal(t, "[min_length] or [max_length] of length_out_of_range_pct metric in status fieldid should be configured", specInvalidErr.Errors[3].Error())
+		assert.Equal(t, "[min_length] of length_out_of_range_pct metric in status fieldid should not be more than [max_length]", specInvalidErr.Errors[4].Error())
+	})
+	t.Run("should return spec invalid error when orphan metric is misconfigured", func(t *testing.T) {
+		urn := "project-1.dataset_a.orders"
+		referenceURN := "project-1.dataset_a.customers"
 
 		tableSpec := &meta.TableSpec{
-			TimePartitioningType: meta.DayPartitioning,
-			PartitionField:       "field_date",
 			Fields: []*meta.FieldSpec{
 				{
-					Name:      "field_date",
-					FieldType: meta.FieldTypeDate,
+					Name:      "customer_id",
+					FieldType: meta.FieldTypeInteger,
+				},
+				{
+					Name:      "region",
+					FieldType: meta.FieldTypeString,
+				},
+			},
+		}
+		referenceSpec := &meta.TableSpec{
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "id",
+					FieldType: meta.FieldTypeString,
 				},
 			},
 		}
@@ -311,8 +1804,36 @@ func TestSpecValidator(t *testing.T) {
 			URN: urn,
 			Tolerances: []*protocol.Tolerance{
 				{
-					FieldID:    "field_date",
-					MetricName: metric.NullnessPct,
+					MetricName: metric.OrphanPct,
+					Metadata: map[string]interface{}{
+						metric.ReferenceURN:    referenceURN,
+						metric.JoinFields:      []interface{}{"customer_id", "region"},
+						metric.ReferenceFields: []interface{}{"id"},
+					},
+				},
+				{
+					MetricName: metric.OrphanPct,
+					Metadata: map[string]interface{}{
+						metric.ReferenceURN:    referenceURN,
+						metric.JoinFields:      []interface{}{"customer_id", "region"},
+						metric.ReferenceFields: []interface{}{"id", "region"},
+					},
+				},
+				{
+					MetricName: metric.OrphanPct,
+					Metadata: map[string]interface{}{
+						metric.ReferenceURN:    "postgres:mart.public.customers",
+						metric.JoinFields:      []interface{}{"customer_id"},
+						metric.ReferenceFields: []interface{}{"id"},
+					},
+				},
+				{
+					MetricName: metric.OrphanPct,
+					Metadata: map[string]interface{}{
+						metric.ReferenceURN:    "project-1.dataset_a.missing",
+						metric.JoinFields:      []interface{}{"customer_id"},
+						metric.ReferenceFields: []interface{}{"id"},
+					},
 				},
 			},
 		}
@@ -321,22 +1842,67 @@ func TestSpecValidator(t *testing.T) {
 		defer metadataStore.AssertExpectations(t)
 
 		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+		metadataStore.On("GetMetadata", referenceURN).Return(referenceSpec, nil)
+		metadataStore.On("GetMetadata", "project-1.dataset_a.missing").Return(&meta.TableSpec{}, protocol.ErrTableMetadataNotFound)
 
 		specValidator := NewSpecValidator(metadataStore)
 		err := specValidator.Validate(toleranceSpec)
 
-		assert.Nil(t, err)
+		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 5)
+		assert.Equal(t, "[reference_urn] of orphan_pct metric should be configured with non empty [join_fields] and [reference_fields] of the same length", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "join field customer_id is INTEGER but reference field id is STRING", specInvalidErr.Errors[1].Error())
+		assert.Equal(t, "reference field ID: region of orphan_pct metric is not found on table : project-1.dataset_a.customers ,fieldspec not found", specInvalidErr.Errors[2].Error())
+		assert.Equal(t, "reference table postgres:mart.public.customers of orphan_pct metric should be stored on the same database as project-1.dataset_a.orders", specInvalidErr.Errors[3].Error())
+		assert.Equal(t, "reference table project-1.dataset_a.missing of orphan_pct metric is not found ,table metadata not found", specInvalidErr.Errors[4].Error())
 	})
-	t.Run("should return error, when table not found", func(t *testing.T) {
-		urn := "project-1.dataset_a.table_x"
+	t.Run("should return spec invalid error when freshness metric is misconfigured", func(t *testing.T) {
+		urn := "postgres:mart.public.orders"
 
-		var tableSpec *meta.TableSpec
+		tableSpec := &meta.TableSpec{
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "created_at",
+					FieldType: meta.FieldTypeDateTime,
+				},
+				{
+					Name:      "created_date",
+					FieldType: meta.FieldTypeDate,
+				},
+			},
+		}
 
+		lessThanHour := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThan, Value: 3600}}
 		toleranceSpec := &protocol.ToleranceSpec{
 			URN: urn,
 			Tolerances: []*protocol.Tolerance{
 				{
-					FieldID: "field_date",
+					MetricName:     metric.FreshnessLagSeconds,
+					Metadata:       map[string]interface{}{metric.TimestampField: "created_at"},
+					ToleranceRules: lessThanHour,
+				},
+				{
+					MetricName:     metric.FreshnessLagSeconds,
+					ToleranceRules: lessThanHour,
+				},
+				{
+					MetricName:     metric.FreshnessLagSeconds,
+					Metadata:       map[string]interface{}{metric.TimestampField: "created_date"},
+					ToleranceRules: lessThanHour,
+				},
+				{
+					MetricName:     metric.FreshnessLagSeconds,
+					Metadata:       map[string]interface{}{metric.TimestampField: "updated_at"},
+					ToleranceRules: lessThanHour,
+				},
+				{
+					FieldID:        "created_at",
+					MetricName:     metric.FreshnessLagSeconds,
+					Metadata:       map[string]interface{}{metric.TimestampField: 10},
+					ToleranceRules: lessThanHour,
 				},
 			},
 		}
@@ -344,28 +1910,60 @@ func TestSpecValidator(t *testing.T) {
 		metadataStore := mock.NewMetadataStore()
 		defer metadataStore.AssertExpectations(t)
 
-		metadataStore.On("GetMetadata", urn).Return(tableSpec, protocol.ErrTableMetadataNotFound)
+		metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
 
 		specValidator := NewSpecValidator(metadataStore)
 		err := specValidator.Validate(toleranceSpec)
 
 		assert.True(t, protocol.IsSpecInvalidError(err))
+
+		specInvalidErr := err.(*protocol.ErrSpecInvalid)
+
+		assert.Len(t, specInvalidErr.Errors, 5)
+		assert.Equal(t, "[timestamp_field] of freshness_lag_seconds metric should be configured, last modified time of postgres table is not available", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "freshness_lag_seconds metric is only supported on timestamp field, created_date is DATE", specInvalidErr.Errors[1].Error())
+		assert.Equal(t, "timestamp field ID: updated_at of freshness_lag_seconds metric is not found on table : postgres:mart.public.orders ,fieldspec not found", specInvalidErr.Errors[2].Error())
+		assert.Equal(t, "freshness_lag_seconds metric is only supported on table level", specInvalidErr.Errors[3].Error())
+		assert.Equal(t, "[timestamp_field] of freshness_lag_seconds metric should be a non empty string, got 10", specInvalidErr.Errors[4].Error())
 	})
-	t.Run("should return spec invalid error and collect all error messages when field not found", func(t *testing.T) {
-		urn := "project-1.dataset_a.table_x"
+	t.Run("should return spec invalid error when schema drift metric is misconfigured", func(t *testing.T) {
+		urn := "project.dataset.orders"
 
-		tableSpec := &meta.TableSpec{}
+		tableSpec := &meta.TableSpec{
+			Fields: []*meta.FieldSpec{
+				{
+					Name:      "id",
+					FieldType: meta.FieldTypeInteger,
+				},
+			},
+		}
 
+		noChange := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}}
 		toleranceSpec := &protocol.ToleranceSpec{
 			URN: urn,
 			Tolerances: []*protocol.Tolerance{
 				{
-					FieldID:    "field_date",
-					MetricName: metric.NullnessPct,
+					MetricName:     metric.SchemaDrift,
+					ToleranceRules: noChange,
 				},
 				{
-					FieldID:    "field_unknown",
-					MetricName: metric.NullnessPct,
+					MetricName: metric.SchemaDrift,
+					Metadata: map[string]interface{}{metric.PinnedSchema: []interface{}{
+						map[string]interface{}{"name": "id", "type": "INTEGER", "mode": "REQUIRED"},
+						map[string]interface{}{"type": "STRING"},
+						map[string]interface{}{"name": "amount", "type": "MONEY", "mode": "OPTIONAL"},
+					}},
+					ToleranceRules: noChange,
+				},
+				{
+					MetricName:     metric.SchemaDrift,
+					Metadata:       map[string]interface{}{metric.PinnedSchema: "id INTEGER"},
+					ToleranceRules: noChange,
+				},
+				{
+					FieldID:        "id",
+					MetricName:     metric.SchemaDrift,
+					ToleranceRules: noChange,
 				},
 			},
 		}
@@ -381,32 +1979,55 @@ func TestSpecValidator(t *testing.T) {
 		assert.True(t, protocol.IsSpecInvalidError(err))
 
 		specInvalidErr := err.(*protocol.ErrSpecInvalid)
-		assert.Len(t, specInvalidErr.Errors, 2)
+
+		assert.Len(t, specInvalidErr.Errors, 5)
+		assert.Equal(t, "[schema] of schema_drift metric, column 2 should have a name", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "[schema] of schema_drift metric, type of column amount is not supported, got MONEY", specInvalidErr.Errors[1].Error())
+		assert.Equal(t, "[schema] of schema_drift metric, mode of column amount should be one of [NULLABLE REQUIRED REPEATED], got OPTIONAL", specInvalidErr.Errors[2].Error())
+		assert.Equal(t, "[schema] of schema_drift metric should be a non empty list of columns with name, type and mode", specInvalidErr.Errors[3].Error())
+		assert.Equal(t, "schema_drift metric is only supported on table level", specInvalidErr.Errors[4].Error())
 	})
-	t.Run("should return spec invalid error unsupported metric is configured", func(t *testing.T) {
-		urn := "project-1.dataset_a.table_x"
+	t.Run("should return spec invalid error when custom sql metric is misconfigured", func(t *testing.T) {
+		urn := "project.dataset.orders"
 
 		tableSpec := &meta.TableSpec{
-			TimePartitioningType: meta.DayPartitioning,
-			PartitionField:       "field_date",
 			Fields: []*meta.FieldSpec{
 				{
-					Name:      "field_date",
-					FieldType: meta.FieldTypeDate,
+					Name:      "id",
+					FieldType: meta.FieldTypeInteger,
 				},
 			},
 		}
 
+		rules := []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 10}}
 		toleranceSpec := &protocol.ToleranceSpec{
 			URN: urn,
 			Tolerances: []*protocol.Tolerance{
 				{
-					FieldID:    "field_date",
-					MetricName: metric.TrendInconsistencyPct,
+					MetricName:     metric.CustomSQL,
+					Metadata:       map[string]interface{}{metric.CustomName: "late_orders", metric.CustomExpression: "COUNT(*)"},
+					ToleranceRules: rules,
 				},
 				{
-					FieldID:    "field_date",
-					MetricName: metric.Type("new_metric"),
+					MetricName:     metric.CustomSQL,
+					Metadata:       map[string]interface{}{metric.CustomName: "late_orders", metric.CustomQuery: "SELECT 1 AS value"},
+					ToleranceRules: rules,
+				},
+				{
+					MetricName:     metric.CustomSQL,
+					Metadata:       map[string]interface{}{metric.CustomExpression: "COUNT(*)"},
+					ToleranceRules: rules,
+				},
+				{
+					MetricName:     metric.CustomSQL,
+					Metadata:       map[string]interface{}{metric.CustomName: "both", metric.CustomExpression: "COUNT(*)", metric.CustomQuery: "SELECT 1 AS value"},
+					ToleranceRules: rules,
+				},
+				{
+					FieldID:        "id",
+					MetricName:     metric.CustomSQL,
+					Metadata:       map[string]interface{}{metric.CustomName: "per_field", metric.CustomExpression: "MAX(id)"},
+					ToleranceRules: rules,
 				},
 			},
 		}
@@ -423,9 +2044,11 @@ func TestSpecValidator(t *testing.T) {
 
 		specInvalidErr := err.(*protocol.ErrSpecInvalid)
 
-		assert.Len(t, specInvalidErr.Errors, 2)
-		assert.Equal(t, specInvalidErr.Errors[0].Error(), "metric : trend_inconsistency_pct is not supported")
-		assert.Equal(t, specInvalidErr.Errors[1].Error(), "metric : new_metric is not supported")
+		assert.Len(t, specInvalidErr.Errors, 4)
+		assert.Equal(t, "custom_sql metric late_orders is configured more than once", specInvalidErr.Errors[0].Error())
+		assert.Equal(t, "[name] of custom_sql metric should be a non empty string", specInvalidErr.Errors[1].Error())
+		assert.Equal(t, "custom_sql metric both should be configured with either [expression] or [query]", specInvalidErr.Errors[2].Error())
+		assert.Equal(t, "custom_sql metric is only supported on table level", specInvalidErr.Errors[3].Error())
 	})
 	t.Run("should return other error and return immediately when API call failed", func(t *testing.T) {
 		urn := "project-1.dataset_a.table_x"
@@ -0,0 +1,403 @@
+package tolerance
+
+import (
+	"math"
+	"time"
+
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+)
+
+const (
+	//nullnessMargin multiplier of the highest observed nullness percentage
+	nullnessMargin = 1.2
+	//rowCountMargin multiplier of the lowest observed row count
+	rowCountMargin = 0.5
+)
+
+//Suggester suggest tolerance spec with nullness, duplication and row count tolerances
+type Suggester struct {
+	metadataStore  protocol.MetadataStore
+	metricStore    protocol.MetricStore
+	profileService protocol.ProfileService
+}
+
+//NewSuggester create Suggester
+func NewSuggester(metadataStore protocol.MetadataStore, metricStore protocol.MetricStore, profileService protocol.ProfileService) *Suggester {
+	return &Suggester{
+		metadataStore:  metadataStore,
+		metricStore:    metricStore,
+		profileService: profileService,
+	}
+}
+
+//observation metric values observed from history or from exploratory profile
+type observation struct {
+	rowCounts []float64
+	//nullness percentages of each field
+	nullness map[string][]float64
+	//uniqueFields candidate unique key of the table
+	uniqueFields []string
+}
+
+//Suggest suggest tolerance spec from metric history of the table, when there is no history an exploratory profile
+//of data matching the filter is enqueued, the spec is suggested by GetSuggestion once the profile is completed
+func (s *Suggester) Suggest(urn string, filter string) (*protocol.SpecSuggestion, error) {
+	tableSpec, err := s.metadataStore.GetMetadata(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	obs, err := s.observeHistory(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(obs.rowCounts) > 0 {
+		return &protocol.SpecSuggestion{
+			URN:  urn,
+			Spec: suggestSpec(urn, tableSpec, obs),
+		}, nil
+	}
+
+	profile, err := s.profileService.CreateProfile(&job.Profile{
+		URN:            urn,
+		Filter:         filter,
+		Mode:           job.ModeComplete,
+		Kind:           job.KindExploratory,
+		Status:         job.StateCreated,
+		Message:        "exploratory profile to suggest tolerance spec",
+		EventTimestamp: time.Now().In(time.UTC),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocol.SpecSuggestion{
+		URN:     urn,
+		Profile: profile,
+	}, nil
+}
+
+//GetSuggestion get suggestion of the exploratory profile, the spec is only suggested when the profile is completed
+func (s *Suggester) GetSuggestion(profileID string) (*protocol.SpecSuggestion, error) {
+	profile, err := s.profileService.Get(profileID)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Kind != job.KindExploratory {
+		return nil, protocol.ErrProfileNotFound
+	}
+
+	suggestion := &protocol.SpecSuggestion{
+		URN:     profile.URN,
+		Profile: profile,
+	}
+	if profile.Status != job.StateCompleted {
+		return suggestion, nil
+	}
+
+	tableSpec, err := s.metadataStore.GetMetadata(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := s.metricStore.GetMetricsByProfileID(profile.ID)
+	if err != nil && err != protocol.ErrNoProfileMetricFound {
+		return nil, err
+	}
+
+	suggestion.Spec = suggestSpec(profile.URN, tableSpec, observeMetrics(suggestedFields(tableSpec), metrics))
+	return suggestion, nil
+}
+
+func suggestSpec(urn string, tableSpec *meta.TableSpec, obs *observation) *protocol.ToleranceSpec {
+	return &protocol.ToleranceSpec{
+		URN:        urn,
+		Tolerances: suggestTolerances(urn, suggestedFields(tableSpec), obs),
+	}
+}
+
+//observeHistory collect row count and nullness of completed profiles, unique key is taken from unique constraint store
+func (s *Suggester) observeHistory(urn string) (*observation, error) {
+	query := &protocol.MetricQuery{
+		URN:         urn,
+		MetricTypes: []metric.Type{metric.Count, metric.NullCount, metric.NullnessPct},
+	}
+	metrics, err := s.metricStore.GetMetrics(query)
+	if err != nil {
+		return nil, err
+	}
+
+	obs := &observation{nullness: make(map[string][]float64)}
+	if len(metrics) == 0 {
+		return obs, nil
+	}
+
+	type sampleKey struct {
+		profileID  string
+		groupValue string
+		fieldID    string
+	}
+	counts := make(map[sampleKey]float64)
+	nullCounts := make(map[sampleKey]float64)
+	hasNullnessPct := make(map[sampleKey]bool)
+	var keys []sampleKey
+
+	for _, m := range metrics {
+		key := sampleKey{profileID: m.ProfileID, groupValue: m.Partition, fieldID: m.FieldID}
+		switch m.MetricName {
+		case metric.Count:
+			if m.FieldID == "" {
+				obs.rowCounts = append(obs.rowCounts, m.MetricValue)
+				continue
+			}
+			counts[key] = m.MetricValue
+		case metric.NullCount:
+			nullCounts[key] = m.MetricValue
+			keys = append(keys, key)
+		case metric.NullnessPct:
+			obs.nullness[m.FieldID] = append(obs.nullness[m.FieldID], m.MetricValue)
+			hasNullnessPct[key] = true
+		}
+	}
+
+	for _, key := range keys {
+		count, ok := counts[key]
+		if !ok || count == 0 || hasNullnessPct[key] {
+			continue
+		}
+		obs.nullness[key.fieldID] = append(obs.nullness[key.fieldID], nullCounts[key]/count*100)
+	}
+
+	uniqueFields, err := s.metadataStore.GetUniqueConstraints(urn)
+	if err != nil && err != protocol.ErrUniqueConstraintNotFound {
+		return nil, err
+	}
+	obs.uniqueFields = uniqueFields
+
+	return obs, nil
+}
+
+//ExploratoryGenerator generate row count, null count and unique count of the fields for exploratory profile
+//the metrics are stored to suggest tolerance spec once the profile is completed
+type ExploratoryGenerator struct {
+	metadataStore  protocol.MetadataStore
+	metricProfiler protocol.MetricProfiler
+	metricStore    protocol.MetricStore
+}
+
+//NewExploratoryGenerator create ExploratoryGenerator
+func NewExploratoryGenerator(metadataStore protocol.MetadataStore, metricProfiler protocol.MetricProfiler, metricStore protocol.MetricStore) *ExploratoryGenerator {
+	return &ExploratoryGenerator{
+		metadataStore:  metadataStore,
+		metricProfiler: metricProfiler,
+		metricStore:    metricStore,
+	}
+}
+
+//Generate profile and store exploratory metrics
+func (e *ExploratoryGenerator) Generate(entry protocol.Entry, profile *job.Profile) ([]*metric.Metric, error) {
+	metricSpecs, err := e.metricSpecs(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := e.metricProfiler.Profile(entry, profile, metricSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.metricStore.Store(profile, metrics); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+//Plan plan queries of exploratory metrics
+func (e *ExploratoryGenerator) Plan(profile *job.Profile) ([]*job.Query, error) {
+	metricSpecs, err := e.metricSpecs(profile.URN)
+	if err != nil {
+		return nil, err
+	}
+	return e.metricProfiler.Plan(profile, metricSpecs)
+}
+
+func (e *ExploratoryGenerator) metricSpecs(urn string) ([]*metric.Spec, error) {
+	tableSpec, err := e.metadataStore.GetMetadata(urn)
+	if err != nil {
+		return nil, err
+	}
+	return exploratoryMetricSpecs(urn, suggestedFields(tableSpec)), nil
+}
+
+func exploratoryMetricSpecs(urn string, fields []*meta.FieldSpec) []*metric.Spec {
+	specs := []*metric.Spec{
+		{
+			Name:    metric.Count,
+			TableID: urn,
+			Owner:   metric.Table,
+		},
+	}
+
+	for _, field := range fields {
+		specs = append(specs,
+			&metric.Spec{
+				Name:    metric.Count,
+				TableID: urn,
+				FieldID: field.ID(),
+				Owner:   metric.Field,
+			},
+			&metric.Spec{
+				Name:    metric.NullCount,
+				TableID: urn,
+				FieldID: field.ID(),
+				Owner:   metric.Field,
+			},
+		)
+	}
+
+	for _, field := range fields {
+		if !isUniqueKeyCandidate(field) {
+			continue
+		}
+		specs = append(specs, &metric.Spec{
+			Name:    metric.UniqueCount,
+			TableID: urn,
+			Owner:   metric.Table,
+			Metadata: map[string]interface{}{
+				metric.UniqueFields: []string{field.ID()},
+			},
+		})
+	}
+
+	return specs
+}
+
+//observeMetrics take row count, nullness and the first field that has no null nor duplicated value as unique key
+func observeMetrics(fields []*meta.FieldSpec, metrics []*metric.Metric) *observation {
+	obs := &observation{nullness: make(map[string][]float64)}
+
+	var rowCount float64
+	counts := make(map[string]float64)
+	nullCounts := make(map[string]float64)
+	uniqueCounts := make(map[string]float64)
+
+	for _, m := range metrics {
+		switch {
+		case m.Type == metric.Count && m.Owner == metric.Table:
+			rowCount = m.Value
+			obs.rowCounts = append(obs.rowCounts, m.Value)
+		case m.Type == metric.Count:
+			counts[m.FieldID] = m.Value
+		case m.Type == metric.NullCount:
+			nullCounts[m.FieldID] = m.Value
+		case m.Type == metric.UniqueCount:
+			if uniqueFields, ok := metric.GetUniqueFields(m.Metadata); ok && len(uniqueFields) == 1 {
+				uniqueCounts[uniqueFields[0]] = m.Value
+			}
+		}
+	}
+
+	for _, field := range fields {
+		fieldID := field.ID()
+		if count := counts[fieldID]; count > 0 {
+			obs.nullness[fieldID] = append(obs.nullness[fieldID], nullCounts[fieldID]/count*100)
+		}
+	}
+
+	for _, field := range fields {
+		fieldID := field.ID()
+		uniqueCount, ok := uniqueCounts[fieldID]
+		if ok && rowCount > 0 && uniqueCount == rowCount && nullCounts[fieldID] == 0 {
+			obs.uniqueFields = []string{fieldID}
+			break
+		}
+	}
+
+	return obs
+}
+
+func suggestTolerances(urn string, fields []*meta.FieldSpec, obs *observation) []*protocol.Tolerance {
+	var tolerances []*protocol.Tolerance
+
+	if len(obs.uniqueFields) > 0 {
+		tolerances = append(tolerances, &protocol.Tolerance{
+			TableURN:   urn,
+			MetricName: metric.DuplicationPct,
+			Metadata: map[string]interface{}{
+				metric.UniqueFields: obs.uniqueFields,
+			},
+			ToleranceRules: []protocol.ToleranceRule{
+				{Comparator: protocol.ComparatorLessThanEq, Value: 0},
+			},
+		})
+	}
+
+	if len(obs.rowCounts) > 0 {
+		tolerances = append(tolerances, &protocol.Tolerance{
+			TableURN:       urn,
+			MetricName:     metric.RowCount,
+			ToleranceRules: []protocol.ToleranceRule{suggestRowCountRule(obs.rowCounts)},
+		})
+	}
+
+	for _, field := range fields {
+		values, ok := obs.nullness[field.ID()]
+		if !ok || len(values) == 0 {
+			continue
+		}
+		tolerances = append(tolerances, &protocol.Tolerance{
+			TableURN:   urn,
+			FieldID:    field.ID(),
+			MetricName: metric.NullnessPct,
+			ToleranceRules: []protocol.ToleranceRule{
+				{Comparator: protocol.ComparatorLessThanEq, Value: suggestNullnessThreshold(values)},
+			},
+		})
+	}
+
+	return tolerances
+}
+
+//suggestRowCountRule expect at least half of the lowest observed row count, or any row when only one row count is observed
+//a single row count comes from exploratory profile of the whole filtered data, not from a single group
+func suggestRowCountRule(rowCounts []float64) protocol.ToleranceRule {
+	if len(rowCounts) > 1 {
+		lowest := rowCounts[0]
+		for _, value := range rowCounts[1:] {
+			lowest = math.Min(lowest, value)
+		}
+		if threshold := math.Floor(lowest * rowCountMargin); threshold > 0 {
+			return protocol.ToleranceRule{Comparator: protocol.ComparatorMoreThanEq, Value: threshold}
+		}
+	}
+	return protocol.ToleranceRule{Comparator: protocol.ComparatorMoreThan, Value: 0}
+}
+
+//suggestNullnessThreshold allow margin above the highest observed nullness, field that never null stays not nullable
+func suggestNullnessThreshold(nullness []float64) float64 {
+	var highest float64
+	for _, value := range nullness {
+		highest = math.Max(highest, value)
+	}
+	return math.Min(100, math.Ceil(highest*nullnessMargin*100)/100)
+}
+
+//suggestedFields are top level fields that are not repeated nor record
+func suggestedFields(tableSpec *meta.TableSpec) []*meta.FieldSpec {
+	var fields []*meta.FieldSpec
+	for _, field := range tableSpec.Fields {
+		if field.Mode == meta.ModeRepeated || field.FieldType == meta.FieldTypeRecord || len(field.Fields) > 0 {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func isUniqueKeyCandidate(field *meta.FieldSpec) bool {
+	return field.FieldType == meta.FieldTypeString || field.FieldType == meta.FieldTypeInteger
+}
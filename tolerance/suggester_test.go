@@ -0,0 +1,340 @@
+package tolerance
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/odpf/predator/mock"
+	"github.com/odpf/predator/protocol"
+	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/meta"
+	"github.com/odpf/predator/protocol/metric"
+	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
+)
+
+func TestSuggester(t *testing.T) {
+	urn := "project.dataset.table"
+	tableSpec := &meta.TableSpec{
+		ProjectName: "project",
+		DatasetName: "dataset",
+		TableName:   "table",
+		Fields: []*meta.FieldSpec{
+			{Name: "id", FieldType: meta.FieldTypeString, Mode: meta.ModeRequired, Level: meta.RootLevel},
+			{Name: "name", FieldType: meta.FieldTypeString, Mode: meta.ModeNullable, Level: meta.RootLevel},
+			{Name: "amount", FieldType: meta.FieldTypeFloat, Mode: meta.ModeNullable, Level: meta.RootLevel},
+			{Name: "tags", FieldType: meta.FieldTypeString, Mode: meta.ModeRepeated, Level: meta.RootLevel},
+		},
+	}
+	historyQuery := &protocol.MetricQuery{
+		URN:         urn,
+		MetricTypes: []metric.Type{metric.Count, metric.NullCount, metric.NullnessPct},
+	}
+
+	t.Run("Suggest", func(t *testing.T) {
+		t.Run("should suggest spec from metric history", func(t *testing.T) {
+			history := []*protocol.ProfileMetric{
+				{ProfileID: "p1", Partition: "2021-01-01", MetricName: metric.Count, MetricValue: 1000},
+				{ProfileID: "p1", Partition: "2021-01-01", FieldID: "name", MetricName: metric.Count, MetricValue: 1000},
+				{ProfileID: "p1", Partition: "2021-01-01", FieldID: "name", MetricName: metric.NullCount, MetricValue: 100},
+				{ProfileID: "p2", Partition: "2021-01-02", MetricName: metric.Count, MetricValue: 800},
+				{ProfileID: "p2", Partition: "2021-01-02", FieldID: "name", MetricName: metric.Count, MetricValue: 800},
+				{ProfileID: "p2", Partition: "2021-01-02", FieldID: "name", MetricName: metric.NullCount, MetricValue: 40},
+				{ProfileID: "p2", Partition: "2021-01-02", FieldID: "id", MetricName: metric.NullnessPct, MetricValue: 0},
+			}
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+			metadataStore.On("GetUniqueConstraints", urn).Return([]string{"id"}, nil)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetrics", historyQuery).Return(history, nil)
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+
+			suggester := NewSuggester(metadataStore, metricStore, profileService)
+			suggestion, err := suggester.Suggest(urn, "")
+
+			expected := &protocol.ToleranceSpec{
+				URN: urn,
+				Tolerances: []*protocol.Tolerance{
+					{
+						TableURN:       urn,
+						MetricName:     metric.DuplicationPct,
+						Metadata:       map[string]interface{}{metric.UniqueFields: []string{"id"}},
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					},
+					{
+						TableURN:       urn,
+						MetricName:     metric.RowCount,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThanEq, Value: 400}},
+					},
+					{
+						TableURN:       urn,
+						FieldID:        "id",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					},
+					{
+						TableURN:       urn,
+						FieldID:        "name",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 12}},
+					},
+				},
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, &protocol.SpecSuggestion{URN: urn, Spec: expected}, suggestion)
+		})
+		t.Run("should enqueue exploratory profile when there is no metric history", func(t *testing.T) {
+			filter := "date(created_at) = '2021-01-01'"
+			var history []*protocol.ProfileMetric
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetrics", historyQuery).Return(history, nil)
+
+			created := &job.Profile{
+				ID:      "profile-1",
+				URN:     urn,
+				Filter:  filter,
+				Kind:    job.KindExploratory,
+				Status:  job.StateCreated,
+				Message: "exploratory profile to suggest tolerance spec",
+			}
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+			profileService.On("CreateProfile", mock2.Anything).Return(created, nil).Run(func(args mock2.Arguments) {
+				profile := args.Get(0).(*job.Profile)
+				assert.Equal(t, urn, profile.URN)
+				assert.Equal(t, filter, profile.Filter)
+				assert.Equal(t, job.KindExploratory, profile.Kind)
+				assert.Equal(t, job.ModeComplete, profile.Mode)
+				assert.Equal(t, job.StateCreated, profile.Status)
+			})
+
+			suggester := NewSuggester(metadataStore, metricStore, profileService)
+			suggestion, err := suggester.Suggest(urn, filter)
+
+			assert.Nil(t, err)
+			assert.Equal(t, &protocol.SpecSuggestion{URN: urn, Profile: created}, suggestion)
+		})
+		t.Run("should return error when enqueue exploratory profile failed", func(t *testing.T) {
+			var history []*protocol.ProfileMetric
+			var created *job.Profile
+			someErr := errors.New("database error")
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetrics", historyQuery).Return(history, nil)
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+			profileService.On("CreateProfile", mock2.Anything).Return(created, someErr)
+
+			suggester := NewSuggester(metadataStore, metricStore, profileService)
+			suggestion, err := suggester.Suggest(urn, "")
+
+			assert.Equal(t, someErr, err)
+			assert.Nil(t, suggestion)
+		})
+		t.Run("should return error when table metadata not found", func(t *testing.T) {
+			var tableSpec *meta.TableSpec
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, protocol.ErrTableMetadataNotFound)
+
+			suggester := NewSuggester(metadataStore, mock.NewMetricStore(), mock.NewProfileService())
+			suggestion, err := suggester.Suggest(urn, "")
+
+			assert.Equal(t, protocol.ErrTableMetadataNotFound, err)
+			assert.Nil(t, suggestion)
+		})
+	})
+	t.Run("GetSuggestion", func(t *testing.T) {
+		t.Run("should suggest spec from metrics of completed exploratory profile", func(t *testing.T) {
+			profile := &job.Profile{ID: "profile-1", URN: urn, Kind: job.KindExploratory, Status: job.StateCompleted}
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+			profileService.On("Get", "profile-1").Return(profile, nil)
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+			metrics := []*metric.Metric{
+				{Type: metric.Count, Owner: metric.Table, Value: 100},
+				{FieldID: "id", Type: metric.Count, Owner: metric.Field, Value: 100},
+				{FieldID: "id", Type: metric.NullCount, Owner: metric.Field, Value: 0},
+				{FieldID: "name", Type: metric.Count, Owner: metric.Field, Value: 100},
+				{FieldID: "name", Type: metric.NullCount, Owner: metric.Field, Value: 25},
+				{FieldID: "amount", Type: metric.Count, Owner: metric.Field, Value: 100},
+				{FieldID: "amount", Type: metric.NullCount, Owner: metric.Field, Value: 0},
+				{Type: metric.UniqueCount, Owner: metric.Table, Value: 100, Metadata: map[string]interface{}{metric.UniqueFields: []interface{}{"id"}}},
+				{Type: metric.UniqueCount, Owner: metric.Table, Value: 60, Metadata: map[string]interface{}{metric.UniqueFields: []interface{}{"name"}}},
+			}
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("GetMetricsByProfileID", "profile-1").Return(metrics, nil)
+
+			suggester := NewSuggester(metadataStore, metricStore, profileService)
+			suggestion, err := suggester.GetSuggestion("profile-1")
+
+			expected := &protocol.ToleranceSpec{
+				URN: urn,
+				Tolerances: []*protocol.Tolerance{
+					{
+						TableURN:       urn,
+						MetricName:     metric.DuplicationPct,
+						Metadata:       map[string]interface{}{metric.UniqueFields: []string{"id"}},
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					},
+					{
+						TableURN:       urn,
+						MetricName:     metric.RowCount,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}},
+					},
+					{
+						TableURN:       urn,
+						FieldID:        "id",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					},
+					{
+						TableURN:       urn,
+						FieldID:        "name",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 30}},
+					},
+					{
+						TableURN:       urn,
+						FieldID:        "amount",
+						MetricName:     metric.NullnessPct,
+						ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorLessThanEq, Value: 0}},
+					},
+				},
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, &protocol.SpecSuggestion{URN: urn, Spec: expected, Profile: profile}, suggestion)
+		})
+		t.Run("should return suggestion without spec when exploratory profile is not completed", func(t *testing.T) {
+			profile := &job.Profile{ID: "profile-1", URN: urn, Kind: job.KindExploratory, Status: job.StateInProgress}
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+			profileService.On("Get", "profile-1").Return(profile, nil)
+
+			suggester := NewSuggester(mock.NewMetadataStore(), mock.NewMetricStore(), profileService)
+			suggestion, err := suggester.GetSuggestion("profile-1")
+
+			assert.Nil(t, err)
+			assert.Equal(t, &protocol.SpecSuggestion{URN: urn, Profile: profile}, suggestion)
+		})
+		t.Run("should return ErrProfileNotFound when profile is not exploratory", func(t *testing.T) {
+			profile := &job.Profile{ID: "profile-1", URN: urn, Kind: job.KindStandard, Status: job.StateCompleted}
+
+			profileService := mock.NewProfileService()
+			defer profileService.AssertExpectations(t)
+			profileService.On("Get", "profile-1").Return(profile, nil)
+
+			suggester := NewSuggester(mock.NewMetadataStore(), mock.NewMetricStore(), profileService)
+			suggestion, err := suggester.GetSuggestion("profile-1")
+
+			assert.Equal(t, protocol.ErrProfileNotFound, err)
+			assert.Nil(t, suggestion)
+		})
+	})
+	t.Run("ExploratoryGenerator", func(t *testing.T) {
+		t.Run("should profile and store exploratory metrics of top level fields", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{ID: "profile-1", URN: urn, Kind: job.KindExploratory}
+			metrics := []*metric.Metric{
+				{Type: metric.Count, Owner: metric.Table, Value: 100},
+			}
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+			profiler := mock.NewProfiler()
+			defer profiler.AssertExpectations(t)
+			profiler.On("Profile", entry, profile, mock2.Anything).Return(metrics, nil).Run(func(args mock2.Arguments) {
+				var uniqueFields [][]string
+				for _, spec := range args.Get(2).([]*metric.Spec) {
+					if spec.Name == metric.UniqueCount {
+						uniqueFields = append(uniqueFields, spec.Metadata[metric.UniqueFields].([]string))
+					}
+					assert.NotEqual(t, "tags", spec.FieldID)
+				}
+				assert.Equal(t, [][]string{{"id"}, {"name"}}, uniqueFields)
+			})
+
+			metricStore := mock.NewMetricStore()
+			defer metricStore.AssertExpectations(t)
+			metricStore.On("Store", profile, metrics).Return(nil)
+
+			generator := NewExploratoryGenerator(metadataStore, profiler, metricStore)
+			result, err := generator.Generate(entry, profile)
+
+			assert.Nil(t, err)
+			assert.Equal(t, metrics, result)
+		})
+		t.Run("should return error when profiling failed", func(t *testing.T) {
+			entry := protocol.NewEntry()
+			profile := &job.Profile{ID: "profile-1", URN: urn, Kind: job.KindExploratory}
+			var metrics []*metric.Metric
+			profileErr := errors.New("query failed")
+
+			metadataStore := mock.NewMetadataStore()
+			defer metadataStore.AssertExpectations(t)
+			metadataStore.On("GetMetadata", urn).Return(tableSpec, nil)
+
+			profiler := mock.NewProfiler()
+			defer profiler.AssertExpectations(t)
+			profiler.On("Profile", entry, profile, mock2.Anything).Return(metrics, profileErr)
+
+			generator := NewExploratoryGenerator(metadataStore, profiler, mock.NewMetricStore())
+			result, err := generator.Generate(entry, profile)
+
+			assert.Equal(t, profileErr, err)
+			assert.Nil(t, result)
+		})
+	})
+	t.Run("Serialise", func(t *testing.T) {
+		t.Run("should serialise suggested spec as compact spec", func(t *testing.T) {
+			spec := &protocol.ToleranceSpec{
+				URN: urn,
+				Tolerances: suggestTolerances(urn, suggestedFields(tableSpec), &observation{
+					rowCounts:    []float64{100, 200},
+					nullness:     map[string][]float64{"name": {10}},
+					uniqueFields: []string{"id"},
+				}),
+			}
+
+			parser := &CompactSpecParser{}
+			content, err := parser.Serialise(spec)
+			assert.Nil(t, err)
+
+			parsed, err := parser.Parse(content)
+			assert.Nil(t, err)
+			assert.Equal(t, urn, parsed.URN)
+			assert.Len(t, parsed.Tolerances, 3)
+			assert.Equal(t, []string{"id"}, parsed.Tolerances[0].Metadata[metric.UniqueFields])
+		})
+	})
+}
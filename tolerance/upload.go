@@ -17,16 +17,21 @@ type UploadFactory struct {
 	gitRepositoryFactory protocol.GitRepositoryFactory
 	statsClientBuilder   stats.ClientBuilder
 	metadataStore        protocol.MetadataStore
+	queryValidator       protocol.SpecValidator
+	maxRemovalPct        int
 }
 
-//NewUploadFactory create UploadFactory
+//NewUploadFactory create UploadFactory, upload that remove more than maxRemovalPct percent of the stored specs is refused
+//zero maxRemovalPct means there is no limit
 func NewUploadFactory(multiTenancyEnabled bool,
 	entityStore protocol.EntityStore,
 	sourceFactory protocol.ToleranceStoreFactory,
 	destination protocol.ToleranceStore,
 	gitRepositoryFactory protocol.GitRepositoryFactory,
 	statsFactory stats.ClientBuilder,
-	metadataStore protocol.MetadataStore) *UploadFactory {
+	metadataStore protocol.MetadataStore,
+	queryValidator protocol.SpecValidator,
+	maxRemovalPct int) *UploadFactory {
 	return &UploadFactory{
 		multiTenancyEnabled:  multiTenancyEnabled,
 		entityStore:          entityStore,
@@ -35,11 +40,13 @@ func NewUploadFactory(multiTenancyEnabled bool,
 		gitRepositoryFactory: gitRepositoryFactory,
 		statsClientBuilder:   statsFactory,
 		metadataStore:        metadataStore,
+		queryValidator:       queryValidator,
+		maxRemovalPct:        maxRemovalPct,
 	}
 }
 
 //Create create protocol.Task
-func (u *UploadFactory) Create(gitRepo *protocol.GitInfo) (protocol.Task, error) {
+func (u *UploadFactory) Create(gitRepo *protocol.GitInfo, dryRun bool) (protocol.Task, error) {
 	gitRepository := u.gitRepositoryFactory.CreateWithPrefix(gitRepo.URL, gitRepo.PathPrefix)
 	fileStore, err := gitRepository.Checkout(gitRepo.CommitID)
 	if err != nil {
@@ -77,21 +84,28 @@ func (u *UploadFactory) Create(gitRepo *protocol.GitInfo) (protocol.Task, error)
 	}
 
 	return &Upload{
-		source:        source,
-		destination:   destination,
-		statsClient:   statsClient,
-		specValidator: NewSpecValidator(u.metadataStore),
+		source:         source,
+		destination:    destination,
+		statsClient:    statsClient,
+		specValidator:  NewSpecValidator(u.metadataStore),
+		queryValidator: u.queryValidator,
+		dryRun:         dryRun,
+		maxRemovalPct:  u.maxRemovalPct,
 	}, nil
 }
 
 type Upload struct {
-	source        protocol.ToleranceStore
-	destination   protocol.ToleranceStore
-	statsClient   stats.Client
-	specValidator protocol.SpecValidator
+	source         protocol.ToleranceStore
+	destination    protocol.ToleranceStore
+	statsClient    stats.Client
+	specValidator  protocol.SpecValidator
+	queryValidator protocol.SpecValidator
+	dryRun         bool
+	maxRemovalPct  int
 }
 
-//Run get entity information
+//Run validate and sync the specs from source to destination, job.Diff is returned
+//on dry run the specs are only validated and protocol.UploadDiff is returned
 func (u *Upload) Run() (i interface{}, err error) {
 	var diff *job.Diff
 	startTime := time.Now().In(time.UTC)
@@ -109,6 +123,14 @@ func (u *Upload) Run() (i interface{}, err error) {
 	}
 
 	diff = job.DiffBetween(sourceURNs, destURNs)
+	removalLimitExceeded := u.exceedsRemovalLimit(diff.RemovedCount(), len(destURNs))
+	if removalLimitExceeded && !u.dryRun {
+		return nil, &protocol.ErrUploadRemovalLimit{
+			RemovedCount:  diff.RemovedCount(),
+			TotalCount:    len(destURNs),
+			MaxRemovalPct: u.maxRemovalPct,
+		}
+	}
 
 	var toBeCreated []string
 	toBeCreated = append(toBeCreated, diff.Add...)
@@ -119,6 +141,10 @@ func (u *Upload) Run() (i interface{}, err error) {
 		return nil, err
 	}
 
+	if u.dryRun {
+		return u.diffSpecs(diff, removalLimitExceeded)
+	}
+
 	err = u.syncFiles(toBeCreated, diff.Remove)
 	if err != nil {
 		return nil, err
@@ -144,6 +170,52 @@ func (u *Upload) Run() (i interface{}, err error) {
 	return diff, nil
 }
 
+//exceedsRemovalLimit whether removing the specs is more than the limit percentage of the stored specs
+func (u *Upload) exceedsRemovalLimit(removedCount int, totalCount int) bool {
+	if u.maxRemovalPct <= 0 || totalCount == 0 {
+		return false
+	}
+	return removedCount*100 > u.maxRemovalPct*totalCount
+}
+
+//diffSpecs compare the source and destination spec of every table that exist on both, without writing to destination
+func (u *Upload) diffSpecs(diff *job.Diff, removalLimitExceeded bool) (*protocol.UploadDiff, error) {
+	specDiffs := make([]*protocol.SpecDiff, len(diff.Update))
+	g := new(errgroup.Group)
+	for i, urn := range diff.Update {
+		index, id := i, urn
+		g.Go(func() error {
+			uploaded, err := u.source.GetByTableID(id)
+			if err != nil {
+				return err
+			}
+			stored, err := u.destination.GetByTableID(id)
+			if err != nil {
+				return fmt.Errorf("failed to read stored %s spec because:\n%w", id, err)
+			}
+			specDiffs[index] = &protocol.SpecDiff{URN: id, Changes: DiffSpec(stored, uploaded)}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	uploadDiff := &protocol.UploadDiff{
+		Added:                diff.Add,
+		Removed:              diff.Remove,
+		RemovalLimitExceeded: removalLimitExceeded,
+	}
+	for _, specDiff := range specDiffs {
+		if len(specDiff.Changes) == 0 {
+			uploadDiff.UnchangedCount++
+			continue
+		}
+		uploadDiff.Changed = append(uploadDiff.Changed, specDiff)
+	}
+	return uploadDiff, nil
+}
+
 func (u *Upload) syncFiles(toBeCreated []string, toBeRemoved []string) error {
 	g := new(errgroup.Group)
 	for _, urn := range toBeCreated {
@@ -196,6 +268,10 @@ func (u *Upload) validateSpecFiles(urns []string) error {
 			if err != nil {
 				return
 			}
+
+			if u.queryValidator != nil {
+				err = u.queryValidator.Validate(spec)
+			}
 		}()
 	}
 
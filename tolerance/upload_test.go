@@ -5,6 +5,7 @@ import (
 	"github.com/odpf/predator/mock"
 	"github.com/odpf/predator/protocol"
 	"github.com/odpf/predator/protocol/job"
+	"github.com/odpf/predator/protocol/metric"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -61,15 +62,17 @@ func TestUploadFactory(t *testing.T) {
 			defer metadataStore.AssertExpectations(t)
 
 			specValidator := NewSpecValidator(metadataStore)
+			queryValidator := mock.NewSpecValidator()
 
 			statsClientBuilder.On("WithEntity", entity).Return(statsClientBuilder)
 			statsClientBuilder.On("Build").Return(statsClient, nil)
 
 			uploadTask := &Upload{
-				source:        NewEntityBasedStore(entity, sourceStore),
-				destination:   NewEntityBasedStore(entity, destStore),
-				statsClient:   statsClient,
-				specValidator: specValidator,
+				source:         NewEntityBasedStore(entity, sourceStore),
+				destination:    NewEntityBasedStore(entity, destStore),
+				statsClient:    statsClient,
+				specValidator:  specValidator,
+				queryValidator: queryValidator,
 			}
 
 			gitRepositoryFac.On("CreateWithPrefix", gitURL, pathPrefix).Return(gitRepository, nil)
@@ -80,8 +83,8 @@ func TestUploadFactory(t *testing.T) {
 
 			entityStore.On("GetEntityByGitURL", gitURL).Return(entity, nil)
 
-			factory := NewUploadFactory(true, entityStore, sourceStoreFactory, destStore, gitRepositoryFac, statsClientBuilder, metadataStore)
-			result, err := factory.Create(gitInfo)
+			factory := NewUploadFactory(true, entityStore, sourceStoreFactory, destStore, gitRepositoryFac, statsClientBuilder, metadataStore, queryValidator, 0)
+			result, err := factory.Create(gitInfo, false)
 
 			assert.Nil(t, err)
 			assert.Equal(t, uploadTask, result)
@@ -123,8 +126,8 @@ func TestUploadFactory(t *testing.T) {
 
 			entityStore.On("GetEntityByGitURL", gitURL).Return(&protocol.Entity{}, protocol.ErrEntityNotFound)
 
-			factory := NewUploadFactory(multiTenancyEnabled, entityStore, sourceStoreFactory, nil, gitRepositoryFac, nil, nil)
-			upload, err := factory.Create(gitInfo)
+			factory := NewUploadFactory(multiTenancyEnabled, entityStore, sourceStoreFactory, nil, gitRepositoryFac, nil, nil, nil, 0)
+			upload, err := factory.Create(gitInfo, false)
 
 			assert.Nil(t, upload)
 			assert.Error(t, err)
@@ -170,22 +173,24 @@ func TestUploadFactory(t *testing.T) {
 			defer metadataStore.AssertExpectations(t)
 
 			specValidator := NewSpecValidator(metadataStore)
+			queryValidator := mock.NewSpecValidator()
 
 			statsClientBuilder.On("Build").Return(statsClient, nil)
 
 			uploadTask := &Upload{
-				source:        sourceStore,
-				destination:   destStore,
-				statsClient:   statsClient,
-				specValidator: specValidator,
+				source:         sourceStore,
+				destination:    destStore,
+				statsClient:    statsClient,
+				specValidator:  specValidator,
+				queryValidator: queryValidator,
 			}
 
 			gitRepositoryFac.On("CreateWithPrefix", gitURL, pathPrefix).Return(gitRepository, nil)
 			gitRepository.On("Checkout", gitInfo.CommitID).Return(fileStore, nil)
 			sourceStoreFactory.On("CreateWithOptions", fileStore, protocol.Git).Return(sourceStore, nil)
 
-			factory := NewUploadFactory(false, entityStore, sourceStoreFactory, destStore, gitRepositoryFac, statsClientBuilder, metadataStore)
-			result, err := factory.Create(gitInfo)
+			factory := NewUploadFactory(false, entityStore, sourceStoreFactory, destStore, gitRepositoryFac, statsClientBuilder, metadataStore, queryValidator, 0)
+			result, err := factory.Create(gitInfo, false)
 
 			assert.Equal(t, uploadTask, result)
 			assert.Nil(t, err)
@@ -412,6 +417,184 @@ func TestUpload(t *testing.T) {
 				assert.Nil(t, report)
 				assert.Equal(t, errSpecValidation, err)
 			})
+			t.Run("should return ErrUploadSpecValidation when query of spec is invalid", func(t *testing.T) {
+				toleranceSpec := &protocol.ToleranceSpec{
+					URN: "entity-1-project-2.dataset_c.table_x",
+				}
+
+				errSpecInvalid := &protocol.ErrSpecInvalid{
+					URN:    "entity-1-project-2.dataset_c.table_x",
+					Errors: []error{errors.New("query of custom_sql metric late_orders is invalid")},
+				}
+
+				errSpecValidation := &protocol.ErrUploadSpecValidation{Errors: []error{errSpecInvalid}}
+
+				sourceStore := mock.NewToleranceStore()
+				defer sourceStore.AssertExpectations(t)
+
+				destStore := mock.NewToleranceStore()
+				defer destStore.AssertExpectations(t)
+
+				specValidator := mock.NewSpecValidator()
+				defer specValidator.AssertExpectations(t)
+
+				queryValidator := mock.NewSpecValidator()
+				defer queryValidator.AssertExpectations(t)
+
+				destStore.On("GetResourceNames").Return([]string{}, nil)
+				sourceStore.On("GetResourceNames").Return([]string{toleranceSpec.URN}, nil)
+
+				sourceStore.On("GetByTableID", toleranceSpec.URN).Return(toleranceSpec, nil)
+				specValidator.On("Validate", toleranceSpec).Return(nil)
+				queryValidator.On("Validate", toleranceSpec).Return(errSpecInvalid)
+
+				statsClient := mock.NewDummyStats()
+				defer statsClient.AssertExpectations(t)
+
+				upload := &Upload{
+					source:         sourceStore,
+					destination:    destStore,
+					statsClient:    statsClient,
+					specValidator:  specValidator,
+					queryValidator: queryValidator,
+				}
+
+				report, err := upload.Run()
+
+				assert.Nil(t, report)
+				assert.Equal(t, errSpecValidation, err)
+			})
+			t.Run("should return changes of the specs without writing on dry run", func(t *testing.T) {
+				addedSpec := &protocol.ToleranceSpec{URN: "entity-1-project-2.dataset_a.table_x"}
+				changedSpec := &protocol.ToleranceSpec{
+					URN: "entity-1-project-1.dataset_b.table_x",
+					Tolerances: []*protocol.Tolerance{
+						{MetricName: metric.RowCount, ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 10}}},
+					},
+				}
+				storedChangedSpec := &protocol.ToleranceSpec{
+					URN: "entity-1-project-1.dataset_b.table_x",
+					Tolerances: []*protocol.Tolerance{
+						{MetricName: metric.RowCount, ToleranceRules: []protocol.ToleranceRule{{Comparator: protocol.ComparatorMoreThan, Value: 0}}},
+					},
+				}
+				unchangedSpec := &protocol.ToleranceSpec{URN: "entity-1-project-1.dataset_c.table_x"}
+
+				sourceStore := mock.NewToleranceStore()
+				defer sourceStore.AssertExpectations(t)
+
+				destStore := mock.NewToleranceStore()
+				defer destStore.AssertExpectations(t)
+
+				specValidator := mock.NewSpecValidator()
+				defer specValidator.AssertExpectations(t)
+
+				sourceStore.On("GetResourceNames").Return([]string{addedSpec.URN, changedSpec.URN, unchangedSpec.URN}, nil)
+				destStore.On("GetResourceNames").Return([]string{changedSpec.URN, unchangedSpec.URN, "entity-1-project-1.dataset_d.table_x"}, nil)
+
+				for _, spec := range []*protocol.ToleranceSpec{addedSpec, changedSpec, unchangedSpec} {
+					sourceStore.On("GetByTableID", spec.URN).Return(spec, nil)
+					specValidator.On("Validate", spec).Return(nil)
+				}
+				destStore.On("GetByTableID", changedSpec.URN).Return(storedChangedSpec, nil)
+				destStore.On("GetByTableID", unchangedSpec.URN).Return(unchangedSpec, nil)
+
+				statsClient := mock.NewDummyStats()
+				defer statsClient.AssertExpectations(t)
+
+				upload := &Upload{
+					source:        sourceStore,
+					destination:   destStore,
+					statsClient:   statsClient,
+					specValidator: specValidator,
+					dryRun:        true,
+				}
+
+				report, err := upload.Run()
+
+				expected := &protocol.UploadDiff{
+					Added:   []string{addedSpec.URN},
+					Removed: []string{"entity-1-project-1.dataset_d.table_x"},
+					Changed: []*protocol.SpecDiff{
+						{
+							URN: changedSpec.URN,
+							Changes: []*protocol.SpecChange{
+								{
+									Type:       protocol.ChangeModified,
+									MetricName: metric.RowCount,
+									Attribute:  "tolerance",
+									Before:     storedChangedSpec.Tolerances[0].ToleranceRules,
+									After:      changedSpec.Tolerances[0].ToleranceRules,
+								},
+							},
+						},
+					},
+					UnchangedCount: 1,
+				}
+
+				assert.Nil(t, err)
+				assert.Equal(t, expected, report)
+			})
+			t.Run("should return ErrUploadRemovalLimit when removed specs are more than the limit", func(t *testing.T) {
+				sourceStore := mock.NewToleranceStore()
+				defer sourceStore.AssertExpectations(t)
+
+				destStore := mock.NewToleranceStore()
+				defer destStore.AssertExpectations(t)
+
+				sourceStore.On("GetResourceNames").Return([]string{"project.dataset.table_a"}, nil)
+				destStore.On("GetResourceNames").Return([]string{"project.dataset.table_a", "project.dataset.table_b", "project.dataset.table_c"}, nil)
+
+				upload := &Upload{
+					source:        sourceStore,
+					destination:   destStore,
+					statsClient:   mock.NewDummyStats(),
+					maxRemovalPct: 50,
+				}
+
+				report, err := upload.Run()
+
+				expectedErr := &protocol.ErrUploadRemovalLimit{RemovedCount: 2, TotalCount: 3, MaxRemovalPct: 50}
+				assert.Nil(t, report)
+				assert.Equal(t, expectedErr, err)
+			})
+			t.Run("should report exceeded removal limit on dry run", func(t *testing.T) {
+				spec := &protocol.ToleranceSpec{URN: "project.dataset.table_a"}
+
+				sourceStore := mock.NewToleranceStore()
+				defer sourceStore.AssertExpectations(t)
+
+				destStore := mock.NewToleranceStore()
+				defer destStore.AssertExpectations(t)
+
+				specValidator := mock.NewSpecValidator()
+				defer specValidator.AssertExpectations(t)
+
+				sourceStore.On("GetResourceNames").Return([]string{spec.URN}, nil)
+				destStore.On("GetResourceNames").Return([]string{spec.URN, "project.dataset.table_b", "project.dataset.table_c"}, nil)
+				sourceStore.On("GetByTableID", spec.URN).Return(spec, nil)
+				destStore.On("GetByTableID", spec.URN).Return(spec, nil)
+				specValidator.On("Validate", spec).Return(nil)
+
+				upload := &Upload{
+					source:        sourceStore,
+					destination:   destStore,
+					statsClient:   mock.NewDummyStats(),
+					specValidator: specValidator,
+					dryRun:        true,
+					maxRemovalPct: 50,
+				}
+
+				report, err := upload.Run()
+
+				expected := &protocol.UploadDiff{
+					Removed:              []string{"project.dataset.table_b", "project.dataset.table_c"},
+					UnchangedCount:       1,
+					RemovalLimitExceeded: true,
+				}
+				assert.Nil(t, err)
+				assert.Equal(t, expected, report)
+			})
 		})
 	})
 }